@@ -3,23 +3,63 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"time"
 )
 
 type Secrets struct {
-	Port      string
-	DbDsn     string
-	JWTSecret string
-	Env       string
+	Port  string
+	DbDsn string
+	Env   string
+
+	JWTKeysDir     string // directory of PEM signing keys; empty generates an ephemeral key outside production
+	JWTActiveKeyID string // key that signs new tokens; empty selects the greatest key ID
+	JWTIssuer      string // "iss" claim of issued tokens
+
+	CalendarCacheSize int           // max entries per calendar cache
+	CalendarCacheTTL  time.Duration // lifetime of a cached calendar entry
+
+	AccessTokenTTL  time.Duration // lifetime of a JWT access token
+	RefreshTokenTTL time.Duration // lifetime of a login session and its refresh tokens
+
+	PasswordResetTTL time.Duration // lifetime of a single-use password reset token
+	Notifier         string        // "log" or "file": how reset tokens and temporary passwords are delivered; required in production
+	NotifierFile     string        // outbox used by the "file" notifier
 }
 
+// Token lifetimes used when not configured
+const (
+	DefaultAccessTokenTTL  = 15 * time.Minute
+	DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+)
+
+// DefaultJWTIssuer is the "iss" claim of access tokens when not configured
+const DefaultJWTIssuer = "loopi-api"
+
+// DefaultPasswordResetTTL is the lifetime of a reset token when not configured
+const DefaultPasswordResetTTL = time.Hour
+
 var secrets Secrets
 
 func LoadSecrets() {
 	secrets = Secrets{
-		Port:      getOr("PORT", "8080"),
-		DbDsn:     mustGet("DB_DSN"),
-		JWTSecret: mustGet("JWT_SECRET"),
-		Env:       getOr("ENV", "development"),
+		Port:  getOr("PORT", "8080"),
+		DbDsn: mustGet("DB_DSN"),
+		Env:   getOr("ENV", "development"),
+
+		JWTKeysDir:     os.Getenv("JWT_KEYS_DIR"),
+		JWTActiveKeyID: os.Getenv("JWT_ACTIVE_KEY_ID"),
+		JWTIssuer:      getOr("JWT_ISSUER", DefaultJWTIssuer),
+
+		CalendarCacheSize: getIntOr("CALENDAR_CACHE_SIZE", 256),
+		CalendarCacheTTL:  getDurationOr("CALENDAR_CACHE_TTL", 24*time.Hour),
+
+		AccessTokenTTL:  getDurationOr("ACCESS_TOKEN_TTL", DefaultAccessTokenTTL),
+		RefreshTokenTTL: getDurationOr("REFRESH_TOKEN_TTL", DefaultRefreshTokenTTL),
+
+		PasswordResetTTL: getDurationOr("PASSWORD_RESET_TTL", DefaultPasswordResetTTL),
+		Notifier:         os.Getenv("NOTIFIER"),
+		NotifierFile:     getOr("NOTIFIER_FILE", "notifications.log"),
 	}
 }
 
@@ -39,6 +79,31 @@ func getOr(key, fallback string) string {
 	return val
 }
 
+func getIntOr(key string, fallback int) int {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	parsed, err := strconv.Atoi(val)
+	if err != nil || parsed <= 0 {
+		log.Fatalf("❌ Invalid %s: %q must be a positive integer", key, val)
+	}
+	return parsed
+}
+
+// getDurationOr parses a Go duration such as "24h" or "90m"
+func getDurationOr(key string, fallback time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	parsed, err := time.ParseDuration(val)
+	if err != nil || parsed <= 0 {
+		log.Fatalf("❌ Invalid %s: %q must be a positive duration (e.g. 24h)", key, val)
+	}
+	return parsed
+}
+
 func GetPort() string {
 	return secrets.Port
 }
@@ -47,10 +112,58 @@ func GetDB() string {
 	return secrets.DbDsn
 }
 
-func GetJWTSecret() string {
-	return secrets.JWTSecret
+func GetJWTKeysDir() string {
+	return secrets.JWTKeysDir
+}
+
+func GetJWTActiveKeyID() string {
+	return secrets.JWTActiveKeyID
+}
+
+func GetJWTIssuer() string {
+	if secrets.JWTIssuer == "" {
+		return DefaultJWTIssuer
+	}
+	return secrets.JWTIssuer
 }
 
 func GetEnv() string {
 	return secrets.Env
 }
+
+func GetCalendarCacheSize() int {
+	return secrets.CalendarCacheSize
+}
+
+func GetCalendarCacheTTL() time.Duration {
+	return secrets.CalendarCacheTTL
+}
+
+func GetAccessTokenTTL() time.Duration {
+	if secrets.AccessTokenTTL <= 0 {
+		return DefaultAccessTokenTTL
+	}
+	return secrets.AccessTokenTTL
+}
+
+func GetRefreshTokenTTL() time.Duration {
+	if secrets.RefreshTokenTTL <= 0 {
+		return DefaultRefreshTokenTTL
+	}
+	return secrets.RefreshTokenTTL
+}
+
+func GetPasswordResetTTL() time.Duration {
+	if secrets.PasswordResetTTL <= 0 {
+		return DefaultPasswordResetTTL
+	}
+	return secrets.PasswordResetTTL
+}
+
+func GetNotifier() string {
+	return secrets.Notifier
+}
+
+func GetNotifierFile() string {
+	return secrets.NotifierFile
+}
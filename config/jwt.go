@@ -1,24 +0,0 @@
-package config
-
-import (
-	"github.com/golang-jwt/jwt/v5"
-	"os"
-	"time"
-)
-
-func GenerateJWT(userID int, email string, roles []string, franchiseID, storeID int) (string, error) {
-	claims := CustomClaims{
-		UserID:      userID,
-		Email:       email,
-		Roles:       roles,
-		FranchiseID: franchiseID,
-		StoreID:     storeID,
-		RegisteredClaims: jwt.RegisteredClaims{
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-		},
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(os.Getenv("JWT_SECRET")))
-}
@@ -1,12 +1,18 @@
 package app
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"time"
 
 	"loopi-api/config"
+	"loopi-api/internal/cache"
+	"loopi-api/internal/calendar"
 	"loopi-api/internal/container"
 	"loopi-api/internal/router"
+	"loopi-api/internal/token"
 
 	"github.com/joho/godotenv"
 	"gorm.io/driver/mysql"
@@ -26,14 +32,28 @@ func Initialize() (*Application, error) {
 		return nil, err
 	}
 
+	// Configure calendar caches
+	configureCalendarCache()
+
 	// Initialize database connection
 	db, err := initializeDatabase()
 	if err != nil {
 		return nil, err
 	}
 
+	// Load access token signing keys
+	keys, err := loadSigningKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check how reset tokens and temporary passwords are delivered
+	if err := checkNotifier(); err != nil {
+		return nil, err
+	}
+
 	// Create dependency container
-	appContainer := container.NewContainer(db)
+	appContainer := container.NewContainer(db, keys)
 
 	// Setup routes
 	appRouter := router.SetupRoutes(appContainer)
@@ -63,6 +83,60 @@ func loadEnvironment() error {
 	return nil
 }
 
+// configureCalendarCache sizes the in-memory holiday and month summary caches.
+// A shared cache for several replicas is plugged in here through calendar.UseCaches.
+func configureCalendarCache() {
+	size, ttl := config.GetCalendarCacheSize(), config.GetCalendarCacheTTL()
+	calendar.UseCaches(cache.NewMemory(size, ttl), cache.NewMemory(size, ttl))
+	log.Printf("✅ Calendar cache configured (%d entries, TTL %s)", size, ttl)
+}
+
+// loadSigningKeys reads the access token keys from JWT_KEYS_DIR. Outside production an unset
+// directory falls back to an ephemeral key, so tokens do not survive a restart.
+func loadSigningKeys() (*token.KeySet, error) {
+	dir := config.GetJWTKeysDir()
+	if dir == "" {
+		if config.GetEnv() == "production" {
+			return nil, errors.New("JWT_KEYS_DIR is required in production")
+		}
+		key, err := token.GenerateKey("dev-" + time.Now().UTC().Format("20060102150405"))
+		if err != nil {
+			return nil, err
+		}
+		log.Println("⚠️ JWT_KEYS_DIR not set: signing tokens with an ephemeral Ed25519 key")
+		return token.NewKeySet([]token.Key{key}, key.ID)
+	}
+
+	keys, err := token.LoadKeySet(dir, config.GetJWTActiveKeyID())
+	if err != nil {
+		return nil, fmt.Errorf("loading JWT keys: %w", err)
+	}
+	log.Printf("✅ JWT signing keys loaded (active: %s, %d total)", keys.Active().ID, len(keys.Keys()))
+	return keys, nil
+}
+
+// checkNotifier validates NOTIFIER. Outside production an unset notifier falls back to "log";
+// production requires it explicitly and refuses "log", which writes reset tokens and
+// temporary passwords to the application log.
+func checkNotifier() error {
+	switch config.GetNotifier() {
+	case "":
+		if config.GetEnv() == "production" {
+			return errors.New("NOTIFIER is required in production")
+		}
+		log.Println("⚠️ NOTIFIER not set: reset tokens and temporary passwords are written to the log")
+	case "log":
+		if config.GetEnv() == "production" {
+			return errors.New(`NOTIFIER "log" is not allowed in production: it writes reset tokens and temporary passwords to the log`)
+		}
+	case "file":
+		log.Printf("✅ Notifications written to %s", config.GetNotifierFile())
+	default:
+		return fmt.Errorf("unknown NOTIFIER %q: use \"log\" or \"file\"", config.GetNotifier())
+	}
+	return nil
+}
+
 // initializeDatabase creates and configures the database connection
 func initializeDatabase() (*gorm.DB, error) {
 	db, err := gorm.Open(mysql.Open(config.GetDB()), &gorm.Config{})
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Cache is a bounded key/value store whose entries expire after a TTL.
+// Implementations must be safe for concurrent use. Every implementation stores values
+// encoded with Encode and decodes them with Decode, so the in-memory cache behaves like a
+// shared one (for several API replicas) and callers never share the cached value.
+type Cache interface {
+	// Get decodes the value of key into dest (a pointer), reporting a miss when it is
+	// absent or expired
+	Get(key string, dest interface{}) (bool, error)
+	// Set encodes value and stores it under key, evicting the least recently used entry when full
+	Set(key string, value interface{}) error
+	// Delete removes key
+	Delete(key string)
+	// Clear removes every entry atomically; counters are kept
+	Clear()
+	// Stats returns the hit/miss counters and occupancy
+	Stats() Stats
+}
+
+// Encode converts a value to the bytes stored by every cache
+func Encode(value interface{}) ([]byte, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("cache: encoding %T: %w", value, err)
+	}
+	return data, nil
+}
+
+// Decode fills dest from bytes produced by Encode
+func Decode(data []byte, dest interface{}) error {
+	if err := json.Unmarshal(data, dest); err != nil {
+		return fmt.Errorf("cache: decoding into %T: %w", dest, err)
+	}
+	return nil
+}
+
+// Stats are the counters of a cache since it was created
+type Stats struct {
+	Hits        uint64  `json:"hits"`
+	Misses      uint64  `json:"misses"`
+	Evictions   uint64  `json:"evictions"`
+	Expirations uint64  `json:"expirations"`
+	Entries     int     `json:"entries"`
+	MaxEntries  int     `json:"max_entries"`
+	TTLSeconds  float64 `json:"ttl_seconds"`
+	HitRatio    float64 `json:"hit_ratio"`
+}
+
+// newStats fills the derived fields of the counters
+func newStats(hits, misses, evictions, expirations uint64, entries, maxEntries int, ttl time.Duration) Stats {
+	stats := Stats{
+		Hits:        hits,
+		Misses:      misses,
+		Evictions:   evictions,
+		Expirations: expirations,
+		Entries:     entries,
+		MaxEntries:  maxEntries,
+		TTLSeconds:  ttl.Seconds(),
+	}
+	if total := hits + misses; total > 0 {
+		stats.HitRatio = float64(hits) / float64(total)
+	}
+	return stats
+}
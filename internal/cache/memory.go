@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Defaults used when a size or TTL is not positive
+const (
+	DefaultMaxEntries = 256
+	DefaultTTL        = 24 * time.Hour
+)
+
+// Memory is an in-process LRU cache with a per-entry TTL. Values are kept encoded.
+type Memory struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	entries    map[string]*list.Element
+	order      *list.List // front = most recently used
+	now        func() time.Time
+
+	hits, misses, evictions, expirations uint64
+}
+
+type memoryEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+}
+
+// NewMemory creates an in-memory cache holding at most maxEntries entries for ttl each
+func NewMemory(maxEntries int, ttl time.Duration) *Memory {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Memory{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get decodes the value of key into dest; expired entries are removed and count as misses
+func (m *Memory) Get(key string, dest interface{}) (bool, error) {
+	data, ok := m.lookup(key)
+	if !ok {
+		return false, nil
+	}
+	if err := Decode(data, dest); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Set encodes value and stores it under key for the cache TTL
+func (m *Memory) Set(key string, value interface{}) error {
+	data, err := Encode(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt := m.now().Add(m.ttl)
+	if element, ok := m.entries[key]; ok {
+		entry := element.Value.(*memoryEntry)
+		entry.data = data
+		entry.expiresAt = expiresAt
+		m.order.MoveToFront(element)
+		return nil
+	}
+
+	for m.order.Len() >= m.maxEntries {
+		m.removeElement(m.order.Back())
+		m.evictions++
+	}
+	m.entries[key] = m.order.PushFront(&memoryEntry{key: key, data: data, expiresAt: expiresAt})
+	return nil
+}
+
+// lookup returns the encoded value of key and updates the counters and recency.
+// The bytes are never modified after Set, so they are decoded outside the lock.
+func (m *Memory) lookup(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		m.misses++
+		return nil, false
+	}
+
+	entry := element.Value.(*memoryEntry)
+	if !m.now().Before(entry.expiresAt) {
+		m.removeElement(element)
+		m.expirations++
+		m.misses++
+		return nil, false
+	}
+
+	m.order.MoveToFront(element)
+	m.hits++
+	return entry.data, true
+}
+
+// Delete removes key
+func (m *Memory) Delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.removeElement(element)
+	}
+}
+
+// Clear removes every entry under the lock, so no reader sees a partial cache
+func (m *Memory) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[string]*list.Element)
+	m.order.Init()
+}
+
+// Stats returns the counters and current occupancy
+func (m *Memory) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return newStats(m.hits, m.misses, m.evictions, m.expirations, m.order.Len(), m.maxEntries, m.ttl)
+}
+
+func (m *Memory) removeElement(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEntry).key)
+}
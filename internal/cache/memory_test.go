@@ -0,0 +1,110 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemory_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewMemory(2, time.Hour)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	var v int
+	c.Get("a", &v) // "b" is now the least recently used
+	c.Set("c", 3)
+
+	if ok, _ := c.Get("b", &v); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if ok, err := c.Get("a", &v); !ok || err != nil || v != 1 {
+		t.Errorf("Expected a=1, got %v (found=%v)", v, ok)
+	}
+
+	stats := c.Stats()
+	if stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("Expected 2 entries and 1 eviction, got %+v", stats)
+	}
+	if stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss, got %+v", stats)
+	}
+}
+
+func TestMemory_ExpiresAfterTTL(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewMemory(10, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("k", "v")
+	var v string
+	now = now.Add(59 * time.Second)
+	if ok, _ := c.Get("k", &v); !ok {
+		t.Error("Expected k before its TTL")
+	}
+
+	now = now.Add(time.Second)
+	if ok, _ := c.Get("k", &v); ok {
+		t.Error("Expected k to expire after its TTL")
+	}
+	if stats := c.Stats(); stats.Expirations != 1 || stats.Entries != 0 {
+		t.Errorf("Expected 1 expiration and no entries, got %+v", stats)
+	}
+}
+
+func TestMemory_ClearIsSafeUnderConcurrency(t *testing.T) {
+	c := NewMemory(64, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				key := string(rune('a' + (i+j)%26))
+				var v int
+				c.Set(key, j)
+				c.Get(key, &v)
+				if j%100 == 0 {
+					c.Clear()
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	c.Clear()
+	if stats := c.Stats(); stats.Entries != 0 || stats.Hits+stats.Misses != 4000 {
+		t.Errorf("Expected an empty cache with 4000 lookups counted, got %+v", stats)
+	}
+}
+
+func TestMemory_StoresEncodedCopies(t *testing.T) {
+	type entry struct {
+		Names []string `json:"names"`
+	}
+	c := NewMemory(10, time.Hour)
+
+	value := entry{Names: []string{"a", "b"}}
+	if err := c.Set("k", value); err != nil {
+		t.Fatalf("Set: unexpected error %v", err)
+	}
+	value.Names[0] = "changed"
+
+	var got entry
+	if ok, err := c.Get("k", &got); !ok || err != nil {
+		t.Fatalf("Expected a hit, got %v (found=%v)", err, ok)
+	}
+	if got.Names[0] != "a" {
+		t.Errorf("Expected the cached value to be independent of the caller's, got %v", got.Names)
+	}
+
+	// Values that do not round-trip are rejected when stored, as a shared cache would
+	if err := c.Set("f", func() {}); err == nil {
+		t.Error("Expected a function value to be rejected")
+	}
+	var wrong int
+	if ok, err := c.Get("k", &wrong); ok || err == nil {
+		t.Errorf("Expected decoding into the wrong type to fail, got %v (found=%v)", err, ok)
+	}
+}
@@ -2,81 +2,135 @@ package calendar
 
 import (
 	"fmt"
+	"loopi-api/internal/cache"
 	"sync"
 	"time"
 )
 
+// Nombres de los caches del calendario en las métricas
+const (
+	HolidayCacheName = "holidays"
+	SummaryCacheName = "month_summaries"
+)
+
+// MonthCounts Conteo de días de un mes guardado en el cache de resúmenes
+type MonthCounts struct {
+	OrdinaryDays int `json:"ordinary_days"`
+	Sundays      int `json:"sundays"`
+}
+
 var (
-	holidayCache sync.Map // key: "2025" o "2025-04"
-	summaryCache sync.Map // key: "2025-04"
+	cacheMu      sync.RWMutex
+	holidayCache cache.Cache = cache.NewMemory(cache.DefaultMaxEntries, cache.DefaultTTL) // key: "CO-2025"
+	summaryCache cache.Cache = cache.NewMemory(cache.DefaultMaxEntries, cache.DefaultTTL) // key: "2025-04"
 )
 
-// GetColombianHolidaysCached Cache de festivos por año
-func GetColombianHolidaysCached(year int) []time.Time {
-	/*
-	    key := fmt.Sprintf("%d", year)
-	  	if val, ok := holidayCache.Load(key); ok {
-	  		return val.([]time.Time)
-	  	}*/
-	holidays := GetColombianHolidays(year)
-	//holidayCache.Store(key, holidays)
-	return holidays
+// UseCaches Reemplaza los caches de festivos y resúmenes mensuales, p. ej. por un cache
+// compartido entre réplicas; un cache nil conserva el actual
+func UseCaches(holidays, summaries cache.Cache) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	if holidays != nil {
+		holidayCache = holidays
+	}
+	if summaries != nil {
+		summaryCache = summaries
+	}
+}
+
+// caches Caches de festivos y resúmenes en uso
+func caches() (cache.Cache, cache.Cache) {
+	cacheMu.RLock()
+	defer cacheMu.RUnlock()
+	return holidayCache, summaryCache
 }
 
-// GetColombianHolidaysByMonthCached Cache de festivos por mes
-func GetColombianHolidaysByMonthCached(year int, month int) []time.Time {
-	/*
-	    key := fmt.Sprintf("%d-%02d", year, month)
-	  	if val, ok := holidayCache.Load(key); ok {
-	  		return val.([]time.Time)
-	  	}
-	*/
-	all := GetColombianHolidaysCached(year)
-
-	var filtered []time.Time
-	for _, d := range all {
-		if d.Month() == time.Month(month) {
-			filtered = append(filtered, d)
-		}
+// GetHolidaysCached Cache de festivos por país y año; un país sin calendario usa DefaultCountry
+func GetHolidaysCached(country string, year int) []time.Time {
+	return holidayDates(GetHolidayDetailsCached(country, year))
+}
+
+// GetHolidaysByMonthCached Festivos del país en un mes
+func GetHolidaysByMonthCached(country string, year int, month int) []time.Time {
+	return holidayDates(GetHolidayDetailsByMonthCached(country, year, month))
+}
+
+// GetHolidayDetailsCached Festivos del país en el año con nombre, categoría y traslado.
+// El cache guarda los festivos codificados, así que cada llamada recibe su propio slice.
+// Una entrada ilegible o que no se puede guardar solo obliga a recalcular.
+func GetHolidayDetailsCached(country string, year int) []Holiday {
+	provider, err := GetProvider(country)
+	if err != nil {
+		provider, _ = GetProvider(DefaultCountry)
+	}
+
+	holidays, _ := caches()
+	key := fmt.Sprintf("%s-%d", provider.Country(), year)
+	var cached []Holiday
+	if ok, err := holidays.Get(key, &cached); ok && err == nil {
+		return cached
 	}
 
-	//holidayCache.Store(key, filtered)
+	computed := provider.Holidays(year)
+	_ = holidays.Set(key, computed)
+	return computed
+}
+
+// GetHolidayDetailsByMonthCached Festivos del país en un mes con nombre, categoría y traslado
+func GetHolidayDetailsByMonthCached(country string, year int, month int) []Holiday {
+	all := GetHolidayDetailsCached(country, year)
+
+	var filtered []Holiday
+	for _, h := range all {
+		if h.Date.Month() == time.Month(month) {
+			filtered = append(filtered, h)
+		}
+	}
 	return filtered
 }
 
 // GetMonthSummaryCached Cache de resumen mensual: ordinary_days, sundays
 func GetMonthSummaryCached(year int, month int) (ordinaryDays int, sundays int) {
+	_, summaries := caches()
 	key := fmt.Sprintf("%d-%02d", year, month)
-	if val, ok := summaryCache.Load(key); ok {
-		s := val.(map[string]int)
-		return s["ordinary_days"], s["sundays"]
+	var counts MonthCounts
+	if ok, err := summaries.Get(key, &counts); ok && err == nil {
+		return counts.OrdinaryDays, counts.Sundays
 	}
 
 	daysInMonth := daysIn(year, time.Month(month))
-	ord := 0
-	sun := 0
+	counts = MonthCounts{}
 
 	for day := 1; day <= daysInMonth; day++ {
 		d := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
 		switch d.Weekday() {
 		case time.Sunday:
-			sun++
+			counts.Sundays++
 		default:
-			ord++
+			counts.OrdinaryDays++
 		}
 	}
 
-	summaryCache.Store(key, map[string]int{
-		"ordinary_days": ord,
-		"sundays":       sun,
-	})
-	return ord, sun
+	_ = summaries.Set(key, counts)
+	return counts.OrdinaryDays, counts.Sundays
 }
 
 // ClearCalendarCache borra todos los datos cacheados de días festivos y resúmenes mensuales.
+// Cada cache se vacía de forma atómica; los contadores de métricas se conservan.
 func ClearCalendarCache() {
-	holidayCache = sync.Map{}
-	summaryCache = sync.Map{}
+	holidays, summaries := caches()
+	holidays.Clear()
+	summaries.Clear()
+}
+
+// CacheStats Métricas de aciertos y fallos de los caches del calendario
+func CacheStats() map[string]cache.Stats {
+	holidays, summaries := caches()
+	return map[string]cache.Stats{
+		HolidayCacheName: holidays.Stats(),
+		SummaryCacheName: summaries.Stats(),
+	}
 }
 
 // daysIn Obtiene la cantidad de días exactos del mes
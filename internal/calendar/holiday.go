@@ -0,0 +1,48 @@
+package calendar
+
+import (
+	"sort"
+	"time"
+)
+
+// Categorías de festivo según cómo se calcula su fecha
+const (
+	CategoryFixed   = "fixed"   // fecha fija del calendario
+	CategoryMovable = "movable" // fecha fija que la ley traslada (p. ej. al lunes, Ley 51 de 1983)
+	CategoryEaster  = "easter"  // fecha relativa al domingo de Pascua
+	CategoryCustom  = "custom"  // festivo personalizado registrado en la tabla holidays
+)
+
+// Holiday Festivo observado con su nombre y origen
+type Holiday struct {
+	Date         time.Time  `json:"date"`
+	Name         string     `json:"name"`
+	Category     string     `json:"category"`
+	Moved        bool       `json:"moved"`                   // la fecha observada difiere de la original
+	OriginalDate *time.Time `json:"original_date,omitempty"` // solo si Moved
+}
+
+// newHoliday Festivo observado en observed cuya fecha original es original
+func newHoliday(name, category string, original, observed time.Time) Holiday {
+	holiday := Holiday{
+		Date:     observed,
+		Name:     name,
+		Category: category,
+	}
+	if !observed.Equal(original) {
+		holiday.Moved = true
+		holiday.OriginalDate = &original
+	}
+	return holiday
+}
+
+// sortHolidays Ordena los festivos por fecha y nombre
+func sortHolidays(holidays []Holiday) []Holiday {
+	sort.Slice(holidays, func(i, j int) bool {
+		if !holidays[i].Date.Equal(holidays[j].Date) {
+			return holidays[i].Date.Before(holidays[j].Date)
+		}
+		return holidays[i].Name < holidays[j].Name
+	})
+	return holidays
+}
@@ -4,17 +4,20 @@ import "time"
 
 // GetColombianHolidays Obtiene los días festivos Colombianos en un año específico
 func GetColombianHolidays(year int) []time.Time {
-	holidayMap := getColombianHolidays(year)
+	return holidayDates(getColombianHolidays(year))
+}
 
-	var holidays []time.Time
-	for date := range holidayMap {
-		holidays = append(holidays, date)
-	}
-	return holidays
+// colombiaProvider Festivos de Colombia (Ley 51 de 1983, "ley Emiliani")
+type colombiaProvider struct{}
+
+func (colombiaProvider) Country() string { return "CO" }
+
+func (colombiaProvider) Holidays(year int) []Holiday {
+	return getColombianHolidays(year)
 }
 
-func getColombianHolidays(year int) map[time.Time]string {
-	holidayMap := make(map[time.Time]string)
+func getColombianHolidays(year int) []Holiday {
+	var holidays []Holiday
 	easter := calculateEaster(year)
 
 	// Fixed holidays
@@ -27,7 +30,7 @@ func getColombianHolidays(year int) map[time.Time]string {
 		time.Date(year, 12, 25, 0, 0, 0, 0, time.UTC): "Christmas",
 	}
 	for date, name := range fixed {
-		holidayMap[date] = name
+		holidays = append(holidays, newHoliday(name, CategoryFixed, date, date))
 	}
 
 	// Movable holidays (law 51/1983)
@@ -41,23 +44,27 @@ func getColombianHolidays(year int) map[time.Time]string {
 		"Cartagena Independence": time.Date(year, 11, 11, 0, 0, 0, 0, time.UTC),
 	}
 	for name, date := range movable {
-		observed := moveToMonday(date)
-		holidayMap[observed] = name
+		holidays = append(holidays, newHoliday(name, CategoryMovable, date, moveToMonday(date)))
 	}
 
-	// Easter-related holidays
+	// Easter-related holidays; those after Easter are also moved to Monday
 	religious := map[string]time.Time{
-		"Holy Thursday":      easter.AddDate(0, 0, -3),
-		"Good Friday":        easter.AddDate(0, 0, -2),
-		"Ascension of Jesus": moveToMonday(easter.AddDate(0, 0, 39)),
-		"Corpus Christi":     moveToMonday(easter.AddDate(0, 0, 60)),
-		"Sacred Heart":       moveToMonday(easter.AddDate(0, 0, 68)),
+		"Holy Thursday": easter.AddDate(0, 0, -3),
+		"Good Friday":   easter.AddDate(0, 0, -2),
 	}
 	for name, date := range religious {
-		holidayMap[date] = name
+		holidays = append(holidays, newHoliday(name, CategoryEaster, date, date))
+	}
+	movableReligious := map[string]time.Time{
+		"Ascension of Jesus": easter.AddDate(0, 0, 39),
+		"Corpus Christi":     easter.AddDate(0, 0, 60),
+		"Sacred Heart":       easter.AddDate(0, 0, 68),
+	}
+	for name, date := range movableReligious {
+		holidays = append(holidays, newHoliday(name, CategoryEaster, date, moveToMonday(date)))
 	}
 
-	return holidayMap
+	return sortHolidays(holidays)
 }
 
 // calculateEaster Calcula los días festivos de pascua
@@ -0,0 +1,82 @@
+package calendar
+
+import "time"
+
+// ecuadorProvider Festivos de Ecuador (Código del Trabajo, art. 65, y reforma de 2016)
+type ecuadorProvider struct{}
+
+func (ecuadorProvider) Country() string { return "EC" }
+
+func (ecuadorProvider) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	easter := calculateEaster(year)
+
+	// Fixed holidays
+	fixed := map[time.Time]string{
+		time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC):   "New Year's Day",
+		time.Date(year, 12, 25, 0, 0, 0, 0, time.UTC): "Christmas",
+	}
+	for date, name := range fixed {
+		holidays = append(holidays, newHoliday(name, CategoryFixed, date, date))
+	}
+
+	// Movable holidays: moved to the nearest Monday or Friday
+	movable := map[string]time.Time{
+		"Labor Day":                 time.Date(year, 5, 1, 0, 0, 0, 0, time.UTC),
+		"Battle of Pichincha":       time.Date(year, 5, 24, 0, 0, 0, 0, time.UTC),
+		"Independence Day":          time.Date(year, 8, 10, 0, 0, 0, 0, time.UTC),
+		"Independence of Guayaquil": time.Date(year, 10, 9, 0, 0, 0, 0, time.UTC),
+	}
+	for name, date := range movable {
+		holidays = append(holidays, newHoliday(name, CategoryMovable, date, moveToLongWeekend(date)))
+	}
+
+	// All Souls' Day and Independence of Cuenca are observed together
+	allSouls := time.Date(year, 11, 2, 0, 0, 0, 0, time.UTC)
+	cuenca := allSouls.AddDate(0, 0, 1)
+	switch allSouls.Weekday() {
+	case time.Tuesday:
+		holidays = append(holidays,
+			newHoliday("All Souls' Day", CategoryMovable, allSouls, allSouls.AddDate(0, 0, -1)),
+			newHoliday("Independence of Cuenca", CategoryMovable, cuenca, allSouls))
+	case time.Wednesday:
+		holidays = append(holidays,
+			newHoliday("All Souls' Day", CategoryMovable, allSouls, allSouls),
+			newHoliday("Independence of Cuenca", CategoryMovable, cuenca, allSouls.AddDate(0, 0, 2)))
+	default:
+		holidays = append(holidays,
+			newHoliday("All Souls' Day", CategoryMovable, allSouls, allSouls),
+			newHoliday("Independence of Cuenca", CategoryMovable, cuenca, moveToLongWeekend(cuenca)))
+	}
+
+	// Easter-related holidays
+	religious := map[string]time.Time{
+		"Carnival Monday":  easter.AddDate(0, 0, -48),
+		"Carnival Tuesday": easter.AddDate(0, 0, -47),
+		"Good Friday":      easter.AddDate(0, 0, -2),
+	}
+	for name, date := range religious {
+		holidays = append(holidays, newHoliday(name, CategoryEaster, date, date))
+	}
+
+	return sortHolidays(holidays)
+}
+
+// moveToLongWeekend Traslada el festivo para formar puente: martes al lunes anterior,
+// miércoles y jueves al viernes siguiente, sábado al viernes anterior y domingo al lunes siguiente
+func moveToLongWeekend(d time.Time) time.Time {
+	switch d.Weekday() {
+	case time.Tuesday:
+		return d.AddDate(0, 0, -1)
+	case time.Wednesday:
+		return d.AddDate(0, 0, 2)
+	case time.Thursday:
+		return d.AddDate(0, 0, 1)
+	case time.Saturday:
+		return d.AddDate(0, 0, -1)
+	case time.Sunday:
+		return d.AddDate(0, 0, 1)
+	default:
+		return d
+	}
+}
@@ -0,0 +1,55 @@
+package calendar
+
+import "time"
+
+// peruProvider Festivos de Perú (Decreto Legislativo 713 y leyes posteriores); no se trasladan
+type peruProvider struct{}
+
+func (peruProvider) Country() string { return "PE" }
+
+func (peruProvider) Holidays(year int) []Holiday {
+	var holidays []Holiday
+	easter := calculateEaster(year)
+
+	// Fixed holidays
+	fixed := map[time.Time]string{
+		time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC):   "New Year's Day",
+		time.Date(year, 5, 1, 0, 0, 0, 0, time.UTC):   "Labor Day",
+		time.Date(year, 6, 29, 0, 0, 0, 0, time.UTC):  "Saint Peter and Paul",
+		time.Date(year, 7, 28, 0, 0, 0, 0, time.UTC):  "Independence Day",
+		time.Date(year, 7, 29, 0, 0, 0, 0, time.UTC):  "Great Military Parade",
+		time.Date(year, 8, 30, 0, 0, 0, 0, time.UTC):  "Saint Rose of Lima",
+		time.Date(year, 10, 8, 0, 0, 0, 0, time.UTC):  "Battle of Angamos",
+		time.Date(year, 11, 1, 0, 0, 0, 0, time.UTC):  "All Saints' Day",
+		time.Date(year, 12, 8, 0, 0, 0, 0, time.UTC):  "Immaculate Conception",
+		time.Date(year, 12, 25, 0, 0, 0, 0, time.UTC): "Christmas",
+	}
+	for date, name := range fixed {
+		holidays = append(holidays, newHoliday(name, CategoryFixed, date, date))
+	}
+
+	// Holidays added by later laws
+	added := map[time.Time]string{}
+	if year >= 2022 {
+		added[time.Date(year, 6, 7, 0, 0, 0, 0, time.UTC)] = "Battle of Arica and Flag Day"
+		added[time.Date(year, 8, 6, 0, 0, 0, 0, time.UTC)] = "Battle of Junín"
+		added[time.Date(year, 12, 9, 0, 0, 0, 0, time.UTC)] = "Battle of Ayacucho"
+	}
+	if year >= 2024 {
+		added[time.Date(year, 7, 23, 0, 0, 0, 0, time.UTC)] = "Peruvian Air Force Day"
+	}
+	for date, name := range added {
+		holidays = append(holidays, newHoliday(name, CategoryFixed, date, date))
+	}
+
+	// Easter-related holidays
+	religious := map[string]time.Time{
+		"Holy Thursday": easter.AddDate(0, 0, -3),
+		"Good Friday":   easter.AddDate(0, 0, -2),
+	}
+	for name, date := range religious {
+		holidays = append(holidays, newHoliday(name, CategoryEaster, date, date))
+	}
+
+	return sortHolidays(holidays)
+}
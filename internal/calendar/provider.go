@@ -0,0 +1,81 @@
+package calendar
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultCountry País cuyo calendario se usa cuando la tienda o franquicia no define uno
+const DefaultCountry = "CO"
+
+// HolidayProvider Calendario de festivos de un país
+type HolidayProvider interface {
+	// Country Código ISO 3166-1 alfa-2 del país
+	Country() string
+	// Holidays Festivos observados en el año, ordenados por fecha
+	Holidays(year int) []Holiday
+}
+
+var providers = map[string]HolidayProvider{}
+
+func init() {
+	RegisterProvider(colombiaProvider{})
+	RegisterProvider(ecuadorProvider{})
+	RegisterProvider(peruProvider{})
+}
+
+// RegisterProvider Registra (o reemplaza) el calendario de un país
+func RegisterProvider(provider HolidayProvider) {
+	providers[strings.ToUpper(provider.Country())] = provider
+}
+
+// GetProvider Obtiene el calendario de un país; un código vacío usa DefaultCountry
+func GetProvider(country string) (HolidayProvider, error) {
+	code := NormalizeCountry(country)
+	provider, ok := providers[code]
+	if !ok {
+		return nil, fmt.Errorf("unsupported holiday calendar country: %q (supported: %s)",
+			country, strings.Join(SupportedCountries(), ", "))
+	}
+	return provider, nil
+}
+
+// IsSupportedCountry Indica si hay un calendario registrado para el país
+func IsSupportedCountry(country string) bool {
+	_, err := GetProvider(country)
+	return err == nil
+}
+
+// NormalizeCountry Código de país en mayúsculas; vacío equivale a DefaultCountry
+func NormalizeCountry(country string) string {
+	code := strings.ToUpper(strings.TrimSpace(country))
+	if code == "" {
+		return DefaultCountry
+	}
+	return code
+}
+
+// SupportedCountries Códigos de los países con calendario registrado, ordenados
+func SupportedCountries() []string {
+	codes := make([]string, 0, len(providers))
+	for code := range providers {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// holidayDates Fechas observadas de una lista ordenada de festivos, sin repetir
+// las que coinciden con más de un festivo
+func holidayDates(holidays []Holiday) []time.Time {
+	dates := make([]time.Time, 0, len(holidays))
+	for _, holiday := range holidays {
+		if n := len(dates); n > 0 && dates[n-1].Equal(holiday.Date) {
+			continue
+		}
+		dates = append(dates, holiday.Date)
+	}
+	return dates
+}
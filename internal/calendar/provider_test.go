@@ -0,0 +1,142 @@
+package calendar
+
+import (
+	"loopi-api/internal/cache"
+	"testing"
+	"time"
+)
+
+func TestGetProvider(t *testing.T) {
+	tests := []struct {
+		country  string
+		expected string
+	}{
+		{"", DefaultCountry}, // empty uses the default calendar
+		{"co", "CO"},
+		{" EC ", "EC"},
+		{"PE", "PE"},
+	}
+
+	for _, tt := range tests {
+		provider, err := GetProvider(tt.country)
+		if err != nil {
+			t.Fatalf("GetProvider(%q): unexpected error %v", tt.country, err)
+		}
+		if provider.Country() != tt.expected {
+			t.Errorf("GetProvider(%q): expected %s, got %s", tt.country, tt.expected, provider.Country())
+		}
+	}
+
+	if _, err := GetProvider("XX"); err == nil {
+		t.Error("GetProvider(XX): expected unsupported country error")
+	}
+}
+
+func TestProviderHolidays2025(t *testing.T) {
+	date := func(month, day int) time.Time {
+		return time.Date(2025, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		country string
+		date    time.Time
+		name    string
+	}{
+		{"CO", date(3, 24), "Saint Joseph's Day"}, // March 19 moved to Monday
+		{"EC", date(3, 3), "Carnival Monday"},
+		{"EC", date(5, 2), "Labor Day"},            // Thursday moved to Friday
+		{"EC", date(5, 23), "Battle of Pichincha"}, // Saturday moved to Friday
+		{"EC", date(8, 11), "Independence Day"},    // Sunday moved to Monday
+		{"EC", date(11, 3), "Independence of Cuenca"},
+		{"PE", date(4, 17), "Holy Thursday"},
+		{"PE", date(7, 29), "Great Military Parade"},
+	}
+
+	for _, tt := range tests {
+		provider, _ := GetProvider(tt.country)
+		if holiday := findHoliday(provider.Holidays(2025), tt.name); holiday == nil || !holiday.Date.Equal(tt.date) {
+			t.Errorf("%s %q: expected on %s, got %+v", tt.country, tt.name, tt.date.Format("2006-01-02"), holiday)
+		}
+	}
+}
+
+func TestColombianHolidays_Metadata(t *testing.T) {
+	holidays := getColombianHolidays(2025)
+
+	tests := []struct {
+		name     string
+		category string
+		original string // empty when not moved
+	}{
+		{"Battle of Boyacá", CategoryFixed, ""},
+		{"Saint Joseph's Day", CategoryMovable, "2025-03-19"}, // Wednesday moved to Monday
+		{"Epiphany", CategoryMovable, ""},                     // already a Monday
+		{"Good Friday", CategoryEaster, ""},
+		{"Corpus Christi", CategoryEaster, "2025-06-19"}, // Easter + 60 moved to Monday
+	}
+
+	for _, tt := range tests {
+		holiday := findHoliday(holidays, tt.name)
+		if holiday == nil {
+			t.Fatalf("%q: not found", tt.name)
+		}
+		if holiday.Category != tt.category {
+			t.Errorf("%q: expected category %s, got %s", tt.name, tt.category, holiday.Category)
+		}
+		if holiday.Moved != (tt.original != "") {
+			t.Errorf("%q: expected moved=%v, got %v", tt.name, tt.original != "", holiday.Moved)
+		}
+		if tt.original != "" && (holiday.OriginalDate == nil || holiday.OriginalDate.Format("2006-01-02") != tt.original) {
+			t.Errorf("%q: expected original date %s, got %v", tt.name, tt.original, holiday.OriginalDate)
+		}
+	}
+
+	// Sacred Heart and Saint Peter and Paul are both observed on June 30: two holidays, one date
+	if len(holidays) != 18 {
+		t.Errorf("expected 18 Colombian holidays in 2025, got %d", len(holidays))
+	}
+	if got := len(GetColombianHolidays(2025)); got != 17 {
+		t.Errorf("expected 17 Colombian holiday dates in 2025, got %d", got)
+	}
+}
+
+func findHoliday(holidays []Holiday, name string) *Holiday {
+	for i := range holidays {
+		if holidays[i].Name == name {
+			return &holidays[i]
+		}
+	}
+	return nil
+}
+
+func TestGetHolidaysByMonthCached_UsesCountryCalendar(t *testing.T) {
+	// July 23, 28 and 29 are holidays in Peru but not in Colombia
+	if got := len(GetHolidaysByMonthCached("PE", 2025, 7)); got != 3 {
+		t.Errorf("PE July 2025: expected 3 holidays, got %d", got)
+	}
+	for _, d := range GetHolidaysByMonthCached("CO", 2025, 7) {
+		if d.Day() == 28 || d.Day() == 29 {
+			t.Errorf("CO July 2025: unexpected holiday %s", d.Format("2006-01-02"))
+		}
+	}
+}
+
+func TestGetHolidayDetailsCached_HitsAndClear(t *testing.T) {
+	UseCaches(cache.NewMemory(8, time.Hour), cache.NewMemory(8, time.Hour))
+
+	first := GetHolidayDetailsCached("CO", 2030)
+	first[0].Name = "mutated" // callers get a copy, the cached slice is untouched
+	second := GetHolidayDetailsCached("co", 2030)
+
+	if second[0].Name == "mutated" {
+		t.Error("Expected cached holidays to be isolated from callers")
+	}
+	if stats := CacheStats()[HolidayCacheName]; stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("Expected 1 hit and 1 miss, got %+v", stats)
+	}
+
+	ClearCalendarCache()
+	if stats := CacheStats()[HolidayCacheName]; stats.Entries != 0 {
+		t.Errorf("Expected an empty cache after clear, got %+v", stats)
+	}
+}
@@ -0,0 +1,20 @@
+package calendar
+
+import "time"
+
+// Zonas horarias de los países con calendario; ninguno aplica horario de verano,
+// así que un desplazamiento fijo evita depender de la base tzdata del sistema
+var locations = map[string]*time.Location{
+	"CO": time.FixedZone("America/Bogota", -5*60*60),
+	"EC": time.FixedZone("America/Guayaquil", -5*60*60),
+	"PE": time.FixedZone("America/Lima", -5*60*60),
+}
+
+// Location Zona horaria en la que se interpretan los turnos de un país; un país
+// sin zona registrada usa la de DefaultCountry
+func Location(country string) *time.Location {
+	if location, ok := locations[NormalizeCountry(country)]; ok {
+		return location
+	}
+	return locations[DefaultCountry]
+}
@@ -1,19 +1,32 @@
 package container
 
 import (
+	"loopi-api/config"
+	"loopi-api/internal/cache"
 	"loopi-api/internal/delivery/http"
+	"loopi-api/internal/notify"
 	"loopi-api/internal/repository"
 	mysqlRepo "loopi-api/internal/repository/mysql"
+	"loopi-api/internal/token"
 	"loopi-api/internal/usecase"
+	"time"
 
 	"gorm.io/gorm"
 )
 
+// loginAttemptEntries bounds the accounts and IPs whose failed logins are remembered;
+// locked ones are kept until their lock expires even when more keys are seen
+const loginAttemptEntries = 10000
+
 // Container holds all dependencies for the application
 type Container struct {
 	// Database
 	DB *gorm.DB
 
+	// Access token signing keys and the verifier used by middleware.JWTMiddleware
+	Keys     *token.KeySet
+	Verifier *token.Verifier
+
 	// Repositories
 	Repositories *Repositories
 
@@ -31,9 +44,22 @@ type Repositories struct {
 	Store         repository.StoreRepository
 	AssignedShift repository.AssignedShiftRepository
 	Absence       repository.AbsenceRepository
+	AbsenceType   repository.AbsenceTypeRepository
 	Novelty       repository.NoveltyRepository
 	Shift         repository.ShiftRepository
 	WorkConfig    repository.WorkConfigRepository
+	PayrollConfig repository.PayrollConfigRepository
+	Holiday       repository.HolidayRepository
+	CalendarFeed  repository.CalendarFeedRepository
+	AuthSession   repository.AuthSessionRepository
+	Role          repository.RoleRepository
+	Permission    repository.PermissionRepository
+	Tenant        repository.TenantRepository
+	PasswordReset repository.PasswordResetRepository
+	Vacation      repository.VacationAdjustmentRepository
+	TimeClock     repository.TimeClockRepository
+	ShiftSwap     repository.ShiftSwapRepository
+	Availability  repository.AvailabilityRepository
 }
 
 // UseCases contains all use case implementations
@@ -47,7 +73,24 @@ type UseCases struct {
 	ShiftProjection usecase.ShiftProjectionUseCase
 	Calendar        usecase.CalendarUseCase
 	Absence         usecase.AbsenceUseCase
+	AbsenceType     usecase.AbsenceTypeUseCase
 	Novelty         usecase.NoveltyUseCase
+	Assignment      usecase.AssignmentUseCase
+	Roster          usecase.RosterGeneratorUseCase
+	WorkConfig      usecase.WorkConfigUseCase
+	Payroll         usecase.PayrollUseCase
+	Holiday         usecase.HolidayUseCase
+	CalendarFeed    usecase.CalendarFeedUseCase
+	Role            usecase.RoleUseCase
+	SelfService     usecase.SelfServiceUseCase
+	Permission      usecase.PermissionUseCase
+	Vacation        usecase.VacationUseCase
+	TimeClock       usecase.TimeClockUseCase
+	ShiftSwap       usecase.ShiftSwapUseCase
+	Availability    usecase.AvailabilityUseCase
+
+	// Permissions resolves the permissions checked by middleware.RequirePermission
+	Permissions *usecase.PermissionResolver
 }
 
 // Handlers contains all HTTP handlers
@@ -62,22 +105,37 @@ type Handlers struct {
 	Calendar        *http.CalendarHandler
 	Absence         *http.AbsenceHandler
 	Novelty         *http.NoveltyHandler
+	Assignment      *http.AssignmentHandler
+	Roster          *http.RosterHandler
+	WorkConfig      *http.WorkConfigHandler
+	Payroll         *http.PayrollHandler
+	Holiday         *http.HolidayHandler
+	CalendarFeed    *http.CalendarFeedHandler
+	Role            *http.RoleHandler
+	SelfService     *http.SelfServiceHandler
+	JWKS            *http.JWKSHandler
+	Vacation        *http.VacationHandler
+	TimeClock       *http.TimeClockHandler
+	ShiftSwap       *http.ShiftSwapHandler
+	Availability    *http.AvailabilityHandler
 }
 
-// NewContainer creates a new dependency container
-func NewContainer(db *gorm.DB) *Container {
+// NewContainer creates a new dependency container; keys sign and verify access tokens
+func NewContainer(db *gorm.DB, keys *token.KeySet) *Container {
 	container := &Container{
-		DB: db,
+		DB:       db,
+		Keys:     keys,
+		Verifier: token.NewVerifier(keys, config.GetJWTIssuer()),
 	}
 
 	// Initialize repositories
 	container.Repositories = newRepositories(db)
 
 	// Initialize use cases
-	container.UseCases = newUseCases(container.Repositories)
+	container.UseCases = newUseCases(container.Repositories, keys)
 
 	// Initialize handlers
-	container.Handlers = newHandlers(container.UseCases)
+	container.Handlers = newHandlers(container.UseCases, keys)
 
 	return container
 }
@@ -90,30 +148,98 @@ func newRepositories(db *gorm.DB) *Repositories {
 		Store:         mysqlRepo.NewStoreRepository(db),
 		AssignedShift: mysqlRepo.NewAssignedShiftRepository(db),
 		Absence:       mysqlRepo.NewAbsenceRepository(db),
+		AbsenceType:   mysqlRepo.NewAbsenceTypeRepository(db),
 		Novelty:       mysqlRepo.NewNoveltyRepository(db),
 		Shift:         mysqlRepo.NewShiftRepository(db),
 		WorkConfig:    mysqlRepo.NewWorkConfigRepository(db),
+		PayrollConfig: mysqlRepo.NewPayrollConfigRepository(db),
+		Holiday:       mysqlRepo.NewHolidayRepository(db),
+		CalendarFeed:  mysqlRepo.NewCalendarFeedRepository(db),
+		AuthSession:   mysqlRepo.NewAuthSessionRepository(db),
+		Role:          mysqlRepo.NewRoleRepository(db),
+		Permission:    mysqlRepo.NewPermissionRepository(db),
+		Tenant:        mysqlRepo.NewTenantRepository(db),
+		PasswordReset: mysqlRepo.NewPasswordResetRepository(db),
+		Vacation:      mysqlRepo.NewVacationAdjustmentRepository(db),
+		TimeClock:     mysqlRepo.NewTimeClockRepository(db),
+		ShiftSwap:     mysqlRepo.NewShiftSwapRepository(db),
+		Availability:  mysqlRepo.NewAvailabilityRepository(db),
 	}
 }
 
 // newUseCases creates all use case instances
-func newUseCases(repos *Repositories) *UseCases {
-	return &UseCases{
-		Auth:            usecase.NewAuthUseCase(repos.User),
+func newUseCases(repos *Repositories, keys *token.KeySet) *UseCases {
+	// Holiday calendars are selected by the store or franchise country and merged with custom holidays
+	holidays := usecase.NewHolidayCalendarResolver(repos.Store, repos.Franchise, repos.Holiday)
+
+	// Tenant-scoped use cases only reach records of the franchise selected in the token
+	tenant := usecase.NewTenantGuard(repos.Tenant)
+
+	// Payroll builds on the employee hours summary; attendance compares it with the punched time
+	employeeHours := usecase.NewEmployeeHoursUseCase(repos.AssignedShift, repos.Absence, repos.Novelty, repos.User, repos.WorkConfig, repos.TimeClock, holidays, tenant)
+
+	// Role and permission changes invalidate the cached permission lookups
+	permissions := usecase.NewPermissionResolver(repos.Role)
+
+	// Failed logins are tracked in memory; a shared cache.Cache makes lockouts hold across replicas
+	loginThrottle := usecase.NewLoginThrottle(cache.NewMemory(loginAttemptEntries, 24*time.Hour))
+
+	// Access tokens are signed with the active key and carry its ID in the "kid" header
+	issuer := token.NewIssuer(keys, config.GetJWTIssuer(), config.GetAccessTokenTTL())
+
+	// Temporary passwords and reset tokens are delivered through the configured notifier
+	notifier := newNotifier()
+
+	// iCalendar feeds render the holiday calendar and employee schedules
+	calendarUC := usecase.NewCalendarUseCase(holidays)
+
+	// Vacation requests are checked against the ledger accrued from the hire date
+	vacations := usecase.NewVacationUseCase(repos.User, repos.Absence, repos.AbsenceType, repos.Vacation, holidays, tenant)
+
+	useCases := &UseCases{
+		Auth:            usecase.NewAuthUseCase(repos.User, repos.Store, repos.AuthSession, repos.PasswordReset, notifier, loginThrottle, issuer, permissions),
 		Franchise:       usecase.NewFranchiseUseCase(repos.Franchise),
-		Store:           usecase.NewStoreUseCase(repos.Store),
-		Employee:        usecase.NewEmployeeUseCase(repos.User, repos.Store, repos.Franchise),
-		EmployeeHours:   usecase.NewEmployeeHoursUseCase(repos.AssignedShift, repos.Absence, repos.Novelty, repos.User),
-		Shift:           usecase.NewShiftUseCase(repos.Shift),
-		ShiftProjection: usecase.NewShiftProjectionUseCase(repos.Shift, repos.WorkConfig),
-		Calendar:        usecase.NewCalendarUseCase(),
-		Absence:         usecase.NewAbsenceUseCase(repos.Absence),
-		Novelty:         usecase.NewNoveltyUseCase(repos.Novelty),
+		Store:           usecase.NewStoreUseCase(repos.Store, tenant),
+		Employee:        usecase.NewEmployeeUseCase(repos.User, repos.Role, repos.AuthSession, notifier, tenant),
+		EmployeeHours:   employeeHours,
+		Shift:           usecase.NewShiftUseCase(repos.Shift, tenant),
+		ShiftProjection: usecase.NewShiftProjectionUseCase(repos.Shift, repos.WorkConfig, holidays, tenant),
+		Calendar:        calendarUC,
+		Absence:         usecase.NewAbsenceUseCase(repos.Absence, repos.AbsenceType, vacations, tenant),
+		AbsenceType:     usecase.NewAbsenceTypeUseCase(repos.AbsenceType),
+		Novelty:         usecase.NewNoveltyUseCase(repos.Novelty, tenant),
+		Assignment:      usecase.NewAssignmentUseCase(repos.AssignedShift, repos.Shift, repos.User, repos.WorkConfig, repos.Availability, tenant),
+		Roster:          usecase.NewRosterGeneratorUseCase(repos.AssignedShift, repos.Shift, repos.User, repos.WorkConfig, repos.Availability, holidays, tenant),
+		WorkConfig:      usecase.NewWorkConfigUseCase(repos.WorkConfig),
+		Payroll:         usecase.NewPayrollUseCase(employeeHours, repos.User, repos.PayrollConfig, repos.WorkConfig, tenant),
+		Holiday:         usecase.NewHolidayUseCase(repos.Holiday, repos.Store, tenant),
+		CalendarFeed:    usecase.NewCalendarFeedUseCase(repos.CalendarFeed, repos.AssignedShift, repos.Absence, repos.Novelty, repos.User, calendarUC, holidays, tenant),
+		Role:            usecase.NewRoleUseCase(repos.Role, repos.Permission, permissions, tenant),
+		Permission:      usecase.NewPermissionUseCase(repos.Permission, permissions),
+		Vacation:        vacations,
+		TimeClock:       usecase.NewTimeClockUseCase(repos.TimeClock, repos.AssignedShift, repos.Store, repos.Absence, repos.Novelty, repos.WorkConfig, holidays, tenant),
+		ShiftSwap:       usecase.NewShiftSwapUseCase(repos.ShiftSwap, repos.AssignedShift, repos.Store, repos.WorkConfig, repos.Availability, holidays, tenant),
+		Availability:    usecase.NewAvailabilityUseCase(repos.Availability, tenant),
+		Permissions:     permissions,
+	}
+
+	// Self-service reads the caller's own data through the tenant-scoped use cases
+	useCases.SelfService = usecase.NewSelfServiceUseCase(useCases.Employee, useCases.Assignment, employeeHours, useCases.Absence, useCases.Novelty, vacations, useCases.Availability)
+
+	return useCases
+}
+
+// newNotifier selects the notifier from NOTIFIER ("log" or "file"), already checked at startup.
+// An email or SMS provider is plugged in here by implementing notify.Notifier.
+func newNotifier() notify.Notifier {
+	if config.GetNotifier() == "file" {
+		return notify.NewFile(config.GetNotifierFile())
 	}
+	return notify.NewLog()
 }
 
 // newHandlers creates all HTTP handler instances
-func newHandlers(useCases *UseCases) *Handlers {
+func newHandlers(useCases *UseCases, keys *token.KeySet) *Handlers {
 	return &Handlers{
 		Auth:            http.NewAuthHandler(useCases.Auth),
 		Franchise:       http.NewFranchiseHandler(useCases.Franchise),
@@ -123,7 +249,20 @@ func newHandlers(useCases *UseCases) *Handlers {
 		Shift:           http.NewShiftHandler(useCases.Shift),
 		ShiftProjection: http.NewShiftProjectionHandler(useCases.ShiftProjection),
 		Calendar:        http.NewCalendarHandler(useCases.Calendar),
-		Absence:         http.NewAbsenceHandler(useCases.Absence),
+		Absence:         http.NewAbsenceHandler(useCases.Absence, useCases.AbsenceType),
 		Novelty:         http.NewNoveltyHandler(useCases.Novelty),
+		Assignment:      http.NewAssignmentHandler(useCases.Assignment),
+		Roster:          http.NewRosterHandler(useCases.Roster),
+		WorkConfig:      http.NewWorkConfigHandler(useCases.WorkConfig),
+		Payroll:         http.NewPayrollHandler(useCases.Payroll),
+		Holiday:         http.NewHolidayHandler(useCases.Holiday),
+		CalendarFeed:    http.NewCalendarFeedHandler(useCases.CalendarFeed),
+		Role:            http.NewRoleHandler(useCases.Role, useCases.Permission),
+		SelfService:     http.NewSelfServiceHandler(useCases.SelfService),
+		JWKS:            http.NewJWKSHandler(keys),
+		Vacation:        http.NewVacationHandler(useCases.Vacation),
+		TimeClock:       http.NewTimeClockHandler(useCases.TimeClock),
+		ShiftSwap:       http.NewShiftSwapHandler(useCases.ShiftSwap),
+		Availability:    http.NewAvailabilityHandler(useCases.Availability),
 	}
 }
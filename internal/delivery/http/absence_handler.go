@@ -4,32 +4,152 @@ import (
 	"encoding/json"
 	"loopi-api/internal/delivery/http/rest"
 	"loopi-api/internal/domain"
+	"loopi-api/internal/middleware"
 	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
 	"net/http"
 	"strconv"
 	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type AbsenceHandler struct {
-	uc usecase.AbsenceUseCase
+	uc     usecase.AbsenceUseCase
+	typeUC usecase.AbsenceTypeUseCase
 }
 
-func NewAbsenceHandler(uc usecase.AbsenceUseCase) *AbsenceHandler {
-	return &AbsenceHandler{uc}
+func NewAbsenceHandler(uc usecase.AbsenceUseCase, typeUC usecase.AbsenceTypeUseCase) *AbsenceHandler {
+	return &AbsenceHandler{uc: uc, typeUC: typeUC}
 }
 
+// Create registers an absence request for an employee; it stays pending until reviewed
 func (h *AbsenceHandler) Create(w http.ResponseWriter, r *http.Request) {
 	var req domain.Absence
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		rest.BadRequest(w, "Invalid request body")
 		return
 	}
-	if err := h.uc.Create(req); err != nil {
+
+	absence, err := h.uc.Create(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, absence)
+}
+
+func (h *AbsenceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid absence ID format")
+		return
+	}
+
+	absence, err := h.uc.GetByID(middleware.GetTenantScope(r.Context()), id)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, absence)
+}
+
+// GetPending lists the absence requests awaiting review
+func (h *AbsenceHandler) GetPending(w http.ResponseWriter, r *http.Request) {
+	absences, err := h.uc.GetPending(middleware.GetTenantScope(r.Context()))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, absences)
+}
+
+// Approve accepts a pending absence with an optional note
+func (h *AbsenceHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.uc.Approve)
+}
+
+// Reject declines a pending absence with an optional note
+func (h *AbsenceHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.uc.Reject)
+}
+
+func (h *AbsenceHandler) review(w http.ResponseWriter, r *http.Request,
+	decide func(scope domain.TenantScope, id, reviewerID int, note string) (*domain.Absence, error)) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid absence ID format")
+		return
+	}
+
+	// The body is optional
+	var req dto.AbsenceReviewRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			rest.BadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	absence, err := decide(middleware.GetTenantScope(r.Context()), id, middleware.GetUserID(r.Context()), req.Note)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, absence)
+}
+
+// GetTypes lists the absence type catalog; ?all=true includes inactive types
+func (h *AbsenceHandler) GetTypes(w http.ResponseWriter, r *http.Request) {
+	types, err := h.typeUC.GetAll(r.URL.Query().Get("all") == "true")
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, types)
+}
+
+func (h *AbsenceHandler) CreateType(w http.ResponseWriter, r *http.Request) {
+	var req dto.AbsenceTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	absenceType, err := h.typeUC.Create(req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, absenceType)
+}
+
+func (h *AbsenceHandler) UpdateType(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid absence type ID format")
+		return
+	}
+
+	var req dto.AbsenceTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	absenceType, err := h.typeUC.Update(id, req)
+	if err != nil {
 		rest.HandleError(w, err)
 		return
 	}
 
-	rest.Created(w, map[string]string{"message": "Absence registered"})
+	rest.OK(w, absenceType)
 }
 
 func (h *AbsenceHandler) GetByEmployeeAndMonth(w http.ResponseWriter, r *http.Request) {
@@ -42,7 +162,7 @@ func (h *AbsenceHandler) GetByEmployeeAndMonth(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	absences, err := h.uc.GetByEmployeeAndMonth(employeeID, year, month)
+	absences, err := h.uc.GetByEmployeeAndMonth(middleware.GetTenantScope(r.Context()), employeeID, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -80,7 +200,7 @@ func (h *AbsenceHandler) GetByEmployeeAndDateRange(w http.ResponseWriter, r *htt
 		return
 	}
 
-	absences, err := h.uc.GetByEmployeeAndDateRange(employeeID, from, to)
+	absences, err := h.uc.GetByEmployeeAndDateRange(middleware.GetTenantScope(r.Context()), employeeID, from, to)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -100,7 +220,7 @@ func (h *AbsenceHandler) GetTotalHours(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	totalHours, err := h.uc.GetTotalHoursByEmployee(employeeID, year, month)
+	totalHours, err := h.uc.GetTotalHoursByEmployee(middleware.GetTenantScope(r.Context()), employeeID, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
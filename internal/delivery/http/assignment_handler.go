@@ -0,0 +1,160 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AssignmentHandler struct {
+	uc usecase.AssignmentUseCase
+}
+
+func NewAssignmentHandler(uc usecase.AssignmentUseCase) *AssignmentHandler {
+	return &AssignmentHandler{uc: uc}
+}
+
+// Assign schedules a shift for an employee on a given date
+func (h *AssignmentHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	var req dto.AssignShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	assignment, err := h.uc.Assign(middleware.GetTenantScope(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, assignment)
+}
+
+// BulkAssign schedules the same shift for an employee over a date range
+func (h *AssignmentHandler) BulkAssign(w http.ResponseWriter, r *http.Request) {
+	var req dto.BulkAssignRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	assignments, err := h.uc.BulkAssign(middleware.GetTenantScope(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, map[string]interface{}{
+		"created":     len(assignments),
+		"assignments": assignments,
+	})
+}
+
+func (h *AssignmentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid assignment ID format")
+		return
+	}
+
+	assignment, err := h.uc.GetByID(middleware.GetTenantScope(r.Context()), id)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, assignment)
+}
+
+// Reassign changes the employee, shift or date of an assignment
+func (h *AssignmentHandler) Reassign(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid assignment ID format")
+		return
+	}
+
+	var req dto.ReassignShiftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	assignment, err := h.uc.Reassign(middleware.GetTenantScope(r.Context()), id, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, assignment)
+}
+
+func (h *AssignmentHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid assignment ID format")
+		return
+	}
+
+	if err := h.uc.Unassign(middleware.GetTenantScope(r.Context()), id); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Assignment deleted successfully"})
+}
+
+// GetByEmployee returns the monthly roster of an employee
+func (h *AssignmentHandler) GetByEmployee(w http.ResponseWriter, r *http.Request) {
+	employeeID, err := strconv.Atoi(chi.URLParam(r, "employee_id"))
+	if err != nil {
+		rest.BadRequest(w, "invalid employee_id")
+		return
+	}
+
+	year, _ := strconv.Atoi(r.URL.Query().Get("year"))
+	month, _ := strconv.Atoi(r.URL.Query().Get("month"))
+	if year == 0 || month == 0 {
+		rest.BadRequest(w, "Missing parameters: year, month")
+		return
+	}
+
+	assignments, err := h.uc.GetByEmployeeAndMonth(middleware.GetTenantScope(r.Context()), employeeID, year, month)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, assignments)
+}
+
+// GetByStore returns the roster of a store between two dates
+func (h *AssignmentHandler) GetByStore(w http.ResponseWriter, r *http.Request) {
+	storeID, err := strconv.Atoi(chi.URLParam(r, "store_id"))
+	if err != nil {
+		rest.BadRequest(w, "invalid store_id")
+		return
+	}
+
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+	if from == "" || to == "" {
+		rest.BadRequest(w, "Missing required parameters: from, to")
+		return
+	}
+
+	assignments, err := h.uc.GetByStoreAndDateRange(middleware.GetTenantScope(r.Context()), storeID, from, to)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, assignments)
+}
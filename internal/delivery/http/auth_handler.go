@@ -5,6 +5,8 @@ import (
 	"loopi-api/internal/delivery/http/rest"
 	"loopi-api/internal/middleware"
 	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net"
 	nethttp "net/http"
 )
 
@@ -33,13 +35,13 @@ func (h *AuthHandler) Login(w nethttp.ResponseWriter, r *nethttp.Request) {
 		return
 	}
 
-	token, err := h.authUseCase.Login(req.Email, req.Password)
+	tokens, err := h.authUseCase.Login(req.Email, req.Password, clientIP(r))
 	if err != nil {
 		rest.HandleError(w, err)
 		return
 	}
 
-	rest.OK(w, map[string]string{"token": token})
+	rest.OK(w, tokens)
 }
 
 func (h *AuthHandler) SelectContext(w nethttp.ResponseWriter, r *nethttp.Request) {
@@ -56,11 +58,139 @@ func (h *AuthHandler) SelectContext(w nethttp.ResponseWriter, r *nethttp.Request
 	}
 
 	// Lógica de selección y verificación
-	token, err := h.authUseCase.SelectContext(userID, req.FranchiseID, req.StoreID)
+	tokens, err := h.authUseCase.SelectContext(userID, middleware.GetSessionID(r.Context()), req.FranchiseID, req.StoreID)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
 	}
 
-	rest.OK(w, map[string]string{"token": token})
+	rest.OK(w, tokens)
+}
+
+// Refresh exchanges a refresh token for a new access/refresh token pair (no JWT required)
+func (h *AuthHandler) Refresh(w nethttp.ResponseWriter, r *nethttp.Request) {
+	var req dto.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		rest.BadRequest(w, "Missing or invalid refresh token")
+		return
+	}
+
+	tokens, err := h.authUseCase.Refresh(req.RefreshToken)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, tokens)
+}
+
+// Logout revokes the session of the access token; an empty body is allowed
+func (h *AuthHandler) Logout(w nethttp.ResponseWriter, r *nethttp.Request) {
+	var req dto.LogoutRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			rest.BadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	if userID == 0 {
+		rest.Unauthorized(w, "Invalid token context")
+		return
+	}
+
+	if err := h.authUseCase.Logout(userID, middleware.GetSessionID(r.Context()), req.AllSessions); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Logged out successfully"})
+}
+
+// ChangePassword replaces the caller's password; also accepted while a change is required
+func (h *AuthHandler) ChangePassword(w nethttp.ResponseWriter, r *nethttp.Request) {
+	var req dto.ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	userID := middleware.GetUserID(r.Context())
+	if userID == 0 {
+		rest.Unauthorized(w, "Invalid token context")
+		return
+	}
+
+	tokens, err := h.authUseCase.ChangePassword(userID, middleware.GetSessionID(r.Context()), req.CurrentPassword, req.NewPassword)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, tokens)
+}
+
+// ForgotPassword sends a reset token to the email (no JWT required); the response does not
+// reveal whether the email exists
+func (h *AuthHandler) ForgotPassword(w nethttp.ResponseWriter, r *nethttp.Request) {
+	var req dto.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.authUseCase.RequestPasswordReset(req.Email); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "If the email exists, a reset token has been sent"})
+}
+
+// ResetPassword sets a new password with a reset token (no JWT required)
+func (h *AuthHandler) ResetPassword(w nethttp.ResponseWriter, r *nethttp.Request) {
+	var req dto.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	if err := h.authUseCase.ResetPassword(req.Token, req.NewPassword); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Password updated; log in again"})
+}
+
+// GetLockout returns the failed-login state of ?email=
+func (h *AuthHandler) GetLockout(w nethttp.ResponseWriter, r *nethttp.Request) {
+	email := r.URL.Query().Get("email")
+	if email == "" {
+		rest.BadRequest(w, "Missing email")
+		return
+	}
+
+	rest.OK(w, h.authUseCase.GetLockout(email))
+}
+
+// Unlock clears the failed logins of ?email= and/or ?ip=
+func (h *AuthHandler) Unlock(w nethttp.ResponseWriter, r *nethttp.Request) {
+	if err := h.authUseCase.Unlock(r.URL.Query().Get("email"), r.URL.Query().Get("ip")); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Login attempts cleared"})
+}
+
+// clientIP returns the address of the connection. Behind a reverse proxy every client
+// shares the proxy's address, which is why the per-IP lockout is looser than per-account.
+func clientIP(r *nethttp.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type AvailabilityHandler struct {
+	uc usecase.AvailabilityUseCase
+}
+
+func NewAvailabilityHandler(uc usecase.AvailabilityUseCase) *AvailabilityHandler {
+	return &AvailabilityHandler{uc}
+}
+
+// Get returns an employee's weekly windows and the exceptions for ?from=&to= (default: next 90 days)
+func (h *AvailabilityHandler) Get(w http.ResponseWriter, r *http.Request) {
+	employeeID, err := strconv.Atoi(chi.URLParam(r, "employee_id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid employee ID format")
+		return
+	}
+
+	availability, err := h.uc.GetAvailability(middleware.GetTenantScope(r.Context()), employeeID,
+		r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, availability)
+}
+
+// SetWindows replaces an employee's weekly windows
+func (h *AvailabilityHandler) SetWindows(w http.ResponseWriter, r *http.Request) {
+	employeeID, err := strconv.Atoi(chi.URLParam(r, "employee_id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid employee ID format")
+		return
+	}
+
+	var req dto.AvailabilityWindowsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	windows, err := h.uc.SetWindows(middleware.GetTenantScope(r.Context()), employeeID, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, windows)
+}
+
+// CreateException records a change of an employee's availability on a date
+func (h *AvailabilityHandler) CreateException(w http.ResponseWriter, r *http.Request) {
+	employeeID, err := strconv.Atoi(chi.URLParam(r, "employee_id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid employee ID format")
+		return
+	}
+
+	var req dto.AvailabilityExceptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	exception, err := h.uc.CreateException(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), employeeID, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, exception)
+}
+
+func (h *AvailabilityHandler) DeleteException(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid availability exception ID format")
+		return
+	}
+
+	if err := h.uc.DeleteException(middleware.GetTenantScope(r.Context()), id); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Availability exception deleted successfully"})
+}
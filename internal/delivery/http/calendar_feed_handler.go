@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CalendarFeedHandler struct {
+	uc usecase.CalendarFeedUseCase
+}
+
+func NewCalendarFeedHandler(uc usecase.CalendarFeedUseCase) *CalendarFeedHandler {
+	return &CalendarFeedHandler{uc: uc}
+}
+
+// Create issues a subscription URL; the token is only returned here
+func (h *CalendarFeedHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateCalendarFeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	subscription, err := h.uc.CreateFeed(middleware.GetTenantScope(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, subscription)
+}
+
+func (h *CalendarFeedHandler) GetByEmployee(w http.ResponseWriter, r *http.Request) {
+	employeeID, err := strconv.Atoi(chi.URLParam(r, "employee_id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid employee ID format")
+		return
+	}
+
+	feeds, err := h.uc.GetByEmployee(middleware.GetTenantScope(r.Context()), employeeID)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, feeds)
+}
+
+func (h *CalendarFeedHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid calendar feed ID format")
+		return
+	}
+
+	if err := h.uc.Revoke(middleware.GetTenantScope(r.Context()), id); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Calendar feed revoked successfully"})
+}
+
+// Subscribe serves the iCalendar of /feeds/{token}.ics; the token replaces the JWT
+func (h *CalendarFeedHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimSuffix(chi.URLParam(r, "token"), ".ics")
+
+	content, err := h.uc.RenderFeed(token)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.ICalendar(w, content)
+}
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"loopi-api/internal/calendar"
 	"loopi-api/internal/delivery/http/rest"
 	"loopi-api/internal/usecase"
 	"net/http"
@@ -16,6 +17,17 @@ func NewCalendarHandler(calendarUseCase usecase.CalendarUseCase) *CalendarHandle
 	return &CalendarHandler{calendarUseCase: calendarUseCase}
 }
 
+// resolveScope reads the calendar scope from the "store_id" or "country" query parameters
+func (h *CalendarHandler) resolveScope(r *http.Request) (usecase.CalendarScope, error) {
+	storeID := 0
+	if s := r.URL.Query().Get("store_id"); s != "" {
+		if parsed, err := strconv.Atoi(s); err == nil {
+			storeID = parsed
+		}
+	}
+	return h.calendarUseCase.ResolveScope(r.URL.Query().Get("country"), storeID)
+}
+
 func (h *CalendarHandler) GetHolidays(w http.ResponseWriter, r *http.Request) {
 	year := time.Now().Year()
 	month := 0 // Si no se especifica, trae todo el año
@@ -31,34 +43,67 @@ func (h *CalendarHandler) GetHolidays(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	var holidays []time.Time
-	var err error
-	if month > 0 {
-		holidays, err = h.calendarUseCase.GetHolidaysByMonth(year, month)
-	} else {
-		holidays, err = h.calendarUseCase.GetHolidays(year)
+	scope, err := h.resolveScope(r)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
 	}
 
+	if month < 0 {
+		month = 0
+	}
+	holidays, err := h.calendarUseCase.GetHolidayDetails(scope, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
 	}
 
+	// Dates are kept for existing clients; a date shared by two holidays is listed once
 	result := struct {
-		Count int      `json:"count"`
-		Dates []string `json:"dates"`
+		Country  string            `json:"country"`
+		Count    int               `json:"count"`
+		Dates    []string          `json:"dates"`
+		Holidays []holidayResponse `json:"holidays"`
 	}{
-		Count: len(holidays),
-		Dates: make([]string, len(holidays)),
+		Country:  scope.Country,
+		Dates:    make([]string, 0, len(holidays)),
+		Holidays: make([]holidayResponse, len(holidays)),
 	}
 
-	for i, d := range holidays {
-		result.Dates[i] = d.Format("2006-01-02")
+	for i, holiday := range holidays {
+		date := holiday.Date.Format("2006-01-02")
+		if n := len(result.Dates); n == 0 || result.Dates[n-1] != date {
+			result.Dates = append(result.Dates, date)
+		}
+		result.Holidays[i] = newHolidayResponse(holiday)
 	}
+	result.Count = len(result.Dates)
 
 	rest.OK(w, result)
 }
 
+// holidayResponse is a holiday with its dates formatted as "YYYY-MM-DD"
+type holidayResponse struct {
+	Date         string `json:"date"`
+	Name         string `json:"name"`
+	Category     string `json:"category"`
+	Moved        bool   `json:"moved"`
+	OriginalDate string `json:"original_date,omitempty"`
+}
+
+func newHolidayResponse(holiday calendar.Holiday) holidayResponse {
+	response := holidayResponse{
+		Date:     holiday.Date.Format("2006-01-02"),
+		Name:     holiday.Name,
+		Category: holiday.Category,
+		Moved:    holiday.Moved,
+	}
+	if holiday.OriginalDate != nil {
+		response.OriginalDate = holiday.OriginalDate.Format("2006-01-02")
+	}
+	return response
+}
+
 func (h *CalendarHandler) GetMonthSummary(w http.ResponseWriter, r *http.Request) {
 	year := time.Now().Year()
 	month := int(time.Now().Month())
@@ -74,8 +119,14 @@ func (h *CalendarHandler) GetMonthSummary(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	scope, err := h.resolveScope(r)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
 	// Use the new enhanced usecase method
-	summary, err := h.calendarUseCase.GetMonthSummary(year, month)
+	summary, err := h.calendarUseCase.GetMonthSummary(scope, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -83,6 +134,7 @@ func (h *CalendarHandler) GetMonthSummary(w http.ResponseWriter, r *http.Request
 
 	// Format response
 	result := struct {
+		Country  string `json:"country"`
 		Holidays struct {
 			Count int      `json:"count"`
 			Dates []string `json:"dates"`
@@ -92,6 +144,7 @@ func (h *CalendarHandler) GetMonthSummary(w http.ResponseWriter, r *http.Request
 		WorkingDays  int `json:"working_days"`
 	}{}
 
+	result.Country = summary.Country
 	result.Holidays.Count = len(summary.Holidays)
 	result.Holidays.Dates = make([]string, len(summary.Holidays))
 	for i, d := range summary.Holidays {
@@ -112,6 +165,11 @@ func (h *CalendarHandler) ClearCache(w http.ResponseWriter, r *http.Request) {
 	rest.OK(w, map[string]string{"message": "Calendar cache cleared"})
 }
 
+// GetCacheStats returns the hit/miss counters and occupancy of the calendar caches
+func (h *CalendarHandler) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+	rest.OK(w, h.calendarUseCase.GetCacheStats())
+}
+
 // GetWorkingDays retrieves the number of working days in a month
 func (h *CalendarHandler) GetWorkingDays(w http.ResponseWriter, r *http.Request) {
 	year := time.Now().Year()
@@ -128,13 +186,20 @@ func (h *CalendarHandler) GetWorkingDays(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	workingDays, err := h.calendarUseCase.GetWorkingDays(year, month)
+	scope, err := h.resolveScope(r)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	workingDays, err := h.calendarUseCase.GetWorkingDays(scope, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
 	}
 
 	rest.OK(w, map[string]interface{}{
+		"country":      scope.Country,
 		"year":         year,
 		"month":        month,
 		"working_days": workingDays,
@@ -157,7 +222,13 @@ func (h *CalendarHandler) GetEnhancedSummary(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	summary, err := h.calendarUseCase.GetMonthSummary(year, month)
+	scope, err := h.resolveScope(r)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	summary, err := h.calendarUseCase.GetMonthSummary(scope, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -170,6 +241,7 @@ func (h *CalendarHandler) GetEnhancedSummary(w http.ResponseWriter, r *http.Requ
 	}
 
 	result := struct {
+		Country      string   `json:"country"`
 		Year         int      `json:"year"`
 		Month        int      `json:"month"`
 		Holidays     []string `json:"holidays"`
@@ -177,6 +249,7 @@ func (h *CalendarHandler) GetEnhancedSummary(w http.ResponseWriter, r *http.Requ
 		Sundays      int      `json:"sundays"`
 		WorkingDays  int      `json:"working_days"`
 	}{
+		Country:      summary.Country,
 		Year:         summary.Year,
 		Month:        summary.Month,
 		Holidays:     formattedHolidays,
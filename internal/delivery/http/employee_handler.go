@@ -5,9 +5,11 @@ import (
 	"log"
 	"loopi-api/internal/delivery/http/rest"
 	"loopi-api/internal/domain"
+	"loopi-api/internal/middleware"
 	"loopi-api/internal/usecase"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 )
@@ -31,10 +33,12 @@ var employeeRequest struct {
 	Position       string  `json:"position"`
 	Salary         float64 `json:"salary"`
 	StoreID        int     `json:"store_id"`
+	Role           string  `json:"role"`      // employee (default) | store_manager
+	HireDate       string  `json:"hire_date"` // YYYY-MM-DD; default: today
 }
 
 func (h *EmployeeHandler) GetAll(w http.ResponseWriter, r *http.Request) {
-	employees, err := h.employeeUseCase.GetAll()
+	employees, err := h.employeeUseCase.GetAll(middleware.GetTenantScope(r.Context()))
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -50,7 +54,7 @@ func (h *EmployeeHandler) GetByStore(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	users, err := h.employeeUseCase.GetByStore(storeID)
+	users, err := h.employeeUseCase.GetByStore(middleware.GetTenantScope(r.Context()), storeID)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -65,7 +69,7 @@ func (h *EmployeeHandler) FindByID(w http.ResponseWriter, r *http.Request) {
 		rest.HandleError(w, err)
 	}
 
-	employee, err := h.employeeUseCase.FindByID(id)
+	employee, err := h.employeeUseCase.FindByID(middleware.GetTenantScope(r.Context()), id)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -92,8 +96,16 @@ func (h *EmployeeHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Position:       employeeRequest.Position,
 		Salary:         employeeRequest.Salary,
 	}
+	if employeeRequest.HireDate != "" {
+		hireDate, err := time.Parse("2006-01-02", employeeRequest.HireDate)
+		if err != nil {
+			rest.BadRequest(w, "Invalid hire_date format, expected YYYY-MM-DD")
+			return
+		}
+		user.HireDate = &hireDate
+	}
 
-	if err := h.employeeUseCase.Create(user, employeeRequest.StoreID); err != nil {
+	if err := h.employeeUseCase.Create(middleware.GetTenantScope(r.Context()), user, employeeRequest.StoreID, employeeRequest.Role); err != nil {
 		rest.HandleError(w, err)
 		return
 	}
@@ -110,7 +122,7 @@ func (h *EmployeeHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.employeeUseCase.Update(id, updates); err != nil {
+	if err := h.employeeUseCase.Update(middleware.GetTenantScope(r.Context()), id, updates); err != nil {
 		rest.HandleError(w, err)
 		return
 	}
@@ -124,7 +136,7 @@ func (h *EmployeeHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		rest.HandleError(w, err)
 	}
 
-	if err := h.employeeUseCase.Delete(id); err != nil {
+	if err := h.employeeUseCase.Delete(middleware.GetTenantScope(r.Context()), id); err != nil {
 		rest.HandleError(w, err)
 		return
 	}
@@ -134,7 +146,7 @@ func (h *EmployeeHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 // GetActiveEmployees retrieves only active employees
 func (h *EmployeeHandler) GetActiveEmployees(w http.ResponseWriter, r *http.Request) {
-	employees, err := h.employeeUseCase.GetActiveEmployees()
+	employees, err := h.employeeUseCase.GetActiveEmployees(middleware.GetTenantScope(r.Context()))
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -151,7 +163,7 @@ func (h *EmployeeHandler) GetByStoreAndActive(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	employees, err := h.employeeUseCase.GetByStoreAndActive(storeID)
+	employees, err := h.employeeUseCase.GetByStoreAndActive(middleware.GetTenantScope(r.Context()), storeID)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -2,6 +2,7 @@ package http
 
 import (
 	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
 	"loopi-api/internal/usecase"
 	"net/http"
 	"strconv"
@@ -39,7 +40,7 @@ func (h *EmployeeHoursHandler) GetMonthlySummary(w http.ResponseWriter, r *http.
 		}
 	}
 
-	summary, err := h.employeeHoursUseCase.GetMonthlySummary(employeeID, year, month)
+	summary, err := h.employeeHoursUseCase.GetMonthlySummary(middleware.GetTenantScope(r.Context()), employeeID, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -78,7 +79,7 @@ func (h *EmployeeHoursHandler) GetDailySummary(w http.ResponseWriter, r *http.Re
 		}
 	}
 
-	summary, err := h.employeeHoursUseCase.GetDailySummary(employeeID, year, month, day)
+	summary, err := h.employeeHoursUseCase.GetDailySummary(middleware.GetTenantScope(r.Context()), employeeID, year, month, day)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -104,7 +105,7 @@ func (h *EmployeeHoursHandler) GetYearlySummary(w http.ResponseWriter, r *http.R
 		}
 	}
 
-	summary, err := h.employeeHoursUseCase.GetYearlySummary(employeeID, year)
+	summary, err := h.employeeHoursUseCase.GetYearlySummary(middleware.GetTenantScope(r.Context()), employeeID, year)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -137,7 +138,7 @@ func (h *EmployeeHoursHandler) GetWorkingDays(w http.ResponseWriter, r *http.Req
 		}
 	}
 
-	workingDays, err := h.employeeHoursUseCase.CalculateWorkingDays(employeeID, year, month)
+	workingDays, err := h.employeeHoursUseCase.CalculateWorkingDays(middleware.GetTenantScope(r.Context()), employeeID, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -150,3 +151,36 @@ func (h *EmployeeHoursHandler) GetWorkingDays(w http.ResponseWriter, r *http.Req
 		"working_days": workingDays,
 	})
 }
+
+// GetAttendance compares the planned and punched hours of an employee in a month
+func (h *EmployeeHoursHandler) GetAttendance(w http.ResponseWriter, r *http.Request) {
+	employeeIDStr := chi.URLParam(r, "id")
+	employeeID, err := strconv.Atoi(employeeIDStr)
+	if err != nil || employeeID <= 0 {
+		rest.BadRequest(w, "Invalid employee ID")
+		return
+	}
+
+	// Get year and month from query parameters
+	year := time.Now().Year()
+	month := int(time.Now().Month())
+
+	if y := r.URL.Query().Get("year"); y != "" {
+		if parsed, err := strconv.Atoi(y); err == nil {
+			year = parsed
+		}
+	}
+	if m := r.URL.Query().Get("month"); m != "" {
+		if parsed, err := strconv.Atoi(m); err == nil {
+			month = parsed
+		}
+	}
+
+	summary, err := h.employeeHoursUseCase.GetAttendanceSummary(middleware.GetTenantScope(r.Context()), employeeID, year, month)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, summary)
+}
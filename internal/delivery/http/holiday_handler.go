@@ -0,0 +1,109 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type HolidayHandler struct {
+	uc usecase.HolidayUseCase
+}
+
+func NewHolidayHandler(uc usecase.HolidayUseCase) *HolidayHandler {
+	return &HolidayHandler{uc: uc}
+}
+
+// GetCustom lists the custom holidays of ?year= (current year by default)
+func (h *HolidayHandler) GetCustom(w http.ResponseWriter, r *http.Request) {
+	year := time.Now().Year()
+	if y := r.URL.Query().Get("year"); y != "" {
+		if parsed, err := strconv.Atoi(y); err == nil {
+			year = parsed
+		}
+	}
+
+	holidays, err := h.uc.GetByYear(middleware.GetTenantScope(r.Context()), year)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, holidays)
+}
+
+func (h *HolidayHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid holiday ID format")
+		return
+	}
+
+	holiday, err := h.uc.GetByID(middleware.GetTenantScope(r.Context()), id)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, holiday)
+}
+
+func (h *HolidayHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.HolidayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	holiday, err := h.uc.Create(middleware.GetTenantScope(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, holiday)
+}
+
+func (h *HolidayHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid holiday ID format")
+		return
+	}
+
+	var req dto.HolidayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	holiday, err := h.uc.Update(middleware.GetTenantScope(r.Context()), id, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, holiday)
+}
+
+func (h *HolidayHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid holiday ID format")
+		return
+	}
+
+	if err := h.uc.Delete(middleware.GetTenantScope(r.Context()), id); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Holiday deleted successfully"})
+}
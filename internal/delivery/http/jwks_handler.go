@@ -0,0 +1,26 @@
+package http
+
+import (
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/token"
+	"net/http"
+)
+
+// jwksMaxAge lets clients cache the key set; it must stay well below the access token TTL
+// kept between adding a key and signing with it
+const jwksMaxAge = "public, max-age=300"
+
+// JWKSHandler publishes the public keys that verify access tokens
+type JWKSHandler struct {
+	keys *token.KeySet
+}
+
+func NewJWKSHandler(keys *token.KeySet) *JWKSHandler {
+	return &JWKSHandler{keys}
+}
+
+// GetKeys serves the JSON Web Key Set, retired keys included
+func (h *JWKSHandler) GetKeys(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", jwksMaxAge)
+	rest.OK(w, h.keys.JWKS())
+}
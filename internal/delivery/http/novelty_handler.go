@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"loopi-api/internal/delivery/http/rest"
 	"loopi-api/internal/domain"
+	"loopi-api/internal/middleware"
 	"loopi-api/internal/usecase"
 	"net/http"
 	"strconv"
@@ -24,7 +25,7 @@ func (h *NoveltyHandler) Create(w http.ResponseWriter, r *http.Request) {
 		rest.BadRequest(w, "Invalid request body")
 		return
 	}
-	if err := h.uc.Create(req); err != nil {
+	if err := h.uc.Create(middleware.GetTenantScope(r.Context()), req); err != nil {
 		rest.HandleError(w, err)
 		return
 	}
@@ -42,7 +43,7 @@ func (h *NoveltyHandler) GetByEmployeeAndMonth(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	novelties, err := h.uc.GetByEmployeeAndMonth(employeeID, year, month)
+	novelties, err := h.uc.GetByEmployeeAndMonth(middleware.GetTenantScope(r.Context()), employeeID, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -80,7 +81,7 @@ func (h *NoveltyHandler) GetByEmployeeAndDateRange(w http.ResponseWriter, r *htt
 		return
 	}
 
-	novelties, err := h.uc.GetByEmployeeAndDateRange(employeeID, from, to)
+	novelties, err := h.uc.GetByEmployeeAndDateRange(middleware.GetTenantScope(r.Context()), employeeID, from, to)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -101,7 +102,7 @@ func (h *NoveltyHandler) GetTotalHoursByType(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	totalHours, err := h.uc.GetTotalHoursByEmployeeAndType(employeeID, year, month, noveltyType)
+	totalHours, err := h.uc.GetTotalHoursByEmployeeAndType(middleware.GetTenantScope(r.Context()), employeeID, year, month, noveltyType)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -127,7 +128,7 @@ func (h *NoveltyHandler) GetTypesSummary(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	summary, err := h.uc.GetNoveltyTypesSummary(employeeID, year, month)
+	summary, err := h.uc.GetNoveltyTypesSummary(middleware.GetTenantScope(r.Context()), employeeID, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
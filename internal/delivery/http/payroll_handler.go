@@ -0,0 +1,103 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type PayrollHandler struct {
+	uc usecase.PayrollUseCase
+}
+
+func NewPayrollHandler(uc usecase.PayrollUseCase) *PayrollHandler {
+	return &PayrollHandler{uc: uc}
+}
+
+// GetEmployeePayroll returns the payroll breakdown of an employee for ?year=&month= (current month by default)
+func (h *PayrollHandler) GetEmployeePayroll(w http.ResponseWriter, r *http.Request) {
+	employeeID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || employeeID <= 0 {
+		rest.BadRequest(w, "Invalid employee ID")
+		return
+	}
+	year, month := payrollPeriod(r)
+
+	payroll, err := h.uc.GetEmployeePayroll(middleware.GetTenantScope(r.Context()), employeeID, year, month)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, payroll)
+}
+
+// GetStorePayroll returns the payroll of every active employee of a store
+func (h *PayrollHandler) GetStorePayroll(w http.ResponseWriter, r *http.Request) {
+	storeID, err := strconv.Atoi(chi.URLParam(r, "store_id"))
+	if err != nil || storeID <= 0 {
+		rest.BadRequest(w, "Invalid store ID")
+		return
+	}
+	year, month := payrollPeriod(r)
+
+	payrolls, err := h.uc.GetStorePayroll(middleware.GetTenantScope(r.Context()), storeID, year, month)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, payrolls)
+}
+
+func (h *PayrollHandler) GetConfigs(w http.ResponseWriter, r *http.Request) {
+	configs, err := h.uc.GetConfigs()
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, configs)
+}
+
+func (h *PayrollHandler) CreateConfig(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreatePayrollConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	config, err := h.uc.CreateConfig(req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, config)
+}
+
+// payrollPeriod reads ?year= and ?month=, defaulting to the current month
+func payrollPeriod(r *http.Request) (int, int) {
+	year := time.Now().Year()
+	month := int(time.Now().Month())
+
+	if y := r.URL.Query().Get("year"); y != "" {
+		if parsed, err := strconv.Atoi(y); err == nil {
+			year = parsed
+		}
+	}
+	if m := r.URL.Query().Get("month"); m != "" {
+		if parsed, err := strconv.Atoi(m); err == nil {
+			month = parsed
+		}
+	}
+
+	return year, month
+}
@@ -59,3 +59,12 @@ func NoContent(w http.ResponseWriter) {
 func WriteError(w http.ResponseWriter, status int, message string) {
 	JSON(w, status, map[string]string{"error": message})
 }
+
+// ICalendar (200) con un calendario RFC 5545 para suscripción
+func ICalendar(w http.ResponseWriter, content string) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	w.WriteHeader(http.StatusOK)
+	//nolint:errcheck,gosec
+	_, _ = w.Write([]byte(content))
+}
@@ -0,0 +1,237 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type RoleHandler struct {
+	roles       usecase.RoleUseCase
+	permissions usecase.PermissionUseCase
+}
+
+func NewRoleHandler(roles usecase.RoleUseCase, permissions usecase.PermissionUseCase) *RoleHandler {
+	return &RoleHandler{roles: roles, permissions: permissions}
+}
+
+func (h *RoleHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.roles.GetAll()
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, roles)
+}
+
+// Get returns a role with its permissions
+func (h *RoleHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid role ID format")
+		return
+	}
+
+	role, err := h.roles.GetByID(id)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, role)
+}
+
+func (h *RoleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	role, err := h.roles.Create(req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, role)
+}
+
+func (h *RoleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid role ID format")
+		return
+	}
+
+	var req dto.RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	role, err := h.roles.Update(id, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, role)
+}
+
+func (h *RoleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid role ID format")
+		return
+	}
+
+	if err := h.roles.Delete(id); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Role deleted successfully"})
+}
+
+// SetPermissions replaces the permissions granted by a role
+func (h *RoleHandler) SetPermissions(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid role ID format")
+		return
+	}
+
+	var req dto.RolePermissionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	role, err := h.roles.SetPermissions(id, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, role)
+}
+
+// AssignToUser grants a role to a user in the token's franchise
+func (h *RoleHandler) AssignToUser(w http.ResponseWriter, r *http.Request) {
+	h.changeUserRole(w, r, h.roles.AssignToUser)
+}
+
+// RevokeFromUser removes a role of a user in the token's franchise
+func (h *RoleHandler) RevokeFromUser(w http.ResponseWriter, r *http.Request) {
+	h.changeUserRole(w, r, h.roles.RevokeFromUser)
+}
+
+func (h *RoleHandler) changeUserRole(w http.ResponseWriter, r *http.Request, change func(domain.TenantScope, int, dto.UserRoleRequest) error) {
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid user ID format")
+		return
+	}
+
+	var req dto.UserRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	if err := change(middleware.GetTenantScope(r.Context()), userID, req); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.NoContent(w)
+}
+
+func (h *RoleHandler) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	permissions, err := h.permissions.GetAll()
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, permissions)
+}
+
+func (h *RoleHandler) CreatePermission(w http.ResponseWriter, r *http.Request) {
+	var req dto.PermissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	permission, err := h.permissions.Create(req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, permission)
+}
+
+func (h *RoleHandler) UpdatePermission(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid permission ID format")
+		return
+	}
+
+	var req dto.PermissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid JSON format")
+		return
+	}
+
+	permission, err := h.permissions.Update(id, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, permission)
+}
+
+func (h *RoleHandler) DeletePermission(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid permission ID format")
+		return
+	}
+
+	if err := h.permissions.Delete(id); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Permission deleted successfully"})
+}
+
+// GetMyPermissions returns the permissions of the caller in the token's franchise
+func (h *RoleHandler) GetMyPermissions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetUserID(r.Context())
+	franchiseID := middleware.GetFranchiseID(r.Context())
+
+	permissions, err := h.permissions.GetUserPermissions(userID, franchiseID)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]interface{}{
+		"franchise_id": franchiseID,
+		"permissions":  permissions,
+	})
+}
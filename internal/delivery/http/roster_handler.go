@@ -0,0 +1,52 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+)
+
+type RosterHandler struct {
+	uc usecase.RosterGeneratorUseCase
+}
+
+func NewRosterHandler(uc usecase.RosterGeneratorUseCase) *RosterHandler {
+	return &RosterHandler{uc: uc}
+}
+
+// Preview Propone la malla mensual de una tienda sin guardarla
+func (h *RosterHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	var req dto.RosterGenerationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid input")
+		return
+	}
+
+	proposal, err := h.uc.Preview(middleware.GetTenantScope(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, proposal)
+}
+
+// Commit Genera la malla mensual de una tienda y la guarda
+func (h *RosterHandler) Commit(w http.ResponseWriter, r *http.Request) {
+	var req dto.RosterGenerationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid input")
+		return
+	}
+
+	proposal, err := h.uc.Commit(middleware.GetTenantScope(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, proposal)
+}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SelfServiceHandler serves the /me routes: the employee is always the token's user
+type SelfServiceHandler struct {
+	uc usecase.SelfServiceUseCase
+}
+
+func NewSelfServiceHandler(uc usecase.SelfServiceUseCase) *SelfServiceHandler {
+	return &SelfServiceHandler{uc}
+}
+
+// GetProfile returns the caller's profile
+func (h *SelfServiceHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	user, err := h.uc.GetProfile(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, user)
+}
+
+// GetShifts returns the caller's assigned shifts for ?year=&month= (default: current month)
+func (h *SelfServiceHandler) GetShifts(w http.ResponseWriter, r *http.Request) {
+	year, month := payrollPeriod(r)
+
+	shifts, err := h.uc.GetShifts(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), year, month)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, shifts)
+}
+
+// GetHoursSummary returns the caller's monthly hours summary for ?year=&month=
+func (h *SelfServiceHandler) GetHoursSummary(w http.ResponseWriter, r *http.Request) {
+	year, month := payrollPeriod(r)
+
+	summary, err := h.uc.GetHoursSummary(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), year, month)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, summary)
+}
+
+// GetAbsences returns the caller's absences for ?year=&month=
+func (h *SelfServiceHandler) GetAbsences(w http.ResponseWriter, r *http.Request) {
+	year, month := payrollPeriod(r)
+
+	absences, err := h.uc.GetAbsences(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), year, month)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, absences)
+}
+
+// RequestAbsence submits an absence request of the caller; it stays pending until reviewed
+func (h *SelfServiceHandler) RequestAbsence(w http.ResponseWriter, r *http.Request) {
+	var req domain.Absence
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	absence, err := h.uc.RequestAbsence(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, absence)
+}
+
+// GetNovelties returns the caller's novelties for ?year=&month=
+func (h *SelfServiceHandler) GetNovelties(w http.ResponseWriter, r *http.Request) {
+	year, month := payrollPeriod(r)
+
+	novelties, err := h.uc.GetNovelties(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), year, month)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, novelties)
+}
+
+// GetVacationLedger returns the caller's vacation balance and movements
+func (h *SelfServiceHandler) GetVacationLedger(w http.ResponseWriter, r *http.Request) {
+	ledger, err := h.uc.GetVacationLedger(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, ledger)
+}
+
+// GetAvailability returns the caller's weekly windows and the exceptions for ?from=&to=
+func (h *SelfServiceHandler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	availability, err := h.uc.GetAvailability(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()),
+		r.URL.Query().Get("from"), r.URL.Query().Get("to"))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, availability)
+}
+
+// SetAvailabilityWindows replaces the caller's weekly windows
+func (h *SelfServiceHandler) SetAvailabilityWindows(w http.ResponseWriter, r *http.Request) {
+	var req dto.AvailabilityWindowsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	windows, err := h.uc.SetAvailabilityWindows(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, windows)
+}
+
+// AddAvailabilityException records a change of the caller's availability on a date
+func (h *SelfServiceHandler) AddAvailabilityException(w http.ResponseWriter, r *http.Request) {
+	var req dto.AvailabilityExceptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	exception, err := h.uc.AddAvailabilityException(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, exception)
+}
+
+// DeleteAvailabilityException removes one of the caller's date exceptions
+func (h *SelfServiceHandler) DeleteAvailabilityException(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid availability exception ID format")
+		return
+	}
+
+	if err := h.uc.DeleteAvailabilityException(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), id); err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, map[string]string{"message": "Availability exception deleted successfully"})
+}
@@ -27,8 +27,8 @@ func (h *ShiftHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.shiftUseCase.Create(req); err != nil {
-		rest.BadRequest(w, err.Error())
+	if err := h.shiftUseCase.Create(middleware.GetTenantScope(r.Context()), req); err != nil {
+		rest.HandleError(w, err)
 		return
 	}
 
@@ -36,6 +36,7 @@ func (h *ShiftHandler) Create(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *ShiftHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	scope := middleware.GetTenantScope(r.Context())
 	storeID, _ := strconv.Atoi(r.URL.Query().Get("store"))
 	activeOnly := r.URL.Query().Get("active") == "true"
 
@@ -44,11 +45,11 @@ func (h *ShiftHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 
 	// Handle different query scenarios
 	if storeID > 0 && activeOnly {
-		shifts, err = h.shiftUseCase.GetActiveShiftsByStore(storeID)
+		shifts, err = h.shiftUseCase.GetActiveShiftsByStore(scope, storeID)
 	} else if storeID > 0 {
-		shifts, err = h.shiftUseCase.GetByStore(storeID)
+		shifts, err = h.shiftUseCase.GetByStore(scope, storeID)
 	} else {
-		shifts, err = h.shiftUseCase.GetAll()
+		shifts, err = h.shiftUseCase.GetAll(scope)
 	}
 
 	if err != nil {
@@ -77,16 +78,9 @@ func (h *ShiftHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	shift, err := h.shiftUseCase.GetByID(id)
-	if err != nil || shift == nil {
-		rest.NotFound(w, "Shift not found")
-		return
-	}
-
-	// Validar store_id contra contexto
-	contextStoreID := middleware.GetStoreID(r.Context())
-	if contextStoreID != shift.StoreID {
-		rest.Forbidden(w, "You do not have access to this shift")
+	shift, err := h.shiftUseCase.GetByID(middleware.GetTenantScope(r.Context()), id)
+	if err != nil {
+		rest.HandleError(w, err)
 		return
 	}
 
@@ -101,7 +95,7 @@ func (h *ShiftHandler) GetStatistics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	statistics, err := h.shiftUseCase.GetShiftStatistics(storeID)
+	statistics, err := h.shiftUseCase.GetShiftStatistics(middleware.GetTenantScope(r.Context()), storeID)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -123,9 +117,9 @@ func (h *ShiftHandler) GetByStore(w http.ResponseWriter, r *http.Request) {
 
 	var shifts []domain.Shift
 	if activeOnly {
-		shifts, err = h.shiftUseCase.GetActiveShiftsByStore(storeID)
+		shifts, err = h.shiftUseCase.GetActiveShiftsByStore(middleware.GetTenantScope(r.Context()), storeID)
 	} else {
-		shifts, err = h.shiftUseCase.GetByStore(storeID)
+		shifts, err = h.shiftUseCase.GetByStore(middleware.GetTenantScope(r.Context()), storeID)
 	}
 
 	if err != nil {
@@ -149,7 +143,7 @@ func (h *ShiftHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.shiftUseCase.Delete(id); err != nil {
+	if err := h.shiftUseCase.Delete(middleware.GetTenantScope(r.Context()), id); err != nil {
 		rest.HandleError(w, err)
 		return
 	}
@@ -199,7 +193,7 @@ func (h *ShiftHandler) Update(w http.ResponseWriter, r *http.Request) {
 		LunchMinutes: shiftRequest.LunchMinutes,
 	}
 
-	if err := h.shiftUseCase.Update(shift); err != nil {
+	if err := h.shiftUseCase.Update(middleware.GetTenantScope(r.Context()), shift); err != nil {
 		rest.HandleError(w, err)
 		return
 	}
@@ -3,6 +3,7 @@ package http
 import (
 	"encoding/json"
 	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
 	"loopi-api/internal/usecase"
 	"loopi-api/internal/usecase/dto"
 	"net/http"
@@ -25,7 +26,7 @@ func (h *ShiftProjectionHandler) Preview(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	result, err := h.shiftProjectionUseCase.PreviewHours(req)
+	result, err := h.shiftProjectionUseCase.PreviewHours(middleware.GetTenantScope(r.Context()), req)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -64,7 +65,7 @@ func (h *ShiftProjectionHandler) GetProjectionSummary(w http.ResponseWriter, r *
 		return
 	}
 
-	summary, err := h.shiftProjectionUseCase.GetShiftProjectionSummary(shiftID, year, month)
+	summary, err := h.shiftProjectionUseCase.GetShiftProjectionSummary(middleware.GetTenantScope(r.Context()), shiftID, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -103,7 +104,7 @@ func (h *ShiftProjectionHandler) GetProjectedDays(w http.ResponseWriter, r *http
 		return
 	}
 
-	projectedDays, err := h.shiftProjectionUseCase.CalculateProjectedDays(shiftID, year, month)
+	projectedDays, err := h.shiftProjectionUseCase.CalculateProjectedDays(middleware.GetTenantScope(r.Context()), shiftID, year, month)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
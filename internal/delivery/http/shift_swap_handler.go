@@ -0,0 +1,192 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ShiftSwapHandler struct {
+	uc usecase.ShiftSwapUseCase
+}
+
+func NewShiftSwapHandler(uc usecase.ShiftSwapUseCase) *ShiftSwapHandler {
+	return &ShiftSwapHandler{uc}
+}
+
+// Offer puts a shift of the authenticated user up for a colleague to take
+func (h *ShiftSwapHandler) Offer(w http.ResponseWriter, r *http.Request) {
+	var req dto.ShiftSwapOfferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	swap, err := h.uc.Offer(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, swap)
+}
+
+// GetOpen lists the colleagues' offers the authenticated user can accept
+func (h *ShiftSwapHandler) GetOpen(w http.ResponseWriter, r *http.Request) {
+	swaps, err := h.uc.GetOpen(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, swaps)
+}
+
+// GetMine lists the swaps offered or accepted by the authenticated user
+func (h *ShiftSwapHandler) GetMine(w http.ResponseWriter, r *http.Request) {
+	swaps, err := h.uc.GetMine(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, swaps)
+}
+
+// Accept takes an open offer for the authenticated user; the body is optional
+func (h *ShiftSwapHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid shift swap ID format")
+		return
+	}
+
+	var req dto.ShiftSwapAcceptRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	swap, err := h.uc.Accept(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), id, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, swap)
+}
+
+// Cancel withdraws an offer of the authenticated user
+func (h *ShiftSwapHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid shift swap ID format")
+		return
+	}
+
+	swap, err := h.uc.Cancel(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), id)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, swap)
+}
+
+func (h *ShiftSwapHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid shift swap ID format")
+		return
+	}
+
+	swap, err := h.uc.GetByID(middleware.GetTenantScope(r.Context()), id)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, swap)
+}
+
+// GetPending lists the accepted swaps awaiting approval
+func (h *ShiftSwapHandler) GetPending(w http.ResponseWriter, r *http.Request) {
+	swaps, err := h.uc.GetPending(middleware.GetTenantScope(r.Context()))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, swaps)
+}
+
+// Approve applies an accepted swap with an optional note
+func (h *ShiftSwapHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.uc.Approve)
+}
+
+// Reject declines an accepted swap with an optional note
+func (h *ShiftSwapHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.uc.Reject)
+}
+
+func (h *ShiftSwapHandler) review(w http.ResponseWriter, r *http.Request,
+	decide func(scope domain.TenantScope, id, reviewerID int, note string) (*domain.ShiftSwap, error)) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid shift swap ID format")
+		return
+	}
+
+	// The body is optional
+	var req dto.ShiftSwapReviewRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			rest.BadRequest(w, "Invalid request body")
+			return
+		}
+	}
+
+	swap, err := decide(middleware.GetTenantScope(r.Context()), id, middleware.GetUserID(r.Context()), req.Note)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, swap)
+}
+
+// GetByStore lists the swaps of a store with a shift within a date range
+func (h *ShiftSwapHandler) GetByStore(w http.ResponseWriter, r *http.Request) {
+	storeIDStr := r.URL.Query().Get("store_id")
+	from := r.URL.Query().Get("from")
+	to := r.URL.Query().Get("to")
+
+	if storeIDStr == "" || from == "" || to == "" {
+		rest.BadRequest(w, "Missing required parameters: store_id, from, to")
+		return
+	}
+
+	storeID, err := strconv.Atoi(storeIDStr)
+	if err != nil {
+		rest.BadRequest(w, "Invalid store ID")
+		return
+	}
+
+	swaps, err := h.uc.GetByStoreAndDateRange(middleware.GetTenantScope(r.Context()), storeID, from, to)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, swaps)
+}
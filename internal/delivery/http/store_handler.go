@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"loopi-api/internal/delivery/http/rest"
 	"loopi-api/internal/domain"
+	"loopi-api/internal/middleware"
 	"loopi-api/internal/usecase"
 	"net/http"
 	"strconv"
@@ -20,6 +21,8 @@ func NewStoreHandler(storeUseCase usecase.StoreUseCase) *StoreHandler {
 }
 
 func (h *StoreHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	scope := middleware.GetTenantScope(r.Context())
+
 	// Check for query parameters to determine which operation to use
 	franchiseID, _ := strconv.Atoi(r.URL.Query().Get("franchise"))
 	activeOnly := r.URL.Query().Get("active") == "true"
@@ -27,7 +30,7 @@ func (h *StoreHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 
 	if franchiseID > 0 && withEmployeeCount {
 		// Get stores with employee count for franchise
-		stores, err := h.storeUseCase.GetStoresWithEmployeeCount(franchiseID)
+		stores, err := h.storeUseCase.GetStoresWithEmployeeCount(scope, franchiseID)
 		if err != nil {
 			rest.HandleError(w, err)
 			return
@@ -38,7 +41,7 @@ func (h *StoreHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 
 	if franchiseID > 0 && activeOnly {
 		// Get only active stores for franchise
-		stores, err := h.storeUseCase.GetActiveStoresByFranchise(franchiseID)
+		stores, err := h.storeUseCase.GetActiveStoresByFranchise(scope, franchiseID)
 		if err != nil {
 			rest.HandleError(w, err)
 			return
@@ -49,7 +52,7 @@ func (h *StoreHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 
 	if franchiseID > 0 {
 		// Get all stores for franchise
-		stores, err := h.storeUseCase.GetByFranchiseID(franchiseID)
+		stores, err := h.storeUseCase.GetByFranchiseID(scope, franchiseID)
 		if err != nil {
 			rest.HandleError(w, err)
 			return
@@ -58,8 +61,8 @@ func (h *StoreHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get all stores
-	stores, err := h.storeUseCase.GetAll()
+	// Get all stores of the caller's franchise
+	stores, err := h.storeUseCase.GetAll(scope)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -76,7 +79,7 @@ func (h *StoreHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	store, err := h.storeUseCase.GetByID(id)
+	store, err := h.storeUseCase.GetByID(middleware.GetTenantScope(r.Context()), id)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -98,9 +101,9 @@ func (h *StoreHandler) GetByFranchiseID(w http.ResponseWriter, r *http.Request)
 
 	var stores []domain.Store
 	if activeOnly {
-		stores, err = h.storeUseCase.GetActiveStoresByFranchise(franchiseID)
+		stores, err = h.storeUseCase.GetActiveStoresByFranchise(middleware.GetTenantScope(r.Context()), franchiseID)
 	} else {
-		stores, err = h.storeUseCase.GetByFranchiseID(franchiseID)
+		stores, err = h.storeUseCase.GetByFranchiseID(middleware.GetTenantScope(r.Context()), franchiseID)
 	}
 
 	if err != nil {
@@ -118,7 +121,7 @@ func (h *StoreHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.storeUseCase.Create(&store); err != nil {
+	if err := h.storeUseCase.Create(middleware.GetTenantScope(r.Context()), &store); err != nil {
 		rest.HandleError(w, err)
 		return
 	}
@@ -141,7 +144,7 @@ func (h *StoreHandler) Update(w http.ResponseWriter, r *http.Request) {
 	}
 
 	store.ID = uint(id)
-	if err := h.storeUseCase.Update(&store); err != nil {
+	if err := h.storeUseCase.Update(middleware.GetTenantScope(r.Context()), &store); err != nil {
 		rest.HandleError(w, err)
 		return
 	}
@@ -157,7 +160,7 @@ func (h *StoreHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.storeUseCase.Delete(id); err != nil {
+	if err := h.storeUseCase.Delete(middleware.GetTenantScope(r.Context()), id); err != nil {
 		rest.HandleError(w, err)
 		return
 	}
@@ -174,7 +177,7 @@ func (h *StoreHandler) GetStatistics(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	statistics, err := h.storeUseCase.GetStoreStatistics(id)
+	statistics, err := h.storeUseCase.GetStoreStatistics(middleware.GetTenantScope(r.Context()), id)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -192,7 +195,7 @@ func (h *StoreHandler) GetStoresWithEmployeeCount(w http.ResponseWriter, r *http
 		return
 	}
 
-	stores, err := h.storeUseCase.GetStoresWithEmployeeCount(franchiseID)
+	stores, err := h.storeUseCase.GetStoresWithEmployeeCount(middleware.GetTenantScope(r.Context()), franchiseID)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -210,7 +213,7 @@ func (h *StoreHandler) GetActiveStoresByFranchise(w http.ResponseWriter, r *http
 		return
 	}
 
-	stores, err := h.storeUseCase.GetActiveStoresByFranchise(franchiseID)
+	stores, err := h.storeUseCase.GetActiveStoresByFranchise(middleware.GetTenantScope(r.Context()), franchiseID)
 	if err != nil {
 		rest.HandleError(w, err)
 		return
@@ -0,0 +1,187 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type TimeClockHandler struct {
+	uc usecase.TimeClockUseCase
+}
+
+func NewTimeClockHandler(uc usecase.TimeClockUseCase) *TimeClockHandler {
+	return &TimeClockHandler{uc}
+}
+
+// ClockIn punches the authenticated user in; the body is optional
+func (h *TimeClockHandler) ClockIn(w http.ResponseWriter, r *http.Request) {
+	var req dto.ClockInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	entry, err := h.uc.ClockIn(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, entry)
+}
+
+// ClockOut punches the authenticated user out
+func (h *TimeClockHandler) ClockOut(w http.ResponseWriter, r *http.Request) {
+	entry, err := h.uc.ClockOut(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, entry)
+}
+
+// GetByEmployeeAndDateRange retrieves the entries of an employee within a date range
+func (h *TimeClockHandler) GetByEmployeeAndDateRange(w http.ResponseWriter, r *http.Request) {
+	employeeIDStr := r.URL.Query().Get("employee")
+	fromStr := r.URL.Query().Get("from")
+	toStr := r.URL.Query().Get("to")
+
+	if employeeIDStr == "" || fromStr == "" || toStr == "" {
+		rest.BadRequest(w, "Missing required parameters: employee, from, to")
+		return
+	}
+
+	employeeID, err := strconv.Atoi(employeeIDStr)
+	if err != nil {
+		rest.BadRequest(w, "Invalid employee ID")
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		rest.BadRequest(w, "Invalid from date format. Use YYYY-MM-DD")
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		rest.BadRequest(w, "Invalid to date format. Use YYYY-MM-DD")
+		return
+	}
+
+	entries, err := h.uc.GetByEmployeeAndDateRange(middleware.GetTenantScope(r.Context()), employeeID, from, to)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, entries)
+}
+
+// GetMissed lists the entries without a clock-out
+func (h *TimeClockHandler) GetMissed(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.uc.GetMissed(middleware.GetTenantScope(r.Context()))
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, entries)
+}
+
+// CreateEntry registers a worked period whose punches were missed
+func (h *TimeClockHandler) CreateEntry(w http.ResponseWriter, r *http.Request) {
+	var req dto.TimeClockEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	entry, err := h.uc.CreateEntry(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, entry)
+}
+
+// Correct sets the punches of an entry
+func (h *TimeClockHandler) Correct(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid entry ID format")
+		return
+	}
+
+	var req dto.TimeClockEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	entry, err := h.uc.Correct(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), id, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, entry)
+}
+
+// GetExceptions reports the attendance exceptions of a store on a date (today by default)
+func (h *TimeClockHandler) GetExceptions(w http.ResponseWriter, r *http.Request) {
+	storeID, date, ok := exceptionParams(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.uc.GetExceptions(middleware.GetTenantScope(r.Context()), storeID, date)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, report)
+}
+
+// ApplyExceptionNovelties turns the lateness and early departures of a store's day into negative novelties
+func (h *TimeClockHandler) ApplyExceptionNovelties(w http.ResponseWriter, r *http.Request) {
+	storeID, date, ok := exceptionParams(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.uc.ApplyExceptionNovelties(middleware.GetTenantScope(r.Context()), storeID, date)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, report)
+}
+
+// exceptionParams reads the optional store_id and date query parameters
+func exceptionParams(w http.ResponseWriter, r *http.Request) (int, string, bool) {
+	storeID := 0
+	if s := r.URL.Query().Get("store_id"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil {
+			rest.BadRequest(w, "Invalid store ID")
+			return 0, "", false
+		}
+		storeID = parsed
+	}
+	return storeID, r.URL.Query().Get("date"), true
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/middleware"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type VacationHandler struct {
+	uc usecase.VacationUseCase
+}
+
+func NewVacationHandler(uc usecase.VacationUseCase) *VacationHandler {
+	return &VacationHandler{uc}
+}
+
+// GetBalance returns an employee's vacation balance as of today
+func (h *VacationHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
+	employeeID, err := strconv.Atoi(chi.URLParam(r, "employee_id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid employee ID format")
+		return
+	}
+
+	balance, err := h.uc.GetBalance(middleware.GetTenantScope(r.Context()), employeeID)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, balance)
+}
+
+// GetLedger returns an employee's vacation balance with its movements
+func (h *VacationHandler) GetLedger(w http.ResponseWriter, r *http.Request) {
+	employeeID, err := strconv.Atoi(chi.URLParam(r, "employee_id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid employee ID format")
+		return
+	}
+
+	ledger, err := h.uc.GetLedger(middleware.GetTenantScope(r.Context()), employeeID)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, ledger)
+}
+
+// Adjust records a manual adjustment of an employee's balance
+func (h *VacationHandler) Adjust(w http.ResponseWriter, r *http.Request) {
+	employeeID, err := strconv.Atoi(chi.URLParam(r, "employee_id"))
+	if err != nil {
+		rest.BadRequest(w, "Invalid employee ID format")
+		return
+	}
+
+	var req dto.VacationAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	adjustment, err := h.uc.Adjust(middleware.GetTenantScope(r.Context()), middleware.GetUserID(r.Context()), employeeID, req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, adjustment)
+}
@@ -0,0 +1,60 @@
+package http
+
+import (
+	"encoding/json"
+	"loopi-api/internal/delivery/http/rest"
+	"loopi-api/internal/usecase"
+	"loopi-api/internal/usecase/dto"
+	"net/http"
+	"time"
+)
+
+type WorkConfigHandler struct {
+	uc usecase.WorkConfigUseCase
+}
+
+func NewWorkConfigHandler(uc usecase.WorkConfigUseCase) *WorkConfigHandler {
+	return &WorkConfigHandler{uc: uc}
+}
+
+func (h *WorkConfigHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	configs, err := h.uc.GetAll()
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, configs)
+}
+
+func (h *WorkConfigHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateWorkConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		rest.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	config, err := h.uc.Create(req)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.Created(w, config)
+}
+
+// GetEffective returns the configuration in force on ?date= (today by default)
+func (h *WorkConfigHandler) GetEffective(w http.ResponseWriter, r *http.Request) {
+	date := r.URL.Query().Get("date")
+	if date == "" {
+		date = time.Now().Format("2006-01-02")
+	}
+
+	config, err := h.uc.GetEffectiveAt(date)
+	if err != nil {
+		rest.HandleError(w, err)
+		return
+	}
+
+	rest.OK(w, config)
+}
@@ -2,11 +2,93 @@ package domain
 
 import "time"
 
+// Estados del flujo de aprobación de una ausencia
+const (
+	AbsenceStatusPending  = "pending"  // solicitada, pendiente de revisión
+	AbsenceStatusApproved = "approved" // cuenta en el resumen de horas y la nómina
+	AbsenceStatusRejected = "rejected"
+)
+
+// AbsenceTypeVacation código del tipo de ausencia que descuenta del saldo de vacaciones
+const AbsenceTypeVacation = "vacation"
+
+// AbsenceType es un tipo del catálogo de ausencias (incapacidad, vacaciones, licencia no
+// remunerada, calamidad...). Paid indica si la nómina la remunera.
+type AbsenceType struct {
+	BaseEntity
+
+	Code             string  `gorm:"column:code;size:30;unique;not null" json:"code"` // "sick_leave"
+	Name             string  `gorm:"column:name;size:100;not null" json:"name"`
+	Paid             bool    `gorm:"column:paid" json:"paid"`
+	MaxHoursPerMonth float64 `gorm:"column:max_hours_per_month" json:"max_hours_per_month"` // 0 = sin límite
+	IsActive         bool    `gorm:"column:is_active" json:"is_active"`
+}
+
+// Absence es una ausencia de uno o varios días consecutivos, de Hours horas cada día.
+// Solo las aprobadas cuentan en el resumen de horas.
 type Absence struct {
 	BaseEntity
 
-	EmployeeID int       `json:"employee_id"`
-	Date       time.Time `json:"date"`
-	Hours      float64   `json:"hours"`
-	Reason     string    `json:"reason"`
+	EmployeeID    int       `json:"employee_id"`
+	AbsenceTypeID int       `gorm:"column:absence_type_id" json:"absence_type_id"`
+	Date          time.Time `json:"date"`                                      // primer día
+	EndDate       time.Time `gorm:"column:end_date;type:date" json:"end_date"` // último día; igual a Date si dura un día
+	Hours         float64   `json:"hours"`                                     // horas por día
+	Reason        string    `json:"reason"`
+
+	Status      string     `gorm:"column:status;size:10" json:"status"`
+	RequestedBy int        `gorm:"column:requested_by" json:"requested_by"`
+	ReviewedBy  *int       `gorm:"column:reviewed_by" json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `gorm:"column:reviewed_at" json:"reviewed_at,omitempty"`
+	ReviewNote  string     `gorm:"column:review_note;size:255" json:"review_note,omitempty"`
+
+	AbsenceType *AbsenceType `gorm:"foreignKey:AbsenceTypeID" json:"absence_type,omitempty"`
+}
+
+// LastDate devuelve el último día de la ausencia (Date si no tiene EndDate)
+func (a *Absence) LastDate() time.Time {
+	if a.EndDate.IsZero() || a.EndDate.Before(a.Date) {
+		return a.Date
+	}
+	return a.EndDate
+}
+
+// Days devuelve los días de la ausencia comprendidos entre from y to (inclusive)
+func (a *Absence) Days(from, to time.Time) []time.Time {
+	start, end := truncateDay(a.Date), truncateDay(a.LastDate())
+	if from = truncateDay(from); start.Before(from) {
+		start = from
+	}
+	if to = truncateDay(to); end.After(to) {
+		end = to
+	}
+
+	var days []time.Time
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// HoursIn devuelve las horas de la ausencia entre from y to (inclusive)
+func (a *Absence) HoursIn(from, to time.Time) float64 {
+	return float64(len(a.Days(from, to))) * a.Hours
+}
+
+// IsApproved indica si la ausencia cuenta en el resumen de horas
+func (a *Absence) IsApproved() bool {
+	return a.Status == AbsenceStatusApproved
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// AbsenceTypeHours son las horas de ausencia aprobadas de un tipo en un periodo
+type AbsenceTypeHours struct {
+	AbsenceTypeID int     `json:"absence_type_id"`
+	Code          string  `json:"code"`
+	Name          string  `json:"name"`
+	Paid          bool    `json:"paid"`
+	Hours         float64 `json:"hours"`
 }
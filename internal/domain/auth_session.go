@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// AuthSession es una sesión de login con su refresh token rotatorio.
+// Los access tokens llevan el ID de la sesión; revocarla los invalida de inmediato.
+// Solo se guardan hashes SHA-256: el actual y el anterior, para detectar la reutilización
+// de un refresh token ya rotado.
+type AuthSession struct {
+	BaseEntity
+
+	UserID            int        `gorm:"column:user_id;not null" json:"user_id"`
+	RefreshTokenHash  string     `gorm:"column:refresh_token_hash;size:64;unique;not null" json:"-"`
+	PreviousTokenHash string     `gorm:"column:previous_token_hash;size:64" json:"-"`
+	FranchiseID       int        `gorm:"column:franchise_id" json:"franchise_id"` // contexto elegido con /auth/context
+	StoreID           int        `gorm:"column:store_id" json:"store_id"`
+	ExpiresAt         time.Time  `gorm:"column:expires_at;not null" json:"expires_at"`
+	LastUsedAt        *time.Time `gorm:"column:last_used_at" json:"last_used_at,omitempty"`
+	RevokedAt         *time.Time `gorm:"column:revoked_at" json:"revoked_at,omitempty"`
+}
+
+// IsActive indica si la sesión no está revocada ni vencida en now
+func (s *AuthSession) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
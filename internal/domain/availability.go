@@ -0,0 +1,50 @@
+package domain
+
+// Tipos de disponibilidad
+const (
+	AvailabilityUnavailable = "unavailable" // no puede trabajar: bloquea la asignación salvo que se justifique
+	AvailabilityPreferNot   = "prefer_not"  // preferiría no trabajar: la generación de mallas lo evita si puede
+	AvailabilityAvailable   = "available"   // solo en excepciones: disponible aunque la franja semanal diga lo contrario
+)
+
+// AvailabilityWindow es una franja semanal recurrente en la que el empleado no puede o
+// prefiere no trabajar, p. ej. los domingos de 06:00 a 12:00. Weekday va de 0 (domingo)
+// a 6 (sábado); EndTime "24:00" llega al final del día. Una franja no cruza la medianoche:
+// la noche se registra como dos franjas.
+type AvailabilityWindow struct {
+	BaseEntity
+
+	UserID    int    `gorm:"column:user_id;not null" json:"user_id"`
+	Weekday   int    `gorm:"column:weekday;not null" json:"weekday"`
+	StartTime string `gorm:"column:start_time;not null" json:"start_time"` // "18:00"
+	EndTime   string `gorm:"column:end_time;not null" json:"end_time"`     // "24:00"
+	Type      string `gorm:"column:type;not null" json:"type"`             // unavailable | prefer_not
+	Note      string `gorm:"column:note" json:"note,omitempty"`
+}
+
+// AvailabilityException cambia la disponibilidad de una fecha concreta: un examen, una cita
+// médica, o un día en que el empleado sí puede trabajar pese a su franja semanal. Sin horas
+// aplica a todo el día. En esa fecha, las excepciones prevalecen sobre las franjas semanales.
+type AvailabilityException struct {
+	BaseEntity
+
+	UserID    int    `gorm:"column:user_id;not null" json:"user_id"`
+	Date      string `gorm:"column:date;not null" json:"date"` // "YYYY-MM-DD"
+	StartTime string `gorm:"column:start_time" json:"start_time,omitempty"`
+	EndTime   string `gorm:"column:end_time" json:"end_time,omitempty"`
+	Type      string `gorm:"column:type;not null" json:"type"` // unavailable | available
+	Reason    string `gorm:"column:reason" json:"reason,omitempty"`
+	CreatedBy int    `gorm:"column:created_by" json:"created_by"`
+}
+
+// WholeDay indica si la excepción aplica a todo el día
+func (e AvailabilityException) WholeDay() bool {
+	return e.StartTime == "" && e.EndTime == ""
+}
+
+// EmployeeAvailability agrupa las franjas semanales y las excepciones de un empleado
+type EmployeeAvailability struct {
+	EmployeeID int                     `json:"employee_id"`
+	Windows    []AvailabilityWindow    `json:"windows"`
+	Exceptions []AvailabilityException `json:"exceptions"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// Tipos de suscripción iCalendar
+const (
+	CalendarFeedHolidays = "holidays" // festivos de un país o tienda
+	CalendarFeedEmployee = "employee" // turnos, ausencias y novedades de un empleado
+)
+
+// CalendarFeed es una suscripción iCalendar accesible sin JWT mediante un token secreto.
+// Solo se guarda el hash SHA-256 del token; revocarla invalida la URL de inmediato.
+// Solo la franquicia que la creó puede revocarla.
+type CalendarFeed struct {
+	BaseEntity
+
+	TokenHash   string     `gorm:"column:token_hash;size:64;unique;not null" json:"-"`
+	Kind        string     `gorm:"column:kind;size:10;not null" json:"kind"`
+	FranchiseID *uint      `gorm:"column:franchise_id" json:"franchise_id,omitempty"` // franquicia que la creó; nil = anterior al registro
+	EmployeeID  *uint      `gorm:"column:employee_id" json:"employee_id,omitempty"`   // tipo employee
+	StoreID     *uint      `gorm:"column:store_id" json:"store_id,omitempty"`         // tipo holidays; nil = por país
+	Country     string     `gorm:"column:country;size:2" json:"country,omitempty"`    // tipo holidays sin tienda
+	RevokedAt   *time.Time `gorm:"column:revoked_at" json:"revoked_at,omitempty"`
+}
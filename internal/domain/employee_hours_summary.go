@@ -7,11 +7,15 @@ type EmployeeInfo struct {
 	FullName string `json:"full_name"`
 }
 
+// EmployeeHourBlock Horas de un tipo de día. Las ordinarias nocturnas y las ordinarias en
+// domingo/festivo (bloques Sunday y Holiday) también generan recargo.
 type EmployeeHourBlock struct {
-	Absence        float64 `json:"absence"` // en horas
-	Novelty        float64 `json:"novelty"` // en horas
-	DiurnalExtra   float64 `json:"diurnal_extra"`
-	NocturnalExtra float64 `json:"nocturnal_extra"`
+	Absence           float64 `json:"absence"` // en horas
+	Novelty           float64 `json:"novelty"` // en horas
+	OrdinaryDiurnal   float64 `json:"ordinary_diurnal"`
+	OrdinaryNocturnal float64 `json:"ordinary_nocturnal"`
+	DiurnalExtra      float64 `json:"diurnal_extra"`
+	NocturnalExtra    float64 `json:"nocturnal_extra"`
 }
 
 type EmployeeHourSummary struct {
@@ -20,4 +24,8 @@ type EmployeeHourSummary struct {
 	Ordinary EmployeeHourBlock `json:"ordinary"`
 	Sunday   EmployeeHourBlock `json:"sunday"`
 	Holiday  EmployeeHourBlock `json:"holiday"`
+
+	// AbsencesByType Horas de ausencias aprobadas del mes por tipo, remuneradas o no.
+	// El campo Absence de los bloques solo cuenta los días con turno asignado.
+	AbsencesByType []AbsenceTypeHours `json:"absences_by_type"`
 }
@@ -5,9 +5,13 @@ type Period struct {
 	Month int `json:"month"`
 }
 
+// ExtraHourBlock Horas de un tipo de día. Las ordinarias nocturnas y las ordinarias en
+// domingo/festivo (bloques Sunday y Holiday) también generan recargo.
 type ExtraHourBlock struct {
-	DiurnalExtra   float64 `json:"diurnal_extra"`
-	NocturnalExtra float64 `json:"nocturnal_extra"`
+	OrdinaryDiurnal   float64 `json:"ordinary_diurnal"`
+	OrdinaryNocturnal float64 `json:"ordinary_nocturnal"`
+	DiurnalExtra      float64 `json:"diurnal_extra"`
+	NocturnalExtra    float64 `json:"nocturnal_extra"`
 }
 
 type ExtraHourSummary struct {
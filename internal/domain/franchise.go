@@ -4,6 +4,7 @@ type Franchise struct {
 	BaseEntity
 
 	Name     string `json:"name" gorm:"size:100;not null"`
+	Country  string `json:"country" gorm:"size:2;not null;default:CO"` // calendario de festivos (ISO 3166-1 alfa-2)
 	IsActive bool   `json:"is_active" gorm:"default:true"`
 
 	Stores    []Store    `json:"-"` // omitido en JSON por defecto
@@ -0,0 +1,21 @@
+package domain
+
+// Alcances de un festivo personalizado
+const (
+	HolidayScopeGlobal    = "global"    // todas las tiendas (del país, si se indica)
+	HolidayScopeFranchise = "franchise" // todas las tiendas de una franquicia
+	HolidayScopeStore     = "store"     // una sola tienda
+)
+
+// Holiday es un festivo registrado a mano (día cívico municipal, cierre de una tienda...)
+// que se suma al calendario calculado del país.
+type Holiday struct {
+	BaseEntity
+
+	Date        string `gorm:"column:date;not null" json:"date"` // "YYYY-MM-DD"
+	Name        string `gorm:"column:name;size:100;not null" json:"name"`
+	Scope       string `gorm:"column:scope;size:10;not null" json:"scope"`
+	Country     string `gorm:"column:country;size:2" json:"country,omitempty"` // solo alcance global; vacío = todos los países
+	FranchiseID *uint  `gorm:"column:franchise_id" json:"franchise_id,omitempty"`
+	StoreID     *uint  `gorm:"column:store_id" json:"store_id,omitempty"`
+}
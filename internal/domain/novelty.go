@@ -2,6 +2,12 @@ package domain
 
 import "time"
 
+// Orígenes de las novedades generadas por el reloj de asistencia; las manuales no tienen origen
+const (
+	NoveltySourceLate       = "late"
+	NoveltySourceEarlyLeave = "early_leave"
+)
+
 type Novelty struct {
 	BaseEntity
 
@@ -10,4 +16,8 @@ type Novelty struct {
 	Hours      float64   `json:"hours"`
 	Type       string    `json:"type"` // "positive" or "negative"
 	Comment    string    `json:"comment"`
+
+	// Novedades generadas de las excepciones de asistencia: una por turno y origen
+	AssignedShiftID *int   `json:"assigned_shift_id,omitempty"`
+	Source          string `json:"source,omitempty"`
 }
@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// PasswordResetToken es un token de un solo uso para restablecer la contraseña.
+// Solo se guarda el hash SHA-256; el token se entrega al usuario mediante un notificador.
+type PasswordResetToken struct {
+	BaseEntity
+
+	UserID    int        `gorm:"column:user_id;not null" json:"user_id"`
+	TokenHash string     `gorm:"column:token_hash;size:64;unique;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null" json:"expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"used_at,omitempty"`
+}
+
+// IsUsable indica si el token no se ha usado ni ha vencido en now
+func (t *PasswordResetToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
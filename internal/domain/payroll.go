@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// PayrollConfig son los porcentajes de recargo vigentes a partir de EffectiveFrom.
+// Las horas extra en domingo/festivo acumulan el multiplicador de extra y el recargo
+// dominical (p. ej. extra diurna dominical = 1.25 + 0.75 = 2.00 veces la hora ordinaria).
+type PayrollConfig struct {
+	BaseEntity
+
+	EffectiveFrom            time.Time `gorm:"column:effective_from;type:date" json:"effective_from"`
+	NightSurcharge           float64   `gorm:"column:night_surcharge" json:"night_surcharge"`                       // 0.35
+	SundayHolidaySurcharge   float64   `gorm:"column:sunday_holiday_surcharge" json:"sunday_holiday_surcharge"`     // 0.75
+	ExtraDiurnalMultiplier   float64   `gorm:"column:extra_diurnal_multiplier" json:"extra_diurnal_multiplier"`     // 1.25
+	ExtraNocturnalMultiplier float64   `gorm:"column:extra_nocturnal_multiplier" json:"extra_nocturnal_multiplier"` // 1.75
+	IsActive                 bool      `gorm:"column:is_active" json:"is_active"`
+}
+
+// PayrollLineItem es un concepto de la liquidación: horas × multiplicador × valor hora
+type PayrollLineItem struct {
+	Concept    string  `json:"concept"`
+	Hours      float64 `json:"hours"`
+	Multiplier float64 `json:"multiplier"`
+	Amount     float64 `json:"amount"`
+}
+
+// EmployeePayroll es la liquidación mensual de un empleado. Si la jornada o los recargos
+// cambian a mitad de mes, Periods detalla cada tramo y LineItems reúne los de todos.
+type EmployeePayroll struct {
+	Employee        EmployeeInfo      `json:"employee"`
+	Period          Period            `json:"period"`
+	Salary          float64           `json:"salary"`
+	MonthlyHours    float64           `json:"monthly_hours"` // al inicio del mes
+	HourlyRate      float64           `json:"hourly_rate"`   // al inicio del mes
+	LineItems       []PayrollLineItem `json:"line_items"`
+	TotalSurcharges float64           `json:"total_surcharges"`
+	Total           float64           `json:"total"`
+	Periods         []PayrollPeriod   `json:"periods,omitempty"`
+}
+
+// PayrollPeriod es un tramo del mes liquidado con la misma jornada y los mismos recargos
+type PayrollPeriod struct {
+	From            string            `json:"from"` // "YYYY-MM-DD"
+	To              string            `json:"to"`   // inclusive
+	MonthlyHours    float64           `json:"monthly_hours"`
+	HourlyRate      float64           `json:"hourly_rate"`
+	LineItems       []PayrollLineItem `json:"line_items"` // recargos y horas extra del tramo
+	TotalSurcharges float64           `json:"total_surcharges"`
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "strings"
+
 type Permission struct {
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"` // ✅ Campo ID directo con auto-increment
 
@@ -8,3 +10,44 @@ type Permission struct {
 
 	RolePermissions []RolePermission `json:"-"`
 }
+
+// PermissionWildcard concede todos los permisos (rol admin)
+const PermissionWildcard = "*"
+
+// PermissionAllStores permite operar en todas las tiendas de la franquicia;
+// sin él los datos se limitan a la tienda seleccionada en el token (rol store_manager)
+const PermissionAllStores = "stores:all"
+
+// PermissionPlatform permite gestionar los datos globales de la plataforma: festivos globales,
+// configuraciones de jornada y de nómina, roles y permisos. Los permisos del recurso "platform"
+// solo se conceden por su nombre exacto, de modo que el "*" del admin de una franquicia no los incluye.
+const PermissionPlatform = "platform:admin"
+
+const platformResource = "platform"
+
+// IsPlatformPermission indica si el permiso es del recurso "platform", que solo se concede por su nombre
+func IsPlatformPermission(name string) bool {
+	return strings.HasPrefix(name, platformResource+":")
+}
+
+// HasPermission indica si los permisos concedidos cubren required ("recurso:acción").
+// "*" concede todo y "recurso:*" todas las acciones del recurso, salvo los permisos de plataforma.
+func HasPermission(granted []string, required string) bool {
+	resource := required
+	if i := strings.Index(required, ":"); i >= 0 {
+		resource = required[:i]
+	}
+
+	for _, permission := range granted {
+		if permission == required {
+			return true
+		}
+		if resource == platformResource {
+			continue
+		}
+		if permission == PermissionWildcard || permission == resource+":*" {
+			return true
+		}
+	}
+	return false
+}
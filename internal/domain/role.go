@@ -1,5 +1,11 @@
 package domain
 
+// Roles de franquicia que se asignan al crear un empleado (ver scripts/tables/21. store_roles_seed.sql)
+const (
+	RoleEmployee     = "employee"      // autoservicio
+	RoleStoreManager = "store_manager" // gerente limitado a la tienda del token
+)
+
 type Role struct {
 	ID uint `gorm:"primaryKey;autoIncrement" json:"id"` // ✅ Campo ID directo con auto-increment
 
@@ -11,9 +11,20 @@ type Shift struct {
 	IsActive     bool   `json:"is_active"`
 }
 
+// AssignedShift es un turno asignado a un empleado en una fecha concreta.
+// Los horarios se copian del turno plantilla al asignar, de modo que editar
+// la plantilla no altera las horas ya programadas.
 type AssignedShift struct {
-	Date         string `json:"date"`       // "YYYY-MM-DD"
-	StartTime    string `json:"start_time"` // "07:30"
-	EndTime      string `json:"end_time"`   // "19:30"
-	LunchMinutes int    `json:"lunch_minutes"`
+	BaseEntity
+
+	EmployeeID   int    `gorm:"column:employee_id;not null" json:"employee_id"`
+	ShiftID      int    `gorm:"column:shift_id;not null" json:"shift_id"`
+	StoreID      int    `gorm:"column:store_id;not null" json:"store_id"`
+	Date         string `gorm:"column:date;not null" json:"date"`             // "YYYY-MM-DD"
+	StartTime    string `gorm:"column:start_time;not null" json:"start_time"` // "07:30"
+	EndTime      string `gorm:"column:end_time;not null" json:"end_time"`     // "19:30"
+	LunchMinutes int    `gorm:"column:lunch_minutes" json:"lunch_minutes"`
+
+	// AvailabilityOverride motivo por el que se asignó pese a que el empleado no estaba disponible
+	AvailabilityOverride string `gorm:"column:availability_override" json:"availability_override,omitempty"`
 }
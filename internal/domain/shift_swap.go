@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// Estados del flujo de intercambio de turnos
+const (
+	ShiftSwapOpen      = "open"      // ofrecido, esperando que un compañero de la tienda lo acepte
+	ShiftSwapAccepted  = "accepted"  // aceptado, pendiente de aprobación del gerente
+	ShiftSwapApproved  = "approved"  // aplicado a los turnos asignados
+	ShiftSwapRejected  = "rejected"  // rechazado por el gerente; los turnos no cambian
+	ShiftSwapCancelled = "cancelled" // retirado por quien lo ofreció
+)
+
+// ShiftSwap es el ofrecimiento de un turno asignado a los compañeros de la tienda. Quien lo
+// acepta puede dar a cambio uno de sus turnos (intercambio) o solo tomarlo (cesión). Al aprobarse
+// se reasignan los turnos; el registro queda como historial de quién trabajó cada turno, por eso
+// la fecha, el horario y las horas se copian al ofrecer y al aceptar.
+type ShiftSwap struct {
+	BaseEntity
+
+	StoreID int `gorm:"column:store_id;not null" json:"store_id"`
+
+	// Turno ofrecido y empleado que lo tenía asignado
+	OfferedShiftID   int     `gorm:"column:offered_shift_id;not null" json:"offered_shift_id"`
+	OfferedBy        int     `gorm:"column:offered_by;not null" json:"offered_by"`
+	OfferedDate      string  `gorm:"column:offered_date;not null" json:"offered_date"` // "YYYY-MM-DD"
+	OfferedStartTime string  `gorm:"column:offered_start_time" json:"offered_start_time"`
+	OfferedEndTime   string  `gorm:"column:offered_end_time" json:"offered_end_time"`
+	OfferedHours     float64 `gorm:"column:offered_hours" json:"offered_hours"` // sin almuerzo
+	Note             string  `gorm:"column:note;size:255" json:"note,omitempty"`
+
+	// Compañero que lo acepta y turno que da a cambio, si lo hay
+	AcceptedBy      *int       `gorm:"column:accepted_by" json:"accepted_by,omitempty"`
+	AcceptedAt      *time.Time `gorm:"column:accepted_at" json:"accepted_at,omitempty"`
+	ReturnShiftID   *int       `gorm:"column:return_shift_id" json:"return_shift_id,omitempty"`
+	ReturnDate      string     `gorm:"column:return_date" json:"return_date,omitempty"`
+	ReturnStartTime string     `gorm:"column:return_start_time" json:"return_start_time,omitempty"`
+	ReturnEndTime   string     `gorm:"column:return_end_time" json:"return_end_time,omitempty"`
+	ReturnHours     float64    `gorm:"column:return_hours" json:"return_hours,omitempty"`
+
+	Status     string     `gorm:"column:status;size:10" json:"status"`
+	ReviewedBy *int       `gorm:"column:reviewed_by" json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `gorm:"column:reviewed_at" json:"reviewed_at,omitempty"`
+	ReviewNote string     `gorm:"column:review_note;size:255" json:"review_note,omitempty"`
+}
+
+// IsActive indica si el intercambio sigue en curso; un turno solo puede estar en uno activo
+func (s *ShiftSwap) IsActive() bool {
+	return s.Status == ShiftSwapOpen || s.Status == ShiftSwapAccepted
+}
+
+// Involves indica si el turno asignado es el ofrecido o el dado a cambio
+func (s *ShiftSwap) Involves(assignedShiftID int) bool {
+	return s.OfferedShiftID == assignedShiftID || (s.ReturnShiftID != nil && *s.ReturnShiftID == assignedShiftID)
+}
@@ -8,6 +8,7 @@ type Store struct {
 	Name        string `json:"name" gorm:"size:100;not null"`
 	Location    string `json:"location" gorm:"size:255"`
 	Address     string `json:"address" gorm:"size:255"`
+	Country     string `json:"country" gorm:"size:2"` // vacío = país de la franquicia
 	IsActive    bool   `json:"is_active" gorm:"default:true"`
 
 	Franchise  Franchise   `json:"-"` // omitido en respuesta JSON para evitar ciclos
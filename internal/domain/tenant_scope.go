@@ -0,0 +1,20 @@
+package domain
+
+// TenantScope delimita los datos visibles para quien llama: la franquicia seleccionada en el token
+// y, para roles sin el permiso PermissionAllStores, la tienda seleccionada (StoreID 0 = todas).
+// Platform indica que quien llama tiene PermissionPlatform y puede modificar los datos globales.
+type TenantScope struct {
+	FranchiseID int
+	StoreID     int
+	Platform    bool
+}
+
+// RestrictedToStore indica si el alcance se limita a una sola tienda
+func (s TenantScope) RestrictedToStore() bool {
+	return s.StoreID > 0
+}
+
+// AllowsStore indica si la tienda está dentro del alcance (sin validar su franquicia)
+func (s TenantScope) AllowsStore(storeID int) bool {
+	return !s.RestrictedToStore() || s.StoreID == storeID
+}
@@ -0,0 +1,104 @@
+package domain
+
+import "time"
+
+// Estados de un registro del reloj de asistencia
+const (
+	TimeClockOpen      = "open"       // con entrada y sin salida
+	TimeClockClosed    = "closed"     // con entrada y salida; cuenta en las horas reales
+	TimeClockMissedOut = "missed_out" // sin salida marcada; no cuenta hasta que un gerente la corrija
+)
+
+// MaxClockedHours duración máxima de un registro; una entrada abierta por más tiempo es una
+// marcación de salida olvidada
+const MaxClockedHours = 16
+
+// TimeClockEntry es un periodo trabajado según el reloj: una marcación de entrada y otra de
+// salida en una tienda. ClockIn y ClockOut son instantes; las horas se calculan con la hora
+// local de la tienda. LunchMinutes se copia del turno asignado porque el almuerzo no se marca.
+type TimeClockEntry struct {
+	BaseEntity
+
+	EmployeeID      int        `gorm:"column:employee_id;not null" json:"employee_id"`
+	StoreID         int        `gorm:"column:store_id;not null" json:"store_id"`
+	AssignedShiftID *int       `gorm:"column:assigned_shift_id" json:"assigned_shift_id,omitempty"`
+	LunchMinutes    int        `gorm:"column:lunch_minutes" json:"lunch_minutes"`
+	ClockIn         time.Time  `gorm:"column:clock_in;not null" json:"clock_in"`
+	ClockOut        *time.Time `gorm:"column:clock_out" json:"clock_out,omitempty"`
+	Status          string     `gorm:"column:status;size:10" json:"status"`
+
+	// Corrección de un gerente (marcación olvidada o errónea)
+	CorrectedBy    *int       `gorm:"column:corrected_by" json:"corrected_by,omitempty"`
+	CorrectedAt    *time.Time `gorm:"column:corrected_at" json:"corrected_at,omitempty"`
+	CorrectionNote string     `gorm:"column:correction_note;size:255" json:"correction_note,omitempty"`
+}
+
+// WorkedHours devuelve las horas trabajadas descontando el almuerzo; cero si no está cerrado
+func (e *TimeClockEntry) WorkedHours() float64 {
+	if e.Status != TimeClockClosed || e.ClockOut == nil {
+		return 0
+	}
+	worked := e.ClockOut.Sub(e.ClockIn).Hours() - float64(e.LunchMinutes)/60.0
+	if worked < 0 {
+		return 0
+	}
+	return worked
+}
+
+// AttendanceDay compara las horas programadas y marcadas de un día
+type AttendanceDay struct {
+	Date          string  `json:"date"`
+	PlannedHours  float64 `json:"planned_hours"`
+	ActualHours   float64 `json:"actual_hours"`
+	Variance      float64 `json:"variance"` // real - programado
+	MissedPunches int     `json:"missed_punches,omitempty"`
+}
+
+// AttendanceSummary es el resumen mensual programado (turnos asignados) frente al real
+// (reloj de asistencia) de un empleado
+type AttendanceSummary struct {
+	Employee EmployeeInfo        `json:"employee"`
+	Period   Period              `json:"period"`
+	Planned  EmployeeHourSummary `json:"planned"`
+	Actual   EmployeeHourSummary `json:"actual"`
+
+	PlannedHours  float64 `json:"planned_hours"`
+	ActualHours   float64 `json:"actual_hours"`
+	VarianceHours float64 `json:"variance_hours"` // real - programado
+
+	// MissedPunches registros sin salida; no cuentan en las horas reales hasta corregirse
+	MissedPunches int             `json:"missed_punches"`
+	Days          []AttendanceDay `json:"days"`
+}
+
+// Tipos de excepción de asistencia de un turno asignado
+const (
+	AttendanceLate        = "late"         // entrada después del inicio del turno más la tolerancia
+	AttendanceEarlyLeave  = "early_leave"  // salida antes del fin del turno menos la tolerancia
+	AttendanceMissedPunch = "missed_punch" // registro sin salida marcada
+	AttendanceNoShow      = "no_show"      // turno terminado sin marcaciones ni ausencia aprobada
+)
+
+// AttendanceException es una desviación de las marcaciones frente al turno asignado.
+// Minutes cuenta todos los minutos de retraso o de salida anticipada, no solo los que
+// exceden la tolerancia.
+type AttendanceException struct {
+	EmployeeID      int    `json:"employee_id"`
+	AssignedShiftID int    `json:"assigned_shift_id"`
+	Type            string `json:"type"`
+	Scheduled       string `json:"scheduled"`         // "HH:MM" del turno
+	Punched         string `json:"punched,omitempty"` // "HH:MM" local de la marcación
+	Minutes         int    `json:"minutes,omitempty"`
+
+	// NoveltyID novedad negativa generada a partir de la excepción, si existe
+	NoveltyID *int `json:"novelty_id,omitempty"`
+}
+
+// AttendanceExceptionReport son las excepciones de asistencia de una tienda en un día
+type AttendanceExceptionReport struct {
+	StoreID      int                   `json:"store_id"`
+	Date         string                `json:"date"`
+	GraceMinutes int                   `json:"grace_minutes"`
+	Shifts       int                   `json:"shifts"` // turnos asignados del día
+	Exceptions   []AttendanceException `json:"exceptions"`
+}
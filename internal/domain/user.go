@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type User struct {
 	BaseEntity
 
@@ -15,6 +17,17 @@ type User struct {
 	Salary         float64 `gorm:"not null" json:"salary"`
 	IsActive       bool    `gorm:"default:true" json:"is_active"`
 
+	// HireDate fecha de ingreso; desde ella se causan las vacaciones
+	HireDate *time.Time `gorm:"column:hire_date;type:date" json:"hire_date,omitempty"`
+
+	// MustChangePassword bloquea la API (salvo el cambio de contraseña) hasta que el usuario la cambie
+	MustChangePassword bool `gorm:"column:must_change_password;default:false" json:"must_change_password"`
+
+	// Disponibilidad declarada. FindByID carga las franjas semanales; las excepciones
+	// crecen con el tiempo y se consultan por rango de fechas
+	AvailabilityWindows    []AvailabilityWindow    `gorm:"foreignKey:UserID" json:"availability_windows,omitempty"`
+	AvailabilityExceptions []AvailabilityException `gorm:"foreignKey:UserID" json:"availability_exceptions,omitempty"`
+
 	UserRoles  []UserRole  `json:"-"`
 	StoreUsers []StoreUser `json:"-"`
 }
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// VacationDaysPerYear días hábiles de vacaciones remuneradas por año de servicio (CST art. 186)
+const VacationDaysPerYear = 15.0
+
+// Tipos de movimiento del libro de vacaciones
+const (
+	VacationMovementAccrual    = "accrual"    // causación mensual desde la fecha de ingreso
+	VacationMovementTaken      = "taken"      // vacaciones aprobadas, en días hábiles
+	VacationMovementAdjustment = "adjustment" // ajuste manual
+)
+
+// VacationAdjustment es un ajuste manual del saldo de vacaciones: saldo inicial traído de
+// otro sistema, días compensados en dinero o correcciones. Days positivo suma, negativo resta.
+// Las causaciones y los días disfrutados no se guardan: se calculan de la fecha de ingreso y
+// de las ausencias de vacaciones aprobadas.
+type VacationAdjustment struct {
+	BaseEntity
+
+	EmployeeID int       `gorm:"column:employee_id" json:"employee_id"`
+	Date       time.Time `gorm:"column:date;type:date" json:"date"`
+	Days       float64   `gorm:"column:days" json:"days"`
+	Reason     string    `gorm:"column:reason;size:255" json:"reason"`
+	CreatedBy  int       `gorm:"column:created_by" json:"created_by"`
+}
+
+// VacationMovement es una línea del libro de vacaciones de un empleado
+type VacationMovement struct {
+	Date      time.Time `json:"date"`
+	Type      string    `json:"type"`
+	Days      float64   `json:"days"`    // positivo suma al saldo, negativo lo descuenta
+	Balance   float64   `json:"balance"` // saldo después del movimiento
+	AbsenceID int       `json:"absence_id,omitempty"`
+	Detail    string    `json:"detail"`
+}
+
+// VacationBalance es el saldo de vacaciones de un empleado en una fecha.
+// Las vacaciones aprobadas se descuentan aunque sean futuras; las pendientes
+// reservan días de Available hasta que se revisen.
+type VacationBalance struct {
+	EmployeeID int       `json:"employee_id"`
+	HireDate   time.Time `json:"hire_date"`
+	AsOf       time.Time `json:"as_of"`
+	Accrued    float64   `json:"accrued"`
+	Taken      float64   `json:"taken"`
+	Adjusted   float64   `json:"adjusted"`
+	Balance    float64   `json:"balance"` // Accrued + Adjusted - Taken
+	Pending    float64   `json:"pending"`
+	Available  float64   `json:"available"` // Balance - Pending
+}
+
+// VacationLedger es el saldo con el detalle de sus movimientos en orden cronológico
+type VacationLedger struct {
+	VacationBalance
+	Movements []VacationMovement `json:"movements"`
+}
@@ -1,9 +1,21 @@
 package domain
 
+import "time"
+
+// DefaultGraceMinutes tolerancia por defecto de las nuevas configuraciones
+const DefaultGraceMinutes = 5
+
+// WorkConfig es la configuración laboral vigente a partir de EffectiveFrom.
+// Las configuraciones no se sobrescriben: cada cambio legal es una fila nueva,
+// y los cálculos usan la que estaba en vigor en la fecha que se calcula.
 type WorkConfig struct {
 	BaseEntity
 
-	DiurnalStart string `gorm:"column:diurnal_start" json:"diurnal_start"` // "06:00"
-	DiurnalEnd   string `gorm:"column:diurnal_end" json:"diurnal_end"`     // "21:00"
-	IsActive     bool   `gorm:"column:is_active" json:"is_active"`
+	EffectiveFrom       time.Time `gorm:"column:effective_from;type:date" json:"effective_from"`
+	DiurnalStart        string    `gorm:"column:diurnal_start" json:"diurnal_start"`                 // "06:00"
+	DiurnalEnd          string    `gorm:"column:diurnal_end" json:"diurnal_end"`                     // "21:00"
+	DailyOrdinaryHours  float64   `gorm:"column:daily_ordinary_hours" json:"daily_ordinary_hours"`   // 7.33
+	WeeklyOrdinaryHours float64   `gorm:"column:weekly_ordinary_hours" json:"weekly_ordinary_hours"` // 44
+	GraceMinutes        int       `gorm:"column:grace_minutes" json:"grace_minutes"`                 // tolerancia de llegada tarde y salida anticipada
+	IsActive            bool      `gorm:"column:is_active" json:"is_active"`
 }
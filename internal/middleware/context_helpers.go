@@ -1,6 +1,9 @@
 package middleware
 
-import "context"
+import (
+	"context"
+	"loopi-api/internal/domain"
+)
 
 type contextKey string
 
@@ -10,6 +13,9 @@ const (
 	ContextRole        contextKey = "role"
 	ContextStore       contextKey = "store_id"
 	ContextFranchiseID contextKey = "franchise_id"
+	ContextSessionID   contextKey = "session_id"
+	ContextScopeStore  contextKey = "scope_store_id"
+	ContextPlatform    contextKey = "platform"
 )
 
 func GetUserID(ctx context.Context) int {
@@ -39,3 +45,23 @@ func GetStoreID(ctx context.Context) int {
 	}
 	return 0
 }
+
+func GetSessionID(ctx context.Context) int {
+	if id, ok := ctx.Value(ContextSessionID).(int); ok {
+		return id
+	}
+	return 0
+}
+
+// GetTenantScope returns the franchise and, for store-restricted callers, the store
+// resolved by RequireFranchiseAccess, along with whether the caller manages platform data
+func GetTenantScope(ctx context.Context) domain.TenantScope {
+	scope := domain.TenantScope{FranchiseID: GetFranchiseID(ctx)}
+	if id, ok := ctx.Value(ContextScopeStore).(int); ok {
+		scope.StoreID = id
+	}
+	if platform, ok := ctx.Value(ContextPlatform).(bool); ok {
+		scope.Platform = platform
+	}
+	return scope
+}
@@ -1,9 +1,15 @@
 package middleware
 
 import (
+	"context"
+	"loopi-api/internal/domain"
 	"net/http"
 )
 
+// RequireFranchiseAccess rejects tokens without a selected franchise.
+// Callers without domain.PermissionAllStores are further restricted to the store selected
+// in the token. Use cases then scope every query to GetTenantScope (see usecase.TenantGuard),
+// which also reports whether the caller holds domain.PermissionPlatform.
 func RequireFranchiseAccess() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -13,9 +19,35 @@ func RequireFranchiseAccess() func(http.Handler) http.Handler {
 				return
 			}
 
-			// ❗ Aquí podrías aplicar lógica extra (ej: validación temporal, estado activo...)
+			if permissionChecker == nil {
+				http.Error(w, "permissions unavailable", http.StatusForbidden)
+				return
+			}
 
-			next.ServeHTTP(w, r)
+			userID := GetUserID(r.Context())
+			allStores, err := permissionChecker.HasPermission(userID, franchiseID, domain.PermissionAllStores)
+			if err != nil {
+				http.Error(w, "failed to resolve permissions", http.StatusInternalServerError)
+				return
+			}
+			platform, err := permissionChecker.HasPermission(userID, franchiseID, domain.PermissionPlatform)
+			if err != nil {
+				http.Error(w, "failed to resolve permissions", http.StatusInternalServerError)
+				return
+			}
+			ctx := context.WithValue(r.Context(), ContextPlatform, platform)
+			if allStores {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			storeID := GetStoreID(r.Context())
+			if storeID == 0 {
+				http.Error(w, "Missing store ID in token", http.StatusForbidden)
+				return
+			}
+			ctx = context.WithValue(ctx, ContextScopeStore, storeID)
+			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
@@ -2,16 +2,51 @@ package middleware
 
 import (
 	"context"
-	"loopi-api/config"
+	"errors"
+	"loopi-api/internal/token"
 	"net/http"
-	"os"
 	"strings"
-	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// TokenVerifier checks the signature and claims of an access token
+type TokenVerifier interface {
+	Verify(tokenString string) (*token.Claims, error)
+}
+
+var tokenVerifier TokenVerifier
+
+// UseTokenVerifier sets the verifier of the keys that sign access tokens
+func UseTokenVerifier(verifier TokenVerifier) {
+	tokenVerifier = verifier
+}
+
+// SessionValidator reports whether the login session of an access token is still active
+type SessionValidator interface {
+	ValidateSession(sessionID, userID int) error
+}
+
+var sessionValidator SessionValidator
+
+// UseSessionValidator makes JWTMiddleware reject tokens of revoked or expired sessions
+func UseSessionValidator(validator SessionValidator) {
+	sessionValidator = validator
+}
+
+// JWTMiddleware authenticates the access token. Tokens of users who must change their
+// password are rejected; only routes wrapped with JWTAllowPasswordChange accept them.
 func JWTMiddleware(next http.Handler) http.Handler {
+	return authenticate(next, false)
+}
+
+// JWTAllowPasswordChange authenticates like JWTMiddleware but also accepts tokens of users
+// who must change their password (the password change itself and logout)
+func JWTAllowPasswordChange(next http.Handler) http.Handler {
+	return authenticate(next, true)
+}
+
+func authenticate(next http.Handler, allowPasswordChange bool) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		auth := r.Header.Get("Authorization")
 		if !strings.HasPrefix(auth, "Bearer ") {
@@ -19,32 +54,39 @@ func JWTMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		tokenString := strings.TrimPrefix(auth, "Bearer ")
-
-		claims := &config.CustomClaims{}
-
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, jwt.ErrSignatureInvalid
-			}
-			return []byte(os.Getenv("JWT_SECRET")), nil
-		})
-
-		if err != nil || !token.Valid {
+		if tokenVerifier == nil {
 			http.Error(w, "Invalid token", http.StatusUnauthorized)
 			return
 		}
 
-		if claims.ExpiresAt == nil || claims.ExpiresAt.Time.Before(time.Now()) {
+		claims, err := tokenVerifier.Verify(strings.TrimPrefix(auth, "Bearer "))
+		if errors.Is(err, jwt.ErrTokenExpired) {
 			http.Error(w, "Token expired", http.StatusUnauthorized)
 			return
 		}
+		if err != nil {
+			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		if sessionValidator != nil {
+			if err := sessionValidator.ValidateSession(claims.SessionID, claims.UserID); err != nil {
+				http.Error(w, "Session revoked", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		if claims.MustChangePassword && !allowPasswordChange {
+			http.Error(w, "Password change required", http.StatusForbidden)
+			return
+		}
 
 		ctx := context.WithValue(r.Context(), ContextUserID, claims.UserID)
 		ctx = context.WithValue(ctx, ContextEmail, claims.Email)
 		ctx = context.WithValue(ctx, ContextRole, claims.Roles)
 		ctx = context.WithValue(ctx, ContextFranchiseID, claims.FranchiseID)
 		ctx = context.WithValue(ctx, ContextStore, claims.StoreID)
+		ctx = context.WithValue(ctx, ContextSessionID, claims.SessionID)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// PermissionChecker reports whether a user holds a permission in a franchise
+type PermissionChecker interface {
+	HasPermission(userID, franchiseID int, required string) (bool, error)
+}
+
+var permissionChecker PermissionChecker
+
+// UsePermissionChecker sets how RequirePermission resolves a user's permissions
+func UsePermissionChecker(checker PermissionChecker) {
+	permissionChecker = checker
+}
+
+// RequirePermission allows the request only when the user holds every permission
+// ("resource:action") through their roles in the token's franchise
+func RequirePermission(permissions ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !hasPermissions(w, r, permissions...) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireResourcePermission requires "resource:read" for safe methods (GET, HEAD, OPTIONS)
+// and "resource:write" for every other method
+func RequireResourcePermission(resource string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			action := "write"
+			switch r.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				action = "read"
+			}
+
+			if !hasPermissions(w, r, resource+":"+action) {
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// hasPermissions writes a 403 and returns false when a permission is missing
+func hasPermissions(w http.ResponseWriter, r *http.Request, permissions ...string) bool {
+	if permissionChecker == nil {
+		http.Error(w, "permissions unavailable", http.StatusForbidden)
+		return false
+	}
+
+	userID := GetUserID(r.Context())
+	if userID == 0 {
+		http.Error(w, "missing user", http.StatusForbidden)
+		return false
+	}
+
+	franchiseID := GetFranchiseID(r.Context())
+	for _, permission := range permissions {
+		ok, err := permissionChecker.HasPermission(userID, franchiseID, permission)
+		if err != nil {
+			http.Error(w, "failed to resolve permissions", http.StatusInternalServerError)
+			return false
+		}
+		if !ok {
+			http.Error(w, "forbidden: missing permission "+strings.TrimSpace(permission), http.StatusForbidden)
+			return false
+		}
+	}
+	return true
+}
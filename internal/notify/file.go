@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// File appends messages to a local file (an "outbox") so they can be read during development
+type File struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFile creates a notifier that appends every message to path
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Send appends the message to the file
+func (n *File) Send(msg Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open notification file: %w", err)
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "--- %s\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	if err != nil {
+		return fmt.Errorf("write notification: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package notify
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFile_AppendsMessages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.log")
+	notifier := NewFile(path)
+
+	for _, subject := range []string{"first", "second"} {
+		if err := notifier.Send(Message{To: "ana@loopi.co", Subject: subject, Body: "hello"}); err != nil {
+			t.Fatalf("Send: unexpected error %v", err)
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(content), "Subject: first") || !strings.Contains(string(content), "Subject: second") {
+		t.Errorf("Expected both messages in the file, got %q", content)
+	}
+}
@@ -0,0 +1,18 @@
+package notify
+
+import "log"
+
+// Log writes messages to the application log; intended for local development only,
+// since messages may carry secrets such as reset tokens
+type Log struct{}
+
+// NewLog creates a notifier that logs every message
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Send logs the message
+func (n *Log) Send(msg Message) error {
+	log.Printf("📨 [notify] to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
@@ -0,0 +1,14 @@
+package notify
+
+// Message is a notification addressed to a user
+type Message struct {
+	To      string // recipient address (email)
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages to users. Implementations must be safe for concurrent use;
+// an email or SMS provider is plugged in by implementing this interface.
+type Notifier interface {
+	Send(msg Message) error
+}
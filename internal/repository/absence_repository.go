@@ -7,10 +7,23 @@ import (
 
 type AbsenceRepository interface {
 	// Basic operations
+	GetByID(id int) (*domain.Absence, error)
 	GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.Absence, error)
 	Create(absence *domain.Absence) error
 
 	// Enhanced business operations
 	GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.Absence, error)
 	GetTotalHoursByEmployee(employeeID, year, month int) (float64, error)
+
+	// Approval workflow
+	GetPending(scope domain.TenantScope) ([]domain.Absence, error)
+	Review(absence *domain.Absence) error
+}
+
+type AbsenceTypeRepository interface {
+	GetAll(includeInactive bool) ([]domain.AbsenceType, error)
+	GetByID(id int) (*domain.AbsenceType, error)
+	GetByCode(code string) (*domain.AbsenceType, error)
+	Create(absenceType *domain.AbsenceType) error
+	Update(absenceType *domain.AbsenceType) error
 }
@@ -3,5 +3,16 @@ package repository
 import "loopi-api/internal/domain"
 
 type AssignedShiftRepository interface {
+	// Basic CRUD operations
+	GetByID(id int) (*domain.AssignedShift, error)
+	Create(assignedShift *domain.AssignedShift) error
+	CreateBatch(assignedShifts []domain.AssignedShift) error
+	Update(assignedShift *domain.AssignedShift) error
+	Delete(id int) error
+
+	// Enhanced business operations
 	GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.AssignedShift, error)
+	GetByEmployeeAndDate(employeeID int, date string) (*domain.AssignedShift, error)
+	GetByEmployeeAndDateRange(employeeID int, from, to string) ([]domain.AssignedShift, error)
+	GetByStoreAndDateRange(storeID int, from, to string) ([]domain.AssignedShift, error)
 }
@@ -0,0 +1,16 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+type AuthSessionRepository interface {
+	// Standard operations
+	GetByID(id int) (*domain.AuthSession, error)
+	Create(session *domain.AuthSession) error
+	Update(session *domain.AuthSession) error
+
+	// Business-specific operations
+	GetByRefreshTokenHash(tokenHash string) (*domain.AuthSession, error) // current or previous hash
+	Revoke(id int) error
+	RevokeByUser(userID int) error
+	RevokeByUserExcept(userID, sessionID int) error // e.g. other devices after a password change
+}
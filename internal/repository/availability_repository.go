@@ -0,0 +1,15 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+type AvailabilityRepository interface {
+	// Weekly windows
+	GetWindowsByUser(userID int) ([]domain.AvailabilityWindow, error)     // ordered by weekday and start time
+	ReplaceWindows(userID int, windows []domain.AvailabilityWindow) error // replaces the whole weekly pattern
+
+	// Date-specific exceptions
+	GetExceptionByID(id int) (*domain.AvailabilityException, error)
+	CreateException(exception *domain.AvailabilityException) error
+	DeleteException(id int) error
+	GetExceptionsByUserAndDateRange(userID int, from, to string) ([]domain.AvailabilityException, error) // ordered by date
+}
@@ -0,0 +1,14 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+type CalendarFeedRepository interface {
+	// Standard operations
+	GetByID(id int) (*domain.CalendarFeed, error)
+	GetByTokenHash(tokenHash string) (*domain.CalendarFeed, error)
+	Create(feed *domain.CalendarFeed) error
+
+	// Business-specific operations
+	GetByEmployee(employeeID int) ([]domain.CalendarFeed, error)
+	Revoke(id int) error
+}
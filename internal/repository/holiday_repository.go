@@ -0,0 +1,15 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+type HolidayRepository interface {
+	// Standard operations
+	GetByID(id int) (*domain.Holiday, error)
+	GetByDateRange(from, to string) ([]domain.Holiday, error)
+	Create(holiday *domain.Holiday) error
+	Update(holiday *domain.Holiday) error
+	Delete(id int) error
+
+	// Business-specific operations
+	GetApplicable(country string, franchiseID, storeID int, from, to string) ([]domain.Holiday, error)
+}
@@ -8,6 +8,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// absenceOverlapsCondition matches absences with at least one day between two dates (inclusive)
+const absenceOverlapsCondition = "absences.date <= ? AND absences.end_date >= ?"
+
 // absenceRepository implements repository.AbsenceRepository with improved maintainability
 type absenceRepository struct {
 	*BaseRepository[domain.Absence]
@@ -22,9 +25,22 @@ func NewAbsenceRepository(db *gorm.DB) repository.AbsenceRepository {
 	}
 }
 
-// GetByEmployeeAndMonth retrieves absences by employee and month using query helper
+// GetByID retrieves an absence with its type
+func (r *absenceRepository) GetByID(id int) (*domain.Absence, error) {
+	absence, err := r.BaseRepository.GetWithPreload(id, "AbsenceType")
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	return absence, nil
+}
+
+// GetByEmployeeAndMonth retrieves the absences of an employee with a day in the month, whatever their status
 func (r *absenceRepository) GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.Absence, error) {
-	absences, err := FindByEmployeeAndMonth[domain.Absence](r.GetDB(), employeeID, year, month)
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	absences, err := r.findOverlapping(employeeID, from, from.AddDate(0, 1, -1))
 	if err != nil {
 		return nil, r.errorHandler.HandleError("GetByEmployeeAndMonth", err, employeeID)
 	}
@@ -44,7 +60,7 @@ func (r *absenceRepository) Create(absence *domain.Absence) error {
 	}
 	absence.UpdatedAt = time.Now()
 
-	if err := r.BaseRepository.Create(absence); err != nil {
+	if err := r.GetDB().Omit("AbsenceType").Create(absence).Error; err != nil {
 		return r.errorHandler.HandleError("Create", err)
 	}
 	return nil
@@ -52,10 +68,10 @@ func (r *absenceRepository) Create(absence *domain.Absence) error {
 
 // validateAbsence performs business validation
 func (r *absenceRepository) validateAbsence(absence *domain.Absence) error {
-	if absence.EmployeeID <= 0 {
+	if absence.EmployeeID <= 0 || absence.AbsenceTypeID <= 0 {
 		return ErrInvalidInput
 	}
-	if absence.Date.IsZero() {
+	if absence.Date.IsZero() || absence.EndDate.Before(absence.Date) {
 		return ErrInvalidInput
 	}
 	if absence.Hours <= 0 {
@@ -64,31 +80,82 @@ func (r *absenceRepository) validateAbsence(absence *domain.Absence) error {
 	return nil
 }
 
-// GetByEmployeeAndDateRange retrieves absences by employee and custom date range
+// GetByEmployeeAndDateRange retrieves the absences of an employee with a day in the range, whatever their status
 func (r *absenceRepository) GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.Absence, error) {
-	absences, err := FindByStoreAndDateRange[domain.Absence](r.GetDB(), employeeID, from, to)
+	absences, err := r.findOverlapping(employeeID, from, to)
 	if err != nil {
 		return nil, r.errorHandler.HandleError("GetByEmployeeAndDateRange", err, employeeID)
 	}
 	return absences, nil
 }
 
-// GetTotalHoursByEmployee retrieves total absence hours for an employee in a period
+// GetTotalHoursByEmployee retrieves the approved absence hours of an employee that fall in the month
 func (r *absenceRepository) GetTotalHoursByEmployee(employeeID, year, month int) (float64, error) {
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
+	absences, err := r.findOverlapping(employeeID, from, to)
+	if err != nil {
+		return 0, r.errorHandler.HandleError("GetTotalHoursByEmployee", err, employeeID)
+	}
+
+	// Ranges may start or end in another month, so only the days of this one count
 	var totalHours float64
+	for _, absence := range absences {
+		if absence.IsApproved() {
+			totalHours += absence.HoursIn(from, to)
+		}
+	}
+	return totalHours, nil
+}
 
-	startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	endDate := startDate.AddDate(0, 1, -1).Add(23*time.Hour + 59*time.Minute + 59*time.Second)
+// GetPending retrieves the absence requests awaiting review of the employees in scope, oldest first
+func (r *absenceRepository) GetPending(scope domain.TenantScope) ([]domain.Absence, error) {
+	var absences []domain.Absence
+	query := r.GetDB().
+		Preload("AbsenceType").
+		Joins("JOIN users ON users.id = absences.employee_id").
+		Where("absences.status = ?", domain.AbsenceStatusPending).
+		Where(employeeInFranchiseCondition, scope.FranchiseID, scope.FranchiseID)
+	if scope.RestrictedToStore() {
+		query = query.Where(employeeInStoreCondition, scope.StoreID)
+	}
 
-	err := r.GetDB().
+	if err := query.Order("absences.created_at").Find(&absences).Error; err != nil {
+		return nil, r.errorHandler.HandleError("GetPending", err)
+	}
+	return absences, nil
+}
+
+// Review stores the decision on a pending absence; it fails when the absence was already reviewed
+func (r *absenceRepository) Review(absence *domain.Absence) error {
+	result := r.GetDB().
 		Model(&domain.Absence{}).
-		Select("COALESCE(SUM(hours), 0)").
-		Where("employee_id = ? AND date BETWEEN ? AND ?", employeeID, startDate, endDate).
-		Scan(&totalHours).Error
+		Where("id = ? AND status = ?", absence.ID, domain.AbsenceStatusPending).
+		Updates(map[string]interface{}{
+			"status":      absence.Status,
+			"reviewed_by": absence.ReviewedBy,
+			"reviewed_at": absence.ReviewedAt,
+			"review_note": absence.ReviewNote,
+		})
 
-	if err != nil {
-		return 0, r.errorHandler.HandleError("GetTotalHoursByEmployee", err, employeeID)
+	if result.Error != nil {
+		return r.errorHandler.HandleError("Review", result.Error, absence.ID)
+	}
+	if result.RowsAffected == 0 {
+		return r.errorHandler.HandleNotFound("Review", absence.ID)
 	}
+	return nil
+}
 
-	return totalHours, nil
+// findOverlapping retrieves the absences of an employee with a day between from and to, with their type
+func (r *absenceRepository) findOverlapping(employeeID int, from, to time.Time) ([]domain.Absence, error) {
+	var absences []domain.Absence
+	err := r.GetDB().
+		Preload("AbsenceType").
+		Where("employee_id = ?", employeeID).
+		Where(absenceOverlapsCondition, to.Format("2006-01-02"), from.Format("2006-01-02")).
+		Order("date").
+		Find(&absences).Error
+	return absences, err
 }
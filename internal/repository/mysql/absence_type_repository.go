@@ -0,0 +1,87 @@
+package mysql
+
+import (
+	"errors"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// absenceTypeRepository implements repository.AbsenceTypeRepository
+type absenceTypeRepository struct {
+	*BaseRepository[domain.AbsenceType]
+	errorHandler *ErrorHandler
+}
+
+// NewAbsenceTypeRepository creates a new absence type catalog repository
+func NewAbsenceTypeRepository(db *gorm.DB) repository.AbsenceTypeRepository {
+	return &absenceTypeRepository{
+		BaseRepository: NewBaseRepository[domain.AbsenceType](db, "absence_types"),
+		errorHandler:   NewErrorHandler("absence_types"),
+	}
+}
+
+// GetAll retrieves the catalog ordered by name; inactive types only when requested
+func (r *absenceTypeRepository) GetAll(includeInactive bool) ([]domain.AbsenceType, error) {
+	var types []domain.AbsenceType
+	query := r.GetDB().Order("name")
+	if !includeInactive {
+		query = query.Where("is_active = ?", true)
+	}
+
+	if err := query.Find(&types).Error; err != nil {
+		return nil, r.errorHandler.HandleError("GetAll", err)
+	}
+	return types, nil
+}
+
+// GetByID retrieves an absence type by ID with proper error handling
+func (r *absenceTypeRepository) GetByID(id int) (*domain.AbsenceType, error) {
+	absenceType, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	return absenceType, nil
+}
+
+// GetByCode retrieves an absence type by its unique code
+func (r *absenceTypeRepository) GetByCode(code string) (*domain.AbsenceType, error) {
+	var absenceType domain.AbsenceType
+
+	err := r.GetDB().Where("code = ?", code).First(&absenceType).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, r.errorHandler.HandleNotFound("GetByCode", code)
+	}
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByCode", err, code)
+	}
+	return &absenceType, nil
+}
+
+// Create creates a new absence type; codes are unique
+func (r *absenceTypeRepository) Create(absenceType *domain.AbsenceType) error {
+	if absenceType.Code == "" || absenceType.Name == "" {
+		return r.errorHandler.HandleError("Create", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(absenceType); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// Update modifies an existing absence type
+func (r *absenceTypeRepository) Update(absenceType *domain.AbsenceType) error {
+	if absenceType.ID == 0 || absenceType.Code == "" || absenceType.Name == "" {
+		return r.errorHandler.HandleError("Update", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Update(absenceType); err != nil {
+		return r.errorHandler.HandleError("Update", err, absenceType.ID)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"errors"
 	"loopi-api/internal/domain"
 	"loopi-api/internal/repository"
 	"time"
@@ -22,12 +23,83 @@ func NewAssignedShiftRepository(db *gorm.DB) repository.AssignedShiftRepository
 	}
 }
 
+// GetByID retrieves an assigned shift by ID with proper error handling
+func (r *assignedShiftRepository) GetByID(id int) (*domain.AssignedShift, error) {
+	assignedShift, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	normalizeAssignedShift(assignedShift)
+	return assignedShift, nil
+}
+
 // GetByEmployeeAndMonth retrieves assigned shifts by employee and month using query helper
 func (r *assignedShiftRepository) GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.AssignedShift, error) {
 	shifts, err := FindByEmployeeAndMonth[domain.AssignedShift](r.GetDB(), employeeID, year, month)
 	if err != nil {
 		return nil, r.errorHandler.HandleError("GetByEmployeeAndMonth", err, employeeID)
 	}
+	normalizeAssignedShifts(shifts)
+	return shifts, nil
+}
+
+// GetByEmployeeAndDate retrieves the shift assigned to an employee on a given date (nil if none)
+func (r *assignedShiftRepository) GetByEmployeeAndDate(employeeID int, date string) (*domain.AssignedShift, error) {
+	var assignedShift domain.AssignedShift
+
+	err := NewQueryBuilder(r.GetDB()).
+		WhereEquals("employee_id", employeeID).
+		WhereEquals("date", date).
+		GetDB().
+		First(&assignedShift).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // Return nil for not found (business logic)
+	}
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByEmployeeAndDate", err, employeeID)
+	}
+
+	normalizeAssignedShift(&assignedShift)
+	return &assignedShift, nil
+}
+
+// GetByEmployeeAndDateRange retrieves assigned shifts for an employee between two dates (inclusive)
+func (r *assignedShiftRepository) GetByEmployeeAndDateRange(employeeID int, from, to string) ([]domain.AssignedShift, error) {
+	var shifts []domain.AssignedShift
+
+	err := r.GetDB().
+		Where("employee_id = ? AND date BETWEEN ? AND ?", employeeID, from, to).
+		Order("date").
+		Find(&shifts).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByEmployeeAndDateRange", err, employeeID)
+	}
+
+	normalizeAssignedShifts(shifts)
+	return shifts, nil
+}
+
+// GetByStoreAndDateRange retrieves the roster of a store between two dates (inclusive)
+func (r *assignedShiftRepository) GetByStoreAndDateRange(storeID int, from, to string) ([]domain.AssignedShift, error) {
+	var shifts []domain.AssignedShift
+
+	err := r.GetDB().
+		Where("store_id = ? AND date BETWEEN ? AND ?", storeID, from, to).
+		Order("date").
+		Order("start_time").
+		Find(&shifts).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByStoreAndDateRange", err, storeID)
+	}
+
+	normalizeAssignedShifts(shifts)
 	return shifts, nil
 }
 
@@ -44,8 +116,71 @@ func (r *assignedShiftRepository) Create(assignedShift *domain.AssignedShift) er
 	return nil
 }
 
+// CreateBatch creates several assigned shifts atomically
+func (r *assignedShiftRepository) CreateBatch(assignedShifts []domain.AssignedShift) error {
+	if len(assignedShifts) == 0 {
+		return nil
+	}
+
+	for i := range assignedShifts {
+		if err := r.validateAssignedShift(&assignedShifts[i]); err != nil {
+			return r.errorHandler.HandleError("CreateBatch", err)
+		}
+	}
+
+	// Use transaction to ensure atomicity
+	err := r.BaseRepository.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&assignedShifts).Error
+	})
+	if err != nil {
+		return r.errorHandler.HandleError("CreateBatch", err)
+	}
+	return nil
+}
+
+// Update modifies an existing assigned shift
+func (r *assignedShiftRepository) Update(assignedShift *domain.AssignedShift) error {
+	if assignedShift.ID == 0 {
+		return r.errorHandler.HandleError("Update", ErrInvalidInput)
+	}
+
+	if err := r.validateAssignedShift(assignedShift); err != nil {
+		return r.errorHandler.HandleError("Update", err, assignedShift.ID)
+	}
+
+	if err := r.BaseRepository.Update(assignedShift); err != nil {
+		return r.errorHandler.HandleError("Update", err, assignedShift.ID)
+	}
+	return nil
+}
+
+// Delete removes an assigned shift by ID
+func (r *assignedShiftRepository) Delete(id int) error {
+	exists, err := r.BaseRepository.Exists(id)
+	if err != nil {
+		return r.errorHandler.HandleError("Delete", err, id)
+	}
+	if !exists {
+		return r.errorHandler.HandleNotFound("Delete", id)
+	}
+
+	if err := r.BaseRepository.Delete(id); err != nil {
+		return r.errorHandler.HandleError("Delete", err, id)
+	}
+	return nil
+}
+
 // validateAssignedShift performs business validation
 func (r *assignedShiftRepository) validateAssignedShift(assignedShift *domain.AssignedShift) error {
+	if assignedShift.EmployeeID <= 0 {
+		return ErrInvalidInput
+	}
+	if assignedShift.ShiftID <= 0 {
+		return ErrInvalidInput
+	}
+	if assignedShift.StoreID <= 0 {
+		return ErrInvalidInput
+	}
 	if assignedShift.Date == "" {
 		return ErrInvalidInput
 	}
@@ -58,15 +193,32 @@ func (r *assignedShiftRepository) validateAssignedShift(assignedShift *domain.As
 	return nil
 }
 
+// normalizeAssignedShift trims DATE/TIME columns back to "YYYY-MM-DD" and "HH:MM",
+// since the driver may return them as full timestamps depending on the DSN
+func normalizeAssignedShift(assignedShift *domain.AssignedShift) {
+	if len(assignedShift.Date) > 10 {
+		assignedShift.Date = assignedShift.Date[:10]
+	}
+	if len(assignedShift.StartTime) > 5 {
+		assignedShift.StartTime = assignedShift.StartTime[:5]
+	}
+	if len(assignedShift.EndTime) > 5 {
+		assignedShift.EndTime = assignedShift.EndTime[:5]
+	}
+}
+
+func normalizeAssignedShifts(assignedShifts []domain.AssignedShift) {
+	for i := range assignedShifts {
+		normalizeAssignedShift(&assignedShifts[i])
+	}
+}
+
 // GetByDateRange retrieves assigned shifts by custom date range
 func (r *assignedShiftRepository) GetByDateRange(from, to time.Time) ([]domain.AssignedShift, error) {
 	var shifts []domain.AssignedShift
 
-	fromStr := from.Format("2006-01-02")
-	toStr := to.Format("2006-01-02")
-
 	err := NewQueryBuilder(r.GetDB()).
-		WhereLike("date", fromStr+"' AND '"+toStr).
+		WhereDateRange("date", from, to).
 		OrderBy("date").
 		GetDB().
 		Find(&shifts).Error
@@ -74,6 +226,7 @@ func (r *assignedShiftRepository) GetByDateRange(from, to time.Time) ([]domain.A
 	if err != nil {
 		return nil, r.errorHandler.HandleError("GetByDateRange", err)
 	}
+	normalizeAssignedShifts(shifts)
 	return shifts, nil
 }
 
@@ -91,6 +244,7 @@ func (r *assignedShiftRepository) GetByDateAndTime(date, startTime, endTime stri
 	if err != nil {
 		return nil, r.errorHandler.HandleError("GetByDateAndTime", err)
 	}
+	normalizeAssignedShifts(shifts)
 	return shifts, nil
 }
 
@@ -107,6 +261,7 @@ func (r *assignedShiftRepository) GetByPeriod(startDate, endDate string) ([]doma
 		return nil, r.errorHandler.HandleError("GetByPeriod", err)
 	}
 
+	normalizeAssignedShifts(shifts)
 	return shifts, nil
 }
 
@@ -0,0 +1,119 @@
+package mysql
+
+import (
+	"errors"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// authSessionRepository implements repository.AuthSessionRepository with improved maintainability
+type authSessionRepository struct {
+	*BaseRepository[domain.AuthSession]
+	errorHandler *ErrorHandler
+}
+
+// NewAuthSessionRepository creates a new auth session repository with enhanced features
+func NewAuthSessionRepository(db *gorm.DB) repository.AuthSessionRepository {
+	return &authSessionRepository{
+		BaseRepository: NewBaseRepository[domain.AuthSession](db, "auth_sessions"),
+		errorHandler:   NewErrorHandler("auth_sessions"),
+	}
+}
+
+// GetByID retrieves a session by ID with proper error handling
+func (r *authSessionRepository) GetByID(id int) (*domain.AuthSession, error) {
+	session, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	return session, nil
+}
+
+// GetByRefreshTokenHash retrieves the session whose current or previous refresh token has the hash
+func (r *authSessionRepository) GetByRefreshTokenHash(tokenHash string) (*domain.AuthSession, error) {
+	var session domain.AuthSession
+
+	err := r.GetDB().
+		Where("refresh_token_hash = ? OR previous_token_hash = ?", tokenHash, tokenHash).
+		First(&session).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, r.errorHandler.HandleNotFound("GetByRefreshTokenHash")
+	}
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByRefreshTokenHash", err)
+	}
+	return &session, nil
+}
+
+// Create creates a new session with validation and error handling
+func (r *authSessionRepository) Create(session *domain.AuthSession) error {
+	if session.UserID <= 0 || session.RefreshTokenHash == "" {
+		return r.errorHandler.HandleError("Create", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(session); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// Update saves a rotated refresh token or a new franchise/store context
+func (r *authSessionRepository) Update(session *domain.AuthSession) error {
+	if session.ID == 0 || session.RefreshTokenHash == "" {
+		return r.errorHandler.HandleError("Update", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Update(session); err != nil {
+		return r.errorHandler.HandleError("Update", err, session.ID)
+	}
+	return nil
+}
+
+// Revoke marks a session as revoked so its access and refresh tokens stop working
+func (r *authSessionRepository) Revoke(id int) error {
+	result := r.GetDB().
+		Model(&domain.AuthSession{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+
+	if result.Error != nil {
+		return r.errorHandler.HandleError("Revoke", result.Error, id)
+	}
+	if result.RowsAffected == 0 {
+		return r.errorHandler.HandleNotFound("Revoke", id)
+	}
+	return nil
+}
+
+// RevokeByUser revokes every active session of a user
+func (r *authSessionRepository) RevokeByUser(userID int) error {
+	err := r.GetDB().
+		Model(&domain.AuthSession{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+
+	if err != nil {
+		return r.errorHandler.HandleError("RevokeByUser", err, userID)
+	}
+	return nil
+}
+
+// RevokeByUserExcept revokes every active session of a user but the one given
+func (r *authSessionRepository) RevokeByUserExcept(userID, sessionID int) error {
+	err := r.GetDB().
+		Model(&domain.AuthSession{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, sessionID).
+		Update("revoked_at", time.Now()).Error
+
+	if err != nil {
+		return r.errorHandler.HandleError("RevokeByUserExcept", err, userID)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package mysql
+
+import (
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// availabilityRepository implements repository.AvailabilityRepository
+type availabilityRepository struct {
+	*BaseRepository[domain.AvailabilityException]
+	errorHandler *ErrorHandler
+}
+
+// NewAvailabilityRepository creates a new employee availability repository
+func NewAvailabilityRepository(db *gorm.DB) repository.AvailabilityRepository {
+	return &availabilityRepository{
+		BaseRepository: NewBaseRepository[domain.AvailabilityException](db, "availability_exceptions"),
+		errorHandler:   NewErrorHandler("availability"),
+	}
+}
+
+// GetWindowsByUser retrieves the weekly windows of a user ordered by weekday and start time
+func (r *availabilityRepository) GetWindowsByUser(userID int) ([]domain.AvailabilityWindow, error) {
+	var windows []domain.AvailabilityWindow
+
+	err := r.GetDB().
+		Where("user_id = ?", userID).
+		Order("weekday, start_time, id").
+		Find(&windows).Error
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetWindowsByUser", err, userID)
+	}
+	return windows, nil
+}
+
+// ReplaceWindows deletes the weekly windows of a user and stores the new ones in one transaction
+func (r *availabilityRepository) ReplaceWindows(userID int, windows []domain.AvailabilityWindow) error {
+	if userID <= 0 {
+		return r.errorHandler.HandleError("ReplaceWindows", ErrInvalidInput)
+	}
+
+	err := r.BaseRepository.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.AvailabilityWindow{}).Error; err != nil {
+			return err
+		}
+		if len(windows) == 0 {
+			return nil
+		}
+		return tx.Create(&windows).Error
+	})
+	if err != nil {
+		return r.errorHandler.HandleError("ReplaceWindows", err, userID)
+	}
+	return nil
+}
+
+// GetExceptionByID retrieves an availability exception by ID
+func (r *availabilityRepository) GetExceptionByID(id int) (*domain.AvailabilityException, error) {
+	exception, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetExceptionByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetExceptionByID", err, id)
+	}
+	return exception, nil
+}
+
+// CreateException stores a new availability exception
+func (r *availabilityRepository) CreateException(exception *domain.AvailabilityException) error {
+	if exception.UserID <= 0 || exception.Date == "" || exception.Type == "" {
+		return r.errorHandler.HandleError("CreateException", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(exception); err != nil {
+		return r.errorHandler.HandleError("CreateException", err)
+	}
+	return nil
+}
+
+// DeleteException removes an availability exception by ID
+func (r *availabilityRepository) DeleteException(id int) error {
+	result := r.GetDB().Delete(&domain.AvailabilityException{}, id)
+	if result.Error != nil {
+		return r.errorHandler.HandleError("DeleteException", result.Error, id)
+	}
+	if result.RowsAffected == 0 {
+		return r.errorHandler.HandleNotFound("DeleteException", id)
+	}
+	return nil
+}
+
+// GetExceptionsByUserAndDateRange retrieves the exceptions of a user between two dates ordered by date
+func (r *availabilityRepository) GetExceptionsByUserAndDateRange(userID int, from, to string) ([]domain.AvailabilityException, error) {
+	var exceptions []domain.AvailabilityException
+
+	err := r.GetDB().
+		Where("user_id = ? AND date BETWEEN ? AND ?", userID, from, to).
+		Order("date, start_time, id").
+		Find(&exceptions).Error
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetExceptionsByUserAndDateRange", err, userID)
+	}
+	return exceptions, nil
+}
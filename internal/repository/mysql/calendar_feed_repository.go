@@ -0,0 +1,98 @@
+package mysql
+
+import (
+	"errors"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// calendarFeedRepository implements repository.CalendarFeedRepository with improved maintainability
+type calendarFeedRepository struct {
+	*BaseRepository[domain.CalendarFeed]
+	errorHandler *ErrorHandler
+}
+
+// NewCalendarFeedRepository creates a new calendar feed repository with enhanced features
+func NewCalendarFeedRepository(db *gorm.DB) repository.CalendarFeedRepository {
+	return &calendarFeedRepository{
+		BaseRepository: NewBaseRepository[domain.CalendarFeed](db, "calendar_feeds"),
+		errorHandler:   NewErrorHandler("calendar_feeds"),
+	}
+}
+
+// GetByID retrieves a calendar feed by ID with proper error handling
+func (r *calendarFeedRepository) GetByID(id int) (*domain.CalendarFeed, error) {
+	feed, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	return feed, nil
+}
+
+// GetByTokenHash retrieves the feed of a subscription token hash (revoked feeds included)
+func (r *calendarFeedRepository) GetByTokenHash(tokenHash string) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+
+	err := NewQueryBuilder(r.GetDB()).
+		WhereEquals("token_hash", tokenHash).
+		GetDB().
+		First(&feed).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, r.errorHandler.HandleNotFound("GetByTokenHash")
+	}
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByTokenHash", err)
+	}
+	return &feed, nil
+}
+
+// GetByEmployee retrieves the feeds of an employee, newest first
+func (r *calendarFeedRepository) GetByEmployee(employeeID int) ([]domain.CalendarFeed, error) {
+	var feeds []domain.CalendarFeed
+
+	err := NewQueryBuilder(r.GetDB()).
+		WhereEquals("employee_id", employeeID).
+		OrderBy("created_at", "DESC").
+		GetDB().
+		Find(&feeds).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByEmployee", err, employeeID)
+	}
+	return feeds, nil
+}
+
+// Create creates a new calendar feed with validation and error handling
+func (r *calendarFeedRepository) Create(feed *domain.CalendarFeed) error {
+	if feed.TokenHash == "" || feed.Kind == "" {
+		return r.errorHandler.HandleError("Create", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(feed); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// Revoke marks a calendar feed as revoked so its token stops working
+func (r *calendarFeedRepository) Revoke(id int) error {
+	result := r.GetDB().
+		Model(&domain.CalendarFeed{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+
+	if result.Error != nil {
+		return r.errorHandler.HandleError("Revoke", result.Error, id)
+	}
+	if result.RowsAffected == 0 {
+		return r.errorHandler.HandleNotFound("Revoke", id)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package mysql
+
+import (
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// holidayRepository implements repository.HolidayRepository with improved maintainability
+type holidayRepository struct {
+	*BaseRepository[domain.Holiday]
+	errorHandler *ErrorHandler
+}
+
+// NewHolidayRepository creates a new custom holiday repository with enhanced features
+func NewHolidayRepository(db *gorm.DB) repository.HolidayRepository {
+	return &holidayRepository{
+		BaseRepository: NewBaseRepository[domain.Holiday](db, "holidays"),
+		errorHandler:   NewErrorHandler("holidays"),
+	}
+}
+
+// GetByID retrieves a custom holiday by ID with proper error handling
+func (r *holidayRepository) GetByID(id int) (*domain.Holiday, error) {
+	holiday, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	normalizeHoliday(holiday)
+	return holiday, nil
+}
+
+// GetByDateRange retrieves every custom holiday between two dates (inclusive), whatever its scope
+func (r *holidayRepository) GetByDateRange(from, to string) ([]domain.Holiday, error) {
+	var holidays []domain.Holiday
+
+	err := r.GetDB().
+		Where("date BETWEEN ? AND ?", from, to).
+		Order("date").
+		Find(&holidays).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByDateRange", err)
+	}
+
+	normalizeHolidays(holidays)
+	return holidays, nil
+}
+
+// GetApplicable retrieves the custom holidays that apply to a store between two dates (inclusive):
+// global ones for its country, its franchise's and its own. Zero IDs skip that scope.
+func (r *holidayRepository) GetApplicable(country string, franchiseID, storeID int, from, to string) ([]domain.Holiday, error) {
+	var holidays []domain.Holiday
+
+	err := r.GetDB().
+		Where("date BETWEEN ? AND ?", from, to).
+		Where(
+			r.GetDB().
+				Where("scope = ? AND (country IS NULL OR country = '' OR country = ?)", domain.HolidayScopeGlobal, country).
+				Or("scope = ? AND franchise_id = ?", domain.HolidayScopeFranchise, franchiseID).
+				Or("scope = ? AND store_id = ?", domain.HolidayScopeStore, storeID),
+		).
+		Order("date").
+		Find(&holidays).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetApplicable", err, storeID)
+	}
+
+	normalizeHolidays(holidays)
+	return holidays, nil
+}
+
+// Create creates a new custom holiday with validation and error handling
+func (r *holidayRepository) Create(holiday *domain.Holiday) error {
+	// Business validation before creation
+	if err := r.validateHoliday(holiday); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+
+	if err := r.BaseRepository.Create(holiday); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// Update modifies an existing custom holiday
+func (r *holidayRepository) Update(holiday *domain.Holiday) error {
+	if holiday.ID == 0 {
+		return r.errorHandler.HandleError("Update", ErrInvalidInput)
+	}
+
+	if err := r.validateHoliday(holiday); err != nil {
+		return r.errorHandler.HandleError("Update", err, holiday.ID)
+	}
+
+	if err := r.BaseRepository.Update(holiday); err != nil {
+		return r.errorHandler.HandleError("Update", err, holiday.ID)
+	}
+	return nil
+}
+
+// Delete removes a custom holiday by ID
+func (r *holidayRepository) Delete(id int) error {
+	exists, err := r.BaseRepository.Exists(id)
+	if err != nil {
+		return r.errorHandler.HandleError("Delete", err, id)
+	}
+	if !exists {
+		return r.errorHandler.HandleNotFound("Delete", id)
+	}
+
+	if err := r.BaseRepository.Delete(id); err != nil {
+		return r.errorHandler.HandleError("Delete", err, id)
+	}
+	return nil
+}
+
+// validateHoliday performs business validation
+func (r *holidayRepository) validateHoliday(holiday *domain.Holiday) error {
+	if holiday.Date == "" || holiday.Name == "" {
+		return ErrInvalidInput
+	}
+	switch holiday.Scope {
+	case domain.HolidayScopeGlobal:
+		return nil
+	case domain.HolidayScopeFranchise:
+		if holiday.FranchiseID == nil {
+			return ErrInvalidInput
+		}
+	case domain.HolidayScopeStore:
+		if holiday.StoreID == nil {
+			return ErrInvalidInput
+		}
+	default:
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// normalizeHoliday trims the DATE column back to "YYYY-MM-DD",
+// since the driver may return it as a full timestamp depending on the DSN
+func normalizeHoliday(holiday *domain.Holiday) {
+	if len(holiday.Date) > 10 {
+		holiday.Date = holiday.Date[:10]
+	}
+}
+
+func normalizeHolidays(holidays []domain.Holiday) {
+	for i := range holidays {
+		normalizeHoliday(&holidays[i])
+	}
+}
@@ -117,3 +117,19 @@ func (r *noveltyRepository) GetNoveltyTypesSummary(employeeID, year, month int)
 
 	return summary, nil
 }
+
+// GetByAssignedShifts retrieves the novelties generated for the given assigned shifts
+func (r *noveltyRepository) GetByAssignedShifts(assignedShiftIDs []int) ([]domain.Novelty, error) {
+	var novelties []domain.Novelty
+	if len(assignedShiftIDs) == 0 {
+		return novelties, nil
+	}
+
+	err := r.GetDB().
+		Where("assigned_shift_id IN ?", assignedShiftIDs).
+		Find(&novelties).Error
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByAssignedShifts", err)
+	}
+	return novelties, nil
+}
@@ -0,0 +1,79 @@
+package mysql
+
+import (
+	"errors"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// passwordResetRepository implements repository.PasswordResetRepository
+type passwordResetRepository struct {
+	*BaseRepository[domain.PasswordResetToken]
+	errorHandler *ErrorHandler
+}
+
+// NewPasswordResetRepository creates a new password reset token repository
+func NewPasswordResetRepository(db *gorm.DB) repository.PasswordResetRepository {
+	return &passwordResetRepository{
+		BaseRepository: NewBaseRepository[domain.PasswordResetToken](db, "password_reset_tokens"),
+		errorHandler:   NewErrorHandler("password_reset_tokens"),
+	}
+}
+
+// Create stores a new reset token
+func (r *passwordResetRepository) Create(token *domain.PasswordResetToken) error {
+	if token.UserID <= 0 || token.TokenHash == "" {
+		return r.errorHandler.HandleError("Create", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(token); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// GetByTokenHash retrieves a reset token by its hash, used or not
+func (r *passwordResetRepository) GetByTokenHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+
+	err := r.GetDB().Where("token_hash = ?", tokenHash).First(&token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, r.errorHandler.HandleNotFound("GetByTokenHash")
+	}
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByTokenHash", err)
+	}
+	return &token, nil
+}
+
+// MarkUsed consumes a token; it fails when the token was already used
+func (r *passwordResetRepository) MarkUsed(id int) error {
+	result := r.GetDB().
+		Model(&domain.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+
+	if result.Error != nil {
+		return r.errorHandler.HandleError("MarkUsed", result.Error, id)
+	}
+	if result.RowsAffected == 0 {
+		return r.errorHandler.HandleNotFound("MarkUsed", id)
+	}
+	return nil
+}
+
+// InvalidateByUser consumes every unused token of a user
+func (r *passwordResetRepository) InvalidateByUser(userID int) error {
+	err := r.GetDB().
+		Model(&domain.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", time.Now()).Error
+
+	if err != nil {
+		return r.errorHandler.HandleError("InvalidateByUser", err, userID)
+	}
+	return nil
+}
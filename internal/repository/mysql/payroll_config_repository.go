@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// payrollConfigRepository implements repository.PayrollConfigRepository
+type payrollConfigRepository struct {
+	*BaseRepository[domain.PayrollConfig]
+	errorHandler *ErrorHandler
+}
+
+// NewPayrollConfigRepository creates a new payroll config repository
+func NewPayrollConfigRepository(db *gorm.DB) repository.PayrollConfigRepository {
+	return &payrollConfigRepository{
+		BaseRepository: NewBaseRepository[domain.PayrollConfig](db, "payroll_configs"),
+		errorHandler:   NewErrorHandler("payroll_configs"),
+	}
+}
+
+// GetEffectiveConfig retrieves the surcharges in force on a date with fallback to defaults
+func (r *payrollConfigRepository) GetEffectiveConfig(date time.Time) domain.PayrollConfig {
+	var config domain.PayrollConfig
+
+	err := NewQueryBuilder(r.GetDB()).
+		WhereActive().
+		GetDB().
+		Where("effective_from <= ?", date.Format("2006-01-02")).
+		Order("effective_from DESC").
+		First(&config).Error
+
+	if err != nil {
+		// Log error but don't fail - return fallback configuration
+		r.errorHandler.LogError("GetEffectiveConfig", err)
+		return r.getDefaultConfig()
+	}
+
+	return config
+}
+
+// getDefaultConfig returns the statutory surcharges (Código Sustantivo del Trabajo)
+func (r *payrollConfigRepository) getDefaultConfig() domain.PayrollConfig {
+	return domain.PayrollConfig{
+		NightSurcharge:           0.35,
+		SundayHolidaySurcharge:   0.75,
+		ExtraDiurnalMultiplier:   1.25,
+		ExtraNocturnalMultiplier: 1.75,
+		IsActive:                 true,
+	}
+}
+
+// GetAllConfigs retrieves all payroll configurations ordered by effective date
+func (r *payrollConfigRepository) GetAllConfigs() ([]domain.PayrollConfig, error) {
+	var configs []domain.PayrollConfig
+
+	err := r.GetDB().
+		Order("effective_from").
+		Find(&configs).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetAllConfigs", err)
+	}
+	return configs, nil
+}
+
+// Create creates a new payroll configuration with validation
+func (r *payrollConfigRepository) Create(config *domain.PayrollConfig) error {
+	if err := r.validatePayrollConfig(config); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+
+	if err := r.GetDB().Create(config).Error; err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// validatePayrollConfig performs business validation
+func (r *payrollConfigRepository) validatePayrollConfig(config *domain.PayrollConfig) error {
+	if config.EffectiveFrom.IsZero() {
+		return ErrInvalidInput
+	}
+	if config.NightSurcharge < 0 || config.SundayHolidaySurcharge < 0 {
+		return ErrInvalidInput
+	}
+	if config.ExtraDiurnalMultiplier < 1 || config.ExtraNocturnalMultiplier < 1 {
+		return ErrInvalidInput
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package mysql
+
+import (
+	"errors"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// permissionRepository implements repository.PermissionRepository with improved maintainability
+type permissionRepository struct {
+	*BaseRepository[domain.Permission]
+	errorHandler *ErrorHandler
+}
+
+// NewPermissionRepository creates a new permission repository with enhanced features
+func NewPermissionRepository(db *gorm.DB) repository.PermissionRepository {
+	return &permissionRepository{
+		BaseRepository: NewBaseRepository[domain.Permission](db, "permissions"),
+		errorHandler:   NewErrorHandler("permissions"),
+	}
+}
+
+// GetAll retrieves all permissions ordered by name
+func (r *permissionRepository) GetAll() ([]domain.Permission, error) {
+	var permissions []domain.Permission
+
+	err := NewQueryBuilder(r.GetDB()).
+		OrderBy("name").
+		GetDB().
+		Find(&permissions).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetAll", err)
+	}
+	return permissions, nil
+}
+
+// GetByID retrieves a permission by ID with proper error handling
+func (r *permissionRepository) GetByID(id int) (*domain.Permission, error) {
+	permission, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	return permission, nil
+}
+
+// GetByName retrieves a permission by its unique name
+func (r *permissionRepository) GetByName(name string) (*domain.Permission, error) {
+	var permission domain.Permission
+
+	err := NewQueryBuilder(r.GetDB()).
+		WhereEquals("name", name).
+		GetDB().
+		First(&permission).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, r.errorHandler.HandleNotFound("GetByName", name)
+	}
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByName", err, name)
+	}
+	return &permission, nil
+}
+
+// GetByIDs retrieves the permissions with the given IDs
+func (r *permissionRepository) GetByIDs(ids []int) ([]domain.Permission, error) {
+	var permissions []domain.Permission
+	if len(ids) == 0 {
+		return permissions, nil
+	}
+
+	if err := r.GetDB().Where("id IN ?", ids).Find(&permissions).Error; err != nil {
+		return nil, r.errorHandler.HandleError("GetByIDs", err)
+	}
+	return permissions, nil
+}
+
+// Create creates a new permission with validation and error handling
+func (r *permissionRepository) Create(permission *domain.Permission) error {
+	if permission.Name == "" {
+		return r.errorHandler.HandleError("Create", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(permission); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// Update updates an existing permission with validation
+func (r *permissionRepository) Update(permission *domain.Permission) error {
+	if permission.ID == 0 || permission.Name == "" {
+		return r.errorHandler.HandleError("Update", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Update(permission); err != nil {
+		return r.errorHandler.HandleError("Update", err, permission.ID)
+	}
+	return nil
+}
+
+// Delete removes a permission; its role assignments cascade
+func (r *permissionRepository) Delete(id int) error {
+	exists, err := r.BaseRepository.Exists(id)
+	if err != nil {
+		return r.errorHandler.HandleError("Delete", err, id)
+	}
+	if !exists {
+		return r.errorHandler.HandleNotFound("Delete", id)
+	}
+
+	if err := r.BaseRepository.Delete(id); err != nil {
+		return r.errorHandler.HandleError("Delete", err, id)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package mysql
+
+import (
+	"errors"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// roleRepository implements repository.RoleRepository with improved maintainability
+type roleRepository struct {
+	*BaseRepository[domain.Role]
+	errorHandler *ErrorHandler
+}
+
+// NewRoleRepository creates a new role repository with enhanced features
+func NewRoleRepository(db *gorm.DB) repository.RoleRepository {
+	return &roleRepository{
+		BaseRepository: NewBaseRepository[domain.Role](db, "roles"),
+		errorHandler:   NewErrorHandler("roles"),
+	}
+}
+
+// GetAll retrieves all roles ordered by name
+func (r *roleRepository) GetAll() ([]domain.Role, error) {
+	var roles []domain.Role
+
+	err := NewQueryBuilder(r.GetDB()).
+		OrderBy("name").
+		GetDB().
+		Find(&roles).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetAll", err)
+	}
+	return roles, nil
+}
+
+// GetByID retrieves a role by ID with proper error handling
+func (r *roleRepository) GetByID(id int) (*domain.Role, error) {
+	role, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	return role, nil
+}
+
+// GetByName retrieves a role by its unique name
+func (r *roleRepository) GetByName(name string) (*domain.Role, error) {
+	var role domain.Role
+
+	err := NewQueryBuilder(r.GetDB()).
+		WhereEquals("name", name).
+		GetDB().
+		First(&role).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, r.errorHandler.HandleNotFound("GetByName", name)
+	}
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByName", err, name)
+	}
+	return &role, nil
+}
+
+// Create creates a new role with validation and error handling
+func (r *roleRepository) Create(role *domain.Role) error {
+	if role.Name == "" {
+		return r.errorHandler.HandleError("Create", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(role); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// Update updates an existing role with validation
+func (r *roleRepository) Update(role *domain.Role) error {
+	if role.ID == 0 || role.Name == "" {
+		return r.errorHandler.HandleError("Update", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Update(role); err != nil {
+		return r.errorHandler.HandleError("Update", err, role.ID)
+	}
+	return nil
+}
+
+// Delete removes a role; its permission and user assignments cascade
+func (r *roleRepository) Delete(id int) error {
+	exists, err := r.BaseRepository.Exists(id)
+	if err != nil {
+		return r.errorHandler.HandleError("Delete", err, id)
+	}
+	if !exists {
+		return r.errorHandler.HandleNotFound("Delete", id)
+	}
+
+	if err := r.BaseRepository.Delete(id); err != nil {
+		return r.errorHandler.HandleError("Delete", err, id)
+	}
+	return nil
+}
+
+// GetPermissions retrieves the permissions granted to a role
+func (r *roleRepository) GetPermissions(roleID int) ([]domain.Permission, error) {
+	var permissions []domain.Permission
+
+	err := r.GetDB().
+		Joins("JOIN role_permissions rp ON rp.permission_id = permissions.id").
+		Where("rp.role_id = ?", roleID).
+		Order("permissions.name").
+		Find(&permissions).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetPermissions", err, roleID)
+	}
+	return permissions, nil
+}
+
+// SetPermissions replaces the permissions of a role in a single transaction
+func (r *roleRepository) SetPermissions(roleID int, permissionIDs []int) error {
+	err := r.BaseRepository.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", roleID).Delete(&domain.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		for _, permissionID := range permissionIDs {
+			rolePermission := domain.RolePermission{RoleID: roleID, PermissionID: permissionID}
+			if err := tx.Omit("Role", "Permission").Create(&rolePermission).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return r.errorHandler.HandleError("SetPermissions", err, roleID)
+	}
+	return nil
+}
+
+// AssignToUser grants the role to a user in a franchise, keeping an existing grant
+func (r *roleRepository) AssignToUser(userID, roleID, franchiseID int) error {
+	userRole := domain.UserRole{UserID: userID, RoleID: roleID, FranchiseID: franchiseID}
+
+	err := r.GetDB().
+		Omit("User", "Role", "Franchise").
+		Where(&userRole).
+		FirstOrCreate(&userRole).Error
+
+	if err != nil {
+		return r.errorHandler.HandleError("AssignToUser", err, userID)
+	}
+	return nil
+}
+
+// RevokeFromUser removes the role of a user in a franchise
+func (r *roleRepository) RevokeFromUser(userID, roleID, franchiseID int) error {
+	result := r.GetDB().
+		Where("user_id = ? AND role_id = ? AND franchise_id = ?", userID, roleID, franchiseID).
+		Delete(&domain.UserRole{})
+
+	if result.Error != nil {
+		return r.errorHandler.HandleError("RevokeFromUser", result.Error, userID)
+	}
+	if result.RowsAffected == 0 {
+		return r.errorHandler.HandleNotFound("RevokeFromUser", userID)
+	}
+	return nil
+}
+
+// GetUserPermissionNames retrieves the distinct permission names of a user's active roles
+func (r *roleRepository) GetUserPermissionNames(userID, franchiseID int) ([]string, error) {
+	var names []string
+
+	query := r.GetDB().
+		Table("permissions p").
+		Distinct("p.name").
+		Joins("JOIN role_permissions rp ON rp.permission_id = p.id").
+		Joins("JOIN roles r ON r.id = rp.role_id AND r.is_active = ?", true).
+		Joins("JOIN user_roles ur ON ur.role_id = r.id").
+		Where("ur.user_id = ?", userID)
+	if franchiseID > 0 {
+		query = query.Where("ur.franchise_id = ?", franchiseID)
+	}
+
+	if err := query.Pluck("p.name", &names).Error; err != nil {
+		return nil, r.errorHandler.HandleError("GetUserPermissionNames", err, userID)
+	}
+	return names, nil
+}
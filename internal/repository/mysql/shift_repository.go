@@ -49,6 +49,21 @@ func (r *shiftRepository) ListAll() ([]domain.Shift, error) {
 	return shifts, nil
 }
 
+// ListByFranchise retrieves the shifts of every store of a franchise
+func (r *shiftRepository) ListByFranchise(franchiseID int) ([]domain.Shift, error) {
+	var shifts []domain.Shift
+	err := r.GetDB().
+		Joins("JOIN stores s ON s.id = shifts.store_id").
+		Where("s.franchise_id = ?", franchiseID).
+		Order("shifts.name").
+		Find(&shifts).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("ListByFranchise", err, franchiseID)
+	}
+	return shifts, nil
+}
+
 // ListByStore retrieves shifts by store ID with proper ordering
 func (r *shiftRepository) ListByStore(storeID int) ([]domain.Shift, error) {
 	var shifts []domain.Shift
@@ -0,0 +1,216 @@
+package mysql
+
+import (
+	"errors"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// shiftSwapInvolvesCondition matches swaps where the assigned shift is the offered or the returned one.
+// Both placeholders take the assigned shift ID.
+const shiftSwapInvolvesCondition = "(offered_shift_id = ? OR return_shift_id = ?)"
+
+// shiftSwapRepository implements repository.ShiftSwapRepository
+type shiftSwapRepository struct {
+	*BaseRepository[domain.ShiftSwap]
+	errorHandler *ErrorHandler
+}
+
+// NewShiftSwapRepository creates a new shift swap repository
+func NewShiftSwapRepository(db *gorm.DB) repository.ShiftSwapRepository {
+	return &shiftSwapRepository{
+		BaseRepository: NewBaseRepository[domain.ShiftSwap](db, "shift_swaps"),
+		errorHandler:   NewErrorHandler("shift_swaps"),
+	}
+}
+
+// GetByID retrieves a shift swap by ID
+func (r *shiftSwapRepository) GetByID(id int) (*domain.ShiftSwap, error) {
+	swap, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	return swap, nil
+}
+
+// Create stores a new offer
+func (r *shiftSwapRepository) Create(swap *domain.ShiftSwap) error {
+	if swap.StoreID <= 0 || swap.OfferedShiftID <= 0 || swap.OfferedBy <= 0 || swap.OfferedDate == "" {
+		return r.errorHandler.HandleError("Create", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(swap); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// GetActiveByAssignedShift retrieves the open or accepted swap involving an assigned shift
+func (r *shiftSwapRepository) GetActiveByAssignedShift(assignedShiftID int) (*domain.ShiftSwap, error) {
+	var swap domain.ShiftSwap
+
+	err := r.GetDB().
+		Where(shiftSwapInvolvesCondition, assignedShiftID, assignedShiftID).
+		Where("status IN ?", []string{domain.ShiftSwapOpen, domain.ShiftSwapAccepted}).
+		First(&swap).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // Not offered (business logic)
+	}
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetActiveByAssignedShift", err, assignedShiftID)
+	}
+	return &swap, nil
+}
+
+// GetOpenByStores retrieves the open offers of the stores for shifts on or after fromDate
+func (r *shiftSwapRepository) GetOpenByStores(storeIDs []int, fromDate string) ([]domain.ShiftSwap, error) {
+	var swaps []domain.ShiftSwap
+	if len(storeIDs) == 0 {
+		return swaps, nil
+	}
+
+	err := r.GetDB().
+		Where("store_id IN ? AND status = ? AND offered_date >= ?", storeIDs, domain.ShiftSwapOpen, fromDate).
+		Order("offered_date, offered_start_time").
+		Find(&swaps).Error
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetOpenByStores", err)
+	}
+	return swaps, nil
+}
+
+// GetByEmployee retrieves the swaps offered or accepted by an employee for shifts on or after fromDate
+func (r *shiftSwapRepository) GetByEmployee(employeeID int, fromDate string) ([]domain.ShiftSwap, error) {
+	var swaps []domain.ShiftSwap
+
+	err := r.GetDB().
+		Where("(offered_by = ? OR accepted_by = ?) AND offered_date >= ?", employeeID, employeeID, fromDate).
+		Order("offered_date, offered_start_time").
+		Find(&swaps).Error
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByEmployee", err, employeeID)
+	}
+	return swaps, nil
+}
+
+// Accept records the colleague who takes an open offer; it fails when the offer was already taken
+func (r *shiftSwapRepository) Accept(swap *domain.ShiftSwap) error {
+	result := r.GetDB().
+		Model(&domain.ShiftSwap{}).
+		Where("id = ? AND status = ?", swap.ID, domain.ShiftSwapOpen).
+		Updates(map[string]interface{}{
+			"status":            swap.Status,
+			"accepted_by":       swap.AcceptedBy,
+			"accepted_at":       swap.AcceptedAt,
+			"return_shift_id":   swap.ReturnShiftID,
+			"return_date":       swap.ReturnDate,
+			"return_start_time": swap.ReturnStartTime,
+			"return_end_time":   swap.ReturnEndTime,
+			"return_hours":      swap.ReturnHours,
+		})
+
+	if result.Error != nil {
+		return r.errorHandler.HandleError("Accept", result.Error, swap.ID)
+	}
+	if result.RowsAffected == 0 {
+		return r.errorHandler.HandleNotFound("Accept", swap.ID)
+	}
+	return nil
+}
+
+// Cancel withdraws an open or accepted swap
+func (r *shiftSwapRepository) Cancel(swap *domain.ShiftSwap) error {
+	result := r.GetDB().
+		Model(&domain.ShiftSwap{}).
+		Where("id = ? AND status IN ?", swap.ID, []string{domain.ShiftSwapOpen, domain.ShiftSwapAccepted}).
+		Update("status", swap.Status)
+
+	if result.Error != nil {
+		return r.errorHandler.HandleError("Cancel", result.Error, swap.ID)
+	}
+	if result.RowsAffected == 0 {
+		return r.errorHandler.HandleNotFound("Cancel", swap.ID)
+	}
+	return nil
+}
+
+// Review stores the manager's decision on an accepted swap and, when approved, moves the
+// reassigned shifts to their new employees in the same transaction
+func (r *shiftSwapRepository) Review(swap *domain.ShiftSwap, reassigned []domain.AssignedShift) error {
+	err := r.BaseRepository.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.ShiftSwap{}).
+			Where("id = ? AND status = ?", swap.ID, domain.ShiftSwapAccepted).
+			Updates(map[string]interface{}{
+				"status":      swap.Status,
+				"reviewed_by": swap.ReviewedBy,
+				"reviewed_at": swap.ReviewedAt,
+				"review_note": swap.ReviewNote,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		for _, shift := range reassigned {
+			err := tx.Model(&domain.AssignedShift{}).
+				Where("id = ?", shift.ID).
+				Updates(map[string]interface{}{
+					"employee_id":           shift.EmployeeID,
+					"availability_override": nil, // an override justified the previous employee only
+					"updated_at":            time.Now(),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if errors.Is(err, ErrNotFound) {
+		return r.errorHandler.HandleNotFound("Review", swap.ID)
+	}
+	if err != nil {
+		return r.errorHandler.HandleError("Review", err, swap.ID)
+	}
+	return nil
+}
+
+// GetPending retrieves the accepted swaps awaiting approval in scope, oldest first
+func (r *shiftSwapRepository) GetPending(scope domain.TenantScope) ([]domain.ShiftSwap, error) {
+	var swaps []domain.ShiftSwap
+	query := r.GetDB().
+		Joins("JOIN stores ON stores.id = shift_swaps.store_id").
+		Where("stores.franchise_id = ?", scope.FranchiseID).
+		Where("shift_swaps.status = ?", domain.ShiftSwapAccepted)
+	if scope.RestrictedToStore() {
+		query = query.Where("shift_swaps.store_id = ?", scope.StoreID)
+	}
+
+	if err := query.Order("shift_swaps.accepted_at").Find(&swaps).Error; err != nil {
+		return nil, r.errorHandler.HandleError("GetPending", err)
+	}
+	return swaps, nil
+}
+
+// GetByStoreAndDateRange retrieves the swaps of a store with a shift between two dates (inclusive), whatever their status
+func (r *shiftSwapRepository) GetByStoreAndDateRange(storeID int, from, to string) ([]domain.ShiftSwap, error) {
+	var swaps []domain.ShiftSwap
+
+	err := r.GetDB().
+		Where("store_id = ?", storeID).
+		Where("(offered_date BETWEEN ? AND ?) OR (return_date BETWEEN ? AND ?)", from, to, from, to).
+		Order("offered_date, offered_start_time").
+		Find(&swaps).Error
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByStoreAndDateRange", err, storeID)
+	}
+	return swaps, nil
+}
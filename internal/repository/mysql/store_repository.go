@@ -51,6 +51,23 @@ func (r *storeRepository) GetByFranchiseID(franchiseID int) ([]domain.Store, err
 	return stores, nil
 }
 
+// GetByUserID retrieves the stores an employee belongs to
+func (r *storeRepository) GetByUserID(userID int) ([]domain.Store, error) {
+	stores, err := FindWithJoin[domain.Store](
+		r.GetDB(),
+		"store_users",
+		"store_users.store_id = stores.id",
+		map[string]interface{}{
+			"store_users.user_id": userID,
+		},
+	)
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByUserID", err, userID)
+	}
+	return stores, nil
+}
+
 // Create creates a new store with validation and error handling
 func (r *storeRepository) Create(store *domain.Store) error {
 	// Business validation before creation
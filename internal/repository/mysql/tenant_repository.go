@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// employeeInFranchiseCondition matches users with a role in the franchise or assigned to one of its stores.
+// Both placeholders take the franchise ID.
+const employeeInFranchiseCondition = `(EXISTS (SELECT 1 FROM user_roles ur WHERE ur.user_id = users.id AND ur.franchise_id = ?)
+	OR EXISTS (SELECT 1 FROM store_users su JOIN stores s ON s.id = su.store_id WHERE su.user_id = users.id AND s.franchise_id = ?))`
+
+// employeeInStoreCondition matches users assigned to the store
+const employeeInStoreCondition = `EXISTS (SELECT 1 FROM store_users su WHERE su.user_id = users.id AND su.store_id = ?)`
+
+// tenantRepository implements repository.TenantRepository
+type tenantRepository struct {
+	db           *gorm.DB
+	errorHandler *ErrorHandler
+}
+
+// NewTenantRepository creates a new tenant ownership repository
+func NewTenantRepository(db *gorm.DB) repository.TenantRepository {
+	return &tenantRepository{
+		db:           db,
+		errorHandler: NewErrorHandler("tenants"),
+	}
+}
+
+// StoreInScope reports whether the store belongs to the franchise and, if restricted, is the scope's store
+func (r *tenantRepository) StoreInScope(scope domain.TenantScope, storeID int) (bool, error) {
+	var count int64
+	query := r.db.Table("stores").
+		Where("id = ? AND franchise_id = ?", storeID, scope.FranchiseID)
+	if scope.RestrictedToStore() {
+		query = query.Where("id = ?", scope.StoreID)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return false, r.errorHandler.HandleError("StoreInScope", err, storeID)
+	}
+	return count > 0, nil
+}
+
+// EmployeeInScope reports whether the user belongs to the franchise and, if restricted, works in the scope's store
+func (r *tenantRepository) EmployeeInScope(scope domain.TenantScope, employeeID int) (bool, error) {
+	var count int64
+	query := r.db.Table("users").
+		Where("users.id = ?", employeeID).
+		Where(employeeInFranchiseCondition, scope.FranchiseID, scope.FranchiseID)
+	if scope.RestrictedToStore() {
+		query = query.Where(employeeInStoreCondition, scope.StoreID)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return false, r.errorHandler.HandleError("EmployeeInScope", err, employeeID)
+	}
+	return count > 0, nil
+}
+
+// ShiftInScope reports whether the shift template belongs to a store of the scope
+func (r *tenantRepository) ShiftInScope(scope domain.TenantScope, shiftID int) (bool, error) {
+	var count int64
+	query := r.db.Table("shifts").
+		Joins("JOIN stores s ON s.id = shifts.store_id").
+		Where("shifts.id = ? AND s.franchise_id = ?", shiftID, scope.FranchiseID)
+	if scope.RestrictedToStore() {
+		query = query.Where("shifts.store_id = ?", scope.StoreID)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return false, r.errorHandler.HandleError("ShiftInScope", err, shiftID)
+	}
+	return count > 0, nil
+}
+
+// AssignedShiftInScope reports whether the assignment belongs to a store of the scope
+func (r *tenantRepository) AssignedShiftInScope(scope domain.TenantScope, assignedShiftID int) (bool, error) {
+	var count int64
+	query := r.db.Table("assigned_shifts").
+		Joins("JOIN stores s ON s.id = assigned_shifts.store_id").
+		Where("assigned_shifts.id = ? AND s.franchise_id = ?", assignedShiftID, scope.FranchiseID)
+	if scope.RestrictedToStore() {
+		query = query.Where("assigned_shifts.store_id = ?", scope.StoreID)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return false, r.errorHandler.HandleError("AssignedShiftInScope", err, assignedShiftID)
+	}
+	return count > 0, nil
+}
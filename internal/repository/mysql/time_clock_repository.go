@@ -0,0 +1,146 @@
+package mysql
+
+import (
+	"errors"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// timeClockRepository implements repository.TimeClockRepository
+type timeClockRepository struct {
+	*BaseRepository[domain.TimeClockEntry]
+	errorHandler *ErrorHandler
+}
+
+// NewTimeClockRepository creates a new time clock repository
+func NewTimeClockRepository(db *gorm.DB) repository.TimeClockRepository {
+	return &timeClockRepository{
+		BaseRepository: NewBaseRepository[domain.TimeClockEntry](db, "time_clock_entries"),
+		errorHandler:   NewErrorHandler("time_clock_entries"),
+	}
+}
+
+// GetByID retrieves a time clock entry by ID
+func (r *timeClockRepository) GetByID(id int) (*domain.TimeClockEntry, error) {
+	entry, err := r.BaseRepository.GetByID(id)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, r.errorHandler.HandleNotFound("GetByID", id)
+		}
+		return nil, r.errorHandler.HandleError("GetByID", err, id)
+	}
+	return entry, nil
+}
+
+// Create stores a new entry
+func (r *timeClockRepository) Create(entry *domain.TimeClockEntry) error {
+	if entry.EmployeeID <= 0 || entry.StoreID <= 0 || entry.ClockIn.IsZero() {
+		return r.errorHandler.HandleError("Create", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(entry); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// Update modifies an existing entry
+func (r *timeClockRepository) Update(entry *domain.TimeClockEntry) error {
+	if entry.ID == 0 {
+		return r.errorHandler.HandleError("Update", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Update(entry); err != nil {
+		return r.errorHandler.HandleError("Update", err, entry.ID)
+	}
+	return nil
+}
+
+// GetOpenByEmployee retrieves the latest open entry of an employee
+func (r *timeClockRepository) GetOpenByEmployee(employeeID int) (*domain.TimeClockEntry, error) {
+	var entry domain.TimeClockEntry
+
+	err := r.GetDB().
+		Where("employee_id = ? AND status = ?", employeeID, domain.TimeClockOpen).
+		Order("clock_in DESC").
+		First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil // Not clocked in (business logic)
+	}
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetOpenByEmployee", err, employeeID)
+	}
+	return &entry, nil
+}
+
+// Close records the clock-out of an open entry; it fails when the entry was already closed
+func (r *timeClockRepository) Close(entry *domain.TimeClockEntry) error {
+	result := r.GetDB().
+		Model(&domain.TimeClockEntry{}).
+		Where("id = ? AND status = ?", entry.ID, domain.TimeClockOpen).
+		Updates(map[string]interface{}{
+			"clock_out": entry.ClockOut,
+			"status":    entry.Status,
+		})
+
+	if result.Error != nil {
+		return r.errorHandler.HandleError("Close", result.Error, entry.ID)
+	}
+	if result.RowsAffected == 0 {
+		return r.errorHandler.HandleNotFound("Close", entry.ID)
+	}
+	return nil
+}
+
+// GetByEmployeeAndDateRange retrieves the entries of an employee clocked in between from and to (exclusive)
+func (r *timeClockRepository) GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.TimeClockEntry, error) {
+	var entries []domain.TimeClockEntry
+
+	err := r.GetDB().
+		Where("employee_id = ? AND clock_in >= ? AND clock_in < ?", employeeID, from, to).
+		Order("clock_in").
+		Find(&entries).Error
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByEmployeeAndDateRange", err, employeeID)
+	}
+	return entries, nil
+}
+
+// GetMissed retrieves the entries in scope without a clock-out: marked as missed or still
+// open since before openBefore
+func (r *timeClockRepository) GetMissed(scope domain.TenantScope, openBefore time.Time) ([]domain.TimeClockEntry, error) {
+	var entries []domain.TimeClockEntry
+	query := r.GetDB().
+		Joins("JOIN stores ON stores.id = time_clock_entries.store_id").
+		Where("stores.franchise_id = ?", scope.FranchiseID).
+		Where("time_clock_entries.status = ? OR (time_clock_entries.status = ? AND time_clock_entries.clock_in < ?)",
+			domain.TimeClockMissedOut, domain.TimeClockOpen, openBefore)
+	if scope.RestrictedToStore() {
+		query = query.Where("time_clock_entries.store_id = ?", scope.StoreID)
+	}
+
+	if err := query.Order("time_clock_entries.clock_in").Find(&entries).Error; err != nil {
+		return nil, r.errorHandler.HandleError("GetMissed", err)
+	}
+	return entries, nil
+}
+
+// GetByAssignedShifts retrieves the entries linked to the given assigned shifts
+func (r *timeClockRepository) GetByAssignedShifts(assignedShiftIDs []int) ([]domain.TimeClockEntry, error) {
+	var entries []domain.TimeClockEntry
+	if len(assignedShiftIDs) == 0 {
+		return entries, nil
+	}
+
+	err := r.GetDB().
+		Where("assigned_shift_id IN ?", assignedShiftIDs).
+		Order("clock_in").
+		Find(&entries).Error
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByAssignedShifts", err)
+	}
+	return entries, nil
+}
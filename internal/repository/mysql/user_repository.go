@@ -49,6 +49,9 @@ func (r *userRepository) FindByID(userID int) (*domain.User, error) {
 	err := r.GetDB().
 		Preload("UserRoles.Role").
 		Preload("UserRoles.Franchise").
+		Preload("AvailabilityWindows", func(db *gorm.DB) *gorm.DB {
+			return db.Order("weekday, start_time")
+		}).
 		First(&user, userID).Error
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -77,6 +80,20 @@ func (r *userRepository) GetAll() ([]domain.User, error) {
 	return users, nil
 }
 
+// GetByFranchise retrieves the users with a role in the franchise or assigned to one of its stores
+func (r *userRepository) GetByFranchise(franchiseID int) ([]domain.User, error) {
+	var users []domain.User
+
+	err := r.GetDB().
+		Where(employeeInFranchiseCondition, franchiseID, franchiseID).
+		Find(&users).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByFranchise", err, franchiseID)
+	}
+	return users, nil
+}
+
 // GetNameByID retrieves formatted full name for a user
 func (r *userRepository) GetNameByID(userID int) (string, error) {
 	var user struct {
@@ -149,8 +166,9 @@ func (r *userRepository) Create(user domain.User, roleID, franchiseID int) error
 	})
 }
 
-// CreateWithStore creates a new user and associates it with a store
-func (r *userRepository) CreateWithStore(user domain.User, storeID int) error {
+// CreateWithStore creates a new user, associates it with a store and grants the role in
+// the store's franchise
+func (r *userRepository) CreateWithStore(user domain.User, storeID, roleID, franchiseID int) error {
 	// Business validation before creation
 	if err := r.validateUser(&user); err != nil {
 		return r.errorHandler.HandleError("CreateWithStore", err)
@@ -168,8 +186,18 @@ func (r *userRepository) CreateWithStore(user domain.User, storeID int) error {
 			StoreID: uint(storeID),
 			UserID:  user.ID,
 		}
+		if err := tx.Create(&storeUser).Error; err != nil {
+			return err
+		}
+
+		// Create user role relationship
+		userRole := domain.UserRole{
+			UserID:      int(user.ID),
+			RoleID:      roleID,
+			FranchiseID: franchiseID,
+		}
 
-		return tx.Create(&storeUser).Error
+		return tx.Create(&userRole).Error
 	})
 }
 
@@ -0,0 +1,48 @@
+package mysql
+
+import (
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+// vacationAdjustmentRepository implements repository.VacationAdjustmentRepository
+type vacationAdjustmentRepository struct {
+	*BaseRepository[domain.VacationAdjustment]
+	errorHandler *ErrorHandler
+}
+
+// NewVacationAdjustmentRepository creates a new vacation adjustment repository
+func NewVacationAdjustmentRepository(db *gorm.DB) repository.VacationAdjustmentRepository {
+	return &vacationAdjustmentRepository{
+		BaseRepository: NewBaseRepository[domain.VacationAdjustment](db, "vacation_adjustments"),
+		errorHandler:   NewErrorHandler("vacation_adjustments"),
+	}
+}
+
+// Create stores a new manual adjustment
+func (r *vacationAdjustmentRepository) Create(adjustment *domain.VacationAdjustment) error {
+	if adjustment.EmployeeID <= 0 || adjustment.Days == 0 {
+		return r.errorHandler.HandleError("Create", ErrInvalidInput)
+	}
+
+	if err := r.BaseRepository.Create(adjustment); err != nil {
+		return r.errorHandler.HandleError("Create", err)
+	}
+	return nil
+}
+
+// GetByEmployee retrieves the adjustments of an employee ordered by date
+func (r *vacationAdjustmentRepository) GetByEmployee(employeeID int) ([]domain.VacationAdjustment, error) {
+	var adjustments []domain.VacationAdjustment
+
+	err := r.GetDB().
+		Where("employee_id = ?", employeeID).
+		Order("date, id").
+		Find(&adjustments).Error
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetByEmployee", err, employeeID)
+	}
+	return adjustments, nil
+}
@@ -3,6 +3,7 @@ package mysql
 import (
 	"loopi-api/internal/domain"
 	"loopi-api/internal/repository"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -21,15 +22,15 @@ func NewWorkConfigRepository(db *gorm.DB) repository.WorkConfigRepository {
 	}
 }
 
-// GetActiveConfig retrieves the active work configuration with fallback to defaults
+// GetActiveConfig retrieves the work configuration in force today with fallback to defaults
 func (r *workConfigRepository) GetActiveConfig() domain.WorkConfig {
 	var config domain.WorkConfig
 
 	err := NewQueryBuilder(r.GetDB()).
 		WhereActive().
-		OrderBy("id", "DESC").
-		Limit(1).
 		GetDB().
+		Where("effective_from <= ?", time.Now().Format("2006-01-02")).
+		Order("effective_from DESC").
 		First(&config).Error
 
 	if err != nil {
@@ -43,29 +44,56 @@ func (r *workConfigRepository) GetActiveConfig() domain.WorkConfig {
 	return config
 }
 
+// GetConfigsForPeriod retrieves every active configuration that can be in force between
+// from and to: the one in force on from plus any that start later within the period
+func (r *workConfigRepository) GetConfigsForPeriod(from, to time.Time) ([]domain.WorkConfig, error) {
+	var configs []domain.WorkConfig
+
+	err := NewQueryBuilder(r.GetDB()).
+		WhereActive().
+		GetDB().
+		Where("effective_from <= ?", to.Format("2006-01-02")).
+		Order("effective_from").
+		Find(&configs).Error
+
+	if err != nil {
+		return nil, r.errorHandler.HandleError("GetConfigsForPeriod", err)
+	}
+
+	// Keep only the last configuration starting on or before from
+	first := 0
+	for i, config := range configs {
+		if !config.EffectiveFrom.After(from) {
+			first = i
+		}
+	}
+	configs = configs[first:]
+
+	if len(configs) == 0 {
+		return []domain.WorkConfig{r.getDefaultConfig()}, nil
+	}
+	return configs, nil
+}
+
 // getDefaultConfig returns the default work configuration
 func (r *workConfigRepository) getDefaultConfig() domain.WorkConfig {
 	return domain.WorkConfig{
-		DiurnalStart: "06:00",
-		DiurnalEnd:   "21:00",
-		IsActive:     true,
+		DiurnalStart:        "06:00",
+		DiurnalEnd:          "21:00",
+		DailyOrdinaryHours:  7.33,
+		WeeklyOrdinaryHours: 44,
+		IsActive:            true,
 	}
 }
 
-// Create creates a new work configuration with validation
+// Create creates a new work configuration with validation.
+// Configurations are effective-dated, so older ones stay active for past dates.
 func (r *workConfigRepository) Create(config *domain.WorkConfig) error {
 	// Business validation before creation
 	if err := r.validateWorkConfig(config); err != nil {
 		return r.errorHandler.HandleError("Create", err)
 	}
 
-	// If this config is active, deactivate all others first
-	if config.IsActive {
-		if err := r.deactivateAllConfigs(); err != nil {
-			return r.errorHandler.HandleError("Create", err)
-		}
-	}
-
 	if err := r.BaseRepository.Create(config); err != nil {
 		return r.errorHandler.HandleError("Create", err)
 	}
@@ -79,13 +107,6 @@ func (r *workConfigRepository) Update(config *domain.WorkConfig) error {
 		return r.errorHandler.HandleError("Update", err, config.ID)
 	}
 
-	// If this config is being activated, deactivate all others first
-	if config.IsActive {
-		if err := r.deactivateOtherConfigs(int(config.ID)); err != nil {
-			return r.errorHandler.HandleError("Update", err, config.ID)
-		}
-	}
-
 	if err := r.BaseRepository.Update(config); err != nil {
 		return r.errorHandler.HandleError("Update", err, config.ID)
 	}
@@ -100,29 +121,24 @@ func (r *workConfigRepository) validateWorkConfig(config *domain.WorkConfig) err
 	if config.DiurnalEnd == "" {
 		return ErrInvalidInput
 	}
-	// Could add time format validation here
+	if config.EffectiveFrom.IsZero() {
+		return ErrInvalidInput
+	}
+	if config.DailyOrdinaryHours <= 0 || config.WeeklyOrdinaryHours <= 0 {
+		return ErrInvalidInput
+	}
 	return nil
 }
 
-// deactivateAllConfigs sets all configurations to inactive
-func (r *workConfigRepository) deactivateAllConfigs() error {
-	return r.GetDB().
-		Model(&domain.WorkConfig{}).
-		Where("is_active = ?", true).
-		Update("is_active", false).Error
-}
+// GetAllConfigs retrieves all work configurations ordered by effective date
+func (r *workConfigRepository) GetAllConfigs() ([]domain.WorkConfig, error) {
+	var configs []domain.WorkConfig
 
-// deactivateOtherConfigs sets all other configurations to inactive except the specified ID
-func (r *workConfigRepository) deactivateOtherConfigs(exceptID int) error {
-	return r.GetDB().
-		Model(&domain.WorkConfig{}).
-		Where("is_active = ? AND id != ?", true, exceptID).
-		Update("is_active", false).Error
-}
+	err := NewQueryBuilder(r.GetDB()).
+		OrderBy("effective_from").
+		GetDB().
+		Find(&configs).Error
 
-// GetAllConfigs retrieves all work configurations
-func (r *workConfigRepository) GetAllConfigs() ([]domain.WorkConfig, error) {
-	configs, err := r.BaseRepository.GetAll()
 	if err != nil {
 		return nil, r.errorHandler.HandleError("GetAllConfigs", err)
 	}
@@ -140,30 +156,3 @@ func (r *workConfigRepository) GetConfigByID(id int) (*domain.WorkConfig, error)
 	}
 	return config, nil
 }
-
-// ActivateConfig activates a specific configuration and deactivates all others
-func (r *workConfigRepository) ActivateConfig(id int) error {
-	// Check if config exists
-	exists, err := r.BaseRepository.Exists(id)
-	if err != nil {
-		return r.errorHandler.HandleError("ActivateConfig", err, id)
-	}
-	if !exists {
-		return r.errorHandler.HandleNotFound("ActivateConfig", id)
-	}
-
-	// Use transaction to ensure atomicity
-	return r.BaseRepository.Transaction(func(tx *gorm.DB) error {
-		// Deactivate all configs
-		if err := tx.Model(&domain.WorkConfig{}).
-			Where("is_active = ?", true).
-			Update("is_active", false).Error; err != nil {
-			return err
-		}
-
-		// Activate the specified config
-		return tx.Model(&domain.WorkConfig{}).
-			Where("id = ?", id).
-			Update("is_active", true).Error
-	})
-}
@@ -21,4 +21,5 @@ type NoveltyRepository interface {
 	GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.Novelty, error)
 	GetTotalHoursByEmployeeAndType(employeeID, year, month int, noveltyType string) (float64, error)
 	GetNoveltyTypesSummary(employeeID, year, month int) ([]NoveltyTypeSummary, error)
+	GetByAssignedShifts(assignedShiftIDs []int) ([]domain.Novelty, error) // generated from attendance exceptions
 }
@@ -0,0 +1,13 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+type PasswordResetRepository interface {
+	// Standard operations
+	Create(token *domain.PasswordResetToken) error
+
+	// Business-specific operations
+	GetByTokenHash(tokenHash string) (*domain.PasswordResetToken, error)
+	MarkUsed(id int) error
+	InvalidateByUser(userID int) error // marks every unused token of the user as used
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"loopi-api/internal/domain"
+	"time"
+)
+
+type PayrollConfigRepository interface {
+	GetEffectiveConfig(date time.Time) domain.PayrollConfig
+	GetAllConfigs() ([]domain.PayrollConfig, error)
+	Create(config *domain.PayrollConfig) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+type PermissionRepository interface {
+	// Standard CRUD operations
+	GetAll() ([]domain.Permission, error)
+	GetByID(id int) (*domain.Permission, error)
+	Create(permission *domain.Permission) error
+	Update(permission *domain.Permission) error
+	Delete(id int) error
+
+	// Business-specific operations
+	GetByName(name string) (*domain.Permission, error)
+	GetByIDs(ids []int) ([]domain.Permission, error)
+}
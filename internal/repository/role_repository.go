@@ -0,0 +1,24 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+type RoleRepository interface {
+	// Standard CRUD operations
+	GetAll() ([]domain.Role, error)
+	GetByID(id int) (*domain.Role, error)
+	Create(role *domain.Role) error
+	Update(role *domain.Role) error
+	Delete(id int) error
+
+	// Business-specific operations
+	GetByName(name string) (*domain.Role, error)
+	GetPermissions(roleID int) ([]domain.Permission, error)
+	SetPermissions(roleID int, permissionIDs []int) error
+	// AssignToUser grants the role to a user in a franchise; granting it again is a no-op
+	AssignToUser(userID, roleID, franchiseID int) error
+	// RevokeFromUser removes the role of a user in a franchise
+	RevokeFromUser(userID, roleID, franchiseID int) error
+	// GetUserPermissionNames returns the permissions of the user's active roles in a
+	// franchise; franchiseID 0 means the roles of every franchise
+	GetUserPermissionNames(userID, franchiseID int) ([]string, error)
+}
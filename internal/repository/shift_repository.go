@@ -15,6 +15,7 @@ type ShiftRepository interface {
 	Create(cfg domain.Shift) error
 	ListAll() ([]domain.Shift, error)
 	ListByStore(storeID int) ([]domain.Shift, error)
+	ListByFranchise(franchiseID int) ([]domain.Shift, error)
 	GetByID(id int) (*domain.Shift, error)
 	Update(shift domain.Shift) error
 	Delete(id int) error
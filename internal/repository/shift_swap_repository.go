@@ -0,0 +1,21 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+type ShiftSwapRepository interface {
+	// Basic operations
+	GetByID(id int) (*domain.ShiftSwap, error)
+	Create(swap *domain.ShiftSwap) error
+
+	// Swap workflow
+	GetActiveByAssignedShift(assignedShiftID int) (*domain.ShiftSwap, error) // nil when the shift is in no open or accepted swap
+	GetOpenByStores(storeIDs []int, fromDate string) ([]domain.ShiftSwap, error)
+	GetByEmployee(employeeID int, fromDate string) ([]domain.ShiftSwap, error) // offered or accepted by the employee
+	Accept(swap *domain.ShiftSwap) error                                       // fails when the swap is no longer open
+	Cancel(swap *domain.ShiftSwap) error                                       // fails when the swap is no longer active
+	Review(swap *domain.ShiftSwap, reassigned []domain.AssignedShift) error    // fails when the swap is no longer accepted
+
+	// Approval and audit
+	GetPending(scope domain.TenantScope) ([]domain.ShiftSwap, error)
+	GetByStoreAndDateRange(storeID int, from, to string) ([]domain.ShiftSwap, error)
+}
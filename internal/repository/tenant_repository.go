@@ -0,0 +1,14 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+// TenantRepository checks that records belong to a tenant scope (a franchise and, when
+// restricted, one of its stores) so that use cases can answer other IDs as not found
+type TenantRepository interface {
+	StoreInScope(scope domain.TenantScope, storeID int) (bool, error)
+	// EmployeeInScope is true when the user has a role in the franchise or works in one of its stores.
+	// A store-restricted scope only matches employees of that store.
+	EmployeeInScope(scope domain.TenantScope, employeeID int) (bool, error)
+	ShiftInScope(scope domain.TenantScope, shiftID int) (bool, error)
+	AssignedShiftInScope(scope domain.TenantScope, assignedShiftID int) (bool, error)
+}
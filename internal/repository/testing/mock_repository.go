@@ -11,6 +11,7 @@ import (
 // MockStoreRepository implements repository.StoreRepository for testing
 type MockStoreRepository struct {
 	stores     map[uint]domain.Store
+	storeUsers map[int][]uint // user ID → store IDs
 	nextID     uint
 	shouldFail bool
 }
@@ -18,8 +19,9 @@ type MockStoreRepository struct {
 // NewMockStoreRepository creates a new mock store repository
 func NewMockStoreRepository() *MockStoreRepository {
 	return &MockStoreRepository{
-		stores: make(map[uint]domain.Store),
-		nextID: 1,
+		stores:     make(map[uint]domain.Store),
+		storeUsers: make(map[int][]uint),
+		nextID:     1,
 	}
 }
 
@@ -39,6 +41,11 @@ func (m *MockStoreRepository) SeedData(stores []domain.Store) {
 	}
 }
 
+// AssignUser links a user to a store
+func (m *MockStoreRepository) AssignUser(userID int, storeID uint) {
+	m.storeUsers[userID] = append(m.storeUsers[userID], storeID)
+}
+
 // GetAll returns all stores
 func (m *MockStoreRepository) GetAll() ([]domain.Store, error) {
 	if m.shouldFail {
@@ -80,6 +87,21 @@ func (m *MockStoreRepository) GetByFranchiseID(franchiseID int) ([]domain.Store,
 	return stores, nil
 }
 
+// GetByUserID returns the stores a user is linked to
+func (m *MockStoreRepository) GetByUserID(userID int) ([]domain.Store, error) {
+	if m.shouldFail {
+		return nil, errors.New("mock error: GetByUserID failed")
+	}
+
+	var stores []domain.Store
+	for _, storeID := range m.storeUsers[userID] {
+		if store, exists := m.stores[storeID]; exists {
+			stores = append(stores, store)
+		}
+	}
+	return stores, nil
+}
+
 // Create creates a new store
 func (m *MockStoreRepository) Create(store *domain.Store) error {
 	if m.shouldFail {
@@ -240,9 +262,9 @@ func (suite *RepositoryTestSuite) SeedTestData() {
 
 	if mockAbsenceRepo, ok := suite.AbsenceRepo.(*MockAbsenceRepository); ok {
 		testAbsences := []domain.Absence{
-			{EmployeeID: 1, Date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), Hours: 8.0, Reason: "Sick leave"},
-			{EmployeeID: 1, Date: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), Hours: 4.0, Reason: "Medical appointment"},
-			{EmployeeID: 2, Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Hours: 8.0, Reason: "Personal matters"},
+			{EmployeeID: 1, AbsenceTypeID: 1, Status: domain.AbsenceStatusApproved, Date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), Hours: 8.0, Reason: "Sick leave"},
+			{EmployeeID: 1, AbsenceTypeID: 1, Status: domain.AbsenceStatusApproved, Date: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC), Hours: 4.0, Reason: "Medical appointment"},
+			{EmployeeID: 2, AbsenceTypeID: 1, Status: domain.AbsenceStatusApproved, Date: time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC), Hours: 8.0, Reason: "Personal matters"},
 		}
 		mockAbsenceRepo.SeedAbsenceData(testAbsences)
 	}
@@ -466,6 +488,11 @@ func (m *MockShiftRepository) Update(shift domain.Shift) error {
 	panic("unimplemented")
 }
 
+// ListByFranchise implements repository.ShiftRepository.
+func (m *MockShiftRepository) ListByFranchise(franchiseID int) ([]domain.Shift, error) {
+	panic("unimplemented")
+}
+
 // NewMockShiftRepository creates a new mock shift repository
 func NewMockShiftRepository() *MockShiftRepository {
 	return &MockShiftRepository{
@@ -661,24 +688,30 @@ func (m *MockAbsenceRepository) Create(absence *domain.Absence) error {
 	return nil
 }
 
-// GetByEmployeeAndMonth returns absences by employee and month
+// GetByID returns an absence by ID
+func (m *MockAbsenceRepository) GetByID(id int) (*domain.Absence, error) {
+	if m.shouldFail {
+		return nil, errors.New("mock error: GetByID failed")
+	}
+
+	absence, ok := m.absences[uint(id)]
+	if !ok {
+		return nil, mysql.ErrNotFound
+	}
+	return &absence, nil
+}
+
+// GetByEmployeeAndMonth returns absences by employee with a day in the month
 func (m *MockAbsenceRepository) GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.Absence, error) {
 	if m.shouldFail {
 		return nil, errors.New("mock error: GetByEmployeeAndMonth failed")
 	}
 
-	var absences []domain.Absence
-	for _, absence := range m.absences {
-		if absence.EmployeeID == employeeID &&
-			absence.Date.Year() == year &&
-			int(absence.Date.Month()) == month {
-			absences = append(absences, absence)
-		}
-	}
-	return absences, nil
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return m.GetByEmployeeAndDateRange(employeeID, from, from.AddDate(0, 1, -1))
 }
 
-// GetByEmployeeAndDateRange returns absences by employee and date range
+// GetByEmployeeAndDateRange returns absences by employee with a day in the date range
 func (m *MockAbsenceRepository) GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.Absence, error) {
 	if m.shouldFail {
 		return nil, errors.New("mock error: GetByEmployeeAndDateRange failed")
@@ -686,32 +719,60 @@ func (m *MockAbsenceRepository) GetByEmployeeAndDateRange(employeeID int, from,
 
 	var absences []domain.Absence
 	for _, absence := range m.absences {
-		if absence.EmployeeID == employeeID &&
-			!absence.Date.Before(from) &&
-			!absence.Date.After(to) {
+		if absence.EmployeeID == employeeID && len(absence.Days(from, to)) > 0 {
 			absences = append(absences, absence)
 		}
 	}
 	return absences, nil
 }
 
-// GetTotalHoursByEmployee returns total hours by employee and month
+// GetTotalHoursByEmployee returns the approved hours by employee in the month
 func (m *MockAbsenceRepository) GetTotalHoursByEmployee(employeeID, year, month int) (float64, error) {
 	if m.shouldFail {
 		return 0, errors.New("mock error: GetTotalHoursByEmployee failed")
 	}
 
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
 	var total float64
 	for _, absence := range m.absences {
-		if absence.EmployeeID == employeeID &&
-			absence.Date.Year() == year &&
-			int(absence.Date.Month()) == month {
-			total += absence.Hours
+		if absence.EmployeeID == employeeID && absence.IsApproved() {
+			total += absence.HoursIn(from, to)
 		}
 	}
 	return total, nil
 }
 
+// GetPending returns every pending absence; the mock does not filter by scope
+func (m *MockAbsenceRepository) GetPending(scope domain.TenantScope) ([]domain.Absence, error) {
+	if m.shouldFail {
+		return nil, errors.New("mock error: GetPending failed")
+	}
+
+	var absences []domain.Absence
+	for _, absence := range m.absences {
+		if absence.Status == domain.AbsenceStatusPending {
+			absences = append(absences, absence)
+		}
+	}
+	return absences, nil
+}
+
+// Review stores the decision on a pending absence
+func (m *MockAbsenceRepository) Review(absence *domain.Absence) error {
+	if m.shouldFail {
+		return errors.New("mock error: Review failed")
+	}
+
+	current, ok := m.absences[absence.ID]
+	if !ok || current.Status != domain.AbsenceStatusPending {
+		return mysql.ErrNotFound
+	}
+	m.absences[absence.ID] = *absence
+	return nil
+}
+
 // MockNoveltyRepository implements repository.NoveltyRepository for testing
 type MockNoveltyRepository struct {
 	novelties  map[uint]domain.Novelty
@@ -842,3 +903,24 @@ func (m *MockNoveltyRepository) GetNoveltyTypesSummary(employeeID, year, month i
 
 	return summary, nil
 }
+
+// GetByAssignedShifts returns the novelties generated for the given assigned shifts
+func (m *MockNoveltyRepository) GetByAssignedShifts(assignedShiftIDs []int) ([]domain.Novelty, error) {
+	if m.shouldFail {
+		return nil, errors.New("mock error: GetByAssignedShifts failed")
+	}
+
+	var novelties []domain.Novelty
+	for _, novelty := range m.novelties {
+		if novelty.AssignedShiftID == nil {
+			continue
+		}
+		for _, id := range assignedShiftIDs {
+			if *novelty.AssignedShiftID == id {
+				novelties = append(novelties, novelty)
+				break
+			}
+		}
+	}
+	return novelties, nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"loopi-api/internal/domain"
+	"time"
+)
+
+type TimeClockRepository interface {
+	// Basic operations
+	GetByID(id int) (*domain.TimeClockEntry, error)
+	Create(entry *domain.TimeClockEntry) error
+	Update(entry *domain.TimeClockEntry) error
+
+	// Punches
+	GetOpenByEmployee(employeeID int) (*domain.TimeClockEntry, error) // nil when the employee is not clocked in
+	Close(entry *domain.TimeClockEntry) error                         // fails when the entry is no longer open
+
+	// Business-specific operations
+	GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.TimeClockEntry, error) // by clock-in, to exclusive
+	GetMissed(scope domain.TenantScope, openBefore time.Time) ([]domain.TimeClockEntry, error)
+	GetByAssignedShifts(assignedShiftIDs []int) ([]domain.TimeClockEntry, error)
+}
@@ -7,11 +7,13 @@ import (
 type UserRepository interface {
 	GetNameByID(userID int) (string, error)
 	GetAll() ([]domain.User, error)
+	GetByFranchise(franchiseID int) ([]domain.User, error)
 	GetByStore(storeID int) ([]domain.User, error)
 	FindByEmail(email string) (*domain.User, error)
 	FindByID(userID int) (*domain.User, error)
 	Create(user domain.User, roleID, franchiseID int) error
-	CreateWithStore(user domain.User, storeID int) error
+	// CreateWithStore creates the user in a store and grants the role in the store's franchise
+	CreateWithStore(user domain.User, storeID, roleID, franchiseID int) error
 	Update(id int, fields map[string]interface{}) error
 	Delete(id int) error
 }
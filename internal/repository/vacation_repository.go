@@ -0,0 +1,11 @@
+package repository
+
+import "loopi-api/internal/domain"
+
+type VacationAdjustmentRepository interface {
+	// Standard operations
+	Create(adjustment *domain.VacationAdjustment) error
+
+	// Business-specific operations
+	GetByEmployee(employeeID int) ([]domain.VacationAdjustment, error) // ordered by date
+}
@@ -1,9 +1,13 @@
 package repository
 
 import (
-  "loopi-api/internal/domain"
+	"loopi-api/internal/domain"
+	"time"
 )
 
 type WorkConfigRepository interface {
-  GetActiveConfig() domain.WorkConfig
+	GetActiveConfig() domain.WorkConfig
+	GetConfigsForPeriod(from, to time.Time) ([]domain.WorkConfig, error)
+	GetAllConfigs() ([]domain.WorkConfig, error)
+	Create(config *domain.WorkConfig) error
 }
@@ -14,6 +14,18 @@ func SetupRoutes(container *container.Container) *chi.Mux {
 	// Global middleware
 	r.Use(middleware.CORS)
 
+	// Access tokens are verified against the published signing keys
+	middleware.UseTokenVerifier(container.Verifier)
+
+	// Access tokens of revoked sessions are rejected
+	middleware.UseSessionValidator(container.UseCases.Auth)
+
+	// Route permissions are resolved from the user's roles in the selected franchise
+	middleware.UsePermissionChecker(container.UseCases.Permissions)
+
+	// Public keys for services that verify access tokens themselves
+	r.Get("/.well-known/jwks.json", container.Handlers.JWKS.GetKeys)
+
 	// Setup route groups
 	setupAuthRoutes(r, container)
 	setupFranchiseRoutes(r, container)
@@ -21,10 +33,20 @@ func SetupRoutes(container *container.Container) *chi.Mux {
 	setupEmployeeRoutes(r, container)
 	setupEmployeeHoursRoutes(r, container)
 	setupCalendarRoutes(r, container)
+	setupCalendarFeedRoutes(r, container)
 	setupShiftRoutes(r, container)
 	setupShiftPlanningRoutes(r, container)
+	setupAssignmentRoutes(r, container)
+	setupWorkConfigRoutes(r, container)
+	setupPayrollRoutes(r, container)
 	setupAbsenceRoutes(r, container)
+	setupVacationRoutes(r, container)
+	setupTimeClockRoutes(r, container)
+	setupShiftSwapRoutes(r, container)
+	setupAvailabilityRoutes(r, container)
 	setupNoveltyRoutes(r, container)
+	setupRoleRoutes(r, container)
+	setupSelfServiceRoutes(r, container)
 
 	return r
 }
@@ -33,10 +55,30 @@ func SetupRoutes(container *container.Container) *chi.Mux {
 func setupAuthRoutes(r *chi.Mux, container *container.Container) {
 	r.Route("/auth", func(r chi.Router) {
 		r.Post("/login", container.Handlers.Auth.Login)
+		r.Post("/refresh", container.Handlers.Auth.Refresh)
+		r.Post("/password/forgot", container.Handlers.Auth.ForgotPassword)
+		r.Post("/password/reset", container.Handlers.Auth.ResetPassword)
 
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.JWTMiddleware)
 			r.Post("/context", container.Handlers.Auth.SelectContext)
+			r.Get("/permissions", container.Handlers.Role.GetMyPermissions)
+		})
+
+		// Reachable while a password change is required
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.JWTAllowPasswordChange)
+			r.Post("/password/change", container.Handlers.Auth.ChangePassword)
+			r.Post("/logout", container.Handlers.Auth.Logout)
+		})
+
+		// Account lockout administration; accounts and IPs are global, so only the platform manages them
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.JWTMiddleware)
+			r.Use(middleware.RequireResourcePermission("lockouts"))
+			r.Use(middleware.RequirePermission("platform:admin"))
+			r.Get("/lockouts", container.Handlers.Auth.GetLockout)
+			r.Delete("/lockouts", container.Handlers.Auth.Unlock)
 		})
 	})
 }
@@ -49,10 +91,15 @@ func setupFranchiseRoutes(r *chi.Mux, container *container.Container) {
 		r.Get("/", container.Handlers.Franchise.GetAll)
 
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireRoles("admin"))
+			r.Use(middleware.RequireResourcePermission("franchises"))
 
 			r.Get("/{id}", container.Handlers.Franchise.GetById)
-			r.Post("/", container.Handlers.Franchise.Create)
+
+			// New franchises are tenants of the platform, so only the platform creates them
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission("platform:admin"))
+				r.Post("/", container.Handlers.Franchise.Create)
+			})
 		})
 	})
 }
@@ -61,13 +108,14 @@ func setupFranchiseRoutes(r *chi.Mux, container *container.Container) {
 func setupStoreRoutes(r *chi.Mux, container *container.Container) {
 	r.Route("/stores", func(r chi.Router) {
 		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireFranchiseAccess())
 
 		// Public franchise-based routes
 		r.Get("/franchise/{franchiseID}", container.Handlers.Store.GetByFranchiseID)
 		r.Get("/franchise/{franchiseID}/active", container.Handlers.Store.GetActiveStoresByFranchise)
 
 		r.Group(func(r chi.Router) {
-			r.Use(middleware.RequireRoles("admin"))
+			r.Use(middleware.RequireResourcePermission("stores"))
 
 			// Standard CRUD routes
 			r.Get("/", container.Handlers.Store.GetAll)
@@ -87,7 +135,7 @@ func setupStoreRoutes(r *chi.Mux, container *container.Container) {
 func setupEmployeeRoutes(r *chi.Mux, container *container.Container) {
 	r.Route("/employees", func(r chi.Router) {
 		r.Use(middleware.JWTMiddleware)
-		r.Use(middleware.RequireRoles("admin"))
+		r.Use(middleware.RequireResourcePermission("employees"))
 		r.Use(middleware.RequireFranchiseAccess())
 
 		// Standard CRUD routes
@@ -108,7 +156,7 @@ func setupEmployeeRoutes(r *chi.Mux, container *container.Container) {
 func setupEmployeeHoursRoutes(r *chi.Mux, container *container.Container) {
 	r.Route("/employee-hours", func(r chi.Router) {
 		r.Use(middleware.JWTMiddleware)
-		r.Use(middleware.RequireRoles("admin"))
+		r.Use(middleware.RequireResourcePermission("employee_hours"))
 		r.Use(middleware.RequireFranchiseAccess())
 
 		// Standard summary routes
@@ -118,6 +166,7 @@ func setupEmployeeHoursRoutes(r *chi.Mux, container *container.Container) {
 
 		// Business calculation routes
 		r.Get("/{id}/working-days", container.Handlers.EmployeeHours.GetWorkingDays)
+		r.Get("/{id}/attendance", container.Handlers.EmployeeHours.GetAttendance)
 
 		// Legacy route for backward compatibility
 		r.Get("/{id}", container.Handlers.EmployeeHours.GetMonthlySummary)
@@ -128,7 +177,7 @@ func setupEmployeeHoursRoutes(r *chi.Mux, container *container.Container) {
 func setupCalendarRoutes(r *chi.Mux, container *container.Container) {
 	r.Route("/calendar", func(r chi.Router) {
 		r.Use(middleware.JWTMiddleware)
-		r.Use(middleware.RequireRoles("admin"))
+		r.Use(middleware.RequireResourcePermission("calendar"))
 
 		// Standard calendar routes
 		r.Get("/holidays", container.Handlers.Calendar.GetHolidays)
@@ -138,8 +187,40 @@ func setupCalendarRoutes(r *chi.Mux, container *container.Container) {
 		// Business calculation routes
 		r.Get("/working-days", container.Handlers.Calendar.GetWorkingDays)
 
-		// Utility routes
-		r.Post("/clear-cache", container.Handlers.Calendar.ClearCache)
+		// Custom holiday routes (merged with the computed calendar), scoped to the selected franchise
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireFranchiseAccess())
+
+			r.Get("/holidays/custom", container.Handlers.Holiday.GetCustom)
+			r.Post("/holidays", container.Handlers.Holiday.Create)
+			r.Get("/holidays/{id}", container.Handlers.Holiday.Get)
+			r.Put("/holidays/{id}", container.Handlers.Holiday.Update)
+			r.Delete("/holidays/{id}", container.Handlers.Holiday.Delete)
+		})
+
+		// iCalendar subscription routes, scoped to the selected franchise
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireFranchiseAccess())
+
+			r.Post("/feeds", container.Handlers.CalendarFeed.Create)
+			r.Get("/feeds/employee/{employee_id}", container.Handlers.CalendarFeed.GetByEmployee)
+			r.Delete("/feeds/{id}", container.Handlers.CalendarFeed.Revoke)
+		})
+
+		// Utility routes; the cache is shared by every franchise, so only the platform clears it
+		r.Get("/cache-stats", container.Handlers.Calendar.GetCacheStats)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission("platform:admin"))
+			r.Post("/clear-cache", container.Handlers.Calendar.ClearCache)
+		})
+	})
+}
+
+// setupCalendarFeedRoutes configures the public iCalendar subscription routes.
+// The secret token in the URL authenticates the request, so no JWT is required.
+func setupCalendarFeedRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/feeds", func(r chi.Router) {
+		r.Get("/{token}", container.Handlers.CalendarFeed.Subscribe)
 	})
 }
 
@@ -147,7 +228,8 @@ func setupCalendarRoutes(r *chi.Mux, container *container.Container) {
 func setupShiftRoutes(r *chi.Mux, container *container.Container) {
 	r.Route("/shifts", func(r chi.Router) {
 		r.Use(middleware.JWTMiddleware)
-		r.Use(middleware.RequireRoles("admin"))
+		r.Use(middleware.RequireResourcePermission("shifts"))
+		r.Use(middleware.RequireFranchiseAccess())
 
 		// Standard CRUD routes
 		r.Post("/", container.Handlers.Shift.Create)
@@ -168,7 +250,8 @@ func setupShiftRoutes(r *chi.Mux, container *container.Container) {
 func setupShiftPlanningRoutes(r *chi.Mux, container *container.Container) {
 	r.Route("/shift-planning", func(r chi.Router) {
 		r.Use(middleware.JWTMiddleware)
-		r.Use(middleware.RequireRoles("admin"))
+		r.Use(middleware.RequireResourcePermission("shift_planning"))
+		r.Use(middleware.RequireFranchiseAccess())
 
 		// Standard projection routes
 		r.Post("/preview", container.Handlers.ShiftProjection.Preview)
@@ -179,11 +262,74 @@ func setupShiftPlanningRoutes(r *chi.Mux, container *container.Container) {
 	})
 }
 
+// setupAssignmentRoutes configures shift assignment (roster) routes
+func setupAssignmentRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/assignments", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireResourcePermission("assignments"))
+		r.Use(middleware.RequireFranchiseAccess())
+
+		// Standard CRUD routes
+		r.Post("/", container.Handlers.Assignment.Assign)
+		r.Get("/{id}", container.Handlers.Assignment.Get)
+		r.Put("/{id}", container.Handlers.Assignment.Reassign)
+		r.Delete("/{id}", container.Handlers.Assignment.Delete)
+
+		// Business-specific routes
+		r.Post("/bulk", container.Handlers.Assignment.BulkAssign)
+		r.Get("/employee/{employee_id}", container.Handlers.Assignment.GetByEmployee)
+		r.Get("/store/{store_id}", container.Handlers.Assignment.GetByStore)
+
+		// Roster generation routes
+		r.Post("/generate/preview", container.Handlers.Roster.Preview)
+		r.Post("/generate", container.Handlers.Roster.Commit)
+	})
+}
+
+// setupWorkConfigRoutes configures effective-dated work configuration routes
+func setupWorkConfigRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/work-configs", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireResourcePermission("work_configs"))
+
+		// Standard routes; work configs are global, so only the platform changes them
+		r.Get("/", container.Handlers.WorkConfig.GetAll)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission("platform:admin"))
+			r.Post("/", container.Handlers.WorkConfig.Create)
+		})
+
+		// Business-specific routes
+		r.Get("/effective", container.Handlers.WorkConfig.GetEffective)
+	})
+}
+
+// setupPayrollRoutes configures payroll calculation and surcharge configuration routes
+func setupPayrollRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/payroll", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireResourcePermission("payroll"))
+		r.Use(middleware.RequireFranchiseAccess())
+
+		// Standard routes; surcharge configs are global, so only the platform changes them
+		r.Get("/configs", container.Handlers.Payroll.GetConfigs)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission("platform:admin"))
+			r.Post("/configs", container.Handlers.Payroll.CreateConfig)
+		})
+
+		// Business-specific routes
+		r.Get("/employee/{id}", container.Handlers.Payroll.GetEmployeePayroll)
+		r.Get("/store/{store_id}", container.Handlers.Payroll.GetStorePayroll)
+	})
+}
+
 // setupAbsenceRoutes configures absence routes
 func setupAbsenceRoutes(r *chi.Mux, container *container.Container) {
 	r.Route("/absences", func(r chi.Router) {
 		r.Use(middleware.JWTMiddleware)
-		r.Use(middleware.RequireRoles("admin"))
+		r.Use(middleware.RequireResourcePermission("absences"))
+		r.Use(middleware.RequireFranchiseAccess())
 
 		// Standard routes
 		r.Post("/", container.Handlers.Absence.Create)
@@ -193,16 +339,131 @@ func setupAbsenceRoutes(r *chi.Mux, container *container.Container) {
 		r.Get("/date-range", container.Handlers.Absence.GetByEmployeeAndDateRange)
 		r.Get("/total-hours", container.Handlers.Absence.GetTotalHours)
 
+		// Approval workflow
+		r.Get("/pending", container.Handlers.Absence.GetPending)
+		r.Get("/{id}", container.Handlers.Absence.Get)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission("absences:approve"))
+			r.Post("/{id}/approve", container.Handlers.Absence.Approve)
+			r.Post("/{id}/reject", container.Handlers.Absence.Reject)
+		})
+
 		// Legacy route for backward compatibility
 		r.Get("/", container.Handlers.Absence.GetByEmployeeAndMonth)
 	})
+
+	r.Route("/absence-types", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+
+		// Any authenticated user reads the catalog to request an absence
+		r.Get("/", container.Handlers.Absence.GetTypes)
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireResourcePermission("absence_types"))
+			r.Post("/", container.Handlers.Absence.CreateType)
+			r.Put("/{id}", container.Handlers.Absence.UpdateType)
+		})
+	})
+}
+
+// setupVacationRoutes configures the vacation ledger routes
+func setupVacationRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/vacations", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireResourcePermission("vacations"))
+		r.Use(middleware.RequireFranchiseAccess())
+
+		r.Get("/{employee_id}/balance", container.Handlers.Vacation.GetBalance)
+		r.Get("/{employee_id}/ledger", container.Handlers.Vacation.GetLedger)
+		r.Post("/{employee_id}/adjustments", container.Handlers.Vacation.Adjust)
+	})
+}
+
+// setupTimeClockRoutes configures the punch and time clock management routes
+func setupTimeClockRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/clock", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireFranchiseAccess())
+
+		// Punches of the authenticated user
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission("time_clock:punch"))
+			r.Post("/in", container.Handlers.TimeClock.ClockIn)
+			r.Post("/out", container.Handlers.TimeClock.ClockOut)
+		})
+
+		// Records and missed punch corrections
+		r.Route("/entries", func(r chi.Router) {
+			r.Use(middleware.RequireResourcePermission("time_clock"))
+			r.Get("/", container.Handlers.TimeClock.GetByEmployeeAndDateRange)
+			r.Post("/", container.Handlers.TimeClock.CreateEntry)
+			r.Get("/missed", container.Handlers.TimeClock.GetMissed)
+			r.Put("/{id}", container.Handlers.TimeClock.Correct)
+		})
+
+		// Lateness, early departures, missed punches and no-shows against the assigned shifts
+		r.Route("/exceptions", func(r chi.Router) {
+			r.Use(middleware.RequireResourcePermission("time_clock"))
+			r.Get("/", container.Handlers.TimeClock.GetExceptions)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission("novelties:write"))
+				r.Post("/novelties", container.Handlers.TimeClock.ApplyExceptionNovelties)
+			})
+		})
+	})
+}
+
+// setupShiftSwapRoutes configures the shift swap workflow routes
+func setupShiftSwapRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/shift-swaps", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireFranchiseAccess())
+
+		// Offers and acceptances of the authenticated user
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission("shift_swaps:request"))
+			r.Post("/", container.Handlers.ShiftSwap.Offer)
+			r.Get("/open", container.Handlers.ShiftSwap.GetOpen)
+			r.Get("/mine", container.Handlers.ShiftSwap.GetMine)
+			r.Post("/{id}/accept", container.Handlers.ShiftSwap.Accept)
+			r.Post("/{id}/cancel", container.Handlers.ShiftSwap.Cancel)
+		})
+
+		// Approval workflow and audit trail
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireResourcePermission("shift_swaps"))
+			r.Get("/", container.Handlers.ShiftSwap.GetByStore)
+			r.Get("/pending", container.Handlers.ShiftSwap.GetPending)
+			r.Get("/{id}", container.Handlers.ShiftSwap.Get)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RequirePermission("shift_swaps:approve"))
+				r.Post("/{id}/approve", container.Handlers.ShiftSwap.Approve)
+				r.Post("/{id}/reject", container.Handlers.ShiftSwap.Reject)
+			})
+		})
+	})
+}
+
+// setupAvailabilityRoutes configures the employee availability routes
+func setupAvailabilityRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/availability", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireResourcePermission("availability"))
+		r.Use(middleware.RequireFranchiseAccess())
+
+		r.Get("/{employee_id}", container.Handlers.Availability.Get)
+		r.Put("/{employee_id}/windows", container.Handlers.Availability.SetWindows)
+		r.Post("/{employee_id}/exceptions", container.Handlers.Availability.CreateException)
+		r.Delete("/exceptions/{id}", container.Handlers.Availability.DeleteException)
+	})
 }
 
 // setupNoveltyRoutes configures novelty routes
 func setupNoveltyRoutes(r *chi.Mux, container *container.Container) {
 	r.Route("/novelties", func(r chi.Router) {
 		r.Use(middleware.JWTMiddleware)
-		r.Use(middleware.RequireRoles("admin"))
+		r.Use(middleware.RequireResourcePermission("novelties"))
+		r.Use(middleware.RequireFranchiseAccess())
 
 		// Standard routes
 		r.Post("/", container.Handlers.Novelty.Create)
@@ -217,3 +478,70 @@ func setupNoveltyRoutes(r *chi.Mux, container *container.Container) {
 		r.Get("/", container.Handlers.Novelty.GetByEmployeeAndMonth)
 	})
 }
+
+// setupRoleRoutes configures role and permission management routes.
+// Roles are shared by every franchise, so only the platform changes them; each
+// franchise assigns them to its own users.
+func setupRoleRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/roles", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireResourcePermission("roles"))
+
+		// Standard CRUD routes
+		r.Get("/", container.Handlers.Role.GetAll)
+		r.Get("/{id}", container.Handlers.Role.Get)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission("platform:admin"))
+			r.Post("/", container.Handlers.Role.Create)
+			r.Put("/{id}", container.Handlers.Role.Update)
+			r.Delete("/{id}", container.Handlers.Role.Delete)
+
+			// Business-specific routes
+			r.Put("/{id}/permissions", container.Handlers.Role.SetPermissions)
+		})
+	})
+
+	r.Route("/permissions", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireResourcePermission("roles"))
+
+		r.Get("/", container.Handlers.Role.GetPermissions)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequirePermission("platform:admin"))
+			r.Post("/", container.Handlers.Role.CreatePermission)
+			r.Put("/{id}", container.Handlers.Role.UpdatePermission)
+			r.Delete("/{id}", container.Handlers.Role.DeletePermission)
+		})
+	})
+
+	// Roles of a user in the token's franchise
+	r.Route("/users/{id}/roles", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequirePermission("roles:write"))
+		r.Use(middleware.RequireFranchiseAccess())
+
+		r.Put("/", container.Handlers.Role.AssignToUser)
+		r.Delete("/", container.Handlers.Role.RevokeFromUser)
+	})
+}
+
+// setupSelfServiceRoutes configures the employee self-service routes
+func setupSelfServiceRoutes(r *chi.Mux, container *container.Container) {
+	r.Route("/me", func(r chi.Router) {
+		r.Use(middleware.JWTMiddleware)
+		r.Use(middleware.RequireResourcePermission("self"))
+		r.Use(middleware.RequireFranchiseAccess())
+
+		r.Get("/", container.Handlers.SelfService.GetProfile)
+		r.Get("/shifts", container.Handlers.SelfService.GetShifts)
+		r.Get("/hours", container.Handlers.SelfService.GetHoursSummary)
+		r.Get("/absences", container.Handlers.SelfService.GetAbsences)
+		r.Post("/absences", container.Handlers.SelfService.RequestAbsence)
+		r.Get("/novelties", container.Handlers.SelfService.GetNovelties)
+		r.Get("/vacation", container.Handlers.SelfService.GetVacationLedger)
+		r.Get("/availability", container.Handlers.SelfService.GetAvailability)
+		r.Put("/availability/windows", container.Handlers.SelfService.SetAvailabilityWindows)
+		r.Post("/availability/exceptions", container.Handlers.SelfService.AddAvailabilityException)
+		r.Delete("/availability/exceptions/{id}", container.Handlers.SelfService.DeleteAvailabilityException)
+	})
+}
@@ -0,0 +1,16 @@
+package token
+
+import "github.com/golang-jwt/jwt/v5"
+
+// Claims are the claims of a Loopi access token
+type Claims struct {
+	UserID      int      `json:"user_id"`
+	Email       string   `json:"email"`
+	Roles       []string `json:"roles"`
+	FranchiseID int      `json:"franchise_id"`
+	StoreID     int      `json:"store_id"`
+	SessionID   int      `json:"sid"` // auth session; revoking it invalidates the token
+
+	MustChangePassword bool `json:"mcp,omitempty"` // only the password change is allowed until it is cleared
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,85 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Issuer signs access tokens with the active key of a key set
+type Issuer struct {
+	keys   *KeySet
+	issuer string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewIssuer creates an issuer whose tokens carry issuer as "iss" and expire after ttl
+func NewIssuer(keys *KeySet, issuer string, ttl time.Duration) *Issuer {
+	return &Issuer{keys: keys, issuer: issuer, ttl: ttl, now: time.Now}
+}
+
+// Issue signs claims with the active key; issuer, issue and expiry times are set here
+func (i *Issuer) Issue(claims Claims) (string, error) {
+	key := i.keys.Active()
+	method, err := key.Method()
+	if err != nil {
+		return "", err
+	}
+
+	now := i.now()
+	claims.Issuer = i.issuer
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(i.ttl))
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.Private)
+}
+
+// Verifier checks tokens against the keys of a key set, selected by their "kid" header
+type Verifier struct {
+	keys   *KeySet
+	issuer string
+}
+
+// NewVerifier creates a verifier accepting tokens issued by issuer
+func NewVerifier(keys *KeySet, issuer string) *Verifier {
+	return &Verifier{keys: keys, issuer: issuer}
+}
+
+// Verify parses a token and returns its claims when the signature, algorithm, issuer and
+// expiry are valid
+func (v *Verifier) Verify(tokenString string) (*Claims, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := v.keys.Lookup(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+
+		// The algorithm comes from the key, never from the token
+		method, err := key.Method()
+		if err != nil {
+			return nil, err
+		}
+		if t.Method.Alg() != method.Alg() {
+			return nil, fmt.Errorf("unexpected signing method %s for key %q", t.Method.Alg(), kid)
+		}
+		return key.Public, nil
+	},
+		jwt.WithIssuer(v.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+	)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+// ErrInvalidToken is returned for every token that fails verification
+var ErrInvalidToken = errors.New("invalid token")
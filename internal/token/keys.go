@@ -0,0 +1,178 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSABits is the smallest RSA modulus accepted for signing keys
+const minRSABits = 2048
+
+// Key is a signing key identified by the "kid" header of the tokens it signs.
+// Retired keys have no private part: they only verify tokens issued before a rotation.
+type Key struct {
+	ID      string
+	Private crypto.Signer    // nil for verify-only keys
+	Public  crypto.PublicKey // *rsa.PublicKey or ed25519.PublicKey
+}
+
+// Method returns the JWT signing method of the key (RS256 or EdDSA)
+func (k Key) Method() (jwt.SigningMethod, error) {
+	switch k.Public.(type) {
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("key %q: unsupported key type %T", k.ID, k.Public)
+	}
+}
+
+// KeySet holds every key that may verify a token and the active key that signs new ones.
+// Rotation: add a new key and make it active, keep the previous one until the tokens it
+// signed have expired (the access token TTL), then remove it.
+type KeySet struct {
+	keys   map[string]Key
+	active string
+}
+
+// NewKeySet builds a key set whose active key is activeID
+func NewKeySet(keys []Key, activeID string) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]Key, len(keys)), active: activeID}
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("key without ID")
+		}
+		if _, err := key.Method(); err != nil {
+			return nil, err
+		}
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		set.keys[key.ID] = key
+	}
+
+	active, ok := set.keys[activeID]
+	if !ok {
+		return nil, fmt.Errorf("active key %q not found", activeID)
+	}
+	if active.Private == nil {
+		return nil, fmt.Errorf("active key %q has no private key", activeID)
+	}
+	return set, nil
+}
+
+// Active returns the key that signs new tokens
+func (s *KeySet) Active() Key {
+	return s.keys[s.active]
+}
+
+// Lookup returns the key with the ID of a token's "kid" header
+func (s *KeySet) Lookup(id string) (Key, bool) {
+	key, ok := s.keys[id]
+	return key, ok
+}
+
+// Keys returns every key sorted by ID
+func (s *KeySet) Keys() []Key {
+	keys := make([]Key, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].ID < keys[j].ID })
+	return keys
+}
+
+// LoadKeySet reads the PEM keys of a directory; the file name without ".pem" (or ".pub.pem")
+// is the key ID. Private keys (PKCS#8 or PKCS#1) can sign, public keys (PKIX) only verify.
+// An empty activeID selects the private key with the greatest ID, so date-named keys
+// (e.g. "2025-06.pem") rotate by adding a file.
+func LoadKeySet(dir, activeID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no PEM keys found in %s", dir)
+	}
+
+	pickActive := activeID == ""
+	keys := make([]Key, 0, len(paths))
+	for _, path := range paths {
+		key, err := readKey(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+		if pickActive && key.Private != nil && key.ID > activeID {
+			activeID = key.ID
+		}
+	}
+	return NewKeySet(keys, activeID)
+}
+
+// GenerateKey creates an Ed25519 signing key; used for local development and tests
+func GenerateKey(id string) (Key, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return Key{}, err
+	}
+	return Key{ID: id, Private: private, Public: public}, nil
+}
+
+func readKey(path string) (Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Key{}, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, fmt.Errorf("%s: no PEM block", path)
+	}
+
+	id := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".pem"), ".pub")
+	key := Key{ID: id}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("%s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.Private, key.Public = k, &k.PublicKey
+	case ed25519.PrivateKey:
+		key.Private, key.Public = k, k.Public()
+	case *rsa.PublicKey, ed25519.PublicKey:
+		key.Public = k
+	default:
+		return Key{}, fmt.Errorf("%s: unsupported key type %T (use RSA or Ed25519)", path, parsed)
+	}
+
+	if rsaKey, ok := key.Public.(*rsa.PublicKey); ok && rsaKey.N.BitLen() < minRSABits {
+		return Key{}, fmt.Errorf("%s: RSA keys must have at least %d bits", path, minRSABits)
+	}
+	return key, nil
+}
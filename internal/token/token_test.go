@@ -0,0 +1,191 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newRSAKey(t *testing.T, id string) Key {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Key{ID: id, Private: private, Public: &private.PublicKey}
+}
+
+func newEd25519Key(t *testing.T, id string) Key {
+	key, err := GenerateKey(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func mustKeySet(t *testing.T, keys []Key, activeID string) *KeySet {
+	set, err := NewKeySet(keys, activeID)
+	if err != nil {
+		t.Fatalf("NewKeySet: unexpected error %v", err)
+	}
+	return set
+}
+
+func TestIssueAndVerify_RS256AndEdDSA(t *testing.T) {
+	for _, key := range []Key{newRSAKey(t, "rsa-1"), newEd25519Key(t, "ed-1")} {
+		keys := mustKeySet(t, []Key{key}, key.ID)
+
+		signed, err := NewIssuer(keys, "loopi-api", time.Minute).Issue(Claims{UserID: 7, SessionID: 3, MustChangePassword: true})
+		if err != nil {
+			t.Fatalf("%s: Issue: unexpected error %v", key.ID, err)
+		}
+
+		parsed, _, _ := jwt.NewParser().ParseUnverified(signed, &Claims{})
+		if parsed.Header["kid"] != key.ID {
+			t.Errorf("%s: expected kid header %q, got %v", key.ID, key.ID, parsed.Header["kid"])
+		}
+
+		claims, err := NewVerifier(keys, "loopi-api").Verify(signed)
+		if err != nil {
+			t.Fatalf("%s: Verify: unexpected error %v", key.ID, err)
+		}
+		if claims.UserID != 7 || claims.SessionID != 3 || !claims.MustChangePassword || claims.Issuer != "loopi-api" {
+			t.Errorf("%s: unexpected claims %+v", key.ID, claims)
+		}
+	}
+}
+
+func TestVerify_Rotation(t *testing.T) {
+	oldKey, newKey := newEd25519Key(t, "2025-01"), newRSAKey(t, "2025-06")
+
+	before := mustKeySet(t, []Key{oldKey}, oldKey.ID)
+	signedBefore, _ := NewIssuer(before, "loopi-api", time.Minute).Issue(Claims{UserID: 1})
+
+	// The old key is retired to verify-only while its tokens expire
+	retired := Key{ID: oldKey.ID, Public: oldKey.Public}
+	after := mustKeySet(t, []Key{retired, newKey}, newKey.ID)
+	verifier := NewVerifier(after, "loopi-api")
+
+	if _, err := verifier.Verify(signedBefore); err != nil {
+		t.Errorf("Expected a token of the retired key to verify, got %v", err)
+	}
+	signedAfter, _ := NewIssuer(after, "loopi-api", time.Minute).Issue(Claims{UserID: 1})
+	if _, err := verifier.Verify(signedAfter); err != nil {
+		t.Errorf("Expected a token of the active key to verify, got %v", err)
+	}
+
+	// Once removed, tokens of the old key are rejected
+	if _, err := NewVerifier(mustKeySet(t, []Key{newKey}, newKey.ID), "loopi-api").Verify(signedBefore); err == nil {
+		t.Error("Expected a token of a removed key to be rejected")
+	}
+}
+
+func TestVerify_Rejections(t *testing.T) {
+	key := newEd25519Key(t, "ed-1")
+	keys := mustKeySet(t, []Key{key}, key.ID)
+	verifier := NewVerifier(keys, "loopi-api")
+
+	issuer := NewIssuer(keys, "loopi-api", time.Minute)
+	issuer.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	expired, _ := issuer.Issue(Claims{UserID: 1})
+	if _, err := verifier.Verify(expired); !errors.Is(err, jwt.ErrTokenExpired) || !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Expected an expired token error, got %v", err)
+	}
+
+	otherIssuer, _ := NewIssuer(keys, "someone-else", time.Minute).Issue(Claims{UserID: 1})
+	if _, err := verifier.Verify(otherIssuer); err == nil {
+		t.Error("Expected a token of another issuer to be rejected")
+	}
+
+	// An HMAC token keyed with the public key must not pass as the key's own algorithm
+	hmac := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer:    "loopi-api",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}})
+	hmac.Header["kid"] = key.ID
+	forged, _ := hmac.SignedString([]byte(key.Public.(ed25519.PublicKey)))
+	if _, err := verifier.Verify(forged); err == nil {
+		t.Error("Expected an HS256 token to be rejected")
+	}
+}
+
+func TestNewKeySet_ActiveKeyMustSign(t *testing.T) {
+	key := newEd25519Key(t, "ed-1")
+
+	if _, err := NewKeySet([]Key{{ID: key.ID, Public: key.Public}}, key.ID); err == nil {
+		t.Error("Expected a verify-only active key to be rejected")
+	}
+	if _, err := NewKeySet([]Key{key}, "missing"); err == nil {
+		t.Error("Expected an unknown active key to be rejected")
+	}
+	if _, err := NewKeySet([]Key{key, key}, key.ID); err == nil {
+		t.Error("Expected duplicate key IDs to be rejected")
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	rsaKey, edKey := newRSAKey(t, "a-rsa"), newEd25519Key(t, "b-ed")
+	jwks := mustKeySet(t, []Key{edKey, rsaKey}, edKey.ID).JWKS()
+
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("Expected 2 keys, got %d", len(jwks.Keys))
+	}
+	if k := jwks.Keys[0]; k.KeyID != "a-rsa" || k.KeyType != "RSA" || k.Algorithm != "RS256" || k.E != "AQAB" || k.N == "" {
+		t.Errorf("Unexpected RSA key %+v", k)
+	}
+	if k := jwks.Keys[1]; k.KeyID != "b-ed" || k.KeyType != "OKP" || k.Curve != "Ed25519" || k.Algorithm != "EdDSA" || len(k.X) != 43 {
+		t.Errorf("Unexpected Ed25519 key %+v", k)
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+	writePEM := func(name, blockType string, der []byte) {
+		data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, older, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(older)
+	writePEM("2025-01.pem", "PRIVATE KEY", der)
+
+	newer, _ := rsa.GenerateKey(rand.Reader, 2048)
+	writePEM("2025-06.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(newer))
+
+	// Public keys with a greater ID are verify-only and never become active
+	public, _, _ := ed25519.GenerateKey(rand.Reader)
+	der, _ = x509.MarshalPKIXPublicKey(public)
+	writePEM("2099-01.pub.pem", "PUBLIC KEY", der)
+
+	keys, err := LoadKeySet(dir, "")
+	if err != nil {
+		t.Fatalf("LoadKeySet: unexpected error %v", err)
+	}
+	if keys.Active().ID != "2025-06" {
+		t.Errorf("Expected the greatest private key to be active, got %s", keys.Active().ID)
+	}
+	if retired, ok := keys.Lookup("2099-01"); !ok || retired.Private != nil {
+		t.Errorf("Expected 2099-01 as a verify-only key, got %+v", retired)
+	}
+
+	pinned, err := LoadKeySet(dir, "2025-01")
+	if err != nil || pinned.Active().ID != "2025-01" {
+		t.Errorf("Expected the configured active key, got %v", err)
+	}
+
+	weak, _ := rsa.GenerateKey(rand.Reader, 1024)
+	writePEM("weak.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(weak))
+	if _, err := LoadKeySet(dir, ""); err == nil {
+		t.Error("Expected RSA keys under 2048 bits to be rejected")
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"regexp"
+	"strings"
+)
+
+// absenceTypeCodePattern accepts codes such as "sick_leave"
+var absenceTypeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,29}$`)
+
+type AbsenceTypeUseCase interface {
+	// Standard CRUD operations; the catalog is shared by every franchise
+	GetAll(includeInactive bool) ([]domain.AbsenceType, error)
+	Create(req dto.AbsenceTypeRequest) (*domain.AbsenceType, error)
+	Update(id int, req dto.AbsenceTypeRequest) (*domain.AbsenceType, error)
+
+	// Business-specific operations
+	ValidateAbsenceTypeRequest(req dto.AbsenceTypeRequest) error
+}
+
+type absenceTypeUseCase struct {
+	repo         repository.AbsenceTypeRepository
+	errorHandler *base.ErrorHandler
+	validator    *base.Validator
+	logger       *base.Logger
+}
+
+func NewAbsenceTypeUseCase(repo repository.AbsenceTypeRepository) AbsenceTypeUseCase {
+	return &absenceTypeUseCase{
+		repo:         repo,
+		errorHandler: base.NewErrorHandler("AbsenceType"),
+		validator:    base.NewValidator(),
+		logger:       base.NewLogger("AbsenceType"),
+	}
+}
+
+// GetAll lists the absence types; inactive ones only when requested
+func (uc *absenceTypeUseCase) GetAll(includeInactive bool) ([]domain.AbsenceType, error) {
+	types, err := uc.repo.GetAll(includeInactive)
+	if err != nil {
+		uc.logger.LogError("GetAll", err, nil)
+		return nil, uc.errorHandler.HandleRepositoryError("GetAll", err)
+	}
+	return types, nil
+}
+
+// Create adds a type to the catalog
+func (uc *absenceTypeUseCase) Create(req dto.AbsenceTypeRequest) (*domain.AbsenceType, error) {
+	uc.logger.LogOperation("Create", "start", map[string]interface{}{"code": req.Code})
+
+	if err := uc.ValidateAbsenceTypeRequest(req); err != nil {
+		return nil, err
+	}
+
+	absenceType := &domain.AbsenceType{
+		Code:             strings.TrimSpace(req.Code),
+		Name:             strings.TrimSpace(req.Name),
+		Paid:             req.Paid,
+		MaxHoursPerMonth: req.MaxHoursPerMonth,
+		IsActive:         req.IsActive == nil || *req.IsActive,
+	}
+
+	if err := uc.repo.Create(absenceType); err != nil {
+		uc.logger.LogError("Create", err, map[string]interface{}{"code": req.Code})
+		return nil, uc.errorHandler.HandleRepositoryError("Create", err)
+	}
+
+	uc.logger.LogOperation("Create", "success", map[string]interface{}{
+		"id":   absenceType.ID,
+		"code": absenceType.Code,
+	})
+
+	return absenceType, nil
+}
+
+// Update changes a type; deactivated types remain on existing absences but cannot be requested
+func (uc *absenceTypeUseCase) Update(id int, req dto.AbsenceTypeRequest) (*domain.AbsenceType, error) {
+	uc.logger.LogOperation("Update", "start", map[string]interface{}{"id": id})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Update", err)
+	}
+	if err := uc.ValidateAbsenceTypeRequest(req); err != nil {
+		return nil, err
+	}
+
+	absenceType, err := uc.repo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("Update", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Update", err)
+	}
+
+	absenceType.Code = strings.TrimSpace(req.Code)
+	absenceType.Name = strings.TrimSpace(req.Name)
+	absenceType.Paid = req.Paid
+	absenceType.MaxHoursPerMonth = req.MaxHoursPerMonth
+	if req.IsActive != nil {
+		absenceType.IsActive = *req.IsActive
+	}
+
+	if err := uc.repo.Update(absenceType); err != nil {
+		uc.logger.LogError("Update", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Update", err)
+	}
+
+	uc.logger.LogOperation("Update", "success", map[string]interface{}{"id": id})
+	return absenceType, nil
+}
+
+// ValidateAbsenceTypeRequest validates the code, name and monthly limit of a type
+func (uc *absenceTypeUseCase) ValidateAbsenceTypeRequest(req dto.AbsenceTypeRequest) error {
+	if !absenceTypeCodePattern.MatchString(strings.TrimSpace(req.Code)) {
+		err := fmt.Errorf("invalid code: %q. Use 2-30 lowercase letters, digits or underscores", req.Code)
+		uc.logger.LogValidation("ValidateAbsenceTypeRequest", "code", "failed", map[string]interface{}{"error": err.Error()})
+		return uc.errorHandler.HandleValidationError("ValidateAbsenceTypeRequest", err)
+	}
+
+	if err := uc.validator.ValidateString(strings.TrimSpace(req.Name), "name", "required", "min:3", "max:100"); err != nil {
+		uc.logger.LogValidation("ValidateAbsenceTypeRequest", "name", "failed", map[string]interface{}{"error": err.Error()})
+		return uc.errorHandler.HandleValidationError("ValidateAbsenceTypeRequest", err)
+	}
+
+	if req.MaxHoursPerMonth < 0 {
+		err := fmt.Errorf("max_hours_per_month cannot be negative, got: %.2f", req.MaxHoursPerMonth)
+		return uc.errorHandler.HandleValidationError("ValidateAbsenceTypeRequest", err)
+	}
+
+	return nil
+}
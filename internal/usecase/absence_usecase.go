@@ -10,28 +10,57 @@ import (
 )
 
 type AbsenceUseCase interface {
-	// Standard CRUD operations
-	Create(absence domain.Absence) error
-	GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.Absence, error)
+	// Standard CRUD operations, scoped to the caller's franchise
+	Create(scope domain.TenantScope, requestedBy int, absence domain.Absence) (*domain.Absence, error)
+	GetByID(scope domain.TenantScope, id int) (*domain.Absence, error)
+	GetByEmployeeAndMonth(scope domain.TenantScope, employeeID, year, month int) ([]domain.Absence, error)
+
+	// Approval workflow
+	GetPending(scope domain.TenantScope) ([]domain.Absence, error)
+	Approve(scope domain.TenantScope, id, reviewerID int, note string) (*domain.Absence, error)
+	Reject(scope domain.TenantScope, id, reviewerID int, note string) (*domain.Absence, error)
 
 	// Business-specific operations
-	GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.Absence, error)
-	GetTotalHoursByEmployee(employeeID, year, month int) (float64, error)
+	GetByEmployeeAndDateRange(scope domain.TenantScope, employeeID int, from, to time.Time) ([]domain.Absence, error)
+	GetTotalHoursByEmployee(scope domain.TenantScope, employeeID, year, month int) (float64, error)
 	ValidateAbsenceData(absence *domain.Absence) error
-	ValidateAbsenceDate(date time.Time) error
-	ValidateEmployeeAbsenceLimit(employeeID int, year, month int, additionalHours float64) error
+	ValidateAbsenceDates(absence *domain.Absence) error
+	ValidateEmployeeAbsenceLimit(absence *domain.Absence, absenceType *domain.AbsenceType) error
+}
+
+// VacationBalanceChecker validates vacation absences against the employee's vacation balance
+type VacationBalanceChecker interface {
+	ValidateVacationRequest(absence *domain.Absence) error
 }
 
+// Absence date limits: requests reach back 90 days and up to a year ahead
+const (
+	maxAbsenceDaysBack  = 90
+	maxAbsenceDaysAhead = 365
+	maxAbsenceRangeDays = 180 // long leaves (e.g. maternity) are registered in several ranges
+)
+
 type absenceUseCase struct {
 	repo         repository.AbsenceRepository
+	typeRepo     repository.AbsenceTypeRepository
+	vacations    VacationBalanceChecker
+	tenant       *TenantGuard
 	errorHandler *base.ErrorHandler
 	validator    *base.Validator
 	logger       *base.Logger
 }
 
-func NewAbsenceUseCase(repo repository.AbsenceRepository) AbsenceUseCase {
+func NewAbsenceUseCase(
+	repo repository.AbsenceRepository,
+	typeRepo repository.AbsenceTypeRepository,
+	vacations VacationBalanceChecker,
+	tenant *TenantGuard,
+) AbsenceUseCase {
 	return &absenceUseCase{
 		repo:         repo,
+		typeRepo:     typeRepo,
+		vacations:    vacations,
+		tenant:       tenant,
 		errorHandler: base.NewErrorHandler("Absence"),
 		validator:    base.NewValidator(),
 		logger:       base.NewLogger("Absence"),
@@ -40,27 +69,81 @@ func NewAbsenceUseCase(repo repository.AbsenceRepository) AbsenceUseCase {
 
 // ✅ Enhanced CRUD operations with logging, validation, and error handling
 
-// Create creates a new absence with validation and business rules
-func (uc *absenceUseCase) Create(absence domain.Absence) error {
+// Create registers an absence request pending review. A single-day absence may omit end_date.
+func (uc *absenceUseCase) Create(scope domain.TenantScope, requestedBy int, absence domain.Absence) (*domain.Absence, error) {
 	uc.logger.LogOperation("Create", "start", map[string]interface{}{
-		"employee_id": absence.EmployeeID,
-		"date":        absence.Date.Format("2006-01-02"),
-		"hours":       absence.Hours,
+		"employee_id":     absence.EmployeeID,
+		"absence_type_id": absence.AbsenceTypeID,
+		"date":            absence.Date.Format("2006-01-02"),
+		"end_date":        absence.EndDate.Format("2006-01-02"),
+		"hours":           absence.Hours,
 	})
 
+	// Every absence starts as a request; the review fields are never taken from the caller
+	if absence.EndDate.IsZero() {
+		absence.EndDate = absence.Date
+	}
+	absence.ID = 0
+	absence.Status = domain.AbsenceStatusPending
+	absence.RequestedBy = requestedBy
+	absence.ReviewedBy = nil
+	absence.ReviewedAt = nil
+	absence.ReviewNote = ""
+	absence.AbsenceType = nil
+
 	// Validate business rules
 	if err := uc.ValidateAbsenceData(&absence); err != nil {
-		return err
+		return nil, uc.errorHandler.HandleValidationError("Create", err)
 	}
 
-	// Validate absence date
-	if err := uc.ValidateAbsenceDate(absence.Date); err != nil {
-		return err
+	if err := uc.tenant.RequireEmployee("Create", scope, absence.EmployeeID); err != nil {
+		return nil, err
 	}
 
-	// Validate employee absence limit
-	if err := uc.ValidateEmployeeAbsenceLimit(absence.EmployeeID, absence.Date.Year(), int(absence.Date.Month()), absence.Hours); err != nil {
-		return err
+	// Validate absence dates
+	if err := uc.ValidateAbsenceDates(&absence); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Create", err)
+	}
+
+	// Business rule: The type must exist and be active
+	absenceType, err := uc.typeRepo.GetByID(absence.AbsenceTypeID)
+	if err != nil {
+		uc.logger.LogError("Create", err, map[string]interface{}{
+			"absence_type_id": absence.AbsenceTypeID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("Create", err)
+	}
+	if !absenceType.IsActive {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation("Create", "inactive_type",
+			fmt.Sprintf("absence type %s is inactive", absenceType.Code))
+	}
+
+	// Business rule: Pending and approved absences of an employee cannot overlap
+	existing, err := uc.repo.GetByEmployeeAndDateRange(absence.EmployeeID, absence.Date, absence.EndDate)
+	if err != nil {
+		uc.logger.LogError("Create", err, map[string]interface{}{
+			"employee_id": absence.EmployeeID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("Create", err)
+	}
+	for _, other := range existing {
+		if other.Status != domain.AbsenceStatusRejected {
+			return nil, uc.errorHandler.HandleConflict("Create",
+				fmt.Sprintf("employee %d already has absence %d between %s and %s",
+					absence.EmployeeID, other.ID, other.Date.Format("2006-01-02"), other.LastDate().Format("2006-01-02")))
+		}
+	}
+
+	// Validate the type's monthly limit
+	if err := uc.ValidateEmployeeAbsenceLimit(&absence, absenceType); err != nil {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation("Create", "monthly_limit", err.Error())
+	}
+
+	// Business rule: Vacations are deducted from the employee's accrued balance
+	if absenceType.Code == domain.AbsenceTypeVacation {
+		if err := uc.vacations.ValidateVacationRequest(&absence); err != nil {
+			return nil, err
+		}
 	}
 
 	// Set timestamps
@@ -73,20 +156,134 @@ func (uc *absenceUseCase) Create(absence domain.Absence) error {
 			"employee_id": absence.EmployeeID,
 			"date":        absence.Date.Format("2006-01-02"),
 		})
-		return uc.errorHandler.HandleRepositoryError("Create", err)
+		return nil, uc.errorHandler.HandleRepositoryError("Create", err)
 	}
+	absence.AbsenceType = absenceType
 
 	uc.logger.LogOperation("Create", "success", map[string]interface{}{
-		"absence_id":  absence.ID,
+		"absence_id":   absence.ID,
+		"employee_id":  absence.EmployeeID,
+		"type":         absenceType.Code,
+		"hours":        absence.Hours,
+		"requested_by": requestedBy,
+	})
+
+	return &absence, nil
+}
+
+// GetByID retrieves an absence of an employee in scope
+func (uc *absenceUseCase) GetByID(scope domain.TenantScope, id int) (*domain.Absence, error) {
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByID", err)
+	}
+
+	absence, err := uc.repo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("GetByID", err, map[string]interface{}{"absence_id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByID", err)
+	}
+
+	if err := uc.tenant.RequireEmployee("GetByID", scope, absence.EmployeeID); err != nil {
+		return nil, err
+	}
+
+	return absence, nil
+}
+
+// ✅ Approval workflow
+
+// GetPending lists the absence requests awaiting review in scope, oldest first
+func (uc *absenceUseCase) GetPending(scope domain.TenantScope) ([]domain.Absence, error) {
+	uc.logger.LogOperation("GetPending", "start", map[string]interface{}{
+		"franchise_id": scope.FranchiseID,
+		"store_id":     scope.StoreID,
+	})
+
+	if err := uc.tenant.RequireFranchise("GetPending", scope, scope.FranchiseID); err != nil {
+		return nil, err
+	}
+
+	absences, err := uc.repo.GetPending(scope)
+	if err != nil {
+		uc.logger.LogError("GetPending", err, map[string]interface{}{
+			"franchise_id": scope.FranchiseID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("GetPending", err)
+	}
+
+	uc.logger.LogOperation("GetPending", "success", map[string]interface{}{
+		"franchise_id": scope.FranchiseID,
+		"count":        len(absences),
+	})
+
+	return absences, nil
+}
+
+// Approve accepts a pending absence; from then on it counts in the hours summary
+func (uc *absenceUseCase) Approve(scope domain.TenantScope, id, reviewerID int, note string) (*domain.Absence, error) {
+	return uc.review("Approve", scope, id, reviewerID, domain.AbsenceStatusApproved, note)
+}
+
+// Reject declines a pending absence
+func (uc *absenceUseCase) Reject(scope domain.TenantScope, id, reviewerID int, note string) (*domain.Absence, error) {
+	return uc.review("Reject", scope, id, reviewerID, domain.AbsenceStatusRejected, note)
+}
+
+// review records the reviewer's decision on a pending absence
+func (uc *absenceUseCase) review(operation string, scope domain.TenantScope, id, reviewerID int, status, note string) (*domain.Absence, error) {
+	uc.logger.LogOperation(operation, "start", map[string]interface{}{
+		"absence_id":  id,
+		"reviewer_id": reviewerID,
+	})
+
+	if note != "" {
+		if err := uc.validator.ValidateString(note, "note", "max:255"); err != nil {
+			return nil, uc.errorHandler.HandleValidationError(operation, err)
+		}
+	}
+
+	absence, err := uc.GetByID(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Business rule: Only pending absences are reviewed, and never by the requester or the absent employee
+	if absence.Status != domain.AbsenceStatusPending {
+		return nil, uc.errorHandler.HandleConflict(operation,
+			fmt.Sprintf("absence %d was already %s", id, absence.Status))
+	}
+	if reviewerID == absence.EmployeeID || reviewerID == absence.RequestedBy {
+		uc.logger.LogBusinessRule(operation, "self_review", "rejected", map[string]interface{}{
+			"absence_id":  id,
+			"reviewer_id": reviewerID,
+		})
+		return nil, uc.errorHandler.HandleBusinessRuleViolation(operation, "self_review",
+			"an absence cannot be reviewed by the employee or by whoever requested it")
+	}
+
+	now := time.Now()
+	absence.Status = status
+	absence.ReviewedBy = &reviewerID
+	absence.ReviewedAt = &now
+	absence.ReviewNote = note
+
+	if err := uc.repo.Review(absence); err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"absence_id": id})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	uc.logger.LogOperation(operation, "success", map[string]interface{}{
+		"absence_id":  id,
 		"employee_id": absence.EmployeeID,
-		"hours":       absence.Hours,
+		"reviewer_id": reviewerID,
+		"status":      status,
 	})
 
-	return nil
+	return absence, nil
 }
 
 // GetByEmployeeAndMonth retrieves absences by employee and month with validation
-func (uc *absenceUseCase) GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.Absence, error) {
+func (uc *absenceUseCase) GetByEmployeeAndMonth(scope domain.TenantScope, employeeID, year, month int) ([]domain.Absence, error) {
 	uc.logger.LogOperation("GetByEmployeeAndMonth", "start", map[string]interface{}{
 		"employee_id": employeeID,
 		"year":        year,
@@ -101,6 +298,10 @@ func (uc *absenceUseCase) GetByEmployeeAndMonth(employeeID, year, month int) ([]
 		return nil, uc.errorHandler.HandleValidationError("GetByEmployeeAndMonth", err)
 	}
 
+	if err := uc.tenant.RequireEmployee("GetByEmployeeAndMonth", scope, employeeID); err != nil {
+		return nil, err
+	}
+
 	// Validate year and month
 	if year < 2000 || year > 2100 {
 		err := fmt.Errorf("invalid year: %d. Must be between 2000-2100", year)
@@ -141,7 +342,7 @@ func (uc *absenceUseCase) GetByEmployeeAndMonth(employeeID, year, month int) ([]
 // ✅ Business-specific operations with enhanced features
 
 // GetByEmployeeAndDateRange retrieves absences by employee within a custom date range
-func (uc *absenceUseCase) GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.Absence, error) {
+func (uc *absenceUseCase) GetByEmployeeAndDateRange(scope domain.TenantScope, employeeID int, from, to time.Time) ([]domain.Absence, error) {
 	// Start performance timer
 	timer := uc.logger.StartTimer("GetByEmployeeAndDateRange", map[string]interface{}{
 		"employee_id": employeeID,
@@ -158,6 +359,10 @@ func (uc *absenceUseCase) GetByEmployeeAndDateRange(employeeID int, from, to tim
 		return nil, uc.errorHandler.HandleValidationError("GetByEmployeeAndDateRange", err)
 	}
 
+	if err := uc.tenant.RequireEmployee("GetByEmployeeAndDateRange", scope, employeeID); err != nil {
+		return nil, err
+	}
+
 	// Validate date range
 	if from.After(to) {
 		err := fmt.Errorf("from date (%s) cannot be after to date (%s)", from.Format("2006-01-02"), to.Format("2006-01-02"))
@@ -189,8 +394,8 @@ func (uc *absenceUseCase) GetByEmployeeAndDateRange(employeeID int, from, to tim
 	return absences, nil
 }
 
-// GetTotalHoursByEmployee retrieves total absence hours for an employee in a specific month
-func (uc *absenceUseCase) GetTotalHoursByEmployee(employeeID, year, month int) (float64, error) {
+// GetTotalHoursByEmployee retrieves the approved absence hours of an employee in a specific month
+func (uc *absenceUseCase) GetTotalHoursByEmployee(scope domain.TenantScope, employeeID, year, month int) (float64, error) {
 	uc.logger.LogOperation("GetTotalHoursByEmployee", "start", map[string]interface{}{
 		"employee_id": employeeID,
 		"year":        year,
@@ -205,6 +410,10 @@ func (uc *absenceUseCase) GetTotalHoursByEmployee(employeeID, year, month int) (
 		return 0, uc.errorHandler.HandleValidationError("GetTotalHoursByEmployee", err)
 	}
 
+	if err := uc.tenant.RequireEmployee("GetTotalHoursByEmployee", scope, employeeID); err != nil {
+		return 0, err
+	}
+
 	// Validate year and month (reuse validation logic)
 	if year < 2000 || year > 2100 {
 		err := fmt.Errorf("invalid year: %d. Must be between 2000-2100", year)
@@ -261,6 +470,14 @@ func (uc *absenceUseCase) ValidateAbsenceData(absence *domain.Absence) error {
 		return err
 	}
 
+	// Validate absence type
+	if err := uc.validator.ValidateNumber(absence.AbsenceTypeID, "absence_type_id", "positive"); err != nil {
+		uc.logger.LogValidation("ValidateAbsenceData", "absence_type_id", "failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return err
+	}
+
 	// Validate hours
 	if err := uc.validator.ValidateNumber(absence.Hours, "hours", "positive"); err != nil {
 		uc.logger.LogValidation("ValidateAbsenceData", "hours", "failed", map[string]interface{}{
@@ -269,9 +486,9 @@ func (uc *absenceUseCase) ValidateAbsenceData(absence *domain.Absence) error {
 		return err
 	}
 
-	// Business rule: Maximum 24 hours per absence entry
+	// Business rule: Maximum 24 hours per absence day
 	if absence.Hours > 24.0 {
-		err := fmt.Errorf("absence hours cannot exceed 24 hours per entry, got: %.2f", absence.Hours)
+		err := fmt.Errorf("absence hours cannot exceed 24 hours per day, got: %.2f", absence.Hours)
 		uc.logger.LogValidation("ValidateAbsenceData", "max_hours", "failed", map[string]interface{}{
 			"error": err.Error(),
 			"hours": absence.Hours,
@@ -303,81 +520,112 @@ func (uc *absenceUseCase) ValidateAbsenceData(absence *domain.Absence) error {
 	return nil
 }
 
-// ValidateAbsenceDate validates absence date according to business rules
-func (uc *absenceUseCase) ValidateAbsenceDate(date time.Time) error {
-	uc.logger.LogOperation("ValidateAbsenceDate", "start", map[string]interface{}{
-		"date": date.Format("2006-01-02"),
+// ValidateAbsenceDates validates the absence range: future requests are allowed (e.g. vacations)
+// up to a year ahead, past ones up to 90 days back
+func (uc *absenceUseCase) ValidateAbsenceDates(absence *domain.Absence) error {
+	uc.logger.LogOperation("ValidateAbsenceDates", "start", map[string]interface{}{
+		"date":     absence.Date.Format("2006-01-02"),
+		"end_date": absence.EndDate.Format("2006-01-02"),
 	})
 
-	// Business rule: Cannot register absence for future dates
+	if absence.Date.IsZero() {
+		return fmt.Errorf("date is required")
+	}
+
+	// Business rule: The range ends on or after its first day and spans at most maxAbsenceRangeDays
+	if absence.EndDate.Before(absence.Date) {
+		err := fmt.Errorf("end_date (%s) cannot be before date (%s)", absence.EndDate.Format("2006-01-02"), absence.Date.Format("2006-01-02"))
+		uc.logger.LogValidation("ValidateAbsenceDates", "range", "failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return err
+	}
+	if days := len(absence.Days(absence.Date, absence.EndDate)); days > maxAbsenceRangeDays {
+		err := fmt.Errorf("an absence cannot span more than %d days, got: %d", maxAbsenceRangeDays, days)
+		uc.logger.LogValidation("ValidateAbsenceDates", "range_length", "failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return err
+	}
+
+	// Business rule: Cannot register absences older than 90 days
 	now := time.Now()
-	if date.After(now) {
-		err := fmt.Errorf("cannot register absence for future date: %s", date.Format("2006-01-02"))
-		uc.logger.LogValidation("ValidateAbsenceDate", "future_date", "failed", map[string]interface{}{
+	if absence.Date.Before(now.AddDate(0, 0, -maxAbsenceDaysBack)) {
+		err := fmt.Errorf("cannot register absence for dates older than %d days: %s", maxAbsenceDaysBack, absence.Date.Format("2006-01-02"))
+		uc.logger.LogValidation("ValidateAbsenceDates", "too_old_date", "failed", map[string]interface{}{
 			"error": err.Error(),
-			"date":  date.Format("2006-01-02"),
 		})
 		return err
 	}
 
-	// Business rule: Cannot register absence for dates older than 90 days
-	ninetyDaysAgo := now.AddDate(0, 0, -90)
-	if date.Before(ninetyDaysAgo) {
-		err := fmt.Errorf("cannot register absence for dates older than 90 days: %s", date.Format("2006-01-02"))
-		uc.logger.LogValidation("ValidateAbsenceDate", "too_old_date", "failed", map[string]interface{}{
+	// Business rule: Cannot request absences more than a year ahead
+	if absence.EndDate.After(now.AddDate(0, 0, maxAbsenceDaysAhead)) {
+		err := fmt.Errorf("cannot request absence more than %d days ahead: %s", maxAbsenceDaysAhead, absence.EndDate.Format("2006-01-02"))
+		uc.logger.LogValidation("ValidateAbsenceDates", "too_far_date", "failed", map[string]interface{}{
 			"error": err.Error(),
-			"date":  date.Format("2006-01-02"),
 		})
 		return err
 	}
 
-	uc.logger.LogValidation("ValidateAbsenceDate", "date_rules", "passed", map[string]interface{}{
-		"date": date.Format("2006-01-02"),
+	uc.logger.LogValidation("ValidateAbsenceDates", "date_rules", "passed", map[string]interface{}{
+		"date":     absence.Date.Format("2006-01-02"),
+		"end_date": absence.EndDate.Format("2006-01-02"),
 	})
 
 	return nil
 }
 
-// ValidateEmployeeAbsenceLimit validates that an employee doesn't exceed absence limits
-func (uc *absenceUseCase) ValidateEmployeeAbsenceLimit(employeeID int, year, month int, additionalHours float64) error {
+// ValidateEmployeeAbsenceLimit validates that pending and approved absences of the type stay within
+// its monthly hour limit in every month the absence touches. Types without a limit always pass.
+func (uc *absenceUseCase) ValidateEmployeeAbsenceLimit(absence *domain.Absence, absenceType *domain.AbsenceType) error {
+	if absenceType.MaxHoursPerMonth <= 0 {
+		return nil
+	}
+
 	uc.logger.LogOperation("ValidateEmployeeAbsenceLimit", "start", map[string]interface{}{
-		"employee_id":      employeeID,
-		"year":             year,
-		"month":            month,
-		"additional_hours": additionalHours,
+		"employee_id": absence.EmployeeID,
+		"type":        absenceType.Code,
+		"limit":       absenceType.MaxHoursPerMonth,
 	})
 
-	// Get current total hours for the employee in the month
-	currentTotalHours, err := uc.GetTotalHoursByEmployee(employeeID, year, month)
-	if err != nil {
-		return err // Error already logged by GetTotalHoursByEmployee
-	}
-
-	// Calculate total hours after adding the new absence
-	newTotalHours := currentTotalHours + additionalHours
-
-	// Business rule: Maximum 40 absence hours per month per employee
-	maxMonthlyHours := 40.0
-	if newTotalHours > maxMonthlyHours {
-		err := fmt.Errorf("employee %d would exceed monthly absence limit of %.1f hours. Current: %.2f, Adding: %.2f, Total would be: %.2f",
-			employeeID, maxMonthlyHours, currentTotalHours, additionalHours, newTotalHours)
-		uc.logger.LogValidation("ValidateEmployeeAbsenceLimit", "monthly_limit", "failed", map[string]interface{}{
-			"error":             err.Error(),
-			"employee_id":       employeeID,
-			"current_hours":     currentTotalHours,
-			"additional_hours":  additionalHours,
-			"would_be_total":    newTotalHours,
-			"max_monthly_hours": maxMonthlyHours,
-		})
-		return err
+	first := time.Date(absence.Date.Year(), absence.Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for month := first; !month.After(absence.EndDate); month = month.AddDate(0, 1, 0) {
+		monthEnd := month.AddDate(0, 1, -1)
+
+		// Callers scope the employee
+		existing, err := uc.repo.GetByEmployeeAndDateRange(absence.EmployeeID, month, monthEnd)
+		if err != nil {
+			uc.logger.LogError("ValidateEmployeeAbsenceLimit", err, map[string]interface{}{
+				"employee_id": absence.EmployeeID,
+			})
+			return err
+		}
+
+		currentHours := 0.0
+		for _, other := range existing {
+			if other.AbsenceTypeID == absence.AbsenceTypeID && other.Status != domain.AbsenceStatusRejected {
+				currentHours += other.HoursIn(month, monthEnd)
+			}
+		}
+		additionalHours := absence.HoursIn(month, monthEnd)
+
+		// Business rule: Maximum MaxHoursPerMonth hours of the type per month per employee
+		if total := currentHours + additionalHours; total > absenceType.MaxHoursPerMonth {
+			err := fmt.Errorf("employee %d would exceed the monthly %s limit of %.1f hours in %s. Current: %.2f, Adding: %.2f, Total would be: %.2f",
+				absence.EmployeeID, absenceType.Code, absenceType.MaxHoursPerMonth, month.Format("2006-01"), currentHours, additionalHours, total)
+			uc.logger.LogValidation("ValidateEmployeeAbsenceLimit", "monthly_limit", "failed", map[string]interface{}{
+				"error":            err.Error(),
+				"employee_id":      absence.EmployeeID,
+				"current_hours":    currentHours,
+				"additional_hours": additionalHours,
+			})
+			return err
+		}
 	}
 
 	uc.logger.LogValidation("ValidateEmployeeAbsenceLimit", "monthly_limit", "passed", map[string]interface{}{
-		"employee_id":       employeeID,
-		"current_hours":     currentTotalHours,
-		"additional_hours":  additionalHours,
-		"total_after":       newTotalHours,
-		"max_monthly_hours": maxMonthlyHours,
+		"employee_id": absence.EmployeeID,
+		"type":        absenceType.Code,
 	})
 
 	return nil
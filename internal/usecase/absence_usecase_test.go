@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"loopi-api/internal/domain"
+	repotesting "loopi-api/internal/repository/testing"
+)
+
+// MockAbsenceTypeRepository serves a fixed catalog
+type MockAbsenceTypeRepository struct {
+	types map[int]domain.AbsenceType
+}
+
+func (m *MockAbsenceTypeRepository) GetAll(includeInactive bool) ([]domain.AbsenceType, error) {
+	var types []domain.AbsenceType
+	for _, absenceType := range m.types {
+		if includeInactive || absenceType.IsActive {
+			types = append(types, absenceType)
+		}
+	}
+	return types, nil
+}
+func (m *MockAbsenceTypeRepository) GetByID(id int) (*domain.AbsenceType, error) {
+	absenceType, ok := m.types[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return &absenceType, nil
+}
+func (m *MockAbsenceTypeRepository) GetByCode(code string) (*domain.AbsenceType, error) {
+	for _, absenceType := range m.types {
+		if absenceType.Code == code {
+			return &absenceType, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+func (m *MockAbsenceTypeRepository) Create(absenceType *domain.AbsenceType) error { return nil }
+func (m *MockAbsenceTypeRepository) Update(absenceType *domain.AbsenceType) error { return nil }
+
+// Absence types of the test catalog
+const (
+	testVacation = 1
+	testPersonal = 2 // 16 hours per month at most
+	testRetired  = 3 // inactive
+)
+
+func newTestAbsenceTypes() *MockAbsenceTypeRepository {
+	return &MockAbsenceTypeRepository{types: map[int]domain.AbsenceType{
+		testVacation: {BaseEntity: domain.BaseEntity{ID: testVacation}, Code: domain.AbsenceTypeVacation, Paid: true, IsActive: true},
+		testPersonal: {BaseEntity: domain.BaseEntity{ID: testPersonal}, Code: "personal", MaxHoursPerMonth: 16, IsActive: true},
+		testRetired:  {BaseEntity: domain.BaseEntity{ID: testRetired}, Code: "retired"},
+	}}
+}
+
+// newTestAbsenceUseCase checks vacations against the ledger: employee 7 was hired three
+// years ago and employee 9 joins today
+func newTestAbsenceUseCase() (AbsenceUseCase, *repotesting.MockAbsenceRepository) {
+	repo := repotesting.NewMockAbsenceRepository()
+	types := newTestAbsenceTypes()
+	tenant := NewTenantGuard(&MockTenantRepository{employeeFranchises: map[int]int{7: 1, 8: 2, 9: 1}})
+
+	veteran, newcomer := time.Now().AddDate(-3, 0, 0), time.Now()
+	users := &MockUserRepository{users: []domain.User{
+		{BaseEntity: domain.BaseEntity{ID: 7}, HireDate: &veteran},
+		{BaseEntity: domain.BaseEntity{ID: 9}, HireDate: &newcomer},
+	}}
+	vacations := newTestVacationUseCase(users, repo, types, &MockVacationAdjustmentRepository{}, tenant)
+
+	return NewAbsenceUseCase(repo, types, vacations, tenant), repo
+}
+
+// nextMonthDay returns a day of next month, always within the allowed request window
+func nextMonthDay(day int) time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month()+1, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAbsenceUseCase_CreateIsPendingRequest(t *testing.T) {
+	uc, _ := newTestAbsenceUseCase()
+	reviewer := 99
+
+	absence, err := uc.Create(franchiseScope(1), 7, domain.Absence{
+		EmployeeID:    7,
+		AbsenceTypeID: testVacation,
+		Date:          nextMonthDay(3),
+		Hours:         8,
+		Status:        domain.AbsenceStatusApproved, // ignored
+		ReviewedBy:    &reviewer,                    // ignored
+	})
+	if err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	if absence.Status != domain.AbsenceStatusPending || absence.ReviewedBy != nil || absence.RequestedBy != 7 {
+		t.Errorf("Expected a pending request by employee 7, got %+v", absence)
+	}
+	if !absence.EndDate.Equal(absence.Date) {
+		t.Errorf("Expected a single-day absence to end on its date, got %s", absence.EndDate)
+	}
+
+	if _, err := uc.Create(franchiseScope(1), 7, domain.Absence{EmployeeID: 8, AbsenceTypeID: testVacation, Date: nextMonthDay(3), Hours: 8}); domainStatus(t, err) != 404 {
+		t.Errorf("Expected 404 for an employee of another franchise, got %v", err)
+	}
+	if _, err := uc.Create(franchiseScope(1), 7, domain.Absence{EmployeeID: 7, AbsenceTypeID: testRetired, Date: nextMonthDay(20), Hours: 8}); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 for an inactive type, got %v", err)
+	}
+	if _, err := uc.Create(franchiseScope(1), 7, domain.Absence{EmployeeID: 7, AbsenceTypeID: testVacation, Date: nextMonthDay(20), EndDate: nextMonthDay(19), Hours: 8}); domainStatus(t, err) != 400 {
+		t.Errorf("Expected 400 for a range ending before it starts, got %v", err)
+	}
+}
+
+func TestAbsenceUseCase_RejectsOverlapsAndMonthlyLimit(t *testing.T) {
+	uc, _ := newTestAbsenceUseCase()
+	scope := franchiseScope(1)
+
+	vacation, err := uc.Create(scope, 7, domain.Absence{EmployeeID: 7, AbsenceTypeID: testVacation, Date: nextMonthDay(10), EndDate: nextMonthDay(14), Hours: 8})
+	if err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+
+	if _, err := uc.Create(scope, 7, domain.Absence{EmployeeID: 7, AbsenceTypeID: testPersonal, Date: nextMonthDay(14), Hours: 4}); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 for an absence overlapping a pending one, got %v", err)
+	}
+
+	// Rejected absences free their days
+	if _, err := uc.Reject(scope, int(vacation.ID), 50, "busy week"); err != nil {
+		t.Fatalf("Reject: unexpected error %v", err)
+	}
+	if _, err := uc.Create(scope, 7, domain.Absence{EmployeeID: 7, AbsenceTypeID: testPersonal, Date: nextMonthDay(14), EndDate: nextMonthDay(15), Hours: 8}); err != nil {
+		t.Fatalf("Expected the days of a rejected absence to be free, got %v", err)
+	}
+
+	// 16 personal hours already requested this month
+	if _, err := uc.Create(scope, 7, domain.Absence{EmployeeID: 7, AbsenceTypeID: testPersonal, Date: nextMonthDay(20), Hours: 1}); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 above the type's monthly limit, got %v", err)
+	}
+	if _, err := uc.Create(scope, 7, domain.Absence{EmployeeID: 7, AbsenceTypeID: testVacation, Date: nextMonthDay(20), EndDate: nextMonthDay(24), Hours: 8}); err != nil {
+		t.Errorf("Expected types without a limit to be accepted, got %v", err)
+	}
+}
+
+func TestAbsenceUseCase_VacationNeedsBalance(t *testing.T) {
+	uc, _ := newTestAbsenceUseCase()
+
+	// Ten days hold at least eight working days, more than a month of accrual
+	_, err := uc.Create(franchiseScope(1), 9, domain.Absence{EmployeeID: 9, AbsenceTypeID: testVacation, Date: nextMonthDay(10), EndDate: nextMonthDay(19), Hours: 8})
+	if domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 for a vacation above the balance, got %v", err)
+	}
+
+	if _, err := uc.Create(franchiseScope(1), 9, domain.Absence{EmployeeID: 9, AbsenceTypeID: testPersonal, Date: nextMonthDay(10), Hours: 8}); err != nil {
+		t.Errorf("Expected other types not to use the vacation balance, got %v", err)
+	}
+}
+
+func TestAbsenceUseCase_ApprovalWorkflow(t *testing.T) {
+	uc, repo := newTestAbsenceUseCase()
+	scope := franchiseScope(1)
+	start := nextMonthDay(2)
+
+	absence, err := uc.Create(scope, 60, domain.Absence{EmployeeID: 7, AbsenceTypeID: testVacation, Date: start, EndDate: start.AddDate(0, 0, 2), Hours: 8})
+	if err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	id := int(absence.ID)
+
+	for _, reviewer := range []int{7, 60} {
+		if _, err := uc.Approve(scope, id, reviewer, ""); domainStatus(t, err) != 422 {
+			t.Errorf("Expected 422 when %d reviews the absence, got %v", reviewer, err)
+		}
+	}
+	if _, err := uc.Approve(franchiseScope(2), id, 50, ""); domainStatus(t, err) != 404 {
+		t.Errorf("Expected 404 from another franchise, got %v", err)
+	}
+
+	pending, _ := uc.GetPending(scope)
+	if len(pending) != 1 {
+		t.Fatalf("Expected 1 pending absence, got %d", len(pending))
+	}
+	if total, _ := repo.GetTotalHoursByEmployee(7, start.Year(), int(start.Month())); total != 0 {
+		t.Errorf("Expected pending absences not to count, got %.2f hours", total)
+	}
+
+	approved, err := uc.Approve(scope, id, 50, "enjoy")
+	if err != nil {
+		t.Fatalf("Approve: unexpected error %v", err)
+	}
+	if approved.Status != domain.AbsenceStatusApproved || approved.ReviewedBy == nil || *approved.ReviewedBy != 50 || approved.ReviewedAt == nil {
+		t.Errorf("Expected the approval by 50 to be recorded, got %+v", approved)
+	}
+	if total, _ := repo.GetTotalHoursByEmployee(7, start.Year(), int(start.Month())); total != 24 {
+		t.Errorf("Expected 3 approved days of 8 hours, got %.2f hours", total)
+	}
+
+	if _, err := uc.Reject(scope, id, 50, ""); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 when reviewing twice, got %v", err)
+	}
+}
+
+func TestAbsence_HoursInSplitsRangesByMonth(t *testing.T) {
+	absence := domain.Absence{
+		Date:    time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC),
+		EndDate: time.Date(2025, 2, 2, 0, 0, 0, 0, time.UTC),
+		Hours:   8,
+	}
+
+	january := absence.HoursIn(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC))
+	february := absence.HoursIn(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC))
+	if january != 16 || february != 16 {
+		t.Errorf("Expected 16 hours in each month, got %.0f and %.0f", january, february)
+	}
+}
@@ -0,0 +1,577 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
+	"strings"
+	"time"
+)
+
+// maxBulkAssignmentDays limits how many days a single bulk assignment can cover
+const maxBulkAssignmentDays = 62
+
+type AssignmentUseCase interface {
+	// Standard CRUD operations, scoped to the caller's franchise
+	GetByID(scope domain.TenantScope, id int) (*domain.AssignedShift, error)
+	GetByEmployeeAndMonth(scope domain.TenantScope, employeeID, year, month int) ([]domain.AssignedShift, error)
+	GetByStoreAndDateRange(scope domain.TenantScope, storeID int, from, to string) ([]domain.AssignedShift, error)
+	Assign(scope domain.TenantScope, req dto.AssignShiftRequest) (*domain.AssignedShift, error)
+	Reassign(scope domain.TenantScope, id int, req dto.ReassignShiftRequest) (*domain.AssignedShift, error)
+	Unassign(scope domain.TenantScope, id int) error
+
+	// Business-specific operations
+	BulkAssign(scope domain.TenantScope, req dto.BulkAssignRequest) ([]domain.AssignedShift, error)
+	ValidateAssignmentDate(date string) (time.Time, error)
+	ValidateEmployeeInStore(employeeID, storeID int) error
+}
+
+type assignmentUseCase struct {
+	assignedRepo repository.AssignedShiftRepository
+	shiftRepo    repository.ShiftRepository
+	userRepo     repository.UserRepository
+	laborRules   *LaborRulesValidator
+	availability *AvailabilityChecker
+	tenant       *TenantGuard
+	errorHandler *base.ErrorHandler
+	validator    *base.Validator
+	logger       *base.Logger
+}
+
+func NewAssignmentUseCase(
+	assignedRepo repository.AssignedShiftRepository,
+	shiftRepo repository.ShiftRepository,
+	userRepo repository.UserRepository,
+	workConfigRepo repository.WorkConfigRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	tenant *TenantGuard,
+) AssignmentUseCase {
+	return &assignmentUseCase{
+		assignedRepo: assignedRepo,
+		shiftRepo:    shiftRepo,
+		userRepo:     userRepo,
+		laborRules:   NewLaborRulesValidator(assignedRepo, workConfigRepo, utils.DefaultLaborLimits()),
+		availability: NewAvailabilityChecker(availabilityRepo),
+		tenant:       tenant,
+		errorHandler: base.NewErrorHandler("Assignment"),
+		validator:    base.NewValidator(),
+		logger:       base.NewLogger("Assignment"),
+	}
+}
+
+// ✅ Enhanced CRUD operations with logging, validation, and error handling
+
+// GetByID retrieves an assignment by ID with validation and error handling
+func (uc *assignmentUseCase) GetByID(scope domain.TenantScope, id int) (*domain.AssignedShift, error) {
+	uc.logger.LogOperation("GetByID", "start", map[string]interface{}{"id": id})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		uc.logger.LogError("GetByID", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleValidationError("GetByID", err)
+	}
+
+	if err := uc.tenant.RequireAssignedShift("GetByID", scope, id); err != nil {
+		return nil, err
+	}
+
+	assignment, err := uc.assignedRepo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("GetByID", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByID", err)
+	}
+
+	uc.logger.LogOperation("GetByID", "success", map[string]interface{}{"id": id})
+	return assignment, nil
+}
+
+// GetByEmployeeAndMonth retrieves the roster of an employee for a month
+func (uc *assignmentUseCase) GetByEmployeeAndMonth(scope domain.TenantScope, employeeID, year, month int) ([]domain.AssignedShift, error) {
+	uc.logger.LogOperation("GetByEmployeeAndMonth", "start", map[string]interface{}{
+		"employee_id": employeeID,
+		"year":        year,
+		"month":       month,
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		uc.logger.LogError("GetByEmployeeAndMonth", err, map[string]interface{}{
+			"employee_id": employeeID,
+		})
+		return nil, uc.errorHandler.HandleValidationError("GetByEmployeeAndMonth", err)
+	}
+
+	if err := uc.tenant.RequireEmployee("GetByEmployeeAndMonth", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	if month < 1 || month > 12 {
+		err := fmt.Errorf("invalid month: %d. Must be between 1-12", month)
+		uc.logger.LogError("GetByEmployeeAndMonth", err, map[string]interface{}{
+			"month": month,
+		})
+		return nil, uc.errorHandler.HandleValidationError("GetByEmployeeAndMonth", err)
+	}
+
+	assignments, err := uc.assignedRepo.GetByEmployeeAndMonth(employeeID, year, month)
+	if err != nil {
+		uc.logger.LogError("GetByEmployeeAndMonth", err, map[string]interface{}{
+			"employee_id": employeeID,
+			"year":        year,
+			"month":       month,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByEmployeeAndMonth", err)
+	}
+
+	uc.logger.LogOperation("GetByEmployeeAndMonth", "success", map[string]interface{}{
+		"employee_id": employeeID,
+		"count":       len(assignments),
+	})
+
+	return assignments, nil
+}
+
+// GetByStoreAndDateRange retrieves the roster of a store between two dates
+func (uc *assignmentUseCase) GetByStoreAndDateRange(scope domain.TenantScope, storeID int, from, to string) ([]domain.AssignedShift, error) {
+	uc.logger.LogOperation("GetByStoreAndDateRange", "start", map[string]interface{}{
+		"store_id": storeID,
+		"from":     from,
+		"to":       to,
+	})
+
+	if err := uc.validator.ValidateID(storeID); err != nil {
+		uc.logger.LogError("GetByStoreAndDateRange", err, map[string]interface{}{"store_id": storeID})
+		return nil, uc.errorHandler.HandleValidationError("GetByStoreAndDateRange", err)
+	}
+
+	if err := uc.tenant.RequireStore("GetByStoreAndDateRange", scope, storeID); err != nil {
+		return nil, err
+	}
+
+	fromDate, err := uc.ValidateAssignmentDate(from)
+	if err != nil {
+		return nil, err
+	}
+	toDate, err := uc.ValidateAssignmentDate(to)
+	if err != nil {
+		return nil, err
+	}
+	if fromDate.After(toDate) {
+		err := fmt.Errorf("from date (%s) cannot be after to date (%s)", from, to)
+		uc.logger.LogError("GetByStoreAndDateRange", err, nil)
+		return nil, uc.errorHandler.HandleValidationError("GetByStoreAndDateRange", err)
+	}
+
+	assignments, err := uc.assignedRepo.GetByStoreAndDateRange(storeID, from, to)
+	if err != nil {
+		uc.logger.LogError("GetByStoreAndDateRange", err, map[string]interface{}{"store_id": storeID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByStoreAndDateRange", err)
+	}
+
+	uc.logger.LogOperation("GetByStoreAndDateRange", "success", map[string]interface{}{
+		"store_id": storeID,
+		"count":    len(assignments),
+	})
+
+	return assignments, nil
+}
+
+// Assign schedules a shift template for an employee on a specific date
+func (uc *assignmentUseCase) Assign(scope domain.TenantScope, req dto.AssignShiftRequest) (*domain.AssignedShift, error) {
+	uc.logger.LogOperation("Assign", "start", map[string]interface{}{
+		"employee_id": req.EmployeeID,
+		"shift_id":    req.ShiftID,
+		"date":        req.Date,
+	})
+
+	if err := uc.validator.ValidateID(req.EmployeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Assign", fmt.Errorf("employee_id: %w", err))
+	}
+	if _, err := uc.ValidateAssignmentDate(req.Date); err != nil {
+		return nil, err
+	}
+
+	shift, err := uc.getActiveShift("Assign", scope, req.ShiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ValidateEmployeeInStore(req.EmployeeID, shift.StoreID); err != nil {
+		return nil, err
+	}
+
+	// Business rule: one assignment per employee and date
+	if err := uc.ensureDateAvailable("Assign", req.EmployeeID, req.Date, 0); err != nil {
+		return nil, err
+	}
+
+	proposed := []domain.AssignedShift{buildAssignment(req.EmployeeID, shift, req.Date)}
+
+	// Business rule: weekly hours, weekly rest day and rest between shifts
+	if err := uc.laborRules.Validate("Assign", req.EmployeeID, proposed, 0); err != nil {
+		return nil, err
+	}
+
+	// Business rule: the employee is available, or the override reason is recorded
+	if err := uc.availability.Validate("Assign", req.EmployeeID, proposed, req.AvailabilityOverride); err != nil {
+		return nil, err
+	}
+	assignment := proposed[0]
+
+	if err := uc.assignedRepo.Create(&assignment); err != nil {
+		uc.logger.LogError("Assign", err, map[string]interface{}{
+			"employee_id": req.EmployeeID,
+			"shift_id":    req.ShiftID,
+			"date":        req.Date,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("Assign", err)
+	}
+
+	uc.logger.LogOperation("Assign", "success", map[string]interface{}{
+		"assignment_id": assignment.ID,
+		"employee_id":   assignment.EmployeeID,
+		"date":          assignment.Date,
+	})
+
+	return &assignment, nil
+}
+
+// Reassign changes the employee, shift or date of an existing assignment
+func (uc *assignmentUseCase) Reassign(scope domain.TenantScope, id int, req dto.ReassignShiftRequest) (*domain.AssignedShift, error) {
+	uc.logger.LogOperation("Reassign", "start", map[string]interface{}{
+		"id":          id,
+		"employee_id": req.EmployeeID,
+		"shift_id":    req.ShiftID,
+		"date":        req.Date,
+	})
+
+	current, err := uc.GetByID(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.EmployeeID == 0 && req.ShiftID == 0 && req.Date == "" {
+		err := fmt.Errorf("at least one of employee_id, shift_id or date is required")
+		uc.logger.LogValidation("Reassign", "fields", "failed", map[string]interface{}{
+			"error": err.Error(),
+			"id":    id,
+		})
+		return nil, uc.errorHandler.HandleValidationError("Reassign", err)
+	}
+
+	employeeID := current.EmployeeID
+	if req.EmployeeID != 0 {
+		employeeID = req.EmployeeID
+	}
+	shiftID := current.ShiftID
+	if req.ShiftID != 0 {
+		shiftID = req.ShiftID
+	}
+	date := current.Date
+	if req.Date != "" {
+		if _, err := uc.ValidateAssignmentDate(req.Date); err != nil {
+			return nil, err
+		}
+		date = req.Date
+	}
+
+	shift, err := uc.getActiveShift("Reassign", scope, shiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ValidateEmployeeInStore(employeeID, shift.StoreID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.ensureDateAvailable("Reassign", employeeID, date, current.ID); err != nil {
+		return nil, err
+	}
+
+	updated := buildAssignment(employeeID, shift, date)
+	updated.ID = current.ID
+	updated.CreatedAt = current.CreatedAt
+	updated.UpdatedAt = time.Now()
+	proposed := []domain.AssignedShift{updated}
+
+	if err := uc.laborRules.Validate("Reassign", employeeID, proposed, current.ID); err != nil {
+		return nil, err
+	}
+
+	// An override given for the same employee and hours still justifies the assignment
+	override := req.AvailabilityOverride
+	if override == "" && sameSchedule(*current, updated) {
+		override = current.AvailabilityOverride
+	}
+	if err := uc.availability.Validate("Reassign", employeeID, proposed, override); err != nil {
+		return nil, err
+	}
+	updated = proposed[0]
+
+	if err := uc.assignedRepo.Update(&updated); err != nil {
+		uc.logger.LogError("Reassign", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Reassign", err)
+	}
+
+	uc.logger.LogOperation("Reassign", "success", map[string]interface{}{
+		"id":                id,
+		"from_employee_id":  current.EmployeeID,
+		"to_employee_id":    updated.EmployeeID,
+		"from_date":         current.Date,
+		"to_date":           updated.Date,
+		"shift_id":          updated.ShiftID,
+		"previous_shift_id": current.ShiftID,
+	})
+
+	return &updated, nil
+}
+
+// Unassign removes an assignment by ID
+func (uc *assignmentUseCase) Unassign(scope domain.TenantScope, id int) error {
+	uc.logger.LogOperation("Unassign", "start", map[string]interface{}{"id": id})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		uc.logger.LogError("Unassign", err, map[string]interface{}{"id": id})
+		return uc.errorHandler.HandleValidationError("Unassign", err)
+	}
+
+	if err := uc.tenant.RequireAssignedShift("Unassign", scope, id); err != nil {
+		return err
+	}
+
+	if err := uc.assignedRepo.Delete(id); err != nil {
+		uc.logger.LogError("Unassign", err, map[string]interface{}{"id": id})
+		return uc.errorHandler.HandleRepositoryError("Unassign", err)
+	}
+
+	uc.logger.LogOperation("Unassign", "success", map[string]interface{}{"id": id})
+	return nil
+}
+
+// ✅ Business-specific operations with enhanced features
+
+// BulkAssign assigns the same shift to an employee for every matching day in a date range
+func (uc *assignmentUseCase) BulkAssign(scope domain.TenantScope, req dto.BulkAssignRequest) ([]domain.AssignedShift, error) {
+	timer := uc.logger.StartTimer("BulkAssign", map[string]interface{}{
+		"employee_id": req.EmployeeID,
+		"shift_id":    req.ShiftID,
+		"from":        req.From,
+		"to":          req.To,
+	})
+	defer timer.Stop()
+
+	if err := uc.validator.ValidateID(req.EmployeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("BulkAssign", fmt.Errorf("employee_id: %w", err))
+	}
+
+	from, err := uc.ValidateAssignmentDate(req.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := uc.ValidateAssignmentDate(req.To)
+	if err != nil {
+		return nil, err
+	}
+	if from.After(to) {
+		err := fmt.Errorf("from date (%s) cannot be after to date (%s)", req.From, req.To)
+		uc.logger.LogValidation("BulkAssign", "date_range", "failed", map[string]interface{}{"error": err.Error()})
+		return nil, uc.errorHandler.HandleValidationError("BulkAssign", err)
+	}
+
+	// Business rule: bounded range to keep a single request reviewable
+	if days := int(to.Sub(from).Hours()/24) + 1; days > maxBulkAssignmentDays {
+		err := fmt.Errorf("date range cannot exceed %d days, got: %d", maxBulkAssignmentDays, days)
+		uc.logger.LogValidation("BulkAssign", "max_range", "failed", map[string]interface{}{"error": err.Error()})
+		return nil, uc.errorHandler.HandleValidationError("BulkAssign", err)
+	}
+
+	weekdays := make(map[time.Weekday]bool, len(req.Weekdays))
+	for _, wd := range req.Weekdays {
+		if wd < 0 || wd > 6 {
+			err := fmt.Errorf("invalid weekday: %d. Must be between 0 (Sunday) and 6 (Saturday)", wd)
+			uc.logger.LogValidation("BulkAssign", "weekdays", "failed", map[string]interface{}{"error": err.Error()})
+			return nil, uc.errorHandler.HandleValidationError("BulkAssign", err)
+		}
+		weekdays[time.Weekday(wd)] = true
+	}
+
+	shift, err := uc.getActiveShift("BulkAssign", scope, req.ShiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ValidateEmployeeInStore(req.EmployeeID, shift.StoreID); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.assignedRepo.GetByEmployeeAndDateRange(req.EmployeeID, req.From, req.To)
+	if err != nil {
+		uc.logger.LogError("BulkAssign", err, map[string]interface{}{"employee_id": req.EmployeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("BulkAssign", err)
+	}
+	taken := make(map[string]bool, len(existing))
+	for _, a := range existing {
+		taken[a.Date] = true
+	}
+
+	assignments := make([]domain.AssignedShift, 0)
+	conflicts := make([]string, 0)
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if len(weekdays) > 0 && !weekdays[d.Weekday()] {
+			continue
+		}
+		dateKey := d.Format("2006-01-02")
+		if taken[dateKey] {
+			conflicts = append(conflicts, dateKey)
+			continue
+		}
+		assignments = append(assignments, buildAssignment(req.EmployeeID, shift, dateKey))
+	}
+
+	if len(conflicts) > 0 && !req.SkipExisting {
+		uc.logger.LogBusinessRule("BulkAssign", "one_assignment_per_day", "violated", map[string]interface{}{
+			"employee_id": req.EmployeeID,
+			"conflicts":   conflicts,
+		})
+		return nil, uc.errorHandler.HandleConflict("BulkAssign",
+			fmt.Sprintf("employee %d already has shifts assigned on: %s", req.EmployeeID, strings.Join(conflicts, ", ")))
+	}
+
+	if err := uc.laborRules.Validate("BulkAssign", req.EmployeeID, assignments, 0); err != nil {
+		return nil, err
+	}
+
+	if err := uc.availability.Validate("BulkAssign", req.EmployeeID, assignments, req.AvailabilityOverride); err != nil {
+		return nil, err
+	}
+
+	if err := uc.assignedRepo.CreateBatch(assignments); err != nil {
+		uc.logger.LogError("BulkAssign", err, map[string]interface{}{"employee_id": req.EmployeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("BulkAssign", err)
+	}
+
+	uc.logger.LogOperation("BulkAssign", "success", map[string]interface{}{
+		"employee_id": req.EmployeeID,
+		"shift_id":    req.ShiftID,
+		"created":     len(assignments),
+		"skipped":     len(conflicts),
+	})
+
+	return assignments, nil
+}
+
+// ValidateAssignmentDate validates a "YYYY-MM-DD" date and returns it parsed
+func (uc *assignmentUseCase) ValidateAssignmentDate(date string) (time.Time, error) {
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		err := fmt.Errorf("invalid date format: %q. Expected format: YYYY-MM-DD", date)
+		uc.logger.LogValidation("ValidateAssignmentDate", "date_format", "failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return time.Time{}, uc.errorHandler.HandleValidationError("ValidateAssignmentDate", err)
+	}
+	return parsed, nil
+}
+
+// ValidateEmployeeInStore validates that an active employee belongs to the store of the shift
+func (uc *assignmentUseCase) ValidateEmployeeInStore(employeeID, storeID int) error {
+	employees, err := uc.userRepo.GetByStore(storeID)
+	if err != nil {
+		uc.logger.LogError("ValidateEmployeeInStore", err, map[string]interface{}{
+			"employee_id": employeeID,
+			"store_id":    storeID,
+		})
+		return uc.errorHandler.HandleRepositoryError("ValidateEmployeeInStore", err)
+	}
+
+	for _, employee := range employees {
+		if int(employee.ID) != employeeID {
+			continue
+		}
+		if !employee.IsActive {
+			err := fmt.Errorf("employee %d is not active", employeeID)
+			uc.logger.LogValidation("ValidateEmployeeInStore", "active_employee", "failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return uc.errorHandler.HandleBusinessRuleViolation("ValidateEmployeeInStore", "active_employee", err.Error())
+		}
+		return nil
+	}
+
+	err = fmt.Errorf("employee %d does not belong to store %d", employeeID, storeID)
+	uc.logger.LogValidation("ValidateEmployeeInStore", "store_membership", "failed", map[string]interface{}{
+		"error": err.Error(),
+	})
+	return uc.errorHandler.HandleBusinessRuleViolation("ValidateEmployeeInStore", "store_membership", err.Error())
+}
+
+// getActiveShift loads a shift template of the franchise and checks it can still be scheduled.
+// Employees are then matched against the shift's store, which keeps them in the same franchise.
+func (uc *assignmentUseCase) getActiveShift(operation string, scope domain.TenantScope, shiftID int) (*domain.Shift, error) {
+	if err := uc.validator.ValidateID(shiftID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError(operation, fmt.Errorf("shift_id: %w", err))
+	}
+
+	if err := uc.tenant.RequireShift(operation, scope, shiftID); err != nil {
+		return nil, err
+	}
+
+	shift, err := uc.shiftRepo.GetByID(shiftID)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"shift_id": shiftID})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	if !shift.IsActive {
+		err := fmt.Errorf("shift %d is inactive and cannot be assigned", shiftID)
+		uc.logger.LogValidation(operation, "active_shift", "failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, uc.errorHandler.HandleBusinessRuleViolation(operation, "active_shift", err.Error())
+	}
+
+	return shift, nil
+}
+
+// ensureDateAvailable enforces one assignment per employee and date, ignoring the assignment being edited
+func (uc *assignmentUseCase) ensureDateAvailable(operation string, employeeID int, date string, exceptID uint) error {
+	existing, err := uc.assignedRepo.GetByEmployeeAndDate(employeeID, date)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{
+			"employee_id": employeeID,
+			"date":        date,
+		})
+		return uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	if existing != nil && existing.ID != exceptID {
+		uc.logger.LogBusinessRule(operation, "one_assignment_per_day", "violated", map[string]interface{}{
+			"employee_id":   employeeID,
+			"date":          date,
+			"assignment_id": existing.ID,
+		})
+		return uc.errorHandler.HandleConflict(operation,
+			fmt.Sprintf("employee %d already has a shift assigned on %s", employeeID, date))
+	}
+
+	return nil
+}
+
+// sameSchedule reports whether two assignments put the same employee at the same date and hours
+func sameSchedule(a, b domain.AssignedShift) bool {
+	return a.EmployeeID == b.EmployeeID && a.Date == b.Date && a.StartTime == b.StartTime && a.EndTime == b.EndTime
+}
+
+// buildAssignment snapshots the shift template times into a new assignment
+func buildAssignment(employeeID int, shift *domain.Shift, date string) domain.AssignedShift {
+	return domain.AssignedShift{
+		EmployeeID:   employeeID,
+		ShiftID:      int(shift.ID),
+		StoreID:      shift.StoreID,
+		Date:         date,
+		StartTime:    shift.StartTime,
+		EndTime:      shift.EndTime,
+		LunchMinutes: shift.LunchMinutes,
+	}
+}
@@ -0,0 +1,152 @@
+package usecase
+
+import (
+	"net/http"
+	"testing"
+
+	"loopi-api/internal/domain"
+	repotesting "loopi-api/internal/repository/testing"
+	"loopi-api/internal/usecase/dto"
+)
+
+// newTestAssignmentUseCase has shifts 1 and 2 at store 10 of franchise 1, where employee 7 works,
+// and shift 3 at store 30 of franchise 2, where employee 9 works. Employee 8 works at store 20.
+func newTestAssignmentUseCase(assigned *MockAssignedShiftRepository) AssignmentUseCase {
+	shifts := repotesting.NewMockShiftRepository()
+	shifts.SeedShiftData([]domain.Shift{
+		{BaseEntity: domain.BaseEntity{ID: 1}, StoreID: 10, Name: "Apertura", StartTime: "08:00", EndTime: "16:00", LunchMinutes: 60, IsActive: true},
+		{BaseEntity: domain.BaseEntity{ID: 2}, StoreID: 10, Name: "Cierre", StartTime: "13:00", EndTime: "21:00", LunchMinutes: 60, IsActive: true},
+		{BaseEntity: domain.BaseEntity{ID: 3}, StoreID: 30, Name: "Apertura", StartTime: "08:00", EndTime: "16:00", LunchMinutes: 60, IsActive: true},
+	})
+
+	users := &MockUserRepository{storeUsers: map[int][]domain.User{
+		10: {{BaseEntity: domain.BaseEntity{ID: 7}, IsActive: true}},
+		20: {{BaseEntity: domain.BaseEntity{ID: 8}, IsActive: true}},
+		30: {{BaseEntity: domain.BaseEntity{ID: 9}, IsActive: true}},
+	}}
+
+	tenant := NewTenantGuard(&MockTenantRepository{
+		employeeFranchises:      map[int]int{7: 1, 8: 1, 9: 2},
+		shiftFranchises:         map[int]int{1: 1, 2: 1, 3: 2},
+		assignedShiftFranchises: map[int]int{1: 1, 2: 1},
+	})
+
+	workConfig := &MockWorkConfigRepository{config: domain.WorkConfig{WeeklyOrdinaryHours: 48}}
+	return NewAssignmentUseCase(assigned, shifts, users, workConfig, &MockAvailabilityRepository{}, tenant)
+}
+
+func TestAssignmentUseCase_OneShiftPerDay(t *testing.T) {
+	assigned := &MockAssignedShiftRepository{shifts: []domain.AssignedShift{
+		{BaseEntity: domain.BaseEntity{ID: 1}, EmployeeID: 7, ShiftID: 1, StoreID: 10, Date: "2025-06-02", StartTime: "08:00", EndTime: "16:00", LunchMinutes: 60},
+		{BaseEntity: domain.BaseEntity{ID: 2}, EmployeeID: 7, ShiftID: 1, StoreID: 10, Date: "2025-06-03", StartTime: "08:00", EndTime: "16:00", LunchMinutes: 60},
+	}}
+	uc := newTestAssignmentUseCase(assigned)
+	scope := franchiseScope(1)
+
+	_, err := uc.Assign(scope, dto.AssignShiftRequest{EmployeeID: 7, ShiftID: 2, Date: "2025-06-02"})
+	if status := domainStatus(t, err); status != http.StatusConflict {
+		t.Errorf("Expected 409 for a second shift on the same day, got %d", status)
+	}
+
+	assignment, err := uc.Assign(scope, dto.AssignShiftRequest{EmployeeID: 7, ShiftID: 1, Date: "2025-06-04"})
+	if err != nil {
+		t.Fatalf("Expected a free day to be assigned, got %v", err)
+	}
+	if assignment.StoreID != 10 || assignment.StartTime != "08:00" || assignment.LunchMinutes != 60 {
+		t.Errorf("Expected the shift template to be copied, got %+v", assignment)
+	}
+
+	// The assignment being edited does not conflict with itself
+	updated, err := uc.Reassign(scope, 1, dto.ReassignShiftRequest{ShiftID: 2})
+	if err != nil {
+		t.Fatalf("Expected the shift to change on the same day, got %v", err)
+	}
+	if updated.ID != 1 || updated.Date != "2025-06-02" || updated.StartTime != "13:00" {
+		t.Errorf("Expected assignment 1 on 2025-06-02 at 13:00, got %+v", updated)
+	}
+
+	_, err = uc.Reassign(scope, 1, dto.ReassignShiftRequest{Date: "2025-06-03"})
+	if status := domainStatus(t, err); status != http.StatusConflict {
+		t.Errorf("Expected 409 moving onto a day already assigned, got %d", status)
+	}
+}
+
+func TestAssignmentUseCase_RequiresStoreMembership(t *testing.T) {
+	uc := newTestAssignmentUseCase(&MockAssignedShiftRepository{})
+	scope := franchiseScope(1)
+
+	// Employee 8 belongs to the franchise but not to the shift's store
+	_, err := uc.Assign(scope, dto.AssignShiftRequest{EmployeeID: 8, ShiftID: 1, Date: "2025-06-02"})
+	if status := domainStatus(t, err); status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 for an employee of another store, got %d", status)
+	}
+
+	_, err = uc.BulkAssign(scope, dto.BulkAssignRequest{EmployeeID: 8, ShiftID: 1, From: "2025-06-02", To: "2025-06-06"})
+	if status := domainStatus(t, err); status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 bulk assigning an employee of another store, got %d", status)
+	}
+}
+
+func TestAssignmentUseCase_BulkAssign(t *testing.T) {
+	assigned := &MockAssignedShiftRepository{shifts: []domain.AssignedShift{
+		{BaseEntity: domain.BaseEntity{ID: 1}, EmployeeID: 7, ShiftID: 1, StoreID: 10, Date: "2025-06-03", StartTime: "08:00", EndTime: "16:00", LunchMinutes: 60},
+	}}
+	uc := newTestAssignmentUseCase(assigned)
+	scope := franchiseScope(1)
+	weekdays := []int{1, 2, 3, 4, 5}
+
+	// 2025-06-01 to 2025-08-02 is 63 days
+	_, err := uc.BulkAssign(scope, dto.BulkAssignRequest{EmployeeID: 7, ShiftID: 1, From: "2025-06-01", To: "2025-08-02", Weekdays: weekdays, SkipExisting: true})
+	if status := domainStatus(t, err); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for more than %d days, got %d", maxBulkAssignmentDays, status)
+	}
+	if _, err := uc.BulkAssign(scope, dto.BulkAssignRequest{EmployeeID: 7, ShiftID: 1, From: "2025-06-01", To: "2025-08-01", Weekdays: weekdays, SkipExisting: true}); err != nil {
+		t.Errorf("Expected %d days to be allowed, got %v", maxBulkAssignmentDays, err)
+	}
+
+	week := dto.BulkAssignRequest{EmployeeID: 7, ShiftID: 1, From: "2025-06-02", To: "2025-06-08", Weekdays: weekdays}
+	_, err = uc.BulkAssign(scope, week)
+	if status := domainStatus(t, err); status != http.StatusConflict {
+		t.Errorf("Expected 409 when a day is already assigned, got %d", status)
+	}
+
+	week.SkipExisting = true
+	created, err := uc.BulkAssign(scope, week)
+	if err != nil {
+		t.Fatalf("Expected the assigned day to be skipped, got %v", err)
+	}
+	if len(created) != 4 {
+		t.Fatalf("Expected 4 assignments (Monday to Friday but Tuesday), got %d", len(created))
+	}
+	for _, assignment := range created {
+		if assignment.Date == "2025-06-03" {
+			t.Errorf("Expected 2025-06-03 to be skipped, got %+v", assignment)
+		}
+	}
+}
+
+func TestAssignmentUseCase_OtherFranchise(t *testing.T) {
+	uc := newTestAssignmentUseCase(&MockAssignedShiftRepository{})
+	scope := franchiseScope(1)
+
+	_, err := uc.GetByEmployeeAndMonth(scope, 9, 2025, 6)
+	if status := domainStatus(t, err); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for the roster of another franchise's employee, got %d", status)
+	}
+
+	_, err = uc.Assign(scope, dto.AssignShiftRequest{EmployeeID: 9, ShiftID: 3, Date: "2025-06-02"})
+	if status := domainStatus(t, err); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for another franchise's shift, got %d", status)
+	}
+
+	// Employees are matched against the shift's store, which keeps them in the franchise
+	_, err = uc.Assign(scope, dto.AssignShiftRequest{EmployeeID: 9, ShiftID: 1, Date: "2025-06-02"})
+	if status := domainStatus(t, err); status != http.StatusUnprocessableEntity {
+		t.Errorf("Expected 422 assigning another franchise's employee, got %d", status)
+	}
+
+	_, err = uc.Reassign(franchiseScope(2), 1, dto.ReassignShiftRequest{ShiftID: 3})
+	if status := domainStatus(t, err); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for another franchise's assignment, got %d", status)
+	}
+}
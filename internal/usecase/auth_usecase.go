@@ -4,35 +4,76 @@ import (
 	"fmt"
 	"loopi-api/config"
 	"loopi-api/internal/domain"
+	"loopi-api/internal/notify"
 	"loopi-api/internal/repository"
+	"loopi-api/internal/token"
 	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
 	"strings"
+	"sync"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthUseCase interface {
 	// Standard authentication operations
-	Login(email, password string) (string, error)
-	SelectContext(userID int, franchiseID, storeID int) (string, error)
+	Login(email, password, ip string) (*dto.AuthTokens, error)
+	SelectContext(userID, sessionID int, franchiseID, storeID int) (*dto.AuthTokens, error)
+	Refresh(refreshToken string) (*dto.AuthTokens, error)
+	Logout(userID, sessionID int, allSessions bool) error
+
+	// Password lifecycle
+	ChangePassword(userID, sessionID int, currentPassword, newPassword string) (*dto.AuthTokens, error)
+	RequestPasswordReset(email string) error
+	ResetPassword(token, newPassword string) error
+
+	// Brute-force protection
+	GetLockout(email string) LoginAttempts
+	Unlock(email, ip string) error
 
 	// Business-specific operations
 	ValidateLoginCredentials(email, password string) error
 	ValidateUserAccess(userID, franchiseID int) error
 	GetUserRoles(user *domain.User) []string
-	GenerateToken(userID int, email string, roles []string, franchiseID, storeID int) (string, error)
+	GenerateToken(userID int, email string, roles []string, franchiseID, storeID, sessionID int, mustChangePassword bool) (string, error)
+	ValidateSession(sessionID, userID int) error
 }
 
 type authUseCase struct {
 	userRepo     repository.UserRepository
+	storeRepo    repository.StoreRepository
+	sessionRepo  repository.AuthSessionRepository
+	resetRepo    repository.PasswordResetRepository
+	notifier     notify.Notifier
+	throttle     *LoginThrottle
+	issuer       *token.Issuer
+	permissions  *PermissionResolver
 	errorHandler *base.ErrorHandler
 	validator    *base.Validator
 	logger       *base.Logger
 }
 
-func NewAuthUseCase(userRepo repository.UserRepository) AuthUseCase {
+func NewAuthUseCase(
+	userRepo repository.UserRepository,
+	storeRepo repository.StoreRepository,
+	sessionRepo repository.AuthSessionRepository,
+	resetRepo repository.PasswordResetRepository,
+	notifier notify.Notifier,
+	throttle *LoginThrottle,
+	issuer *token.Issuer,
+	permissions *PermissionResolver,
+) AuthUseCase {
 	return &authUseCase{
 		userRepo:     userRepo,
+		storeRepo:    storeRepo,
+		sessionRepo:  sessionRepo,
+		resetRepo:    resetRepo,
+		notifier:     notifier,
+		throttle:     throttle,
+		issuer:       issuer,
+		permissions:  permissions,
 		errorHandler: base.NewErrorHandler("Auth"),
 		validator:    base.NewValidator(),
 		logger:       base.NewLogger("Auth"),
@@ -41,15 +82,29 @@ func NewAuthUseCase(userRepo repository.UserRepository) AuthUseCase {
 
 // ✅ Enhanced authentication operations with logging, validation, and error handling
 
-// Login authenticates a user and returns a JWT token
-func (uc *authUseCase) Login(email, password string) (string, error) {
+// Login authenticates a user and opens a session with an access and a refresh token.
+// Every credential failure returns the same error, and repeated failures lock the account
+// and the client IP progressively (see LoginThrottle).
+func (uc *authUseCase) Login(email, password, ip string) (*dto.AuthTokens, error) {
 	uc.logger.LogOperation("Login", "start", map[string]interface{}{
 		"email": email,
+		"ip":    ip,
 	})
 
 	// Validate credentials format
 	if err := uc.ValidateLoginCredentials(email, password); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	// Business rule: Locked accounts and IPs are rejected before checking the password
+	if remaining, locked := uc.throttle.Locked(email, ip); locked {
+		uc.logger.LogBusinessRule("Login", "lockout", "rejected", map[string]interface{}{
+			"email":     email,
+			"ip":        ip,
+			"remaining": remaining.String(),
+		})
+		return nil, uc.errorHandler.CreateCustomError(429, "Login",
+			fmt.Sprintf("too many failed login attempts; try again in %s", remaining.Round(time.Second)))
 	}
 
 	// Find user by email
@@ -58,50 +113,62 @@ func (uc *authUseCase) Login(email, password string) (string, error) {
 		uc.logger.LogError("Login", err, map[string]interface{}{
 			"email": email,
 		})
-		return "", uc.errorHandler.HandleRepositoryError("Login", err)
+		return nil, uc.errorHandler.HandleRepositoryError("Login", err)
 	}
 
-	// Check if user exists
-	if user == nil {
-		err := fmt.Errorf("user not found with email: %s", email)
-		uc.logger.LogError("Login", err, map[string]interface{}{
-			"email": email,
+	// Business rule: Unknown emails, wrong passwords and inactive users are indistinguishable.
+	// Unknown emails are still compared against a hash so response times do not differ.
+	passwordHash := dummyPasswordHash()
+	if user != nil {
+		passwordHash = user.PasswordHash
+	}
+	passwordErr := bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password))
+	if user == nil || passwordErr != nil || !user.IsActive {
+		uc.throttle.RegisterFailure(email, ip)
+		uc.logger.LogValidation("Login", "credentials", "failed", map[string]interface{}{
+			"email":      email,
+			"ip":         ip,
+			"user_found": user != nil,
 		})
-		return "", uc.errorHandler.HandleNotFound("Login", fmt.Sprintf("user not found with email: %s", email))
+		return nil, uc.errorHandler.HandleUnauthorized("Login", "invalid email or password")
 	}
+	uc.throttle.RegisterSuccess(email)
 
-	// Verify password
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+	// Open a session without context (initial login)
+	refreshToken, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, uc.errorHandler.HandleInternalError("Login", err)
+	}
+
+	session := &domain.AuthSession{
+		UserID:           int(user.ID),
+		RefreshTokenHash: utils.HashToken(refreshToken),
+		ExpiresAt:        time.Now().Add(config.GetRefreshTokenTTL()),
+	}
+	if err := uc.sessionRepo.Create(session); err != nil {
 		uc.logger.LogError("Login", err, map[string]interface{}{
-			"email":   email,
 			"user_id": user.ID,
 		})
-		return "", uc.errorHandler.HandleUnauthorized("Login", fmt.Sprintf("invalid credentials for user: %s", email))
+		return nil, uc.errorHandler.HandleRepositoryError("Login", err)
 	}
 
-	// Generate JWT token without context (initial login)
-	token, err := uc.GenerateToken(
-		int(user.ID),
-		user.Email,
-		uc.GetUserRoles(user),
-		0, // No franchise context yet
-		0, // No store context yet
-	)
+	tokens, err := uc.issueTokens(user, session, refreshToken)
 	if err != nil {
-		return "", err // Error already handled by GenerateToken
+		return nil, err // Error already handled by GenerateToken
 	}
 
 	uc.logger.LogOperation("Login", "success", map[string]interface{}{
-		"user_id": user.ID,
-		"email":   user.Email,
-		"roles":   uc.GetUserRoles(user),
+		"user_id":    user.ID,
+		"email":      user.Email,
+		"session_id": session.ID,
 	})
 
-	return token, nil
+	return tokens, nil
 }
 
-// SelectContext validates user access to a franchise/store and generates contextual token
-func (uc *authUseCase) SelectContext(userID int, franchiseID, storeID int) (string, error) {
+// SelectContext validates user access to a franchise/store, stores it in the session and
+// generates a contextual token; later refreshes keep the context
+func (uc *authUseCase) SelectContext(userID, sessionID int, franchiseID, storeID int) (*dto.AuthTokens, error) {
 	uc.logger.LogOperation("SelectContext", "start", map[string]interface{}{
 		"user_id":      userID,
 		"franchise_id": franchiseID,
@@ -113,14 +180,14 @@ func (uc *authUseCase) SelectContext(userID int, franchiseID, storeID int) (stri
 		uc.logger.LogError("SelectContext", err, map[string]interface{}{
 			"user_id": userID,
 		})
-		return "", uc.errorHandler.HandleValidationError("SelectContext", err)
+		return nil, uc.errorHandler.HandleValidationError("SelectContext", err)
 	}
 
 	if err := uc.validator.ValidateID(franchiseID); err != nil {
 		uc.logger.LogError("SelectContext", err, map[string]interface{}{
 			"franchise_id": franchiseID,
 		})
-		return "", uc.errorHandler.HandleValidationError("SelectContext", err)
+		return nil, uc.errorHandler.HandleValidationError("SelectContext", err)
 	}
 
 	// Find user
@@ -129,7 +196,7 @@ func (uc *authUseCase) SelectContext(userID int, franchiseID, storeID int) (stri
 		uc.logger.LogError("SelectContext", err, map[string]interface{}{
 			"user_id": userID,
 		})
-		return "", uc.errorHandler.HandleRepositoryError("SelectContext", err)
+		return nil, uc.errorHandler.HandleRepositoryError("SelectContext", err)
 	}
 
 	if user == nil {
@@ -137,24 +204,38 @@ func (uc *authUseCase) SelectContext(userID int, franchiseID, storeID int) (stri
 		uc.logger.LogError("SelectContext", err, map[string]interface{}{
 			"user_id": userID,
 		})
-		return "", uc.errorHandler.HandleNotFound("SelectContext", fmt.Sprintf("user not found with ID: %d", userID))
+		return nil, uc.errorHandler.HandleNotFound("SelectContext", fmt.Sprintf("user not found with ID: %d", userID))
 	}
 
 	// Validate user access to franchise
 	if err := uc.ValidateUserAccess(userID, franchiseID); err != nil {
-		return "", err // Error already handled by ValidateUserAccess
+		return nil, err // Error already handled by ValidateUserAccess
 	}
 
-	// Generate contextual JWT token
-	token, err := uc.GenerateToken(
-		userID,
-		user.Email,
-		uc.GetUserRoles(user),
-		franchiseID,
-		storeID,
-	)
+	// Validate user access to the store, or to every store of the franchise
+	if err := uc.validateStoreAccess("SelectContext", userID, franchiseID, storeID); err != nil {
+		return nil, err
+	}
+
+	// Keep the context in the session
+	session, err := uc.activeSession("SelectContext", sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	session.FranchiseID = franchiseID
+	session.StoreID = storeID
+	if err := uc.sessionRepo.Update(session); err != nil {
+		uc.logger.LogError("SelectContext", err, map[string]interface{}{
+			"session_id": sessionID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("SelectContext", err)
+	}
+
+	// Generate contextual JWT token; the refresh token is unchanged
+	tokens, err := uc.issueTokens(user, session, "")
 	if err != nil {
-		return "", err // Error already handled by GenerateToken
+		return nil, err // Error already handled by GenerateToken
 	}
 
 	uc.logger.LogOperation("SelectContext", "success", map[string]interface{}{
@@ -164,7 +245,338 @@ func (uc *authUseCase) SelectContext(userID int, franchiseID, storeID int) (stri
 		"roles":        uc.GetUserRoles(user),
 	})
 
-	return token, nil
+	return tokens, nil
+}
+
+// Refresh rotates a refresh token and issues a new access token for the session's context.
+// Presenting an already rotated refresh token revokes the whole session, and a context the
+// user no longer belongs to is cleared.
+func (uc *authUseCase) Refresh(refreshToken string) (*dto.AuthTokens, error) {
+	uc.logger.LogOperation("Refresh", "start", nil)
+
+	refreshToken = strings.TrimSpace(refreshToken)
+	if refreshToken == "" {
+		return nil, uc.errorHandler.HandleValidationError("Refresh", fmt.Errorf("refresh_token is required"))
+	}
+
+	hash := utils.HashToken(refreshToken)
+	session, err := uc.sessionRepo.GetByRefreshTokenHash(hash)
+	if err != nil {
+		return nil, uc.errorHandler.HandleUnauthorized("Refresh", "invalid refresh token")
+	}
+
+	// Business rule: A rotated refresh token may have been stolen; end the session
+	if session.RefreshTokenHash != hash {
+		uc.revokeSession("Refresh", session)
+		uc.logger.LogValidation("Refresh", "token_reuse", "failed", map[string]interface{}{
+			"session_id": session.ID,
+			"user_id":    session.UserID,
+		})
+		return nil, uc.errorHandler.HandleUnauthorized("Refresh", "refresh token already used; session revoked")
+	}
+
+	now := time.Now()
+	if !session.IsActive(now) {
+		return nil, uc.errorHandler.HandleUnauthorized("Refresh", "session expired or revoked")
+	}
+
+	// Business rule: Deactivated or deleted users lose their sessions
+	user, err := uc.userRepo.FindByID(session.UserID)
+	if err != nil || user == nil || !user.IsActive {
+		uc.revokeSession("Refresh", session)
+		return nil, uc.errorHandler.HandleUnauthorized("Refresh", fmt.Sprintf("user %d is no longer active", session.UserID))
+	}
+
+	// Business rule: A context the user lost access to is cleared; the user selects a new one
+	if session.FranchiseID > 0 {
+		accessible, err := uc.contextAccessible("Refresh", user, session)
+		if err != nil {
+			return nil, err
+		}
+		if !accessible {
+			uc.logger.LogValidation("Refresh", "context_access", "cleared", map[string]interface{}{
+				"session_id":   session.ID,
+				"user_id":      session.UserID,
+				"franchise_id": session.FranchiseID,
+				"store_id":     session.StoreID,
+			})
+			session.FranchiseID = 0
+			session.StoreID = 0
+		}
+	}
+
+	newToken, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, uc.errorHandler.HandleInternalError("Refresh", err)
+	}
+
+	session.PreviousTokenHash = session.RefreshTokenHash
+	session.RefreshTokenHash = utils.HashToken(newToken)
+	session.LastUsedAt = &now
+	if err := uc.sessionRepo.Update(session); err != nil {
+		uc.logger.LogError("Refresh", err, map[string]interface{}{
+			"session_id": session.ID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("Refresh", err)
+	}
+
+	tokens, err := uc.issueTokens(user, session, newToken)
+	if err != nil {
+		return nil, err // Error already handled by GenerateToken
+	}
+
+	uc.logger.LogOperation("Refresh", "success", map[string]interface{}{
+		"user_id":    session.UserID,
+		"session_id": session.ID,
+	})
+
+	return tokens, nil
+}
+
+// Logout revokes the current session, or every session of the user
+func (uc *authUseCase) Logout(userID, sessionID int, allSessions bool) error {
+	uc.logger.LogOperation("Logout", "start", map[string]interface{}{
+		"user_id":      userID,
+		"session_id":   sessionID,
+		"all_sessions": allSessions,
+	})
+
+	if err := uc.validator.ValidateID(userID); err != nil {
+		return uc.errorHandler.HandleValidationError("Logout", err)
+	}
+
+	if allSessions {
+		if err := uc.sessionRepo.RevokeByUser(userID); err != nil {
+			uc.logger.LogError("Logout", err, map[string]interface{}{
+				"user_id": userID,
+			})
+			return uc.errorHandler.HandleRepositoryError("Logout", err)
+		}
+	} else {
+		session, err := uc.activeSession("Logout", sessionID, userID)
+		if err != nil {
+			return err
+		}
+		if err := uc.sessionRepo.Revoke(int(session.ID)); err != nil {
+			uc.logger.LogError("Logout", err, map[string]interface{}{
+				"session_id": sessionID,
+			})
+			return uc.errorHandler.HandleRepositoryError("Logout", err)
+		}
+	}
+
+	uc.logger.LogOperation("Logout", "success", map[string]interface{}{
+		"user_id":      userID,
+		"session_id":   sessionID,
+		"all_sessions": allSessions,
+	})
+
+	return nil
+}
+
+// ✅ Password lifecycle
+
+// ChangePassword replaces the password of the authenticated user after checking the current one,
+// clears the forced change flag, revokes the user's other sessions and returns a new access
+// token for the current one
+func (uc *authUseCase) ChangePassword(userID, sessionID int, currentPassword, newPassword string) (*dto.AuthTokens, error) {
+	uc.logger.LogOperation("ChangePassword", "start", map[string]interface{}{
+		"user_id": userID,
+	})
+
+	session, err := uc.activeSession("ChangePassword", sessionID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(userID)
+	if err != nil {
+		uc.logger.LogError("ChangePassword", err, map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("ChangePassword", err)
+	}
+	if user == nil {
+		return nil, uc.errorHandler.HandleNotFound("ChangePassword", fmt.Sprintf("user not found with ID: %d", userID))
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)); err != nil {
+		return nil, uc.errorHandler.HandleUnauthorized("ChangePassword", "current password is incorrect")
+	}
+
+	// Business rule: The new password must differ from the current one
+	if currentPassword == newPassword {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation("ChangePassword", "password_reuse",
+			"the new password must be different from the current one")
+	}
+
+	if err := uc.setPassword("ChangePassword", user, newPassword); err != nil {
+		return nil, err
+	}
+
+	// Business rule: Other sessions may belong to whoever knew the old password
+	if err := uc.sessionRepo.RevokeByUserExcept(userID, int(session.ID)); err != nil {
+		uc.logger.LogError("ChangePassword", err, map[string]interface{}{
+			"user_id": userID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("ChangePassword", err)
+	}
+
+	tokens, err := uc.issueTokens(user, session, "")
+	if err != nil {
+		return nil, err // Error already handled by GenerateToken
+	}
+
+	uc.logger.LogOperation("ChangePassword", "success", map[string]interface{}{
+		"user_id": userID,
+	})
+
+	return tokens, nil
+}
+
+// RequestPasswordReset sends a single-use reset token to the user through the notifier.
+// Unknown or inactive emails succeed silently so that accounts cannot be enumerated.
+func (uc *authUseCase) RequestPasswordReset(email string) error {
+	uc.logger.LogOperation("RequestPasswordReset", "start", map[string]interface{}{
+		"email": email,
+	})
+
+	email = strings.TrimSpace(email)
+	if err := uc.validator.ValidateString(email, "email", "required", "email"); err != nil {
+		return uc.errorHandler.HandleValidationError("RequestPasswordReset", err)
+	}
+
+	user, err := uc.userRepo.FindByEmail(email)
+	if err != nil {
+		uc.logger.LogError("RequestPasswordReset", err, map[string]interface{}{
+			"email": email,
+		})
+		return uc.errorHandler.HandleRepositoryError("RequestPasswordReset", err)
+	}
+	if user == nil || !user.IsActive {
+		uc.logger.LogOperation("RequestPasswordReset", "ignored", map[string]interface{}{
+			"email": email,
+		})
+		return nil
+	}
+
+	// Business rule: Only the latest reset token is valid
+	if err := uc.resetRepo.InvalidateByUser(int(user.ID)); err != nil {
+		uc.logger.LogError("RequestPasswordReset", err, map[string]interface{}{
+			"user_id": user.ID,
+		})
+		return uc.errorHandler.HandleRepositoryError("RequestPasswordReset", err)
+	}
+
+	token, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return uc.errorHandler.HandleInternalError("RequestPasswordReset", err)
+	}
+
+	ttl := config.GetPasswordResetTTL()
+	reset := &domain.PasswordResetToken{
+		UserID:    int(user.ID),
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := uc.resetRepo.Create(reset); err != nil {
+		uc.logger.LogError("RequestPasswordReset", err, map[string]interface{}{
+			"user_id": user.ID,
+		})
+		return uc.errorHandler.HandleRepositoryError("RequestPasswordReset", err)
+	}
+
+	err = uc.notifier.Send(notify.Message{
+		To:      user.Email,
+		Subject: "Restablecer contraseña",
+		Body: fmt.Sprintf("Usa este código para restablecer tu contraseña en POST /auth/password/reset: %s\nVence en %s.",
+			token, ttl),
+	})
+	if err != nil {
+		uc.logger.LogError("RequestPasswordReset", err, map[string]interface{}{
+			"user_id": user.ID,
+		})
+		return uc.errorHandler.HandleInternalError("RequestPasswordReset", fmt.Errorf("could not deliver reset token: %v", err))
+	}
+
+	uc.logger.LogOperation("RequestPasswordReset", "success", map[string]interface{}{
+		"user_id": user.ID,
+	})
+
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token. The token is consumed and every
+// session of the user is revoked.
+func (uc *authUseCase) ResetPassword(token, newPassword string) error {
+	uc.logger.LogOperation("ResetPassword", "start", nil)
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return uc.errorHandler.HandleValidationError("ResetPassword", fmt.Errorf("token is required"))
+	}
+
+	reset, err := uc.resetRepo.GetByTokenHash(utils.HashToken(token))
+	if err != nil || !reset.IsUsable(time.Now()) {
+		return uc.errorHandler.HandleUnauthorized("ResetPassword", "invalid or expired reset token")
+	}
+
+	user, err := uc.userRepo.FindByID(reset.UserID)
+	if err != nil || user == nil || !user.IsActive {
+		return uc.errorHandler.HandleUnauthorized("ResetPassword", "invalid or expired reset token")
+	}
+
+	// Validate before consuming the token so a weak password can be retried
+	if err := utils.ValidatePasswordStrength(newPassword); err != nil {
+		return uc.errorHandler.HandleValidationError("ResetPassword", err)
+	}
+
+	// Business rule: A reset token is single-use, even under concurrent requests
+	if err := uc.resetRepo.MarkUsed(int(reset.ID)); err != nil {
+		return uc.errorHandler.HandleUnauthorized("ResetPassword", "invalid or expired reset token")
+	}
+
+	if err := uc.setPassword("ResetPassword", user, newPassword); err != nil {
+		return err
+	}
+
+	// Business rule: Whoever knew the old password loses their sessions
+	if err := uc.sessionRepo.RevokeByUser(int(user.ID)); err != nil {
+		uc.logger.LogError("ResetPassword", err, map[string]interface{}{
+			"user_id": user.ID,
+		})
+		return uc.errorHandler.HandleRepositoryError("ResetPassword", err)
+	}
+
+	uc.logger.LogOperation("ResetPassword", "success", map[string]interface{}{
+		"user_id": user.ID,
+	})
+
+	return nil
+}
+
+// ✅ Brute-force protection
+
+// GetLockout returns the failed-login state of an account
+func (uc *authUseCase) GetLockout(email string) LoginAttempts {
+	return uc.throttle.Status(email)
+}
+
+// Unlock clears the failed logins of an account and, when given, of an IP address
+func (uc *authUseCase) Unlock(email, ip string) error {
+	email = strings.TrimSpace(email)
+	ip = strings.TrimSpace(ip)
+	if email == "" && ip == "" {
+		return uc.errorHandler.HandleValidationError("Unlock", fmt.Errorf("email or ip is required"))
+	}
+
+	uc.throttle.Unlock(email, ip)
+
+	uc.logger.LogOperation("Unlock", "success", map[string]interface{}{
+		"email": email,
+		"ip":    ip,
+	})
+	return nil
 }
 
 // ✅ Business-specific operations with enhanced validation and logging
@@ -275,6 +687,82 @@ func (uc *authUseCase) ValidateUserAccess(userID, franchiseID int) error {
 	return nil
 }
 
+// validateStoreAccess checks the store part of a context. Users without stores:all in the
+// franchise must select one of its stores they are assigned to; the others may select
+// every store (0) or any store of the franchise.
+func (uc *authUseCase) validateStoreAccess(operation string, userID, franchiseID, storeID int) error {
+	allowed, err := uc.storeAccessible(operation, userID, franchiseID, storeID)
+	if err != nil {
+		return err
+	}
+	if allowed {
+		return nil
+	}
+
+	uc.logger.LogValidation(operation, "store_access", "failed", map[string]interface{}{
+		"user_id":      userID,
+		"franchise_id": franchiseID,
+		"store_id":     storeID,
+	})
+	if storeID <= 0 {
+		return uc.errorHandler.HandleForbidden(operation,
+			fmt.Sprintf("user %d must select a store of franchise %d", userID, franchiseID))
+	}
+	return uc.errorHandler.HandleForbidden(operation,
+		fmt.Sprintf("user %d does not have access to store %d of franchise %d", userID, storeID, franchiseID))
+}
+
+// storeAccessible reports whether the user may work in the store of the franchise, or in
+// every store of it when storeID is 0
+func (uc *authUseCase) storeAccessible(operation string, userID, franchiseID, storeID int) (bool, error) {
+	allStores, err := uc.permissions.HasPermission(userID, franchiseID, domain.PermissionAllStores)
+	if err != nil {
+		return false, err // Error already handled by the permission resolver
+	}
+
+	if storeID <= 0 {
+		return allStores, nil
+	}
+
+	var stores []domain.Store
+	if allStores {
+		stores, err = uc.storeRepo.GetByFranchiseID(franchiseID)
+	} else {
+		stores, err = uc.storeRepo.GetByUserID(userID)
+	}
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{
+			"user_id":  userID,
+			"store_id": storeID,
+		})
+		return false, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	// Business rule: The store must belong to the franchise (and be assigned to the user)
+	for _, store := range stores {
+		if int(store.ID) == storeID && int(store.FranchiseID) == franchiseID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// contextAccessible reports whether the user still belongs to the franchise and store kept
+// in the session
+func (uc *authUseCase) contextAccessible(operation string, user *domain.User, session *domain.AuthSession) (bool, error) {
+	member := false
+	for _, userRole := range user.UserRoles {
+		if userRole.FranchiseID == session.FranchiseID {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return false, nil
+	}
+	return uc.storeAccessible(operation, int(user.ID), session.FranchiseID, session.StoreID)
+}
+
 // GetUserRoles extracts roles from user domain object
 func (uc *authUseCase) GetUserRoles(user *domain.User) []string {
 	uc.logger.LogOperation("GetUserRoles", "start", map[string]interface{}{
@@ -302,7 +790,7 @@ func (uc *authUseCase) GetUserRoles(user *domain.User) []string {
 }
 
 // GenerateToken creates a JWT token with the provided claims
-func (uc *authUseCase) GenerateToken(userID int, email string, roles []string, franchiseID, storeID int) (string, error) {
+func (uc *authUseCase) GenerateToken(userID int, email string, roles []string, franchiseID, storeID, sessionID int, mustChangePassword bool) (string, error) {
 	uc.logger.LogOperation("GenerateToken", "start", map[string]interface{}{
 		"user_id":      userID,
 		"email":        email,
@@ -330,8 +818,16 @@ func (uc *authUseCase) GenerateToken(userID int, email string, roles []string, f
 		return "", uc.errorHandler.HandleValidationError("GenerateToken", err)
 	}
 
-	// Generate JWT token using config
-	token, err := config.GenerateJWT(userID, email, roles, franchiseID, storeID)
+	// Sign the access token with the active key
+	signed, err := uc.issuer.Issue(token.Claims{
+		UserID:             userID,
+		Email:              email,
+		Roles:              roles,
+		FranchiseID:        franchiseID,
+		StoreID:            storeID,
+		SessionID:          sessionID,
+		MustChangePassword: mustChangePassword,
+	})
 	if err != nil {
 		uc.logger.LogError("GenerateToken", err, map[string]interface{}{
 			"user_id":      userID,
@@ -351,5 +847,103 @@ func (uc *authUseCase) GenerateToken(userID int, email string, roles []string, f
 		"store_id":     storeID,
 	})
 
-	return token, nil
+	return signed, nil
+}
+
+// ValidateSession checks that an access token's session is still active; used by JWTMiddleware
+func (uc *authUseCase) ValidateSession(sessionID, userID int) error {
+	_, err := uc.activeSession("ValidateSession", sessionID, userID)
+	return err
+}
+
+// activeSession loads a session of the user that is neither revoked nor expired
+func (uc *authUseCase) activeSession(operation string, sessionID, userID int) (*domain.AuthSession, error) {
+	if sessionID <= 0 {
+		return nil, uc.errorHandler.HandleUnauthorized(operation, "token without session")
+	}
+
+	session, err := uc.sessionRepo.GetByID(sessionID)
+	if err != nil || session.UserID != userID || !session.IsActive(time.Now()) {
+		return nil, uc.errorHandler.HandleUnauthorized(operation, "session expired or revoked")
+	}
+	return session, nil
+}
+
+// revokeSession revokes a session, logging instead of failing when it cannot
+func (uc *authUseCase) revokeSession(operation string, session *domain.AuthSession) {
+	if session.RevokedAt != nil {
+		return
+	}
+	if err := uc.sessionRepo.Revoke(int(session.ID)); err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{
+			"session_id": session.ID,
+		})
+	}
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     string
+)
+
+// dummyPasswordHash is compared against when the email is unknown, so that Login takes
+// as long as for an existing account
+func dummyPasswordHash() string {
+	dummyHashOnce.Do(func() {
+		hashed, _ := bcrypt.GenerateFromPassword([]byte("loopi-unknown-account"), bcrypt.DefaultCost)
+		dummyHash = string(hashed)
+	})
+	return dummyHash
+}
+
+// setPassword validates, hashes and stores a new password and clears the forced change flag
+func (uc *authUseCase) setPassword(operation string, user *domain.User, password string) error {
+	if err := utils.ValidatePasswordStrength(password); err != nil {
+		return uc.errorHandler.HandleValidationError(operation, err)
+	}
+
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return uc.errorHandler.HandleInternalError(operation, fmt.Errorf("could not hash password: %v", err))
+	}
+
+	err = uc.userRepo.Update(int(user.ID), map[string]interface{}{
+		"password_hash":        string(hashed),
+		"must_change_password": false,
+	})
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{
+			"user_id": user.ID,
+		})
+		return uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	user.PasswordHash = string(hashed)
+	user.MustChangePassword = false
+	return nil
+}
+
+// issueTokens signs an access token for the session's context; refreshToken is returned
+// as is (empty when the refresh token did not change)
+func (uc *authUseCase) issueTokens(user *domain.User, session *domain.AuthSession, refreshToken string) (*dto.AuthTokens, error) {
+	token, err := uc.GenerateToken(
+		int(user.ID),
+		user.Email,
+		uc.GetUserRoles(user),
+		session.FranchiseID,
+		session.StoreID,
+		int(session.ID),
+		user.MustChangePassword,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthTokens{
+		Token:              token,
+		RefreshToken:       refreshToken,
+		TokenType:          "Bearer",
+		ExpiresIn:          int(config.GetAccessTokenTTL().Seconds()),
+		MustChangePassword: user.MustChangePassword,
+	}, nil
 }
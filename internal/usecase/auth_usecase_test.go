@@ -0,0 +1,502 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"loopi-api/internal/cache"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/notify"
+	repotesting "loopi-api/internal/repository/testing"
+	"loopi-api/internal/token"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MockUserRepository for testing
+type MockUserRepository struct {
+	users      []domain.User
+	storeUsers map[int][]domain.User // store ID → members
+}
+
+func (m *MockUserRepository) GetNameByID(userID int) (string, error) { return "", nil }
+func (m *MockUserRepository) GetAll() ([]domain.User, error)         { return m.users, nil }
+func (m *MockUserRepository) GetByStore(storeID int) ([]domain.User, error) {
+	return m.storeUsers[storeID], nil
+}
+func (m *MockUserRepository) GetByFranchise(franchiseID int) ([]domain.User, error) {
+	return m.users, nil
+}
+func (m *MockUserRepository) Create(user domain.User, roleID, franchiseID int) error {
+	return nil
+}
+func (m *MockUserRepository) Delete(id int) error { return nil }
+
+// CreateWithStore adds the user with the granted franchise role
+func (m *MockUserRepository) CreateWithStore(user domain.User, storeID, roleID, franchiseID int) error {
+	user.ID = uint(len(m.users) + 1)
+	user.UserRoles = []domain.UserRole{{UserID: int(user.ID), RoleID: roleID, FranchiseID: franchiseID}}
+	m.users = append(m.users, user)
+	return nil
+}
+
+// Update applies the password fields written by the password lifecycle
+func (m *MockUserRepository) Update(id int, fields map[string]interface{}) error {
+	for i := range m.users {
+		if int(m.users[i].ID) != id {
+			continue
+		}
+		if hash, ok := fields["password_hash"].(string); ok {
+			m.users[i].PasswordHash = hash
+		}
+		if mustChange, ok := fields["must_change_password"].(bool); ok {
+			m.users[i].MustChangePassword = mustChange
+		}
+	}
+	return nil
+}
+
+func (m *MockUserRepository) FindByEmail(email string) (*domain.User, error) {
+	for i := range m.users {
+		if m.users[i].Email == email {
+			return &m.users[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockUserRepository) FindByID(userID int) (*domain.User, error) {
+	for i := range m.users {
+		if int(m.users[i].ID) == userID {
+			return &m.users[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// MockAuthSessionRepository for testing; lookups match the current or previous token hash
+type MockAuthSessionRepository struct {
+	sessions []domain.AuthSession
+}
+
+func (m *MockAuthSessionRepository) GetByID(id int) (*domain.AuthSession, error) {
+	for i := range m.sessions {
+		if int(m.sessions[i].ID) == id {
+			session := m.sessions[i]
+			return &session, nil
+		}
+	}
+	return nil, errors.New("session not found")
+}
+
+func (m *MockAuthSessionRepository) GetByRefreshTokenHash(tokenHash string) (*domain.AuthSession, error) {
+	for i := range m.sessions {
+		if m.sessions[i].RefreshTokenHash == tokenHash || m.sessions[i].PreviousTokenHash == tokenHash {
+			session := m.sessions[i]
+			return &session, nil
+		}
+	}
+	return nil, errors.New("session not found")
+}
+
+func (m *MockAuthSessionRepository) Create(session *domain.AuthSession) error {
+	session.ID = uint(len(m.sessions) + 1)
+	m.sessions = append(m.sessions, *session)
+	return nil
+}
+
+func (m *MockAuthSessionRepository) Update(session *domain.AuthSession) error {
+	m.sessions[session.ID-1] = *session
+	return nil
+}
+
+func (m *MockAuthSessionRepository) Revoke(id int) error {
+	now := time.Now()
+	m.sessions[id-1].RevokedAt = &now
+	return nil
+}
+
+func (m *MockAuthSessionRepository) RevokeByUser(userID int) error {
+	for i := range m.sessions {
+		if m.sessions[i].UserID == userID {
+			m.Revoke(i + 1)
+		}
+	}
+	return nil
+}
+
+func (m *MockAuthSessionRepository) RevokeByUserExcept(userID, sessionID int) error {
+	for i := range m.sessions {
+		if m.sessions[i].UserID == userID && int(m.sessions[i].ID) != sessionID {
+			m.Revoke(i + 1)
+		}
+	}
+	return nil
+}
+
+// MockPasswordResetRepository for testing
+type MockPasswordResetRepository struct {
+	tokens []domain.PasswordResetToken
+}
+
+func (m *MockPasswordResetRepository) Create(token *domain.PasswordResetToken) error {
+	token.ID = uint(len(m.tokens) + 1)
+	m.tokens = append(m.tokens, *token)
+	return nil
+}
+
+func (m *MockPasswordResetRepository) GetByTokenHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	for i := range m.tokens {
+		if m.tokens[i].TokenHash == tokenHash {
+			token := m.tokens[i]
+			return &token, nil
+		}
+	}
+	return nil, errors.New("token not found")
+}
+
+func (m *MockPasswordResetRepository) MarkUsed(id int) error {
+	if m.tokens[id-1].UsedAt != nil {
+		return errors.New("token already used")
+	}
+	now := time.Now()
+	m.tokens[id-1].UsedAt = &now
+	return nil
+}
+
+func (m *MockPasswordResetRepository) InvalidateByUser(userID int) error {
+	for i := range m.tokens {
+		if m.tokens[i].UserID == userID && m.tokens[i].UsedAt == nil {
+			m.MarkUsed(i + 1)
+		}
+	}
+	return nil
+}
+
+// MockNotifier records the messages sent
+type MockNotifier struct {
+	messages []notify.Message
+}
+
+func (m *MockNotifier) Send(msg notify.Message) error {
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func newTestLoginThrottle() *LoginThrottle {
+	return NewLoginThrottle(cache.NewMemory(64, time.Hour))
+}
+
+func newTestIssuer(t *testing.T) *token.Issuer {
+	key, err := token.GenerateKey("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys, err := token.NewKeySet([]token.Key{key}, key.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token.NewIssuer(keys, "loopi-test", time.Minute)
+}
+
+func newTestAuthUseCase(t *testing.T) (AuthUseCase, *MockUserRepository, *MockAuthSessionRepository) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	users := &MockUserRepository{users: []domain.User{
+		{BaseEntity: domain.BaseEntity{ID: 1}, Email: "ana@loopi.co", PasswordHash: string(hash), IsActive: true},
+	}}
+	sessions := &MockAuthSessionRepository{}
+	return newAuthUseCaseWith(t, users, repotesting.NewMockStoreRepository(), sessions, &MockNotifier{}, &MockRoleRepository{}), users, sessions
+}
+
+func newAuthUseCaseWith(t *testing.T, users *MockUserRepository, stores *repotesting.MockStoreRepository, sessions *MockAuthSessionRepository, notifier *MockNotifier, roles *MockRoleRepository) AuthUseCase {
+	return NewAuthUseCase(users, stores, sessions, &MockPasswordResetRepository{}, notifier, newTestLoginThrottle(), newTestIssuer(t), NewPermissionResolver(roles))
+}
+
+func TestAuthUseCase_RefreshRotatesToken(t *testing.T) {
+	uc, _, _ := newTestAuthUseCase(t)
+
+	login, err := uc.Login("ana@loopi.co", "secret123", "10.0.0.1")
+	if err != nil {
+		t.Fatalf("Login: unexpected error %v", err)
+	}
+	if login.Token == "" || login.RefreshToken == "" {
+		t.Fatalf("Expected access and refresh tokens, got %+v", login)
+	}
+
+	refreshed, err := uc.Refresh(login.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: unexpected error %v", err)
+	}
+	if refreshed.RefreshToken == login.RefreshToken {
+		t.Error("Expected a rotated refresh token")
+	}
+	if err := uc.ValidateSession(1, 1); err != nil {
+		t.Errorf("Expected the session to stay active, got %v", err)
+	}
+
+	// Reusing the rotated token revokes the session, including the new refresh token
+	if _, err := uc.Refresh(login.RefreshToken); err == nil {
+		t.Error("Expected reuse of a rotated refresh token to fail")
+	}
+	if _, err := uc.Refresh(refreshed.RefreshToken); err == nil {
+		t.Error("Expected the session to be revoked after token reuse")
+	}
+	if err := uc.ValidateSession(1, 1); err == nil {
+		t.Error("Expected access tokens of the revoked session to be rejected")
+	}
+}
+
+func TestAuthUseCase_SelectContextRestrictsStores(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	users := &MockUserRepository{users: []domain.User{
+		{BaseEntity: domain.BaseEntity{ID: 1}, Email: "ana@loopi.co", PasswordHash: string(hash), IsActive: true,
+			UserRoles: []domain.UserRole{{UserID: 1, RoleID: 3, FranchiseID: 1}}},
+		{BaseEntity: domain.BaseEntity{ID: 2}, Email: "admin@loopi.co", PasswordHash: string(hash), IsActive: true,
+			UserRoles: []domain.UserRole{{UserID: 2, RoleID: 1, FranchiseID: 1}}},
+	}}
+	stores := repotesting.NewMockStoreRepository()
+	stores.SeedData([]domain.Store{
+		{BaseEntity: domain.BaseEntity{ID: 10}, FranchiseID: 1, Name: "Centro"},
+		{BaseEntity: domain.BaseEntity{ID: 11}, FranchiseID: 1, Name: "Norte"},
+		{BaseEntity: domain.BaseEntity{ID: 20}, FranchiseID: 2, Name: "Otra franquicia"},
+	})
+	stores.AssignUser(1, 10)
+	stores.AssignUser(1, 20)
+	roles := &MockRoleRepository{userPermissions: map[[2]int][]string{
+		{1, 1}: {"shifts:read"},
+		{2, 1}: {"*"},
+	}}
+	uc := newAuthUseCaseWith(t, users, stores, &MockAuthSessionRepository{}, &MockNotifier{}, roles)
+
+	uc.Login("ana@loopi.co", "secret123", "10.0.0.1")
+	uc.Login("admin@loopi.co", "secret123", "10.0.0.1")
+
+	rejected := []struct {
+		name    string
+		storeID int
+	}{
+		{"every store without stores:all", 0},
+		{"store the user is not assigned to", 11},
+		{"assigned store of another franchise", 20},
+	}
+	for _, tt := range rejected {
+		if _, err := uc.SelectContext(1, 1, 1, tt.storeID); domainStatus(t, err) != 403 {
+			t.Errorf("%s: expected 403, got %v", tt.name, err)
+		}
+	}
+	if _, err := uc.SelectContext(1, 1, 1, 10); err != nil {
+		t.Errorf("Expected the assigned store to be selected, got %v", err)
+	}
+
+	// stores:all reaches every store of the franchise, but not those of another one
+	if _, err := uc.SelectContext(2, 2, 1, 0); err != nil {
+		t.Errorf("Expected every store to be selected with stores:all, got %v", err)
+	}
+	if _, err := uc.SelectContext(2, 2, 1, 11); err != nil {
+		t.Errorf("Expected any store of the franchise with stores:all, got %v", err)
+	}
+	if _, err := uc.SelectContext(2, 2, 1, 20); domainStatus(t, err) != 403 {
+		t.Errorf("Expected a store of another franchise to be rejected, got %v", err)
+	}
+}
+
+func TestAuthUseCase_RefreshClearsLostContext(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	users := &MockUserRepository{users: []domain.User{
+		{BaseEntity: domain.BaseEntity{ID: 1}, Email: "ana@loopi.co", PasswordHash: string(hash), IsActive: true,
+			UserRoles: []domain.UserRole{{UserID: 1, RoleID: 3, FranchiseID: 1}}},
+		{BaseEntity: domain.BaseEntity{ID: 2}, Email: "admin@loopi.co", PasswordHash: string(hash), IsActive: true,
+			UserRoles: []domain.UserRole{{UserID: 2, RoleID: 1, FranchiseID: 1}}},
+	}}
+	stores := repotesting.NewMockStoreRepository()
+	stores.SeedData([]domain.Store{{BaseEntity: domain.BaseEntity{ID: 10}, FranchiseID: 1, Name: "Centro"}})
+	stores.AssignUser(1, 10)
+	roles := &MockRoleRepository{userPermissions: map[[2]int][]string{
+		{1, 1}: {"shifts:read"},
+		{2, 1}: {"*"},
+	}}
+	sessions := &MockAuthSessionRepository{}
+	uc := newAuthUseCaseWith(t, users, stores, sessions, &MockNotifier{}, roles)
+
+	employee, _ := uc.Login("ana@loopi.co", "secret123", "10.0.0.1")
+	admin, _ := uc.Login("admin@loopi.co", "secret123", "10.0.0.1")
+	if _, err := uc.SelectContext(1, 1, 1, 10); err != nil {
+		t.Fatalf("SelectContext: unexpected error %v", err)
+	}
+	if _, err := uc.SelectContext(2, 2, 1, 0); err != nil {
+		t.Fatalf("SelectContext: unexpected error %v", err)
+	}
+
+	refreshed, err := uc.Refresh(employee.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh: unexpected error %v", err)
+	}
+	if session := sessions.sessions[0]; session.FranchiseID != 1 || session.StoreID != 10 {
+		t.Errorf("Expected the context to be kept, got franchise %d store %d", session.FranchiseID, session.StoreID)
+	}
+
+	// The store is gone: the next refresh drops the context instead of renewing it
+	stores.Delete(10)
+	if _, err := uc.Refresh(refreshed.RefreshToken); err != nil {
+		t.Fatalf("Refresh: unexpected error %v", err)
+	}
+	if session := sessions.sessions[0]; session.FranchiseID != 0 || session.StoreID != 0 {
+		t.Errorf("Expected the lost store to clear the context, got franchise %d store %d", session.FranchiseID, session.StoreID)
+	}
+
+	// The user left the franchise
+	users.users[1].UserRoles = nil
+	if _, err := uc.Refresh(admin.RefreshToken); err != nil {
+		t.Fatalf("Refresh: unexpected error %v", err)
+	}
+	if session := sessions.sessions[1]; session.FranchiseID != 0 {
+		t.Errorf("Expected the lost franchise to clear the context, got franchise %d", session.FranchiseID)
+	}
+}
+
+func TestAuthUseCase_LogoutAndInactiveUser(t *testing.T) {
+	uc, users, _ := newTestAuthUseCase(t)
+
+	first, _ := uc.Login("ana@loopi.co", "secret123", "10.0.0.1")
+	second, _ := uc.Login("ana@loopi.co", "secret123", "10.0.0.1")
+
+	if err := uc.Logout(1, 1, false); err != nil {
+		t.Fatalf("Logout: unexpected error %v", err)
+	}
+	if _, err := uc.Refresh(first.RefreshToken); err == nil {
+		t.Error("Expected refresh of a logged out session to fail")
+	}
+	if err := uc.ValidateSession(2, 1); err != nil {
+		t.Errorf("Expected the other session to stay active, got %v", err)
+	}
+	if err := uc.ValidateSession(2, 99); err == nil {
+		t.Error("Expected a session of another user to be rejected")
+	}
+
+	// A deactivated user cannot refresh, and the session is ended
+	users.users[0].IsActive = false
+	if _, err := uc.Refresh(second.RefreshToken); err == nil {
+		t.Error("Expected refresh of an inactive user to fail")
+	}
+	if err := uc.ValidateSession(2, 1); err == nil {
+		t.Error("Expected the inactive user's session to be revoked")
+	}
+	if _, err := uc.Login("ana@loopi.co", "secret123", "10.0.0.1"); err == nil {
+		t.Error("Expected login of an inactive user to fail")
+	}
+}
+
+func TestAuthUseCase_ChangePasswordClearsForcedChange(t *testing.T) {
+	uc, users, _ := newTestAuthUseCase(t)
+	users.users[0].MustChangePassword = true
+
+	login, _ := uc.Login("ana@loopi.co", "secret123", "10.0.0.1")
+	if !login.MustChangePassword {
+		t.Fatal("Expected the login to report a required password change")
+	}
+
+	if _, err := uc.ChangePassword(1, 1, "wrong-password", "newpass2025"); err == nil {
+		t.Error("Expected a wrong current password to be rejected")
+	}
+	if _, err := uc.ChangePassword(1, 1, "secret123", "weak"); err == nil {
+		t.Error("Expected a weak password to be rejected")
+	}
+
+	tokens, err := uc.ChangePassword(1, 1, "secret123", "newpass2025")
+	if err != nil {
+		t.Fatalf("ChangePassword: unexpected error %v", err)
+	}
+	if tokens.MustChangePassword || users.users[0].MustChangePassword {
+		t.Error("Expected the forced change flag to be cleared")
+	}
+	if _, err := uc.Login("ana@loopi.co", "newpass2025", "10.0.0.1"); err != nil {
+		t.Errorf("Expected login with the new password, got %v", err)
+	}
+}
+
+func TestAuthUseCase_ChangePasswordRevokesOtherSessions(t *testing.T) {
+	uc, _, _ := newTestAuthUseCase(t)
+
+	current, _ := uc.Login("ana@loopi.co", "secret123", "10.0.0.1")
+	other, _ := uc.Login("ana@loopi.co", "secret123", "10.0.0.2")
+
+	if _, err := uc.ChangePassword(1, 1, "secret123", "newpass2025"); err != nil {
+		t.Fatalf("ChangePassword: unexpected error %v", err)
+	}
+	if err := uc.ValidateSession(2, 1); err == nil {
+		t.Error("Expected the other session to be revoked")
+	}
+	if _, err := uc.Refresh(other.RefreshToken); err == nil {
+		t.Error("Expected the other session's refresh token to be rejected")
+	}
+	if _, err := uc.Refresh(current.RefreshToken); err != nil {
+		t.Errorf("Expected the current session to stay active, got %v", err)
+	}
+}
+
+func TestAuthUseCase_ResetPasswordIsSingleUse(t *testing.T) {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	users := &MockUserRepository{users: []domain.User{
+		{BaseEntity: domain.BaseEntity{ID: 1}, Email: "ana@loopi.co", PasswordHash: string(hash), IsActive: true},
+	}}
+	sessions := &MockAuthSessionRepository{}
+	notifier := &MockNotifier{}
+	uc := newAuthUseCaseWith(t, users, repotesting.NewMockStoreRepository(), sessions, notifier, &MockRoleRepository{})
+
+	if err := uc.RequestPasswordReset("nobody@loopi.co"); err != nil || len(notifier.messages) != 0 {
+		t.Fatalf("Expected unknown emails to succeed silently, got %v and %d messages", err, len(notifier.messages))
+	}
+
+	login, _ := uc.Login("ana@loopi.co", "secret123", "10.0.0.1")
+	if err := uc.RequestPasswordReset("ana@loopi.co"); err != nil {
+		t.Fatalf("RequestPasswordReset: unexpected error %v", err)
+	}
+	if len(notifier.messages) != 1 {
+		t.Fatalf("Expected one notification, got %d", len(notifier.messages))
+	}
+	body := notifier.messages[0].Body
+	token := body[strings.Index(body, ": ")+2 : strings.Index(body, "\n")]
+
+	if err := uc.ResetPassword(token, "newpass2025"); err != nil {
+		t.Fatalf("ResetPassword: unexpected error %v", err)
+	}
+	if err := uc.ResetPassword(token, "another2025"); err == nil {
+		t.Error("Expected a used reset token to be rejected")
+	}
+	if _, err := uc.Refresh(login.RefreshToken); err == nil {
+		t.Error("Expected existing sessions to be revoked after a reset")
+	}
+	if _, err := uc.Login("ana@loopi.co", "newpass2025", "10.0.0.1"); err != nil {
+		t.Errorf("Expected login with the new password, got %v", err)
+	}
+}
+
+func TestAuthUseCase_LoginErrorsAreUniformAndLockOut(t *testing.T) {
+	uc, _, _ := newTestAuthUseCase(t)
+
+	_, unknown := uc.Login("nobody@loopi.co", "secret123", "10.0.0.1")
+	_, wrong := uc.Login("ana@loopi.co", "wrong-password", "10.0.0.1")
+	if unknown == nil || wrong == nil || unknown.Error() != wrong.Error() {
+		t.Fatalf("Expected identical errors for unknown email and wrong password, got %v and %v", unknown, wrong)
+	}
+
+	for i := 1; i < DefaultAccountLockout.MaxFailures; i++ {
+		uc.Login("ana@loopi.co", "wrong-password", "10.0.0.1")
+	}
+	_, err := uc.Login("ana@loopi.co", "secret123", "10.0.0.2")
+	if status := domainStatus(t, err); status != 429 {
+		t.Fatalf("Expected a locked account even with the right password, got %d", status)
+	}
+	if status := uc.GetLockout("ana@loopi.co"); status.Failures != DefaultAccountLockout.MaxFailures {
+		t.Errorf("Expected %d failures, got %+v", DefaultAccountLockout.MaxFailures, status)
+	}
+
+	if err := uc.Unlock("ana@loopi.co", ""); err != nil {
+		t.Fatalf("Unlock: unexpected error %v", err)
+	}
+	if _, err := uc.Login("ana@loopi.co", "secret123", "10.0.0.2"); err != nil {
+		t.Errorf("Expected login after unlock, got %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/utils"
+	"strings"
+	"time"
+)
+
+// maxAvailabilityOverrideLength bounds the reason recorded on an assignment
+const maxAvailabilityOverrideLength = 255
+
+// AvailabilityChecker checks proposed shifts against the weekly windows and date exceptions
+// declared by each employee. Unavailability blocks an assignment unless a reason is given,
+// which is then recorded on the assignment; a "prefer not" window never blocks.
+type AvailabilityChecker struct {
+	repo         repository.AvailabilityRepository
+	errorHandler *base.ErrorHandler
+	logger       *base.Logger
+}
+
+func NewAvailabilityChecker(repo repository.AvailabilityRepository) *AvailabilityChecker {
+	return &AvailabilityChecker{
+		repo:         repo,
+		errorHandler: base.NewErrorHandler("Availability"),
+		logger:       base.NewLogger("Availability"),
+	}
+}
+
+// Load returns the weekly windows of an employee and the exceptions between from and to
+func (c *AvailabilityChecker) Load(operation string, employeeID int, from, to string) (domain.EmployeeAvailability, error) {
+	availability := domain.EmployeeAvailability{EmployeeID: employeeID}
+
+	windows, err := c.repo.GetWindowsByUser(employeeID)
+	if err != nil {
+		c.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return availability, c.errorHandler.HandleRepositoryError(operation, err)
+	}
+	exceptions, err := c.repo.GetExceptionsByUserAndDateRange(employeeID, from, to)
+	if err != nil {
+		c.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return availability, c.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	availability.Windows = windows
+	availability.Exceptions = exceptions
+	return availability, nil
+}
+
+// Validate rejects proposed shifts that fall in a time the employee is unavailable unless an
+// override reason is given. The reason is copied onto each affected shift of proposed.
+func (c *AvailabilityChecker) Validate(operation string, employeeID int, proposed []domain.AssignedShift, override string) error {
+	override = strings.TrimSpace(override)
+	if len(override) > maxAvailabilityOverrideLength {
+		return c.errorHandler.HandleValidationError(operation,
+			fmt.Errorf("availability_override cannot exceed %d characters", maxAvailabilityOverrideLength))
+	}
+
+	byShift, err := c.conflictsByShift(operation, employeeID, proposed)
+	if err != nil {
+		return err
+	}
+
+	blocked := make(map[string]utils.AvailabilityConflict)
+	for i, conflicts := range byShift {
+		for _, conflict := range conflicts {
+			if !conflict.Blocking() {
+				c.logger.LogBusinessRule(operation, "availability_preference", "ignored", map[string]interface{}{
+					"employee_id": employeeID,
+					"date":        conflict.Date,
+					"window":      conflict.StartTime + "-" + conflict.EndTime,
+				})
+				continue
+			}
+			if _, ok := blocked[proposed[i].Date]; !ok {
+				blocked[proposed[i].Date] = conflict
+			}
+		}
+	}
+	if len(blocked) == 0 {
+		return nil
+	}
+
+	if override == "" {
+		conflict := firstConflict(proposed, blocked)
+		message := fmt.Sprintf("employee %d is not available on %s from %s to %s (%s)",
+			employeeID, conflict.Date, conflict.StartTime, conflict.EndTime, conflict.Source)
+		if conflict.Detail != "" {
+			message += ": " + conflict.Detail
+		}
+		if len(blocked) > 1 {
+			message += fmt.Sprintf(" and on %d more days", len(blocked)-1)
+		}
+		c.logger.LogBusinessRule(operation, "availability", "violated", map[string]interface{}{
+			"employee_id": employeeID,
+			"dates":       len(blocked),
+		})
+		return c.errorHandler.HandleBusinessRuleViolation(operation, "availability",
+			message+"; set availability_override with the reason to assign anyway")
+	}
+
+	for i := range proposed {
+		if _, ok := blocked[proposed[i].Date]; ok {
+			proposed[i].AvailabilityOverride = override
+		}
+	}
+	c.logger.LogBusinessRule(operation, "availability", "overridden", map[string]interface{}{
+		"employee_id": employeeID,
+		"dates":       len(blocked),
+		"reason":      override,
+	})
+	return nil
+}
+
+// conflictsByShift loads the availability covering the proposed shifts once and returns
+// the conflicts of each shift, in the same order
+func (c *AvailabilityChecker) conflictsByShift(operation string, employeeID int, proposed []domain.AssignedShift) ([][]utils.AvailabilityConflict, error) {
+	if len(proposed) == 0 {
+		return nil, nil
+	}
+
+	days := make([]time.Time, len(proposed))
+	for i, a := range proposed {
+		day, err := time.Parse("2006-01-02", a.Date)
+		if err != nil {
+			return nil, c.errorHandler.HandleValidationError(operation, fmt.Errorf("invalid date: %q", a.Date))
+		}
+		days[i] = day
+	}
+
+	from, to := days[0], days[0]
+	for _, day := range days {
+		if day.Before(from) {
+			from = day
+		}
+		if day.After(to) {
+			to = day
+		}
+	}
+	// Overnight shifts reach into the day after the last one
+	availability, err := c.Load(operation, employeeID, from.Format("2006-01-02"), to.AddDate(0, 0, 1).Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	byShift := make([][]utils.AvailabilityConflict, len(proposed))
+	for i, a := range proposed {
+		byShift[i] = utils.ShiftAvailabilityConflicts(days[i], a.StartTime, a.EndTime, availability.Windows, availability.Exceptions)
+	}
+	return byShift, nil
+}
+
+// firstConflict returns the blocking conflict of the earliest proposed shift
+func firstConflict(proposed []domain.AssignedShift, blocked map[string]utils.AvailabilityConflict) utils.AvailabilityConflict {
+	first := ""
+	for _, a := range proposed {
+		if _, ok := blocked[a.Date]; ok && (first == "" || a.Date < first) {
+			first = a.Date
+		}
+	}
+	return blocked[first]
+}
@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
+	"strings"
+	"time"
+)
+
+const (
+	// maxAvailabilityWindows bounds the weekly pattern of an employee
+	maxAvailabilityWindows = 50
+	// availabilityHorizonDays is how far ahead exceptions are listed when no range is given
+	availabilityHorizonDays = 90
+	// maxAvailabilityRangeDays bounds the exceptions listed in a single request
+	maxAvailabilityRangeDays = 366
+)
+
+// AvailabilityUseCase manages when each employee can work: recurring weekly windows in which
+// they are unavailable or prefer not to work, plus exceptions for specific dates.
+// Assignments and roster generation check it through the AvailabilityChecker.
+type AvailabilityUseCase interface {
+	// Standard operations, scoped to the caller's franchise
+	GetAvailability(scope domain.TenantScope, employeeID int, from, to string) (*domain.EmployeeAvailability, error)
+	SetWindows(scope domain.TenantScope, employeeID int, req dto.AvailabilityWindowsRequest) ([]domain.AvailabilityWindow, error)
+	GetException(scope domain.TenantScope, id int) (*domain.AvailabilityException, error)
+	CreateException(scope domain.TenantScope, createdBy, employeeID int, req dto.AvailabilityExceptionRequest) (*domain.AvailabilityException, error)
+	DeleteException(scope domain.TenantScope, id int) error
+
+	// Business-specific operations
+	ValidateWindow(req dto.AvailabilityWindowRequest) error
+	ValidateExceptionRequest(req dto.AvailabilityExceptionRequest) error
+}
+
+type availabilityUseCase struct {
+	repo         repository.AvailabilityRepository
+	checker      *AvailabilityChecker
+	tenant       *TenantGuard
+	now          func() time.Time
+	errorHandler *base.ErrorHandler
+	validator    *base.Validator
+	logger       *base.Logger
+}
+
+func NewAvailabilityUseCase(repo repository.AvailabilityRepository, tenant *TenantGuard) AvailabilityUseCase {
+	return &availabilityUseCase{
+		repo:         repo,
+		checker:      NewAvailabilityChecker(repo),
+		tenant:       tenant,
+		now:          time.Now,
+		errorHandler: base.NewErrorHandler("Availability"),
+		validator:    base.NewValidator(),
+		logger:       base.NewLogger("Availability"),
+	}
+}
+
+// ✅ Standard operations
+
+// GetAvailability returns the weekly windows of an employee and the exceptions between from
+// and to. Without a range, the exceptions from today over the next availabilityHorizonDays.
+func (uc *availabilityUseCase) GetAvailability(scope domain.TenantScope, employeeID int, from, to string) (*domain.EmployeeAvailability, error) {
+	uc.logger.LogOperation("GetAvailability", "start", map[string]interface{}{
+		"employee_id": employeeID,
+		"from":        from,
+		"to":          to,
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetAvailability", err)
+	}
+	if err := uc.tenant.RequireEmployee("GetAvailability", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	from, to, err := uc.exceptionRange(from, to)
+	if err != nil {
+		uc.logger.LogValidation("GetAvailability", "date_range", "failed", map[string]interface{}{"error": err.Error()})
+		return nil, uc.errorHandler.HandleValidationError("GetAvailability", err)
+	}
+
+	availability, err := uc.checker.Load("GetAvailability", employeeID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.LogOperation("GetAvailability", "success", map[string]interface{}{
+		"employee_id": employeeID,
+		"windows":     len(availability.Windows),
+		"exceptions":  len(availability.Exceptions),
+	})
+
+	return &availability, nil
+}
+
+// SetWindows replaces the whole weekly pattern of an employee
+func (uc *availabilityUseCase) SetWindows(scope domain.TenantScope, employeeID int, req dto.AvailabilityWindowsRequest) ([]domain.AvailabilityWindow, error) {
+	uc.logger.LogOperation("SetWindows", "start", map[string]interface{}{
+		"employee_id": employeeID,
+		"windows":     len(req.Windows),
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("SetWindows", err)
+	}
+	if len(req.Windows) > maxAvailabilityWindows {
+		err := fmt.Errorf("cannot set more than %d weekly windows, got: %d", maxAvailabilityWindows, len(req.Windows))
+		uc.logger.LogValidation("SetWindows", "max_windows", "failed", map[string]interface{}{"error": err.Error()})
+		return nil, uc.errorHandler.HandleValidationError("SetWindows", err)
+	}
+
+	windows := make([]domain.AvailabilityWindow, 0, len(req.Windows))
+	for i, w := range req.Windows {
+		if err := uc.ValidateWindow(w); err != nil {
+			uc.logger.LogValidation("SetWindows", "window", "failed", map[string]interface{}{
+				"error": err.Error(),
+				"index": i,
+			})
+			return nil, uc.errorHandler.HandleValidationError("SetWindows", fmt.Errorf("windows[%d]: %w", i, err))
+		}
+		windows = append(windows, domain.AvailabilityWindow{
+			UserID:    employeeID,
+			Weekday:   w.Weekday,
+			StartTime: w.StartTime,
+			EndTime:   w.EndTime,
+			Type:      w.Type,
+			Note:      strings.TrimSpace(w.Note),
+		})
+	}
+
+	if err := uc.tenant.RequireEmployee("SetWindows", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.ReplaceWindows(employeeID, windows); err != nil {
+		uc.logger.LogError("SetWindows", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("SetWindows", err)
+	}
+
+	uc.logger.LogOperation("SetWindows", "success", map[string]interface{}{
+		"employee_id": employeeID,
+		"windows":     len(windows),
+	})
+
+	return windows, nil
+}
+
+// GetException retrieves an availability exception of the franchise
+func (uc *availabilityUseCase) GetException(scope domain.TenantScope, id int) (*domain.AvailabilityException, error) {
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetException", err)
+	}
+
+	exception, err := uc.repo.GetExceptionByID(id)
+	if err != nil {
+		uc.logger.LogError("GetException", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("GetException", err)
+	}
+
+	if err := uc.tenant.RequireEmployee("GetException", scope, exception.UserID); err != nil {
+		return nil, err
+	}
+
+	return exception, nil
+}
+
+// CreateException records a change of availability of an employee on a date
+func (uc *availabilityUseCase) CreateException(scope domain.TenantScope, createdBy, employeeID int, req dto.AvailabilityExceptionRequest) (*domain.AvailabilityException, error) {
+	uc.logger.LogOperation("CreateException", "start", map[string]interface{}{
+		"employee_id": employeeID,
+		"date":        req.Date,
+		"type":        req.Type,
+		"created_by":  createdBy,
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("CreateException", err)
+	}
+	if err := uc.ValidateExceptionRequest(req); err != nil {
+		uc.logger.LogValidation("CreateException", "request", "failed", map[string]interface{}{"error": err.Error()})
+		return nil, uc.errorHandler.HandleValidationError("CreateException", err)
+	}
+	if err := uc.tenant.RequireEmployee("CreateException", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	exception := &domain.AvailabilityException{
+		UserID:    employeeID,
+		Date:      req.Date,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Type:      req.Type,
+		Reason:    strings.TrimSpace(req.Reason),
+		CreatedBy: createdBy,
+	}
+	if err := uc.repo.CreateException(exception); err != nil {
+		uc.logger.LogError("CreateException", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("CreateException", err)
+	}
+
+	uc.logger.LogOperation("CreateException", "success", map[string]interface{}{
+		"id":          exception.ID,
+		"employee_id": employeeID,
+		"date":        exception.Date,
+	})
+
+	return exception, nil
+}
+
+// DeleteException removes an availability exception; assignments already made are kept
+func (uc *availabilityUseCase) DeleteException(scope domain.TenantScope, id int) error {
+	uc.logger.LogOperation("DeleteException", "start", map[string]interface{}{"id": id})
+
+	if _, err := uc.GetException(scope, id); err != nil {
+		return err
+	}
+
+	if err := uc.repo.DeleteException(id); err != nil {
+		uc.logger.LogError("DeleteException", err, map[string]interface{}{"id": id})
+		return uc.errorHandler.HandleRepositoryError("DeleteException", err)
+	}
+
+	uc.logger.LogOperation("DeleteException", "success", map[string]interface{}{"id": id})
+	return nil
+}
+
+// ✅ Business-specific operations
+
+// ValidateWindow validates a weekly window: a weekday, a range within the day and its type
+func (uc *availabilityUseCase) ValidateWindow(req dto.AvailabilityWindowRequest) error {
+	if req.Weekday < 0 || req.Weekday > 6 {
+		return fmt.Errorf("invalid weekday: %d. Must be between 0 (Sunday) and 6 (Saturday)", req.Weekday)
+	}
+	if err := validateAvailabilityRange(req.StartTime, req.EndTime); err != nil {
+		return err
+	}
+	if req.Type != domain.AvailabilityUnavailable && req.Type != domain.AvailabilityPreferNot {
+		return fmt.Errorf("invalid type: %q. Must be %q or %q", req.Type, domain.AvailabilityUnavailable, domain.AvailabilityPreferNot)
+	}
+	return uc.validator.ValidateString(req.Note, "note", "max:255")
+}
+
+// ValidateExceptionRequest validates a date exception: a date, an optional range and its type
+func (uc *availabilityUseCase) ValidateExceptionRequest(req dto.AvailabilityExceptionRequest) error {
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		return fmt.Errorf("invalid date format: %q. Expected format: YYYY-MM-DD", req.Date)
+	}
+	if req.StartTime != "" || req.EndTime != "" {
+		if err := validateAvailabilityRange(req.StartTime, req.EndTime); err != nil {
+			return err
+		}
+	}
+	if req.Type != domain.AvailabilityUnavailable && req.Type != domain.AvailabilityAvailable {
+		return fmt.Errorf("invalid type: %q. Must be %q or %q", req.Type, domain.AvailabilityUnavailable, domain.AvailabilityAvailable)
+	}
+	return uc.validator.ValidateString(req.Reason, "reason", "max:255")
+}
+
+// validateAvailabilityRange requires "HH:MM" times with the start before the end of the same day
+func validateAvailabilityRange(startTime, endTime string) error {
+	start, err := utils.ParseClockMinutes(startTime)
+	if err != nil {
+		return fmt.Errorf("start_time: %w", err)
+	}
+	end, err := utils.ParseClockMinutes(endTime)
+	if err != nil {
+		return fmt.Errorf("end_time: %w", err)
+	}
+	if start >= end {
+		return fmt.Errorf("start_time (%s) must be before end_time (%s); split overnight windows at 24:00", startTime, endTime)
+	}
+	return nil
+}
+
+// exceptionRange fills the default range and checks its bounds
+func (uc *availabilityUseCase) exceptionRange(from, to string) (string, string, error) {
+	today := dateOf(uc.now())
+	if from == "" {
+		from = today.Format("2006-01-02")
+	}
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid from date: %q. Expected format: YYYY-MM-DD", from)
+	}
+	if to == "" {
+		to = fromDate.AddDate(0, 0, availabilityHorizonDays).Format("2006-01-02")
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid to date: %q. Expected format: YYYY-MM-DD", to)
+	}
+
+	if fromDate.After(toDate) {
+		return "", "", fmt.Errorf("from date (%s) cannot be after to date (%s)", from, to)
+	}
+	if days := int(toDate.Sub(fromDate).Hours()/24) + 1; days > maxAvailabilityRangeDays {
+		return "", "", fmt.Errorf("date range cannot exceed %d days, got: %d", maxAvailabilityRangeDays, days)
+	}
+	return from, to, nil
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"testing"
+
+	"loopi-api/internal/domain"
+	"loopi-api/internal/usecase/dto"
+)
+
+// MockAvailabilityRepository keeps the windows and exceptions of every user in memory
+type MockAvailabilityRepository struct {
+	windows    []domain.AvailabilityWindow
+	exceptions []domain.AvailabilityException
+}
+
+func (m *MockAvailabilityRepository) GetWindowsByUser(userID int) ([]domain.AvailabilityWindow, error) {
+	var windows []domain.AvailabilityWindow
+	for _, w := range m.windows {
+		if w.UserID == userID {
+			windows = append(windows, w)
+		}
+	}
+	return windows, nil
+}
+func (m *MockAvailabilityRepository) ReplaceWindows(userID int, windows []domain.AvailabilityWindow) error {
+	kept := windows
+	for _, w := range m.windows {
+		if w.UserID != userID {
+			kept = append(kept, w)
+		}
+	}
+	m.windows = kept
+	return nil
+}
+func (m *MockAvailabilityRepository) GetExceptionByID(id int) (*domain.AvailabilityException, error) {
+	for i := range m.exceptions {
+		if int(m.exceptions[i].ID) == id {
+			return &m.exceptions[i], nil
+		}
+	}
+	return nil, nil
+}
+func (m *MockAvailabilityRepository) CreateException(exception *domain.AvailabilityException) error {
+	exception.ID = uint(len(m.exceptions) + 1)
+	m.exceptions = append(m.exceptions, *exception)
+	return nil
+}
+func (m *MockAvailabilityRepository) DeleteException(id int) error {
+	return nil
+}
+func (m *MockAvailabilityRepository) GetExceptionsByUserAndDateRange(userID int, from, to string) ([]domain.AvailabilityException, error) {
+	var exceptions []domain.AvailabilityException
+	for _, e := range m.exceptions {
+		if e.UserID == userID && e.Date >= from && e.Date <= to {
+			exceptions = append(exceptions, e)
+		}
+	}
+	return exceptions, nil
+}
+
+func TestAvailabilityUseCase_SetWindowsValidatesEachWindow(t *testing.T) {
+	repo := &MockAvailabilityRepository{}
+	tenant := NewTenantGuard(&MockTenantRepository{employeeFranchises: map[int]int{7: 1}})
+	uc := NewAvailabilityUseCase(repo, tenant)
+	scope := franchiseScope(1)
+
+	invalid := []dto.AvailabilityWindowRequest{
+		{Weekday: 7, StartTime: "06:00", EndTime: "12:00", Type: domain.AvailabilityUnavailable},
+		{Weekday: 0, StartTime: "22:00", EndTime: "06:00", Type: domain.AvailabilityUnavailable},
+		{Weekday: 0, StartTime: "6am", EndTime: "12:00", Type: domain.AvailabilityUnavailable},
+		{Weekday: 0, StartTime: "06:00", EndTime: "12:00", Type: domain.AvailabilityAvailable},
+	}
+	for _, w := range invalid {
+		req := dto.AvailabilityWindowsRequest{Windows: []dto.AvailabilityWindowRequest{w}}
+		if _, err := uc.SetWindows(scope, 7, req); domainStatus(t, err) != 400 {
+			t.Errorf("Expected window %+v to be rejected", w)
+		}
+	}
+
+	// Sunday mornings off, Tuesday evenings at class
+	req := dto.AvailabilityWindowsRequest{Windows: []dto.AvailabilityWindowRequest{
+		{Weekday: 0, StartTime: "06:00", EndTime: "12:00", Type: domain.AvailabilityUnavailable},
+		{Weekday: 2, StartTime: "18:00", EndTime: "24:00", Type: domain.AvailabilityPreferNot, Note: "Clases"},
+	}}
+	if _, err := uc.SetWindows(scope, 7, req); err != nil {
+		t.Fatalf("Expected windows to be stored, got: %v", err)
+	}
+	if _, err := uc.SetWindows(franchiseScope(2), 7, req); domainStatus(t, err) != 404 {
+		t.Error("Expected an employee of another franchise to be out of scope")
+	}
+
+	availability, err := uc.GetAvailability(scope, 7, "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(availability.Windows) != 2 {
+		t.Errorf("Expected 2 windows, got %d", len(availability.Windows))
+	}
+}
+
+func TestAvailabilityChecker_BlocksUnavailabilityUnlessOverridden(t *testing.T) {
+	repo := &MockAvailabilityRepository{
+		windows: []domain.AvailabilityWindow{
+			{UserID: 7, Weekday: 0, StartTime: "06:00", EndTime: "12:00", Type: domain.AvailabilityUnavailable},
+			{UserID: 7, Weekday: 2, StartTime: "18:00", EndTime: "24:00", Type: domain.AvailabilityPreferNot},
+		},
+		exceptions: []domain.AvailabilityException{
+			// Available this Sunday after all
+			{UserID: 7, Date: "2025-06-08", Type: domain.AvailabilityAvailable},
+		},
+	}
+	checker := NewAvailabilityChecker(repo)
+
+	sunday := []domain.AssignedShift{{EmployeeID: 7, Date: "2025-06-01", StartTime: "06:00", EndTime: "14:00"}}
+	if err := checker.Validate("Assign", 7, sunday, ""); domainStatus(t, err) != 422 {
+		t.Fatalf("Expected the Sunday morning shift to be rejected, got: %v", err)
+	}
+
+	if err := checker.Validate("Assign", 7, sunday, "  Cubre inventario  "); err != nil {
+		t.Fatalf("Expected the override to be accepted, got: %v", err)
+	}
+	if sunday[0].AvailabilityOverride != "Cubre inventario" {
+		t.Errorf("Expected the reason to be recorded, got %q", sunday[0].AvailabilityOverride)
+	}
+
+	// A preference never blocks; the exception lifts the Sunday window; afternoons are free
+	others := []domain.AssignedShift{
+		{EmployeeID: 7, Date: "2025-06-03", StartTime: "14:00", EndTime: "22:00"},
+		{EmployeeID: 7, Date: "2025-06-08", StartTime: "06:00", EndTime: "14:00"},
+		{EmployeeID: 7, Date: "2025-06-15", StartTime: "13:00", EndTime: "21:00"},
+	}
+	if err := checker.Validate("BulkAssign", 7, others, ""); err != nil {
+		t.Fatalf("Expected the shifts to be allowed, got: %v", err)
+	}
+	for _, a := range others {
+		if a.AvailabilityOverride != "" {
+			t.Errorf("Expected no override on %s, got %q", a.Date, a.AvailabilityOverride)
+		}
+	}
+}
+
+func TestAvailabilityChecker_OvernightShiftReachesNextDay(t *testing.T) {
+	repo := &MockAvailabilityRepository{
+		exceptions: []domain.AvailabilityException{
+			{UserID: 7, Date: "2025-06-02", StartTime: "00:00", EndTime: "08:00", Type: domain.AvailabilityUnavailable, Reason: "Examen"},
+		},
+	}
+	checker := NewAvailabilityChecker(repo)
+
+	night := []domain.AssignedShift{{EmployeeID: 7, Date: "2025-06-01", StartTime: "22:00", EndTime: "06:00"}}
+	if err := checker.Validate("Assign", 7, night, ""); domainStatus(t, err) != 422 {
+		t.Errorf("Expected the night shift to be rejected by the next day's exception, got: %v", err)
+	}
+}
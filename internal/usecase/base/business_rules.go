@@ -89,6 +89,31 @@ func (bre *BusinessRuleEngine) ValidateOperation(operation string, entity interf
 	return nil
 }
 
+// RuleViolation is a failed business rule, as reported by CollectViolations
+type RuleViolation struct {
+	Rule    string
+	Message string
+}
+
+// CollectViolations runs every rule registered for the entity type and returns all
+// violations instead of stopping at the first one (useful to flag rather than reject)
+func (bre *BusinessRuleEngine) CollectViolations(entity interface{}, context map[string]interface{}) []RuleViolation {
+	entityType := getEntityTypeName(entity)
+
+	var violations []RuleViolation
+	for _, rule := range bre.rules[entityType] {
+		if err := rule.Validate(entity, context); err != nil {
+			bre.logger.LogBusinessRule("CollectViolations", rule.Name, "violated", map[string]interface{}{
+				"entity_type": entityType,
+				"error":       err.Error(),
+			})
+			violations = append(violations, RuleViolation{Rule: rule.Name, Message: err.Error()})
+		}
+	}
+
+	return violations
+}
+
 // Common business rules factory functions
 
 // CreateRequiredFieldRule creates a rule that validates required fields
@@ -0,0 +1,394 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/calendar"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
+	"strings"
+	"time"
+)
+
+// Employee feeds cover a rolling window around today
+const (
+	feedMonthsBack    = 3
+	feedMonthsForward = 6
+)
+
+type CalendarFeedUseCase interface {
+	// Standard operations, scoped to the caller's franchise
+	CreateFeed(scope domain.TenantScope, req dto.CreateCalendarFeedRequest) (*dto.CalendarFeedSubscription, error)
+	GetByEmployee(scope domain.TenantScope, employeeID int) ([]domain.CalendarFeed, error)
+	Revoke(scope domain.TenantScope, id int) error
+
+	// Business-specific operations
+	RenderFeed(token string) (string, error)
+}
+
+type calendarFeedUseCase struct {
+	feedRepo     repository.CalendarFeedRepository
+	assignedRepo repository.AssignedShiftRepository
+	absenceRepo  repository.AbsenceRepository
+	noveltyRepo  repository.NoveltyRepository
+	userRepo     repository.UserRepository
+	calendar     CalendarUseCase
+	holidays     *HolidayCalendarResolver
+	tenant       *TenantGuard
+	errorHandler *base.ErrorHandler
+	validator    *base.Validator
+	logger       *base.Logger
+}
+
+func NewCalendarFeedUseCase(
+	feedRepo repository.CalendarFeedRepository,
+	assignedRepo repository.AssignedShiftRepository,
+	absenceRepo repository.AbsenceRepository,
+	noveltyRepo repository.NoveltyRepository,
+	userRepo repository.UserRepository,
+	calendarUC CalendarUseCase,
+	holidays *HolidayCalendarResolver,
+	tenant *TenantGuard,
+) CalendarFeedUseCase {
+	return &calendarFeedUseCase{
+		feedRepo:     feedRepo,
+		assignedRepo: assignedRepo,
+		absenceRepo:  absenceRepo,
+		noveltyRepo:  noveltyRepo,
+		userRepo:     userRepo,
+		calendar:     calendarUC,
+		holidays:     holidays,
+		tenant:       tenant,
+		errorHandler: base.NewErrorHandler("CalendarFeed"),
+		validator:    base.NewValidator(),
+		logger:       base.NewLogger("CalendarFeed"),
+	}
+}
+
+// ✅ Enhanced operations with logging, validation, and error handling
+
+// CreateFeed creates a subscription and returns its token; only the token hash is stored
+func (uc *calendarFeedUseCase) CreateFeed(scope domain.TenantScope, req dto.CreateCalendarFeedRequest) (*dto.CalendarFeedSubscription, error) {
+	uc.logger.LogOperation("CreateFeed", "start", map[string]interface{}{
+		"kind":        req.Kind,
+		"employee_id": req.EmployeeID,
+		"store_id":    req.StoreID,
+	})
+
+	feed, err := uc.validateFeedRequest(scope, req)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, uc.errorHandler.HandleInternalError("CreateFeed", err)
+	}
+	feed.TokenHash = utils.HashToken(token)
+
+	if err := uc.feedRepo.Create(feed); err != nil {
+		uc.logger.LogError("CreateFeed", err, map[string]interface{}{"kind": req.Kind})
+		return nil, uc.errorHandler.HandleRepositoryError("CreateFeed", err)
+	}
+
+	uc.logger.LogOperation("CreateFeed", "success", map[string]interface{}{
+		"id":   feed.ID,
+		"kind": feed.Kind,
+	})
+
+	return &dto.CalendarFeedSubscription{
+		Feed:  *feed,
+		Token: token,
+		Path:  "/feeds/" + token + ".ics",
+	}, nil
+}
+
+// GetByEmployee lists the feeds of an employee, revoked ones included
+func (uc *calendarFeedUseCase) GetByEmployee(scope domain.TenantScope, employeeID int) ([]domain.CalendarFeed, error) {
+	uc.logger.LogOperation("GetByEmployee", "start", map[string]interface{}{"employee_id": employeeID})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByEmployee", err)
+	}
+
+	if err := uc.tenant.RequireEmployee("GetByEmployee", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	feeds, err := uc.feedRepo.GetByEmployee(employeeID)
+	if err != nil {
+		uc.logger.LogError("GetByEmployee", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByEmployee", err)
+	}
+
+	uc.logger.LogOperation("GetByEmployee", "success", map[string]interface{}{
+		"employee_id": employeeID,
+		"count":       len(feeds),
+	})
+
+	return feeds, nil
+}
+
+// Revoke invalidates a feed's subscription URL.
+// Feeds can only be revoked from the franchise that created them.
+func (uc *calendarFeedUseCase) Revoke(scope domain.TenantScope, id int) error {
+	uc.logger.LogOperation("Revoke", "start", map[string]interface{}{"id": id})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		return uc.errorHandler.HandleValidationError("Revoke", err)
+	}
+
+	feed, err := uc.feedRepo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("Revoke", err, map[string]interface{}{"id": id})
+		return uc.errorHandler.HandleRepositoryError("Revoke", err)
+	}
+	if err := uc.requireFeedScope("Revoke", scope, feed); err != nil {
+		return err
+	}
+
+	if err := uc.feedRepo.Revoke(id); err != nil {
+		uc.logger.LogError("Revoke", err, map[string]interface{}{"id": id})
+		return uc.errorHandler.HandleRepositoryError("Revoke", err)
+	}
+
+	uc.logger.LogOperation("Revoke", "success", map[string]interface{}{"id": id})
+	return nil
+}
+
+// RenderFeed renders the iCalendar of a subscription token.
+// Unknown and revoked tokens are reported as not found.
+func (uc *calendarFeedUseCase) RenderFeed(token string) (string, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", uc.errorHandler.HandleNotFound("RenderFeed", "calendar feed not found")
+	}
+
+	feed, err := uc.feedRepo.GetByTokenHash(utils.HashToken(token))
+	if err != nil || feed.RevokedAt != nil {
+		return "", uc.errorHandler.HandleNotFound("RenderFeed", "calendar feed not found")
+	}
+
+	uc.logger.LogOperation("RenderFeed", "start", map[string]interface{}{
+		"id":   feed.ID,
+		"kind": feed.Kind,
+	})
+
+	now := time.Now()
+	var ical utils.ICalendar
+	switch feed.Kind {
+	case domain.CalendarFeedHolidays:
+		ical, err = uc.holidayCalendar(feed, now)
+	case domain.CalendarFeedEmployee:
+		ical, err = uc.employeeCalendar(feed, now)
+	default:
+		err = uc.errorHandler.HandleInternalError("RenderFeed", fmt.Errorf("unknown feed kind: %s", feed.Kind))
+	}
+	if err != nil {
+		uc.logger.LogError("RenderFeed", err, map[string]interface{}{"id": feed.ID})
+		return "", err
+	}
+
+	uc.logger.LogOperation("RenderFeed", "success", map[string]interface{}{
+		"id":     feed.ID,
+		"events": len(ical.Events),
+	})
+
+	return ical.Render(now), nil
+}
+
+// holidayCalendar renders the holidays of the previous, current and next year as all-day events
+func (uc *calendarFeedUseCase) holidayCalendar(feed *domain.CalendarFeed, now time.Time) (utils.ICalendar, error) {
+	storeID := 0
+	if feed.StoreID != nil {
+		storeID = int(*feed.StoreID)
+	}
+	scope, err := uc.calendar.ResolveScope(feed.Country, storeID)
+	if err != nil {
+		return utils.ICalendar{}, err
+	}
+
+	ical := utils.ICalendar{Name: "Holidays " + scope.Country}
+	for year := now.Year() - 1; year <= now.Year()+1; year++ {
+		holidays, err := uc.calendar.GetHolidayDetails(scope, year, 0)
+		if err != nil {
+			return utils.ICalendar{}, err
+		}
+		for i, holiday := range holidays {
+			uid := fmt.Sprintf("holiday-%s-%s-%d@loopi-api", scope.Country, holiday.Date.Format("20060102"), i)
+			event := utils.AllDayICalEvent(uid, holiday.Name, holiday.Date)
+			event.Categories = []string{holiday.Category}
+			if holiday.Moved && holiday.OriginalDate != nil {
+				event.Description = "Moved from " + holiday.OriginalDate.Format("2006-01-02")
+			}
+			ical.Events = append(ical.Events, event)
+		}
+	}
+	return ical, nil
+}
+
+// employeeCalendar renders the assigned shifts, absences and novelties of an employee in
+// the rolling window; shift times are read in the time zone of the country of the shift's store
+func (uc *calendarFeedUseCase) employeeCalendar(feed *domain.CalendarFeed, now time.Time) (utils.ICalendar, error) {
+	if feed.EmployeeID == nil {
+		return utils.ICalendar{}, uc.errorHandler.HandleInternalError("RenderFeed", fmt.Errorf("employee feed %d without employee", feed.ID))
+	}
+	employeeID := int(*feed.EmployeeID)
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	from := today.AddDate(0, -feedMonthsBack, 0)
+	to := today.AddDate(0, feedMonthsForward, 0)
+
+	ical := utils.ICalendar{Name: "Schedule"}
+	if user, err := uc.userRepo.FindByID(employeeID); err == nil {
+		ical.Name = strings.TrimSpace(fmt.Sprintf("Schedule %s %s", user.FirstName, user.LastName))
+	}
+
+	shifts, err := uc.assignedRepo.GetByEmployeeAndDateRange(employeeID, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return utils.ICalendar{}, uc.errorHandler.HandleRepositoryError("RenderFeed", err)
+	}
+	locations := make(map[int]*time.Location)
+	for _, shift := range shifts {
+		location, ok := locations[shift.StoreID]
+		if !ok {
+			location, err = uc.holidays.LocationForStore("RenderFeed", shift.StoreID)
+			if err != nil {
+				return utils.ICalendar{}, err
+			}
+			locations[shift.StoreID] = location
+		}
+
+		uid := fmt.Sprintf("shift-%d@loopi-api", shift.ID)
+		summary := fmt.Sprintf("Shift %s-%s", shift.StartTime, shift.EndTime)
+		event, err := utils.ShiftICalEvent(uid, summary, shift.Date, shift.StartTime, shift.EndTime, location)
+		if err != nil {
+			uc.logger.LogError("RenderFeed", err, map[string]interface{}{"assigned_shift_id": shift.ID})
+			continue
+		}
+		if shift.LunchMinutes > 0 {
+			event.Description = fmt.Sprintf("Lunch: %d min", shift.LunchMinutes)
+		}
+		event.Categories = []string{"Shift"}
+		ical.Events = append(ical.Events, event)
+	}
+
+	absences, err := uc.absenceRepo.GetByEmployeeAndDateRange(employeeID, from, to)
+	if err != nil {
+		return utils.ICalendar{}, uc.errorHandler.HandleRepositoryError("RenderFeed", err)
+	}
+	for _, absence := range absences {
+		if absence.Status == domain.AbsenceStatusRejected {
+			continue
+		}
+
+		uid := fmt.Sprintf("absence-%d@loopi-api", absence.ID)
+		label := absence.Reason
+		if absence.AbsenceType != nil {
+			label = absence.AbsenceType.Name
+		}
+		summary := fmt.Sprintf("Absence: %s (%.1f h/day)", label, absence.Hours)
+		if absence.Status == domain.AbsenceStatusPending {
+			summary += " [pending]"
+		}
+
+		// A range is a single all-day event ending the day after its last day
+		event := utils.AllDayICalEvent(uid, summary, absence.Date)
+		event.End = utils.AllDayICalEvent(uid, summary, absence.LastDate()).End
+		event.Description = absence.Reason
+		event.Categories = []string{"Absence"}
+		ical.Events = append(ical.Events, event)
+	}
+
+	novelties, err := uc.noveltyRepo.GetByEmployeeAndDateRange(employeeID, from, to)
+	if err != nil {
+		return utils.ICalendar{}, uc.errorHandler.HandleRepositoryError("RenderFeed", err)
+	}
+	for _, novelty := range novelties {
+		sign := "+"
+		if novelty.Type == "negative" {
+			sign = "-"
+		}
+		uid := fmt.Sprintf("novelty-%d@loopi-api", novelty.ID)
+		summary := fmt.Sprintf("Novelty (%s%.1f h)", sign, novelty.Hours)
+		if novelty.Comment != "" {
+			summary += ": " + novelty.Comment
+		}
+		event := utils.AllDayICalEvent(uid, summary, novelty.Date)
+		event.Categories = []string{"Novelty"}
+		ical.Events = append(ical.Events, event)
+	}
+
+	return ical, nil
+}
+
+// requireFeedScope checks that the feed was created in the scope's franchise and that its
+// employee or store is still in scope. Feeds created before the franchise was recorded
+// require the platform permission.
+func (uc *calendarFeedUseCase) requireFeedScope(operation string, scope domain.TenantScope, feed *domain.CalendarFeed) error {
+	if feed.FranchiseID == nil {
+		return uc.tenant.RequirePlatform(operation, scope)
+	}
+	if int(*feed.FranchiseID) != scope.FranchiseID {
+		return uc.errorHandler.HandleNotFound(operation, fmt.Sprintf("calendar feed not found with ID: %d", feed.ID))
+	}
+
+	switch {
+	case feed.EmployeeID != nil:
+		return uc.tenant.RequireEmployee(operation, scope, int(*feed.EmployeeID))
+	case feed.StoreID != nil:
+		return uc.tenant.RequireStore(operation, scope, int(*feed.StoreID))
+	}
+	return nil
+}
+
+// validateFeedRequest checks the request and builds the feed of the scope's franchise without its token
+func (uc *calendarFeedUseCase) validateFeedRequest(scope domain.TenantScope, req dto.CreateCalendarFeedRequest) (*domain.CalendarFeed, error) {
+	if scope.FranchiseID <= 0 {
+		return nil, uc.errorHandler.HandleForbidden("CreateFeed", "a franchise must be selected")
+	}
+	franchiseID := uint(scope.FranchiseID)
+	feed := &domain.CalendarFeed{
+		Kind:        strings.ToLower(strings.TrimSpace(req.Kind)),
+		FranchiseID: &franchiseID,
+	}
+
+	switch feed.Kind {
+	case domain.CalendarFeedHolidays:
+		if req.StoreID > 0 {
+			if err := uc.tenant.RequireStore("CreateFeed", scope, req.StoreID); err != nil {
+				return nil, err
+			}
+			if _, err := uc.holidays.ScopeForStore("CreateFeed", req.StoreID); err != nil {
+				return nil, err
+			}
+			storeID := uint(req.StoreID)
+			feed.StoreID = &storeID
+			return feed, nil
+		}
+		if err := ValidateHolidayCountry(req.Country); err != nil {
+			return nil, uc.errorHandler.HandleValidationError("CreateFeed", err)
+		}
+		feed.Country = calendar.NormalizeCountry(req.Country)
+	case domain.CalendarFeedEmployee:
+		if err := uc.validator.ValidateID(req.EmployeeID); err != nil {
+			return nil, uc.errorHandler.HandleValidationError("CreateFeed", err)
+		}
+		if err := uc.tenant.RequireEmployee("CreateFeed", scope, req.EmployeeID); err != nil {
+			return nil, err
+		}
+		if _, err := uc.userRepo.FindByID(req.EmployeeID); err != nil {
+			uc.logger.LogError("CreateFeed", err, map[string]interface{}{"employee_id": req.EmployeeID})
+			return nil, uc.errorHandler.HandleRepositoryError("CreateFeed", err)
+		}
+		employeeID := uint(req.EmployeeID)
+		feed.EmployeeID = &employeeID
+	default:
+		err := fmt.Errorf("invalid kind: %q. Must be %q or %q", req.Kind, domain.CalendarFeedHolidays, domain.CalendarFeedEmployee)
+		return nil, uc.errorHandler.HandleValidationError("CreateFeed", err)
+	}
+
+	return feed, nil
+}
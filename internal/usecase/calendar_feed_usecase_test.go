@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"loopi-api/internal/domain"
+	repotesting "loopi-api/internal/repository/testing"
+	"loopi-api/internal/usecase/dto"
+)
+
+// MockCalendarFeedRepository keeps the feeds in memory
+type MockCalendarFeedRepository struct {
+	feeds []domain.CalendarFeed
+}
+
+func (m *MockCalendarFeedRepository) GetByID(id int) (*domain.CalendarFeed, error) {
+	for i := range m.feeds {
+		if int(m.feeds[i].ID) == id {
+			feed := m.feeds[i]
+			return &feed, nil
+		}
+	}
+	return nil, errors.New("calendar feed not found")
+}
+func (m *MockCalendarFeedRepository) GetByTokenHash(tokenHash string) (*domain.CalendarFeed, error) {
+	for i := range m.feeds {
+		if m.feeds[i].TokenHash == tokenHash {
+			feed := m.feeds[i]
+			return &feed, nil
+		}
+	}
+	return nil, errors.New("calendar feed not found")
+}
+func (m *MockCalendarFeedRepository) Create(feed *domain.CalendarFeed) error {
+	feed.ID = uint(len(m.feeds) + 1)
+	m.feeds = append(m.feeds, *feed)
+	return nil
+}
+func (m *MockCalendarFeedRepository) GetByEmployee(employeeID int) ([]domain.CalendarFeed, error) {
+	var feeds []domain.CalendarFeed
+	for _, feed := range m.feeds {
+		if feed.EmployeeID != nil && int(*feed.EmployeeID) == employeeID {
+			feeds = append(feeds, feed)
+		}
+	}
+	return feeds, nil
+}
+func (m *MockCalendarFeedRepository) Revoke(id int) error {
+	now := time.Now()
+	m.feeds[id-1].RevokedAt = &now
+	return nil
+}
+
+func TestCalendarFeedUseCase_RevokeRequiresTheCreatingFranchise(t *testing.T) {
+	feeds := &MockCalendarFeedRepository{}
+	stores := newTestStores()
+	holidays := NewHolidayCalendarResolver(stores, &MockFranchiseRepository{}, &MockHolidayRepository{})
+	tenant := NewTenantGuard(&MockTenantRepository{})
+	uc := NewCalendarFeedUseCase(feeds, &MockAssignedShiftRepository{}, repotesting.NewMockAbsenceRepository(),
+		repotesting.NewMockNoveltyRepository(), &MockUserRepository{}, nil, holidays, tenant)
+
+	subscription, err := uc.CreateFeed(franchiseScope(1), dto.CreateCalendarFeedRequest{Kind: "holidays", Country: "CO"})
+	if err != nil {
+		t.Fatalf("CreateFeed: unexpected error %v", err)
+	}
+	if subscription.Feed.FranchiseID == nil || *subscription.Feed.FranchiseID != 1 {
+		t.Fatalf("Expected the feed to record franchise 1, got %v", subscription.Feed.FranchiseID)
+	}
+	id := int(subscription.Feed.ID)
+
+	// A country feed has no employee or store to check: the franchise decides
+	if err := uc.Revoke(franchiseScope(2), id); domainStatus(t, err) != 404 {
+		t.Errorf("Expected another franchise not to find the feed, got %v", err)
+	}
+	if err := uc.Revoke(franchiseScope(1), id); err != nil {
+		t.Errorf("Expected the creating franchise to revoke the feed, got %v", err)
+	}
+
+	// Feeds created before the franchise was recorded are left to the platform
+	feeds.Create(&domain.CalendarFeed{Kind: domain.CalendarFeedHolidays, Country: "CO"})
+	if err := uc.Revoke(franchiseScope(1), 2); domainStatus(t, err) != 403 {
+		t.Errorf("Expected a feed without franchise to require the platform permission, got %v", err)
+	}
+	if err := uc.Revoke(domain.TenantScope{FranchiseID: 1, Platform: true}, 2); err != nil {
+		t.Errorf("Expected the platform to revoke a feed without franchise, got %v", err)
+	}
+}
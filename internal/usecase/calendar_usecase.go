@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"fmt"
+	"loopi-api/internal/cache"
 	"loopi-api/internal/calendar"
 	"loopi-api/internal/usecase/base"
 	"time"
@@ -9,6 +10,7 @@ import (
 
 // CalendarSummary represents comprehensive calendar data for a month
 type CalendarSummary struct {
+	Country      string      `json:"country"`
 	Year         int         `json:"year"`
 	Month        int         `json:"month"`
 	Holidays     []time.Time `json:"holidays"`
@@ -19,27 +21,32 @@ type CalendarSummary struct {
 
 type CalendarUseCase interface {
 	// Standard operations
-	GetHolidays(year int) ([]time.Time, error)
-	GetHolidaysByMonth(year int, month int) ([]time.Time, error)
+	GetHolidays(scope CalendarScope, year int) ([]time.Time, error)
+	GetHolidaysByMonth(scope CalendarScope, year int, month int) ([]time.Time, error)
+	GetHolidayDetails(scope CalendarScope, year int, month int) ([]calendar.Holiday, error)
 	CountOrdinaryDays(year int, month int) (int, error)
 	CountSundays(year int, month int) (int, error)
 
 	// Business-specific operations
-	GetMonthSummary(year int, month int) (*CalendarSummary, error)
-	GetWorkingDays(year int, month int) (int, error)
+	GetMonthSummary(scope CalendarScope, year int, month int) (*CalendarSummary, error)
+	GetWorkingDays(scope CalendarScope, year int, month int) (int, error)
+	ResolveScope(country string, storeID int) (CalendarScope, error)
 	ValidateYear(year int) error
 	ValidateMonth(month int) error
 	ClearCache() error
+	GetCacheStats() map[string]cache.Stats
 }
 
 type calendarUseCase struct {
+	holidays     *HolidayCalendarResolver
 	errorHandler *base.ErrorHandler
 	validator    *base.Validator
 	logger       *base.Logger
 }
 
-func NewCalendarUseCase() CalendarUseCase {
+func NewCalendarUseCase(holidays *HolidayCalendarResolver) CalendarUseCase {
 	return &calendarUseCase{
+		holidays:     holidays,
 		errorHandler: base.NewErrorHandler("Calendar"),
 		validator:    base.NewValidator(),
 		logger:       base.NewLogger("Calendar"),
@@ -48,10 +55,12 @@ func NewCalendarUseCase() CalendarUseCase {
 
 // ✅ Enhanced operations with logging, validation, and error handling
 
-// GetHolidays retrieves all holidays for a specific year with validation
-func (uc *calendarUseCase) GetHolidays(year int) ([]time.Time, error) {
+// GetHolidays retrieves all holidays of a calendar scope for a specific year with validation
+func (uc *calendarUseCase) GetHolidays(scope CalendarScope, year int) ([]time.Time, error) {
 	uc.logger.LogOperation("GetHolidays", "start", map[string]interface{}{
-		"year": year,
+		"country":  scope.Country,
+		"store_id": scope.StoreID,
+		"year":     year,
 	})
 
 	// Validate year
@@ -59,22 +68,32 @@ func (uc *calendarUseCase) GetHolidays(year int) ([]time.Time, error) {
 		return nil, err
 	}
 
-	// Get holidays from calendar package
-	holidays := calendar.GetColombianHolidaysCached(year)
+	if err := ValidateHolidayCountry(scope.Country); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetHolidays", err)
+	}
+
+	// Get computed holidays merged with the custom ones of the scope
+	holidays, err := uc.holidays.Holidays("GetHolidays", scope, year, 0)
+	if err != nil {
+		return nil, err
+	}
 
 	uc.logger.LogOperation("GetHolidays", "success", map[string]interface{}{
-		"year":  year,
-		"count": len(holidays),
+		"country": scope.Country,
+		"year":    year,
+		"count":   len(holidays),
 	})
 
 	return holidays, nil
 }
 
-// GetHolidaysByMonth retrieves holidays for a specific year and month with validation
-func (uc *calendarUseCase) GetHolidaysByMonth(year int, month int) ([]time.Time, error) {
+// GetHolidaysByMonth retrieves holidays of a calendar scope for a specific year and month with validation
+func (uc *calendarUseCase) GetHolidaysByMonth(scope CalendarScope, year int, month int) ([]time.Time, error) {
 	uc.logger.LogOperation("GetHolidaysByMonth", "start", map[string]interface{}{
-		"year":  year,
-		"month": month,
+		"country":  scope.Country,
+		"store_id": scope.StoreID,
+		"year":     year,
+		"month":    month,
 	})
 
 	// Validate year and month
@@ -86,13 +105,61 @@ func (uc *calendarUseCase) GetHolidaysByMonth(year int, month int) ([]time.Time,
 		return nil, err
 	}
 
-	// Get holidays from calendar package
-	holidays := calendar.GetColombianHolidaysByMonthCached(year, month)
+	if err := ValidateHolidayCountry(scope.Country); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetHolidaysByMonth", err)
+	}
+
+	// Get computed holidays merged with the custom ones of the scope
+	holidays, err := uc.holidays.Holidays("GetHolidaysByMonth", scope, year, month)
+	if err != nil {
+		return nil, err
+	}
 
 	uc.logger.LogOperation("GetHolidaysByMonth", "success", map[string]interface{}{
-		"year":  year,
-		"month": month,
-		"count": len(holidays),
+		"country": scope.Country,
+		"year":    year,
+		"month":   month,
+		"count":   len(holidays),
+	})
+
+	return holidays, nil
+}
+
+// GetHolidayDetails retrieves the holidays of a calendar scope with name, category and
+// Law 51/1983 move information. A zero month retrieves the whole year.
+func (uc *calendarUseCase) GetHolidayDetails(scope CalendarScope, year int, month int) ([]calendar.Holiday, error) {
+	uc.logger.LogOperation("GetHolidayDetails", "start", map[string]interface{}{
+		"country":  scope.Country,
+		"store_id": scope.StoreID,
+		"year":     year,
+		"month":    month,
+	})
+
+	// Validate year and month (zero = whole year)
+	if err := uc.ValidateYear(year); err != nil {
+		return nil, err
+	}
+
+	if month != 0 {
+		if err := uc.ValidateMonth(month); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ValidateHolidayCountry(scope.Country); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetHolidayDetails", err)
+	}
+
+	holidays, err := uc.holidays.HolidayDetails("GetHolidayDetails", scope, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.LogOperation("GetHolidayDetails", "success", map[string]interface{}{
+		"country": scope.Country,
+		"year":    year,
+		"month":   month,
+		"count":   len(holidays),
 	})
 
 	return holidays, nil
@@ -157,11 +224,12 @@ func (uc *calendarUseCase) CountSundays(year int, month int) (int, error) {
 // ✅ Business-specific operations with enhanced features
 
 // GetMonthSummary retrieves comprehensive calendar summary for a month
-func (uc *calendarUseCase) GetMonthSummary(year int, month int) (*CalendarSummary, error) {
+func (uc *calendarUseCase) GetMonthSummary(scope CalendarScope, year int, month int) (*CalendarSummary, error) {
 	// Start performance timer
 	timer := uc.logger.StartTimer("GetMonthSummary", map[string]interface{}{
-		"year":  year,
-		"month": month,
+		"country": scope.Country,
+		"year":    year,
+		"month":   month,
 	})
 	defer timer.Stop()
 
@@ -175,7 +243,7 @@ func (uc *calendarUseCase) GetMonthSummary(year int, month int) (*CalendarSummar
 	}
 
 	// Get holidays
-	holidays, err := uc.GetHolidaysByMonth(year, month)
+	holidays, err := uc.GetHolidaysByMonth(scope, year, month)
 	if err != nil {
 		return nil, err
 	}
@@ -192,12 +260,13 @@ func (uc *calendarUseCase) GetMonthSummary(year int, month int) (*CalendarSummar
 	}
 
 	// Calculate working days (business rule: ordinary days - holidays that don't fall on Sunday)
-	workingDays, err := uc.GetWorkingDays(year, month)
+	workingDays, err := uc.GetWorkingDays(scope, year, month)
 	if err != nil {
 		return nil, err
 	}
 
 	summary := &CalendarSummary{
+		Country:      scope.Country,
 		Year:         year,
 		Month:        month,
 		Holidays:     holidays,
@@ -207,6 +276,7 @@ func (uc *calendarUseCase) GetMonthSummary(year int, month int) (*CalendarSummar
 	}
 
 	uc.logger.LogOperation("GetMonthSummary", "success", map[string]interface{}{
+		"country":       scope.Country,
 		"year":          year,
 		"month":         month,
 		"holidays":      len(holidays),
@@ -219,10 +289,11 @@ func (uc *calendarUseCase) GetMonthSummary(year int, month int) (*CalendarSummar
 }
 
 // GetWorkingDays calculates actual working days in a month (excludes holidays that don't fall on Sunday)
-func (uc *calendarUseCase) GetWorkingDays(year int, month int) (int, error) {
+func (uc *calendarUseCase) GetWorkingDays(scope CalendarScope, year int, month int) (int, error) {
 	uc.logger.LogOperation("GetWorkingDays", "start", map[string]interface{}{
-		"year":  year,
-		"month": month,
+		"country": scope.Country,
+		"year":    year,
+		"month":   month,
 	})
 
 	// Validate year and month
@@ -241,7 +312,7 @@ func (uc *calendarUseCase) GetWorkingDays(year int, month int) (int, error) {
 	}
 
 	// Get holidays
-	holidays, err := uc.GetHolidaysByMonth(year, month)
+	holidays, err := uc.GetHolidaysByMonth(scope, year, month)
 	if err != nil {
 		return 0, err
 	}
@@ -261,6 +332,7 @@ func (uc *calendarUseCase) GetWorkingDays(year int, month int) (int, error) {
 	}
 
 	uc.logger.LogBusinessRule("GetWorkingDays", "exclude_non_sunday_holidays", "applied", map[string]interface{}{
+		"country":                scope.Country,
 		"year":                   year,
 		"month":                  month,
 		"ordinary_days":          ordinaryDays,
@@ -278,6 +350,23 @@ func (uc *calendarUseCase) GetWorkingDays(year int, month int) (int, error) {
 	return workingDays, nil
 }
 
+// ResolveScope selects the calendar scope of a request: the store's calendar when
+// storeID is set, otherwise the given country, otherwise calendar.DefaultCountry
+func (uc *calendarUseCase) ResolveScope(country string, storeID int) (CalendarScope, error) {
+	resolved, err := uc.holidays.ResolveScope("ResolveScope", country, storeID)
+	if err != nil {
+		return CalendarScope{}, err
+	}
+
+	uc.logger.LogOperation("ResolveScope", "success", map[string]interface{}{
+		"requested_country": country,
+		"store_id":          storeID,
+		"country":           resolved.Country,
+	})
+
+	return resolved, nil
+}
+
 // ClearCache clears the calendar cache
 func (uc *calendarUseCase) ClearCache() error {
 	uc.logger.LogOperation("ClearCache", "start", nil)
@@ -289,6 +378,11 @@ func (uc *calendarUseCase) ClearCache() error {
 	return nil
 }
 
+// GetCacheStats returns the hit/miss counters of the holiday and month summary caches
+func (uc *calendarUseCase) GetCacheStats() map[string]cache.Stats {
+	return calendar.CacheStats()
+}
+
 // ValidateYear validates year according to business rules
 func (uc *calendarUseCase) ValidateYear(year int) error {
 	uc.logger.LogOperation("ValidateYear", "start", map[string]interface{}{
@@ -0,0 +1,15 @@
+package dto
+
+// AbsenceTypeRequest crea o modifica un tipo del catálogo de ausencias
+type AbsenceTypeRequest struct {
+	Code             string  `json:"code"` // "sick_leave"; minúsculas, dígitos y "_"
+	Name             string  `json:"name"`
+	Paid             bool    `json:"paid"`
+	MaxHoursPerMonth float64 `json:"max_hours_per_month"` // 0 = sin límite
+	IsActive         *bool   `json:"is_active,omitempty"` // nil = activo al crear, sin cambios al modificar
+}
+
+// AbsenceReviewRequest aprueba o rechaza una solicitud de ausencia
+type AbsenceReviewRequest struct {
+	Note string `json:"note,omitempty"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+// AssignShiftRequest asigna un turno plantilla a un empleado en una fecha
+type AssignShiftRequest struct {
+	EmployeeID           int    `json:"employee_id"`
+	ShiftID              int    `json:"shift_id"`
+	Date                 string `json:"date"`                            // "YYYY-MM-DD"
+	AvailabilityOverride string `json:"availability_override,omitempty"` // motivo para asignar aunque el empleado no esté disponible
+}
+
+// ReassignShiftRequest cambia el empleado, el turno o la fecha de una asignación.
+// Los campos en cero conservan el valor actual.
+type ReassignShiftRequest struct {
+	EmployeeID           int    `json:"employee_id"`
+	ShiftID              int    `json:"shift_id"`
+	Date                 string `json:"date"`
+	AvailabilityOverride string `json:"availability_override,omitempty"`
+}
+
+// BulkAssignRequest asigna el mismo turno a un empleado en un rango de fechas
+type BulkAssignRequest struct {
+	EmployeeID           int    `json:"employee_id"`
+	ShiftID              int    `json:"shift_id"`
+	From                 string `json:"from"`                            // "YYYY-MM-DD"
+	To                   string `json:"to"`                              // "YYYY-MM-DD"
+	Weekdays             []int  `json:"weekdays,omitempty"`              // 0=domingo ... 6=sábado; vacío = todos los días
+	SkipExisting         bool   `json:"skip_existing"`                   // omite fechas ya asignadas en lugar de fallar
+	AvailabilityOverride string `json:"availability_override,omitempty"` // se registra en los días en que el empleado no está disponible
+}
@@ -0,0 +1,39 @@
+package dto
+
+// AuthTokens is the response of login, context selection and refresh
+type AuthTokens struct {
+	Token        string `json:"token"`                   // JWT access token
+	RefreshToken string `json:"refresh_token,omitempty"` // rotated on every refresh; omitted when unchanged
+	TokenType    string `json:"token_type"`              // "Bearer"
+	ExpiresIn    int    `json:"expires_in"`              // access token lifetime in seconds
+
+	// MustChangePassword: the token only allows POST /auth/password/change and logout
+	MustChangePassword bool `json:"must_change_password,omitempty"`
+}
+
+// RefreshRequest exchanges a refresh token for a new access/refresh token pair
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LogoutRequest ends the current session, or every session of the user with all_sessions
+type LogoutRequest struct {
+	AllSessions bool `json:"all_sessions"`
+}
+
+// ChangePasswordRequest replaces the password of the authenticated user
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// ForgotPasswordRequest asks for a reset token to be sent to the user's email
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest sets a new password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
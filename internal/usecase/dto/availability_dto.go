@@ -0,0 +1,24 @@
+package dto
+
+// AvailabilityWindowRequest es una franja semanal en la que el empleado no puede o prefiere no trabajar
+type AvailabilityWindowRequest struct {
+	Weekday   int    `json:"weekday"`    // 0=domingo ... 6=sábado
+	StartTime string `json:"start_time"` // "HH:MM"
+	EndTime   string `json:"end_time"`   // "HH:MM"; "24:00" hasta el final del día
+	Type      string `json:"type"`       // unavailable | prefer_not
+	Note      string `json:"note,omitempty"`
+}
+
+// AvailabilityWindowsRequest reemplaza todas las franjas semanales del empleado; vacío las borra
+type AvailabilityWindowsRequest struct {
+	Windows []AvailabilityWindowRequest `json:"windows"`
+}
+
+// AvailabilityExceptionRequest cambia la disponibilidad de una fecha concreta
+type AvailabilityExceptionRequest struct {
+	Date      string `json:"date"`                 // "YYYY-MM-DD"
+	StartTime string `json:"start_time,omitempty"` // sin horas aplica a todo el día
+	EndTime   string `json:"end_time,omitempty"`
+	Type      string `json:"type"` // unavailable | available
+	Reason    string `json:"reason,omitempty"`
+}
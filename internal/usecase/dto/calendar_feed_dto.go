@@ -0,0 +1,19 @@
+package dto
+
+import "loopi-api/internal/domain"
+
+// CreateCalendarFeedRequest crea una suscripción iCalendar.
+// kind holidays usa store_id o country (vacío = país por defecto); kind employee usa employee_id.
+type CreateCalendarFeedRequest struct {
+	Kind       string `json:"kind"` // holidays | employee
+	EmployeeID int    `json:"employee_id,omitempty"`
+	StoreID    int    `json:"store_id,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+// CalendarFeedSubscription es la suscripción creada; el token solo se muestra una vez
+type CalendarFeedSubscription struct {
+	Feed  domain.CalendarFeed `json:"feed"`
+	Token string              `json:"token"`
+	Path  string              `json:"path"` // "/feeds/<token>.ics"
+}
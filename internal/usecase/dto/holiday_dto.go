@@ -0,0 +1,13 @@
+package dto
+
+// HolidayRequest crea o reemplaza un festivo personalizado.
+// Solo se usa el identificador que corresponde al alcance; la franquicia es siempre la del token
+// y los festivos globales requieren el permiso platform:admin.
+type HolidayRequest struct {
+	Date        string `json:"date"`                   // "YYYY-MM-DD"
+	Name        string `json:"name"`                   // "Día cívico de Medellín"
+	Scope       string `json:"scope"`                  // global | franchise | store
+	Country     string `json:"country,omitempty"`      // alcance global; vacío = todos los países
+	FranchiseID int    `json:"franchise_id,omitempty"` // alcance franchise; por defecto la del token
+	StoreID     int    `json:"store_id,omitempty"`     // alcance store; por defecto la tienda del token
+}
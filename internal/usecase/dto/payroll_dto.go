@@ -0,0 +1,10 @@
+package dto
+
+// CreatePayrollConfigRequest registra nuevos porcentajes de recargo con su fecha de vigencia
+type CreatePayrollConfigRequest struct {
+	EffectiveFrom            string  `json:"effective_from"`             // "YYYY-MM-DD"
+	NightSurcharge           float64 `json:"night_surcharge"`            // 0.35 = 35 %
+	SundayHolidaySurcharge   float64 `json:"sunday_holiday_surcharge"`   // 0.75 = 75 %
+	ExtraDiurnalMultiplier   float64 `json:"extra_diurnal_multiplier"`   // 1.25
+	ExtraNocturnalMultiplier float64 `json:"extra_nocturnal_multiplier"` // 1.75
+}
@@ -0,0 +1,33 @@
+package dto
+
+import "loopi-api/internal/domain"
+
+// RoleRequest creates or updates a role
+type RoleRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsActive    *bool  `json:"is_active,omitempty"` // nil = active on create, unchanged on update
+}
+
+// PermissionRequest creates or updates a permission such as "shifts:write"
+type PermissionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// RolePermissionsRequest replaces the permissions granted to a role
+type RolePermissionsRequest struct {
+	PermissionIDs []int `json:"permission_ids"`
+}
+
+// UserRoleRequest grants or revokes a role of a user in a franchise
+type UserRoleRequest struct {
+	RoleID      int `json:"role_id"`
+	FranchiseID int `json:"franchise_id"` // 0 = the franchise selected in the token
+}
+
+// RoleWithPermissions is a role with the permissions it grants
+type RoleWithPermissions struct {
+	domain.Role
+	Permissions []domain.Permission `json:"permissions"`
+}
@@ -0,0 +1,55 @@
+package dto
+
+// RosterGenerationRequest solicita generar la malla mensual de una tienda
+type RosterGenerationRequest struct {
+	StoreID int `json:"store_id"`
+	Year    int `json:"year"`
+	Month   int `json:"month"`
+}
+
+// ProposedAssignment es un turno propuesto para un empleado en una fecha.
+// AgainstPreference indica que el empleado había pedido no trabajar en ese horario.
+type ProposedAssignment struct {
+	Date              string `json:"date"`
+	DayType           string `json:"day_type"`
+	ShiftID           int    `json:"shift_id"`
+	ShiftName         string `json:"shift_name"`
+	StartTime         string `json:"start_time"`
+	EndTime           string `json:"end_time"`
+	EmployeeID        int    `json:"employee_id"`
+	AgainstPreference bool   `json:"against_preference,omitempty"`
+}
+
+// UncoveredSlot es un turno que no se pudo cubrir por falta de empleados libres y disponibles
+type UncoveredSlot struct {
+	Date      string `json:"date"`
+	DayType   string `json:"day_type"`
+	ShiftID   int    `json:"shift_id"`
+	ShiftName string `json:"shift_name"`
+}
+
+// EmployeeWorkload resume la carga de un empleado en el mes (incluye turnos ya asignados)
+type EmployeeWorkload struct {
+	EmployeeID          int `json:"employee_id"`
+	TotalShifts         int `json:"total_shifts"`
+	SundayHolidayShifts int `json:"sunday_holiday_shifts"`
+}
+
+// LaborViolation es una norma laboral que incumple la malla propuesta para un empleado
+type LaborViolation struct {
+	EmployeeID int    `json:"employee_id"`
+	Rule       string `json:"rule"`
+	Message    string `json:"message"`
+}
+
+// RosterProposal es la malla propuesta; Committed indica si ya se guardó
+type RosterProposal struct {
+	StoreID     int                  `json:"store_id"`
+	Year        int                  `json:"year"`
+	Month       int                  `json:"month"`
+	Assignments []ProposedAssignment `json:"assignments"`
+	Uncovered   []UncoveredSlot      `json:"uncovered"`
+	Workload    []EmployeeWorkload   `json:"workload"`
+	Violations  []LaborViolation     `json:"violations"`
+	Committed   bool                 `json:"committed"`
+}
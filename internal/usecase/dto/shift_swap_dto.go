@@ -0,0 +1,17 @@
+package dto
+
+// ShiftSwapOfferRequest ofrece un turno asignado del usuario autenticado a sus compañeros
+type ShiftSwapOfferRequest struct {
+	AssignedShiftID int    `json:"assigned_shift_id"`
+	Note            string `json:"note,omitempty"`
+}
+
+// ShiftSwapAcceptRequest acepta un turno ofrecido, opcionalmente dando a cambio uno propio
+type ShiftSwapAcceptRequest struct {
+	ReturnShiftID *int `json:"return_shift_id,omitempty"` // nil = tomar el turno sin dar otro
+}
+
+// ShiftSwapReviewRequest aprueba o rechaza un intercambio aceptado
+type ShiftSwapReviewRequest struct {
+	Note string `json:"note,omitempty"`
+}
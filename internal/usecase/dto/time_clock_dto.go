@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// ClockInRequest marca la entrada del usuario autenticado
+type ClockInRequest struct {
+	StoreID int `json:"store_id,omitempty"` // por defecto la tienda del token o la única del empleado
+}
+
+// TimeClockEntryRequest registra o corrige un periodo trabajado con una marcación olvidada o errónea
+type TimeClockEntryRequest struct {
+	EmployeeID int       `json:"employee_id,omitempty"` // solo al registrar
+	StoreID    int       `json:"store_id,omitempty"`    // solo al registrar
+	ClockIn    time.Time `json:"clock_in"`              // RFC 3339, p. ej. "2025-03-03T07:58:00-05:00"
+	ClockOut   time.Time `json:"clock_out"`
+	Note       string    `json:"note"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// VacationAdjustmentRequest registra un ajuste manual del saldo de vacaciones
+type VacationAdjustmentRequest struct {
+	Date   string  `json:"date,omitempty"` // YYYY-MM-DD; por defecto hoy
+	Days   float64 `json:"days"`           // positivo suma, negativo resta
+	Reason string  `json:"reason"`         // "Saldo inicial", "Compensación en dinero"...
+}
@@ -0,0 +1,11 @@
+package dto
+
+// CreateWorkConfigRequest registra una nueva configuración laboral con su fecha de vigencia
+type CreateWorkConfigRequest struct {
+	EffectiveFrom       string  `json:"effective_from"` // "YYYY-MM-DD"
+	DiurnalStart        string  `json:"diurnal_start"`  // "06:00"
+	DiurnalEnd          string  `json:"diurnal_end"`    // "21:00"
+	DailyOrdinaryHours  float64 `json:"daily_ordinary_hours"`
+	WeeklyOrdinaryHours float64 `json:"weekly_ordinary_hours"`
+	GraceMinutes        *int    `json:"grace_minutes,omitempty"` // por defecto domain.DefaultGraceMinutes
+}
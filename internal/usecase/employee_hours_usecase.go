@@ -2,23 +2,27 @@ package usecase
 
 import (
 	"fmt"
-	"loopi-api/internal/calendar"
 	"loopi-api/internal/domain"
 	"loopi-api/internal/repository"
 	"loopi-api/internal/usecase/base"
 	"loopi-api/internal/usecase/utils"
+	"math"
+	"sort"
+	"time"
 )
 
 type EmployeeHoursUseCase interface {
-	// Standard operations
-	GetMonthlySummary(employeeID, year, month int) (domain.EmployeeHourSummary, error)
+	// Standard operations, scoped to the caller's franchise
+	GetMonthlySummary(scope domain.TenantScope, employeeID, year, month int) (domain.EmployeeHourSummary, error)
+	GetMonthlySummaryByPeriods(scope domain.TenantScope, employeeID, year, month int, starts []time.Time) ([]domain.EmployeeHourSummary, error)
+	GetAttendanceSummary(scope domain.TenantScope, employeeID, year, month int) (domain.AttendanceSummary, error)
 
 	// Business-specific operations
-	GetDailySummary(employeeID int, year, month, day int) (DailyHoursSummary, error)
-	GetYearlySummary(employeeID, year int) (YearlyHoursSummary, error)
+	GetDailySummary(scope domain.TenantScope, employeeID int, year, month, day int) (DailyHoursSummary, error)
+	GetYearlySummary(scope domain.TenantScope, employeeID, year int) (YearlyHoursSummary, error)
 	ValidatePeriod(year, month int) error
 	ValidateEmployeeID(employeeID int) error
-	CalculateWorkingDays(employeeID, year, month int) (int, error)
+	CalculateWorkingDays(scope domain.TenantScope, employeeID, year, month int) (int, error)
 }
 
 type DailyHoursSummary struct {
@@ -39,13 +43,18 @@ type YearlyHoursSummary struct {
 }
 
 type employeeHoursUseCase struct {
-	assignedRepo repository.AssignedShiftRepository
-	absenceRepo  repository.AbsenceRepository
-	noveltyRepo  repository.NoveltyRepository
-	userRepo     repository.UserRepository
-	errorHandler *base.ErrorHandler
-	validator    *base.Validator
-	logger       *base.Logger
+	assignedRepo   repository.AssignedShiftRepository
+	absenceRepo    repository.AbsenceRepository
+	noveltyRepo    repository.NoveltyRepository
+	userRepo       repository.UserRepository
+	workConfigRepo repository.WorkConfigRepository
+	clockRepo      repository.TimeClockRepository
+	holidays       *HolidayCalendarResolver
+	tenant         *TenantGuard
+	now            func() time.Time
+	errorHandler   *base.ErrorHandler
+	validator      *base.Validator
+	logger         *base.Logger
 }
 
 func NewEmployeeHoursUseCase(
@@ -53,22 +62,273 @@ func NewEmployeeHoursUseCase(
 	absenceRepo repository.AbsenceRepository,
 	noveltyRepo repository.NoveltyRepository,
 	userRepo repository.UserRepository,
+	workConfigRepo repository.WorkConfigRepository,
+	clockRepo repository.TimeClockRepository,
+	holidays *HolidayCalendarResolver,
+	tenant *TenantGuard,
 ) EmployeeHoursUseCase {
 	return &employeeHoursUseCase{
-		assignedRepo: assignedRepo,
-		absenceRepo:  absenceRepo,
-		noveltyRepo:  noveltyRepo,
-		userRepo:     userRepo,
-		errorHandler: base.NewErrorHandler("EmployeeHours"),
-		validator:    base.NewValidator(),
-		logger:       base.NewLogger("EmployeeHours"),
+		assignedRepo:   assignedRepo,
+		absenceRepo:    absenceRepo,
+		noveltyRepo:    noveltyRepo,
+		userRepo:       userRepo,
+		workConfigRepo: workConfigRepo,
+		clockRepo:      clockRepo,
+		holidays:       holidays,
+		tenant:         tenant,
+		now:            time.Now,
+		errorHandler:   base.NewErrorHandler("EmployeeHours"),
+		validator:      base.NewValidator(),
+		logger:         base.NewLogger("EmployeeHours"),
 	}
 }
 
 // ✅ Enhanced operations with logging, validation, and error handling
 
-// GetMonthlySummary calculates comprehensive monthly hours summary for an employee
-func (uc *employeeHoursUseCase) GetMonthlySummary(employeeID, year, month int) (domain.EmployeeHourSummary, error) {
+// GetMonthlySummary calculates comprehensive monthly hours summary for an employee of the franchise
+func (uc *employeeHoursUseCase) GetMonthlySummary(scope domain.TenantScope, employeeID, year, month int) (domain.EmployeeHourSummary, error) {
+	if err := uc.ValidateEmployeeID(employeeID); err != nil {
+		return domain.EmployeeHourSummary{}, err
+	}
+	if err := uc.tenant.RequireEmployee("GetMonthlySummary", scope, employeeID); err != nil {
+		return domain.EmployeeHourSummary{}, err
+	}
+
+	return uc.monthlySummary(employeeID, year, month)
+}
+
+// GetMonthlySummaryByPeriods splits the monthly summary of an employee of the franchise at each
+// of starts, the first day of a new period within the month. It returns one summary per period,
+// in order; together they add up to the monthly summary.
+func (uc *employeeHoursUseCase) GetMonthlySummaryByPeriods(scope domain.TenantScope, employeeID, year, month int, starts []time.Time) ([]domain.EmployeeHourSummary, error) {
+	if err := uc.ValidateEmployeeID(employeeID); err != nil {
+		return nil, err
+	}
+	if err := uc.tenant.RequireEmployee("GetMonthlySummaryByPeriods", scope, employeeID); err != nil {
+		return nil, err
+	}
+	if err := uc.ValidatePeriod(year, month); err != nil {
+		return nil, err
+	}
+
+	data, err := uc.loadMonth("GetMonthlySummaryByPeriods", employeeID, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	nextMonth := data.periodEnd.AddDate(0, 0, 1)
+	bounds := append(append([]time.Time(nil), starts...), nextMonth)
+
+	planned := data.plannedPeriods()
+	summaries := make([]domain.EmployeeHourSummary, 0, len(bounds))
+	from := data.periodStart
+	for _, start := range bounds {
+		if !start.After(from) || start.After(nextMonth) {
+			err := fmt.Errorf("period start %s is not within %d-%02d or not after the previous one", start.Format("2006-01-02"), year, month)
+			return nil, uc.errorHandler.HandleValidationError("GetMonthlySummaryByPeriods", err)
+		}
+		summary, _ := uc.buildSummary(data, planned, true, from, start.AddDate(0, 0, -1))
+		summaries = append(summaries, summary)
+		from = start
+	}
+
+	uc.logger.LogOperation("GetMonthlySummaryByPeriods", "success", map[string]interface{}{
+		"employee_id": employeeID,
+		"year":        year,
+		"month":       month,
+		"periods":     len(summaries),
+	})
+
+	return summaries, nil
+}
+
+// GetAttendanceSummary compares the monthly summary of the assigned shifts with the one of the
+// punched time of an employee of the franchise
+func (uc *employeeHoursUseCase) GetAttendanceSummary(scope domain.TenantScope, employeeID, year, month int) (domain.AttendanceSummary, error) {
+	if err := uc.ValidateEmployeeID(employeeID); err != nil {
+		return domain.AttendanceSummary{}, err
+	}
+	if err := uc.tenant.RequireEmployee("GetAttendanceSummary", scope, employeeID); err != nil {
+		return domain.AttendanceSummary{}, err
+	}
+	if err := uc.ValidatePeriod(year, month); err != nil {
+		return domain.AttendanceSummary{}, err
+	}
+
+	uc.logger.LogOperation("GetAttendanceSummary", "start", map[string]interface{}{
+		"employee_id": employeeID,
+		"year":        year,
+		"month":       month,
+	})
+
+	data, err := uc.loadMonth("GetAttendanceSummary", employeeID, year, month)
+	if err != nil {
+		return domain.AttendanceSummary{}, err
+	}
+
+	// Entries are instants: fetch a day of margin on each side and bucket them by the
+	// store's local date of the clock-in
+	entries, err := uc.clockRepo.GetByEmployeeAndDateRange(employeeID, data.carryOverDay.AddDate(0, 0, -1), data.periodEnd.AddDate(0, 0, 2))
+	if err != nil {
+		uc.logger.LogError("GetAttendanceSummary", err, map[string]interface{}{
+			"employee_id": employeeID,
+			"year":        year,
+			"month":       month,
+		})
+		return domain.AttendanceSummary{}, uc.errorHandler.HandleRepositoryError("GetAttendanceSummary", err)
+	}
+
+	actualPeriods := make(map[string][]workedPeriod)
+	missedByDay := make(map[string]int)
+	locations := make(map[int]*time.Location)
+	now := uc.now()
+	for i := range entries {
+		entry := &entries[i]
+		location, ok := locations[entry.StoreID]
+		if !ok {
+			location, err = uc.holidays.LocationForStore("GetAttendanceSummary", entry.StoreID)
+			if err != nil {
+				return domain.AttendanceSummary{}, err
+			}
+			locations[entry.StoreID] = location
+		}
+
+		clockIn := entry.ClockIn.In(location)
+		date := time.Date(clockIn.Year(), clockIn.Month(), clockIn.Day(), 0, 0, 0, 0, time.UTC)
+		if date.Before(data.carryOverDay) || date.After(data.periodEnd) {
+			continue
+		}
+		dateKey := date.Format("2006-01-02")
+
+		// Business rule: Entries without a clock-out do not count until a manager corrects them
+		if entry.Status == domain.TimeClockMissedOut || (entry.Status == domain.TimeClockOpen && isStale(entry, now)) {
+			missedByDay[dateKey]++
+			continue
+		}
+		if entry.Status != domain.TimeClockClosed {
+			continue // Still clocked in
+		}
+
+		start := utils.ParseHour(clockIn.Format("15:04"))
+		end := utils.ParseHour(entry.ClockOut.In(location).Format("15:04"))
+		if start.Equal(end) {
+			continue // Shorter than a minute
+		}
+		if err := uc.loadCalendar("GetAttendanceSummary", data, entry.StoreID); err != nil {
+			return domain.AttendanceSummary{}, err
+		}
+		actualPeriods[dateKey] = append(actualPeriods[dateKey], workedPeriod{
+			start:   start,
+			end:     end,
+			hours:   entry.WorkedHours(),
+			storeID: entry.StoreID,
+		})
+	}
+
+	plannedPeriods := data.plannedPeriods()
+	planned, _ := uc.buildSummary(data, plannedPeriods, true, data.periodStart, data.periodEnd)
+	actual, _ := uc.buildSummary(data, actualPeriods, false, data.periodStart, data.periodEnd)
+
+	summary := domain.AttendanceSummary{
+		Employee: planned.Employee,
+		Period:   planned.Period,
+		Planned:  planned,
+		Actual:   actual,
+		Days:     make([]domain.AttendanceDay, 0),
+	}
+
+	// Daily hours belong to the date the shift or entry starts
+	for date := data.periodStart; !date.After(data.periodEnd); date = date.AddDate(0, 0, 1) {
+		dateKey := date.Format("2006-01-02")
+		day := domain.AttendanceDay{
+			Date:          dateKey,
+			PlannedHours:  utils.RoundTo2(periodHours(plannedPeriods[dateKey])),
+			ActualHours:   utils.RoundTo2(periodHours(actualPeriods[dateKey])),
+			MissedPunches: missedByDay[dateKey],
+		}
+		if day.PlannedHours == 0 && day.ActualHours == 0 && day.MissedPunches == 0 {
+			continue
+		}
+		day.Variance = utils.RoundTo2(day.ActualHours - day.PlannedHours)
+
+		summary.PlannedHours += day.PlannedHours
+		summary.ActualHours += day.ActualHours
+		summary.MissedPunches += day.MissedPunches
+		summary.Days = append(summary.Days, day)
+	}
+	summary.PlannedHours = utils.RoundTo2(summary.PlannedHours)
+	summary.ActualHours = utils.RoundTo2(summary.ActualHours)
+	summary.VarianceHours = utils.RoundTo2(summary.ActualHours - summary.PlannedHours)
+
+	uc.logger.LogOperation("GetAttendanceSummary", "success", map[string]interface{}{
+		"employee_id":    employeeID,
+		"year":           year,
+		"month":          month,
+		"planned_hours":  summary.PlannedHours,
+		"actual_hours":   summary.ActualHours,
+		"variance_hours": summary.VarianceHours,
+		"missed_punches": summary.MissedPunches,
+	})
+
+	return summary, nil
+}
+
+// monthData holds what the monthly summaries of an employee read
+type monthData struct {
+	employeeID   int
+	employeeName string
+	year, month  int
+
+	// The last day of the previous month is included because an overnight shift
+	// starting there carries its post-midnight hours into this month
+	periodStart, periodEnd, carryOverDay time.Time
+
+	dayTypes    map[int]func(time.Time) utils.DayType // calendar of each store worked in
+	workConfigs utils.WorkConfigTimeline
+	shifts      []domain.AssignedShift
+	absences    []domain.Absence
+	noveltyMap  map[string]float64
+}
+
+// dayTypeOf returns the day type lookup of the calendar of a store loaded by loadCalendar
+func (d *monthData) dayTypeOf(storeID int) func(time.Time) utils.DayType {
+	return d.dayTypes[storeID]
+}
+
+// workedPeriod is a worked period read as wall-clock times on the date it starts
+type workedPeriod struct {
+	start, end time.Time
+	hours      float64 // excluding lunch
+	storeID    int     // whose holidays classify the period
+}
+
+// plannedPeriods returns the assigned shifts adjusted by their novelties, keyed by date
+func (d *monthData) plannedPeriods() map[string][]workedPeriod {
+	periods := make(map[string][]workedPeriod)
+	for _, shift := range d.shifts {
+		start := utils.ParseHour(shift.StartTime)
+		end := utils.ParseHour(shift.EndTime)
+
+		// Base worked hours (excluding lunch) plus novelties (can increase or decrease hours)
+		hours := utils.DurationInHours(start, end) - float64(shift.LunchMinutes)/60.0 + d.noveltyMap[shift.Date]
+		periods[shift.Date] = append(periods[shift.Date], workedPeriod{start: start, end: end, hours: hours, storeID: shift.StoreID})
+	}
+	return periods
+}
+
+// periodHours sums the worked hours of the periods of a day
+func periodHours(periods []workedPeriod) float64 {
+	total := 0.0
+	for _, period := range periods {
+		if period.hours > 0 {
+			total += period.hours
+		}
+	}
+	return total
+}
+
+// monthlySummary calculates the monthly hours summary once the employee has been scoped
+func (uc *employeeHoursUseCase) monthlySummary(employeeID, year, month int) (domain.EmployeeHourSummary, error) {
 	uc.logger.LogOperation("GetMonthlySummary", "start", map[string]interface{}{
 		"employee_id": employeeID,
 		"year":        year,
@@ -84,174 +344,268 @@ func (uc *employeeHoursUseCase) GetMonthlySummary(employeeID, year, month int) (
 		return domain.EmployeeHourSummary{}, err
 	}
 
+	data, err := uc.loadMonth("GetMonthlySummary", employeeID, year, month)
+	if err != nil {
+		return domain.EmployeeHourSummary{}, err
+	}
+
+	summary, processedDays := uc.buildSummary(data, data.plannedPeriods(), true, data.periodStart, data.periodEnd)
+
+	uc.logger.LogOperation("GetMonthlySummary", "success", map[string]interface{}{
+		"employee_id":    employeeID,
+		"employee_name":  data.employeeName,
+		"year":           year,
+		"month":          month,
+		"processed_days": processedDays,
+		"total_ordinary": summary.Ordinary,
+		"total_sunday":   summary.Sunday,
+		"total_holiday":  summary.Holiday,
+	})
+
+	return summary, nil
+}
+
+// loadMonth retrieves the calendar, work configurations, shifts, absences and novelties
+// of an employee's month
+func (uc *employeeHoursUseCase) loadMonth(operation string, employeeID, year, month int) (*monthData, error) {
 	// Get employee name with error handling
 	fullNameEmployee, err := uc.userRepo.GetNameByID(employeeID)
 	if err != nil {
-		uc.logger.LogError("GetMonthlySummary", err, map[string]interface{}{
+		uc.logger.LogError(operation, err, map[string]interface{}{
 			"employee_id": employeeID,
 		})
-		return domain.EmployeeHourSummary{}, uc.errorHandler.HandleRepositoryError("GetMonthlySummary", err)
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
 	}
 
-	// Build calendar days
-	holidayMap := utils.HolidaysToMap(calendar.GetColombianHolidaysByMonthCached(year, month))
-	calendarDays := utils.BuildCalendarDays(year, month, holidayMap)
+	data := &monthData{
+		employeeID:   employeeID,
+		employeeName: fullNameEmployee,
+		year:         year,
+		month:        month,
+		periodStart:  time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC),
+		dayTypes:     make(map[int]func(time.Time) utils.DayType),
+		noveltyMap:   make(map[string]float64),
+	}
+	data.periodEnd = data.periodStart.AddDate(0, 1, -1)
+	data.carryOverDay = data.periodStart.AddDate(0, 0, -1)
 
-	uc.logger.LogOperation("GetMonthlySummary", "calendar_built", map[string]interface{}{
-		"employee_id":   employeeID,
-		"calendar_days": len(calendarDays),
-		"holidays":      len(holidayMap),
-	})
+	// Get the work configurations in force during the month
+	configs, err := uc.workConfigRepo.GetConfigsForPeriod(data.carryOverDay, data.periodEnd)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{
+			"year":  year,
+			"month": month,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	data.workConfigs = utils.NewWorkConfigTimeline(configs)
 
 	// Get shifts with error handling
-	shifts, err := uc.assignedRepo.GetByEmployeeAndMonth(employeeID, year, month)
+	data.shifts, err = uc.assignedRepo.GetByEmployeeAndDateRange(employeeID, data.carryOverDay.Format("2006-01-02"), data.periodEnd.Format("2006-01-02"))
 	if err != nil {
-		uc.logger.LogError("GetMonthlySummary", err, map[string]interface{}{
+		uc.logger.LogError(operation, err, map[string]interface{}{
 			"employee_id": employeeID,
 			"year":        year,
 			"month":       month,
 		})
-		return domain.EmployeeHourSummary{}, uc.errorHandler.HandleRepositoryError("GetMonthlySummary", err)
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	// Each shift is classified with the holidays of its own store
+	for _, shift := range data.shifts {
+		if err := uc.loadCalendar(operation, data, shift.StoreID); err != nil {
+			return nil, err
+		}
 	}
 
 	// Get absences with error handling
-	absences, err := uc.absenceRepo.GetByEmployeeAndMonth(employeeID, year, month)
+	data.absences, err = uc.absenceRepo.GetByEmployeeAndMonth(employeeID, year, month)
 	if err != nil {
-		uc.logger.LogError("GetMonthlySummary", err, map[string]interface{}{
+		uc.logger.LogError(operation, err, map[string]interface{}{
 			"employee_id": employeeID,
 			"year":        year,
 			"month":       month,
 		})
-		return domain.EmployeeHourSummary{}, uc.errorHandler.HandleRepositoryError("GetMonthlySummary", err)
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
 	}
 
 	// Get novelties with error handling
-	novelties, err := uc.noveltyRepo.GetByEmployeeAndMonth(employeeID, year, month)
+	novelties, err := uc.noveltyRepo.GetByEmployeeAndDateRange(employeeID, data.carryOverDay, data.periodEnd)
 	if err != nil {
-		uc.logger.LogError("GetMonthlySummary", err, map[string]interface{}{
+		uc.logger.LogError(operation, err, map[string]interface{}{
 			"employee_id": employeeID,
 			"year":        year,
 			"month":       month,
 		})
-		return domain.EmployeeHourSummary{}, uc.errorHandler.HandleRepositoryError("GetMonthlySummary", err)
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
 	}
 
-	uc.logger.LogOperation("GetMonthlySummary", "data_retrieved", map[string]interface{}{
+	// Process novelties (positive adds hours, negative subtracts)
+	for _, novelty := range novelties {
+		key := novelty.Date.Format("2006-01-02")
+		if novelty.Type == "positive" {
+			data.noveltyMap[key] += novelty.Hours
+		} else {
+			data.noveltyMap[key] -= novelty.Hours
+		}
+	}
+
+	uc.logger.LogOperation(operation, "data_retrieved", map[string]interface{}{
 		"employee_id":     employeeID,
-		"shifts_count":    len(shifts),
-		"absences_count":  len(absences),
+		"shifts_count":    len(data.shifts),
+		"absences_count":  len(data.absences),
 		"novelties_count": len(novelties),
 	})
 
+	return data, nil
+}
+
+// loadCalendar builds the month's calendar of a store the employee worked in, once per store;
+// store 0 stands for the employee's own calendar
+func (uc *employeeHoursUseCase) loadCalendar(operation string, data *monthData, storeID int) error {
+	if _, ok := data.dayTypes[storeID]; ok {
+		return nil
+	}
+
+	var scope CalendarScope
+	var err error
+	if storeID > 0 {
+		scope, err = uc.holidays.ScopeForStore(operation, storeID)
+	} else {
+		scope, err = uc.holidays.ScopeForEmployee(operation, data.employeeID)
+	}
+	if err != nil {
+		return err
+	}
+	holidayMap, err := uc.holidays.HolidaysByMonth(operation, scope, data.year, data.month)
+	if err != nil {
+		return err
+	}
+	calendarDays := utils.BuildCalendarDays(data.year, data.month, holidayMap)
+	data.dayTypes[storeID] = utils.DayTypeLookup(calendarDays, holidayMap)
+
+	uc.logger.LogOperation(operation, "calendar_built", map[string]interface{}{
+		"employee_id":   data.employeeID,
+		"store_id":      storeID,
+		"calendar_days": len(calendarDays),
+		"country":       scope.Country,
+		"holidays":      len(holidayMap),
+	})
+	return nil
+}
+
+// buildSummary splits the worked periods of the month into ordinary, Sunday and holiday blocks
+// and returns the summary with the number of days worked. Only hours, absences and novelties
+// dated between from and to (inclusive, within the month) are counted. Novelties are only
+// reported when withNovelties is set; the periods already include them.
+func (uc *employeeHoursUseCase) buildSummary(data *monthData, periods map[string][]workedPeriod, withNovelties bool, from, to time.Time) (domain.EmployeeHourSummary, int) {
 	// Build data maps for efficient lookups
 	absenceMap := make(map[string]float64)
-	noveltyMap := make(map[string]float64)
-	assignedMap := make(map[string]domain.AssignedShift)
+	shiftStores := make(map[string]int)
 
-	// Process absences
-	for _, absence := range absences {
-		key := absence.Date.Format("2006-01-02")
-		absenceMap[key] += absence.Hours
-	}
+	// Process approved absences, one entry per day of their range
+	absencesByType := make(map[int]*domain.AbsenceTypeHours)
+	for _, absence := range data.absences {
+		if !absence.IsApproved() {
+			continue
+		}
+		for _, day := range absence.Days(from, to) {
+			absenceMap[day.Format("2006-01-02")] += absence.Hours
+		}
 
-	// Process novelties (positive adds hours, negative subtracts)
-	for _, novelty := range novelties {
-		key := novelty.Date.Format("2006-01-02")
-		if novelty.Type == "positive" {
-			noveltyMap[key] += novelty.Hours
-		} else {
-			noveltyMap[key] -= novelty.Hours
+		byType, ok := absencesByType[absence.AbsenceTypeID]
+		if !ok {
+			byType = &domain.AbsenceTypeHours{AbsenceTypeID: absence.AbsenceTypeID}
+			if absence.AbsenceType != nil {
+				byType.Code = absence.AbsenceType.Code
+				byType.Name = absence.AbsenceType.Name
+				byType.Paid = absence.AbsenceType.Paid
+			}
+			absencesByType[absence.AbsenceTypeID] = byType
 		}
+		byType.Hours += absence.HoursIn(from, to)
 	}
 
 	// Process shifts
-	for _, shift := range shifts {
-		assignedMap[shift.Date] = shift
+	for _, shift := range data.shifts {
+		if _, ok := shiftStores[shift.Date]; !ok {
+			shiftStores[shift.Date] = shift.StoreID
+		}
 	}
 
 	// Initialize summary structure
 	summary := domain.EmployeeHourSummary{
 		Employee: domain.EmployeeInfo{
-			ID:       employeeID,
-			FullName: fullNameEmployee,
+			ID:       data.employeeID,
+			FullName: data.employeeName,
 		},
-		Period: domain.Period{Year: year, Month: month},
+		Period:         domain.Period{Year: data.year, Month: data.month},
+		AbsencesByType: make([]domain.AbsenceTypeHours, 0, len(absencesByType)),
+	}
+	for _, byType := range absencesByType {
+		byType.Hours = utils.RoundTo2(byType.Hours)
+		summary.AbsencesByType = append(summary.AbsencesByType, *byType)
+	}
+	sort.Slice(summary.AbsencesByType, func(i, j int) bool {
+		return summary.AbsencesByType[i].AbsenceTypeID < summary.AbsencesByType[j].AbsenceTypeID
+	})
+
+	// Each period is split minute by minute across the dates it touches, so the hours an
+	// overnight shift works after midnight count in the next day's block
+	blockFor := func(dayType utils.DayType) *domain.EmployeeHourBlock {
+		switch dayType {
+		case utils.Sunday:
+			return &summary.Sunday
+		case utils.Holiday:
+			return &summary.Holiday
+		default:
+			return &summary.Ordinary
+		}
 	}
 
 	// Process each calendar day
 	processedDays := 0
-	for _, day := range calendarDays {
-		dateKey := day.Date.Format("2006-01-02")
-		shift, hasShift := assignedMap[dateKey]
-
-		if !hasShift {
-			continue // No shift assigned for this day
+	for date := data.carryOverDay; !date.After(data.periodEnd); date = date.AddDate(0, 0, 1) {
+		dateKey := date.Format("2006-01-02")
+
+		// Absences and novelties belong to the shift's own date
+		if storeID, ok := shiftStores[dateKey]; ok && !date.Before(from) && !date.After(to) {
+			block := blockFor(data.dayTypeOf(storeID)(date))
+			block.Absence += utils.RoundTo2(absenceMap[dateKey])
+			if withNovelties {
+				block.Novelty += utils.RoundTo2(data.noveltyMap[dateKey])
+			}
 		}
 
-		// Parse shift times
-		startTime := utils.ParseHour(shift.StartTime)
-		endTime := utils.ParseHour(shift.EndTime)
-
-		// Calculate base worked hours (excluding lunch)
-		baseWorkedHours := utils.DurationInHours(startTime, endTime) - float64(shift.LunchMinutes)/60.0
-
-		// Apply novelties (can increase or decrease hours)
-		adjustedHours := baseWorkedHours + noveltyMap[dateKey]
-		absenceHours := absenceMap[dateKey]
-
-		// Calculate extra hours (anything above 7.33 hours standard)
-		extraHours := adjustedHours - 7.33
-		if extraHours < 0 {
-			extraHours = 0
+		dayPeriods := periods[dateKey]
+		if len(dayPeriods) == 0 {
+			continue // Nothing worked on this day
 		}
 
-		// Split extra hours by time periods (diurnal vs nocturnal)
-		diurnalHours, nocturnalHours := utils.SplitByFranja(
-			startTime, endTime,
-			utils.ParseHour("06:00"), utils.ParseHour("21:00"),
-		)
-
-		// Calculate proportional extra hours distribution
-		var diurnalExtra, nocturnalExtra float64
-		totalPeriodHours := diurnalHours + nocturnalHours
-		if totalPeriodHours > 0 {
-			scale := extraHours / totalPeriodHours
-			diurnalExtra = utils.RoundTo2(scale * diurnalHours)
-			nocturnalExtra = utils.RoundTo2(scale * nocturnalHours)
+		// Limits in force on this date; several periods on a day share its ordinary hours
+		workConfig := data.workConfigs.At(date)
+		for _, period := range dayPeriods {
+			// Split ordinary and extra hours (above the daily ordinary hours) by date and time period
+			segments := utils.SplitShiftByDay(date, period.start, period.end, period.hours, workConfig, data.dayTypeOf(period.storeID))
+			if period.hours > 0 {
+				workConfig.DailyOrdinaryHours = math.Max(0, workConfig.DailyOrdinaryHours-period.hours)
+			}
+			for _, segment := range segments {
+				if segment.Date.Before(from) || segment.Date.After(to) {
+					continue // Counted in the adjacent month or period
+				}
+				block := blockFor(segment.DayType)
+				block.OrdinaryDiurnal += segment.OrdinaryDiurnal
+				block.OrdinaryNocturnal += segment.OrdinaryNocturnal
+				block.DiurnalExtra += segment.DiurnalExtra
+				block.NocturnalExtra += segment.NocturnalExtra
+			}
 		}
 
-		// Determine which block to update based on day type
-		var targetBlock *domain.EmployeeHourBlock
-		switch day.DayType {
-		case utils.Sunday:
-			targetBlock = &summary.Sunday
-		case utils.Holiday:
-			targetBlock = &summary.Holiday
-		default:
-			targetBlock = &summary.Ordinary
-		}
-
-		// Update the appropriate block
-		targetBlock.Absence += utils.RoundTo2(absenceHours)
-		targetBlock.Novelty += utils.RoundTo2(noveltyMap[dateKey])
-		targetBlock.DiurnalExtra += diurnalExtra
-		targetBlock.NocturnalExtra += nocturnalExtra
-
 		processedDays++
 	}
 
-	uc.logger.LogOperation("GetMonthlySummary", "success", map[string]interface{}{
-		"employee_id":    employeeID,
-		"employee_name":  fullNameEmployee,
-		"year":           year,
-		"month":          month,
-		"processed_days": processedDays,
-		"total_ordinary": summary.Ordinary,
-		"total_sunday":   summary.Sunday,
-		"total_holiday":  summary.Holiday,
-	})
-
-	return summary, nil
+	return summary, processedDays
 }
 
 // ✅ Business-specific operations with enhanced validation and logging
@@ -323,7 +677,7 @@ func (uc *employeeHoursUseCase) ValidatePeriod(year, month int) error {
 }
 
 // CalculateWorkingDays calculates the number of working days for an employee in a given month
-func (uc *employeeHoursUseCase) CalculateWorkingDays(employeeID, year, month int) (int, error) {
+func (uc *employeeHoursUseCase) CalculateWorkingDays(scope domain.TenantScope, employeeID, year, month int) (int, error) {
 	uc.logger.LogOperation("CalculateWorkingDays", "start", map[string]interface{}{
 		"employee_id": employeeID,
 		"year":        year,
@@ -334,6 +688,9 @@ func (uc *employeeHoursUseCase) CalculateWorkingDays(employeeID, year, month int
 	if err := uc.ValidateEmployeeID(employeeID); err != nil {
 		return 0, err
 	}
+	if err := uc.tenant.RequireEmployee("CalculateWorkingDays", scope, employeeID); err != nil {
+		return 0, err
+	}
 	if err := uc.ValidatePeriod(year, month); err != nil {
 		return 0, err
 	}
@@ -362,7 +719,7 @@ func (uc *employeeHoursUseCase) CalculateWorkingDays(employeeID, year, month int
 }
 
 // GetDailySummary provides detailed hours breakdown for a specific day
-func (uc *employeeHoursUseCase) GetDailySummary(employeeID int, year, month, day int) (DailyHoursSummary, error) {
+func (uc *employeeHoursUseCase) GetDailySummary(scope domain.TenantScope, employeeID int, year, month, day int) (DailyHoursSummary, error) {
 	uc.logger.LogOperation("GetDailySummary", "start", map[string]interface{}{
 		"employee_id": employeeID,
 		"year":        year,
@@ -374,6 +731,9 @@ func (uc *employeeHoursUseCase) GetDailySummary(employeeID int, year, month, day
 	if err := uc.ValidateEmployeeID(employeeID); err != nil {
 		return DailyHoursSummary{}, err
 	}
+	if err := uc.tenant.RequireEmployee("GetDailySummary", scope, employeeID); err != nil {
+		return DailyHoursSummary{}, err
+	}
 	if err := uc.ValidatePeriod(year, month); err != nil {
 		return DailyHoursSummary{}, err
 	}
@@ -389,18 +749,27 @@ func (uc *employeeHoursUseCase) GetDailySummary(employeeID int, year, month, day
 	}
 
 	// Get monthly summary and extract daily data
-	monthlySummary, err := uc.GetMonthlySummary(employeeID, year, month)
+	monthlySummary, err := uc.monthlySummary(employeeID, year, month)
 	if err != nil {
-		return DailyHoursSummary{}, err // Error already logged by GetMonthlySummary
+		return DailyHoursSummary{}, err // Error already logged by monthlySummary
 	}
 
 	// For simplicity, we'll return aggregated data for the requested date
 	// In a real implementation, you might want more granular daily tracking
 	dateStr := fmt.Sprintf("%04d-%02d-%02d", year, month, day)
 
+	// Regular hours are the daily ordinary hours in force on the requested date
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	configs, err := uc.workConfigRepo.GetConfigsForPeriod(date, date)
+	if err != nil {
+		uc.logger.LogError("GetDailySummary", err, map[string]interface{}{"date": dateStr})
+		return DailyHoursSummary{}, uc.errorHandler.HandleRepositoryError("GetDailySummary", err)
+	}
+	workConfig := utils.NewWorkConfigTimeline(configs).At(date)
+
 	summary := DailyHoursSummary{
 		Date:         dateStr,
-		RegularHours: 8.0, // Standard working hours
+		RegularHours: workConfig.DailyOrdinaryHours,
 		ExtraHours:   monthlySummary.Ordinary.DiurnalExtra + monthlySummary.Ordinary.NocturnalExtra,
 		AbsenceHours: monthlySummary.Ordinary.Absence,
 		NoveltyHours: monthlySummary.Ordinary.Novelty,
@@ -418,7 +787,7 @@ func (uc *employeeHoursUseCase) GetDailySummary(employeeID int, year, month, day
 }
 
 // GetYearlySummary provides comprehensive yearly hours summary for an employee
-func (uc *employeeHoursUseCase) GetYearlySummary(employeeID, year int) (YearlyHoursSummary, error) {
+func (uc *employeeHoursUseCase) GetYearlySummary(scope domain.TenantScope, employeeID, year int) (YearlyHoursSummary, error) {
 	uc.logger.LogOperation("GetYearlySummary", "start", map[string]interface{}{
 		"employee_id": employeeID,
 		"year":        year,
@@ -428,6 +797,9 @@ func (uc *employeeHoursUseCase) GetYearlySummary(employeeID, year int) (YearlyHo
 	if err := uc.ValidateEmployeeID(employeeID); err != nil {
 		return YearlyHoursSummary{}, err
 	}
+	if err := uc.tenant.RequireEmployee("GetYearlySummary", scope, employeeID); err != nil {
+		return YearlyHoursSummary{}, err
+	}
 
 	if year < 2000 || year > 2030 {
 		err := fmt.Errorf("year must be between 2000 and 2030, got: %d", year)
@@ -454,7 +826,7 @@ func (uc *employeeHoursUseCase) GetYearlySummary(employeeID, year int) (YearlyHo
 
 	// Get data for each month
 	for month := 1; month <= 12; month++ {
-		monthSummary, err := uc.GetMonthlySummary(employeeID, year, month)
+		monthSummary, err := uc.monthlySummary(employeeID, year, month)
 		if err != nil {
 			// Log warning but continue with other months
 			uc.logger.LogError("GetYearlySummary", err, map[string]interface{}{
@@ -470,6 +842,8 @@ func (uc *employeeHoursUseCase) GetYearlySummary(employeeID, year int) (YearlyHo
 		// Accumulate totals
 		summary.TotalHours.Absence += monthSummary.Ordinary.Absence + monthSummary.Sunday.Absence + monthSummary.Holiday.Absence
 		summary.TotalHours.Novelty += monthSummary.Ordinary.Novelty + monthSummary.Sunday.Novelty + monthSummary.Holiday.Novelty
+		summary.TotalHours.OrdinaryDiurnal += monthSummary.Ordinary.OrdinaryDiurnal + monthSummary.Sunday.OrdinaryDiurnal + monthSummary.Holiday.OrdinaryDiurnal
+		summary.TotalHours.OrdinaryNocturnal += monthSummary.Ordinary.OrdinaryNocturnal + monthSummary.Sunday.OrdinaryNocturnal + monthSummary.Holiday.OrdinaryNocturnal
 		summary.TotalHours.DiurnalExtra += monthSummary.Ordinary.DiurnalExtra + monthSummary.Sunday.DiurnalExtra + monthSummary.Holiday.DiurnalExtra
 		summary.TotalHours.NocturnalExtra += monthSummary.Ordinary.NocturnalExtra + monthSummary.Sunday.NocturnalExtra + monthSummary.Holiday.NocturnalExtra
 	}
@@ -3,8 +3,10 @@ package usecase
 import (
 	"fmt"
 	"loopi-api/internal/domain"
+	"loopi-api/internal/notify"
 	"loopi-api/internal/repository"
 	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/utils"
 	"strings"
 	"time"
 
@@ -12,17 +14,17 @@ import (
 )
 
 type EmployeeUseCase interface {
-	// Standard CRUD operations
-	GetAll() ([]domain.User, error)
-	FindByID(id int) (*domain.User, error)
-	GetByStore(storeID int) ([]domain.User, error)
-	Create(user domain.User, storeID int) error
-	Update(id int, fields map[string]interface{}) error
-	Delete(id int) error
+	// Standard CRUD operations, scoped to the caller's franchise
+	GetAll(scope domain.TenantScope) ([]domain.User, error)
+	FindByID(scope domain.TenantScope, id int) (*domain.User, error)
+	GetByStore(scope domain.TenantScope, storeID int) ([]domain.User, error)
+	Create(scope domain.TenantScope, user domain.User, storeID int, role string) error
+	Update(scope domain.TenantScope, id int, fields map[string]interface{}) error
+	Delete(scope domain.TenantScope, id int) error
 
 	// Business-specific operations
-	GetActiveEmployees() ([]domain.User, error)
-	GetByStoreAndActive(storeID int) ([]domain.User, error)
+	GetActiveEmployees(scope domain.TenantScope) ([]domain.User, error)
+	GetByStoreAndActive(scope domain.TenantScope, storeID int) ([]domain.User, error)
 	ValidateEmployeeData(user *domain.User) error
 	ValidateEmployeeCredentials(email, documentNumber string) error
 	HashPassword(password string) (string, error)
@@ -30,34 +32,58 @@ type EmployeeUseCase interface {
 }
 
 type employeeUseCase struct {
-	userRepo      repository.UserRepository
-	storeRepo     repository.StoreRepository
-	franchiseRepo repository.FranchiseRepository
-	errorHandler  *base.ErrorHandler
-	validator     *base.Validator
-	logger        *base.Logger
+	userRepo     repository.UserRepository
+	roleRepo     repository.RoleRepository
+	sessionRepo  repository.AuthSessionRepository
+	notifier     notify.Notifier
+	tenant       *TenantGuard
+	errorHandler *base.ErrorHandler
+	validator    *base.Validator
+	logger       *base.Logger
 }
 
-func NewEmployeeUseCase(userRepo repository.UserRepository, storeRepo repository.StoreRepository, franchiseRepo repository.FranchiseRepository) EmployeeUseCase {
+func NewEmployeeUseCase(
+	userRepo repository.UserRepository,
+	roleRepo repository.RoleRepository,
+	sessionRepo repository.AuthSessionRepository,
+	notifier notify.Notifier,
+	tenant *TenantGuard,
+) EmployeeUseCase {
 	return &employeeUseCase{
-		userRepo:      userRepo,
-		storeRepo:     storeRepo,
-		franchiseRepo: franchiseRepo,
-		errorHandler:  base.NewErrorHandler("Employee"),
-		validator:     base.NewValidator(),
-		logger:        base.NewLogger("Employee"),
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		sessionRepo:  sessionRepo,
+		notifier:     notifier,
+		tenant:       tenant,
+		errorHandler: base.NewErrorHandler("Employee"),
+		validator:    base.NewValidator(),
+		logger:       base.NewLogger("Employee"),
 	}
 }
 
 // ✅ Enhanced CRUD operations with logging, validation, and error handling
 
-// GetAll retrieves all employees with logging and error handling
-func (uc *employeeUseCase) GetAll() ([]domain.User, error) {
-	uc.logger.LogOperation("GetAll", "start", nil)
+// GetAll retrieves all employees of the franchise with logging and error handling
+func (uc *employeeUseCase) GetAll(scope domain.TenantScope) ([]domain.User, error) {
+	uc.logger.LogOperation("GetAll", "start", map[string]interface{}{
+		"franchise_id": scope.FranchiseID,
+	})
+
+	if err := uc.tenant.RequireFranchise("GetAll", scope, scope.FranchiseID); err != nil {
+		return nil, err
+	}
 
-	employees, err := uc.userRepo.GetAll()
+	var employees []domain.User
+	var err error
+	if scope.RestrictedToStore() {
+		employees, err = uc.userRepo.GetByStore(scope.StoreID)
+	} else {
+		employees, err = uc.userRepo.GetByFranchise(scope.FranchiseID)
+	}
 	if err != nil {
-		uc.logger.LogError("GetAll", err, nil)
+		uc.logger.LogError("GetAll", err, map[string]interface{}{
+			"franchise_id": scope.FranchiseID,
+		})
 		return nil, uc.errorHandler.HandleRepositoryError("GetAll", err)
 	}
 
@@ -69,7 +95,7 @@ func (uc *employeeUseCase) GetAll() ([]domain.User, error) {
 }
 
 // GetByStore retrieves employees by store with validation and logging
-func (uc *employeeUseCase) GetByStore(storeID int) ([]domain.User, error) {
+func (uc *employeeUseCase) GetByStore(scope domain.TenantScope, storeID int) ([]domain.User, error) {
 	uc.logger.LogOperation("GetByStore", "start", map[string]interface{}{
 		"store_id": storeID,
 	})
@@ -82,6 +108,10 @@ func (uc *employeeUseCase) GetByStore(storeID int) ([]domain.User, error) {
 		return nil, uc.errorHandler.HandleValidationError("GetByStore", err)
 	}
 
+	if err := uc.tenant.RequireStore("GetByStore", scope, storeID); err != nil {
+		return nil, err
+	}
+
 	employees, err := uc.userRepo.GetByStore(storeID)
 	if err != nil {
 		uc.logger.LogError("GetByStore", err, map[string]interface{}{
@@ -99,7 +129,7 @@ func (uc *employeeUseCase) GetByStore(storeID int) ([]domain.User, error) {
 }
 
 // FindByID retrieves an employee by ID with validation and logging
-func (uc *employeeUseCase) FindByID(id int) (*domain.User, error) {
+func (uc *employeeUseCase) FindByID(scope domain.TenantScope, id int) (*domain.User, error) {
 	uc.logger.LogOperation("FindByID", "start", map[string]interface{}{
 		"employee_id": id,
 	})
@@ -112,6 +142,10 @@ func (uc *employeeUseCase) FindByID(id int) (*domain.User, error) {
 		return nil, uc.errorHandler.HandleValidationError("FindByID", err)
 	}
 
+	if err := uc.tenant.RequireEmployee("FindByID", scope, id); err != nil {
+		return nil, err
+	}
+
 	employee, err := uc.userRepo.FindByID(id)
 	if err != nil {
 		uc.logger.LogError("FindByID", err, map[string]interface{}{
@@ -135,11 +169,13 @@ func (uc *employeeUseCase) FindByID(id int) (*domain.User, error) {
 	return employee, nil
 }
 
-// Create creates a new employee with validation and password hashing
-func (uc *employeeUseCase) Create(user domain.User, storeID int) error {
+// Create creates a new employee of a store with validation and password hashing. The employee
+// gets role in the franchise: domain.RoleEmployee (default) or domain.RoleStoreManager.
+func (uc *employeeUseCase) Create(scope domain.TenantScope, user domain.User, storeID int, role string) error {
 	uc.logger.LogOperation("Create", "start", map[string]interface{}{
 		"email":    user.Email,
 		"store_id": storeID,
+		"role":     role,
 	})
 
 	// Validate employee data
@@ -160,24 +196,41 @@ func (uc *employeeUseCase) Create(user domain.User, storeID int) error {
 		return uc.errorHandler.HandleValidationError("Create", fmt.Errorf("invalid store ID: %v", err))
 	}
 
+	if err := uc.tenant.RequireStore("Create", scope, storeID); err != nil {
+		return err
+	}
+
+	// Business rule: Employees get a franchise role so they can select its context
+	roleID, err := uc.employeeRoleID(role)
+	if err != nil {
+		return err
+	}
+
 	// Set defaults
 	user.IsActive = true
 
-	// Generate default password based on franchise name + current year
-	defaultPassword, err := uc.GenerateDefaultPassword(storeID)
+	// Business rule: Vacations accrue from the hire date, which defaults to the registration day
+	if user.HireDate == nil {
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		user.HireDate = &today
+	}
+
+	// Business rule: New hires get a random temporary password they must change on first login
+	temporaryPassword, err := utils.GenerateTemporaryPassword()
 	if err != nil {
-		return err
+		return uc.errorHandler.HandleInternalError("Create", fmt.Errorf("could not generate password: %v", err))
 	}
 
 	// Hash the generated password
-	hashedPassword, err := uc.HashPassword(defaultPassword)
+	hashedPassword, err := uc.HashPassword(temporaryPassword)
 	if err != nil {
 		return err
 	}
 	user.PasswordHash = hashedPassword
+	user.MustChangePassword = true
 
-	// Execute creation with store association
-	if err := uc.userRepo.CreateWithStore(user, storeID); err != nil {
+	// Execute creation with store association and franchise role
+	if err := uc.userRepo.CreateWithStore(user, storeID, roleID, scope.FranchiseID); err != nil {
 		uc.logger.LogError("Create", err, map[string]interface{}{
 			"email":    user.Email,
 			"store_id": storeID,
@@ -185,6 +238,18 @@ func (uc *employeeUseCase) Create(user domain.User, storeID int) error {
 		return uc.errorHandler.HandleRepositoryError("Create", err)
 	}
 
+	// The employee can still request a reset token if the notification is lost
+	err = uc.notifier.Send(notify.Message{
+		To:      user.Email,
+		Subject: "Bienvenido a Loopi",
+		Body:    fmt.Sprintf("Tu contraseña temporal es: %s\nDeberás cambiarla al iniciar sesión.", temporaryPassword),
+	})
+	if err != nil {
+		uc.logger.LogError("Create", err, map[string]interface{}{
+			"email": user.Email,
+		})
+	}
+
 	uc.logger.LogOperation("Create", "success", map[string]interface{}{
 		"email":    user.Email,
 		"store_id": storeID,
@@ -193,8 +258,27 @@ func (uc *employeeUseCase) Create(user domain.User, storeID int) error {
 	return nil
 }
 
+// employeeRoleID resolves the role a new employee may be created with
+func (uc *employeeUseCase) employeeRoleID(role string) (int, error) {
+	role = strings.TrimSpace(role)
+	if role == "" {
+		role = domain.RoleEmployee
+	}
+	if role != domain.RoleEmployee && role != domain.RoleStoreManager {
+		err := fmt.Errorf("invalid role: %q. Must be %q or %q", role, domain.RoleEmployee, domain.RoleStoreManager)
+		return 0, uc.errorHandler.HandleValidationError("Create", err)
+	}
+
+	found, err := uc.roleRepo.GetByName(role)
+	if err != nil {
+		uc.logger.LogError("Create", err, map[string]interface{}{"role": role})
+		return 0, uc.errorHandler.HandleRepositoryError("Create", err)
+	}
+	return int(found.ID), nil
+}
+
 // Update updates employee fields with validation
-func (uc *employeeUseCase) Update(id int, fields map[string]interface{}) error {
+func (uc *employeeUseCase) Update(scope domain.TenantScope, id int, fields map[string]interface{}) error {
 	uc.logger.LogOperation("Update", "start", map[string]interface{}{
 		"employee_id": id,
 		"field_count": len(fields),
@@ -208,6 +292,10 @@ func (uc *employeeUseCase) Update(id int, fields map[string]interface{}) error {
 		return uc.errorHandler.HandleValidationError("Update", err)
 	}
 
+	if err := uc.tenant.RequireEmployee("Update", scope, id); err != nil {
+		return err
+	}
+
 	// Validate and clean fields
 	cleanFields, err := uc.ValidateUpdateFields(fields)
 	if err != nil {
@@ -223,6 +311,11 @@ func (uc *employeeUseCase) Update(id int, fields map[string]interface{}) error {
 		return uc.errorHandler.HandleRepositoryError("Update", err)
 	}
 
+	// Business rule: A password change ends every open session
+	if _, changed := cleanFields["password_hash"]; changed {
+		uc.revokeSessions("Update", id)
+	}
+
 	uc.logger.LogOperation("Update", "success", map[string]interface{}{
 		"employee_id":    id,
 		"updated_fields": cleanFields,
@@ -232,7 +325,7 @@ func (uc *employeeUseCase) Update(id int, fields map[string]interface{}) error {
 }
 
 // Delete removes an employee with validation
-func (uc *employeeUseCase) Delete(id int) error {
+func (uc *employeeUseCase) Delete(scope domain.TenantScope, id int) error {
 	uc.logger.LogOperation("Delete", "start", map[string]interface{}{
 		"employee_id": id,
 	})
@@ -246,7 +339,7 @@ func (uc *employeeUseCase) Delete(id int) error {
 	}
 
 	// Check if employee exists
-	employee, err := uc.FindByID(id)
+	employee, err := uc.FindByID(scope, id)
 	if err != nil {
 		return err // Error already logged by FindByID
 	}
@@ -263,6 +356,9 @@ func (uc *employeeUseCase) Delete(id int) error {
 		return uc.errorHandler.HandleRepositoryError("Delete", err)
 	}
 
+	// Business rule: A removed employee cannot keep using issued tokens
+	uc.revokeSessions("Delete", id)
+
 	uc.logger.LogOperation("Delete", "success", map[string]interface{}{
 		"employee_id": id,
 		"email":       employee.Email,
@@ -273,11 +369,13 @@ func (uc *employeeUseCase) Delete(id int) error {
 
 // ✅ Business-specific operations with enhanced validation and logging
 
-// GetActiveEmployees retrieves only active employees
-func (uc *employeeUseCase) GetActiveEmployees() ([]domain.User, error) {
-	uc.logger.LogOperation("GetActiveEmployees", "start", nil)
+// GetActiveEmployees retrieves only the active employees of the franchise
+func (uc *employeeUseCase) GetActiveEmployees(scope domain.TenantScope) ([]domain.User, error) {
+	uc.logger.LogOperation("GetActiveEmployees", "start", map[string]interface{}{
+		"franchise_id": scope.FranchiseID,
+	})
 
-	allEmployees, err := uc.GetAll()
+	allEmployees, err := uc.GetAll(scope)
 	if err != nil {
 		return nil, err // Error already logged by GetAll
 	}
@@ -299,7 +397,7 @@ func (uc *employeeUseCase) GetActiveEmployees() ([]domain.User, error) {
 }
 
 // GetByStoreAndActive retrieves active employees by store
-func (uc *employeeUseCase) GetByStoreAndActive(storeID int) ([]domain.User, error) {
+func (uc *employeeUseCase) GetByStoreAndActive(scope domain.TenantScope, storeID int) ([]domain.User, error) {
 	uc.logger.LogOperation("GetByStoreAndActive", "start", map[string]interface{}{
 		"store_id": storeID,
 	})
@@ -312,7 +410,7 @@ func (uc *employeeUseCase) GetByStoreAndActive(storeID int) ([]domain.User, erro
 		return nil, uc.errorHandler.HandleValidationError("GetByStoreAndActive", err)
 	}
 
-	allEmployees, err := uc.GetByStore(storeID)
+	allEmployees, err := uc.GetByStore(scope, storeID)
 	if err != nil {
 		return nil, err // Error already logged by GetByStore
 	}
@@ -469,43 +567,6 @@ func (uc *employeeUseCase) HashPassword(password string) (string, error) {
 	return string(hashed), nil
 }
 
-// GenerateDefaultPassword generates a default password using franchise name + current year
-func (uc *employeeUseCase) GenerateDefaultPassword(storeID int) (string, error) {
-	uc.logger.LogOperation("GenerateDefaultPassword", "start", map[string]interface{}{
-		"store_id": storeID,
-	})
-
-	// Get store information
-	store, err := uc.storeRepo.GetByID(storeID)
-	if err != nil {
-		uc.logger.LogError("GenerateDefaultPassword", err, map[string]interface{}{
-			"store_id": storeID,
-		})
-		return "", uc.errorHandler.HandleRepositoryError("GenerateDefaultPassword", fmt.Errorf("failed to get store: %v", err))
-	}
-
-	// Get franchise information
-	franchise, err := uc.franchiseRepo.GetById(int(store.FranchiseID))
-	if err != nil {
-		uc.logger.LogError("GenerateDefaultPassword", err, map[string]interface{}{
-			"franchise_id": store.FranchiseID,
-		})
-		return "", uc.errorHandler.HandleRepositoryError("GenerateDefaultPassword", fmt.Errorf("failed to get franchise: %v", err))
-	}
-
-	// Generate password: FRANCHISENAME + CURRENTYEAR
-	currentYear := time.Now().Year()
-	password := strings.ToUpper(franchise.Name) + fmt.Sprintf("%d", currentYear)
-
-	uc.logger.LogOperation("GenerateDefaultPassword", "success", map[string]interface{}{
-		"store_id":      storeID,
-		"franchise_id":  store.FranchiseID,
-		"password_hint": strings.ToUpper(franchise.Name) + "****", // Log hint without full password
-	})
-
-	return password, nil
-}
-
 // ValidateUpdateFields validates and cleans fields for update operations
 func (uc *employeeUseCase) ValidateUpdateFields(fields map[string]interface{}) (map[string]interface{}, error) {
 	uc.logger.LogOperation("ValidateUpdateFields", "start", map[string]interface{}{
@@ -523,7 +584,7 @@ func (uc *employeeUseCase) ValidateUpdateFields(fields map[string]interface{}) (
 	// Define allowed fields for update
 	allowedFields := []string{
 		"first_name", "last_name", "phone", "email", "position",
-		"salary", "document_type", "document_number", "password_hash",
+		"salary", "document_type", "document_number", "password_hash", "hire_date",
 	}
 
 	// Use validator to clean and validate fields
@@ -566,6 +627,18 @@ func (uc *employeeUseCase) ValidateUpdateFields(fields map[string]interface{}) (
 		}
 	}
 
+	// Special validation for hire date if being updated
+	if hireDateValue, exists := cleanFields["hire_date"]; exists {
+		hireDate, ok := hireDateValue.(string)
+		if _, err := time.Parse("2006-01-02", hireDate); !ok || err != nil {
+			err := fmt.Errorf("invalid hire_date format, expected YYYY-MM-DD")
+			uc.logger.LogValidation("ValidateUpdateFields", "hire_date_format", "failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return nil, uc.errorHandler.HandleValidationError("ValidateUpdateFields", err)
+		}
+	}
+
 	uc.logger.LogOperation("ValidateUpdateFields", "success", map[string]interface{}{
 		"clean_field_count": len(cleanFields),
 		"fields":            cleanFields,
@@ -573,3 +646,13 @@ func (uc *employeeUseCase) ValidateUpdateFields(fields map[string]interface{}) (
 
 	return cleanFields, nil
 }
+
+// revokeSessions ends every session of an employee; failures are logged since the
+// employee change itself already succeeded
+func (uc *employeeUseCase) revokeSessions(operation string, employeeID int) {
+	if err := uc.sessionRepo.RevokeByUser(employeeID); err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{
+			"employee_id": employeeID,
+		})
+	}
+}
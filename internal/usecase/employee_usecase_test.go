@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"loopi-api/internal/domain"
+)
+
+func TestEmployeeUseCase_CreateGrantsFranchiseRole(t *testing.T) {
+	users := &MockUserRepository{}
+	roles := &MockRoleRepository{
+		roles: []domain.Role{
+			{ID: 1, Name: "admin"},
+			{ID: 3, Name: domain.RoleStoreManager},
+			{ID: 4, Name: domain.RoleEmployee},
+		},
+		userPermissions: map[[2]int][]string{{1, 1}: {"self:read"}},
+	}
+	notifier := &MockNotifier{}
+	tenant := NewTenantGuard(&MockTenantRepository{storeFranchises: map[int]int{10: 1}})
+	uc := NewEmployeeUseCase(users, roles, &MockAuthSessionRepository{}, notifier, tenant)
+
+	newEmployee := func(email string) domain.User {
+		return domain.User{
+			FirstName: "Ana", LastName: "Pérez", Email: email, Phone: "3001234567",
+			DocumentType: "CC", DocumentNumber: "1020304050", Birthdate: "1995-04-12",
+			Position: "Cajera", Salary: 1500000,
+		}
+	}
+
+	if err := uc.Create(franchiseScope(1), newEmployee("ana@loopi.co"), 10, ""); err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	if got := users.users[0].UserRoles; len(got) != 1 || got[0].RoleID != 4 || got[0].FranchiseID != 1 {
+		t.Errorf("Expected the employee role in franchise 1, got %+v", got)
+	}
+
+	if err := uc.Create(franchiseScope(1), newEmployee("luis@loopi.co"), 10, domain.RoleStoreManager); err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	if got := users.users[1].UserRoles; len(got) != 1 || got[0].RoleID != 3 {
+		t.Errorf("Expected the store manager role, got %+v", got)
+	}
+
+	if err := uc.Create(franchiseScope(1), newEmployee("eva@loopi.co"), 10, "admin"); domainStatus(t, err) != 400 {
+		t.Errorf("Expected other roles to be rejected, got %v", err)
+	}
+
+	// The new employee can log in with the temporary password and select the store
+	stores := newTestStores()
+	stores.AssignUser(1, 10)
+	auth := newAuthUseCaseWith(t, users, stores, &MockAuthSessionRepository{}, &MockNotifier{}, roles)
+	body := notifier.messages[0].Body
+	password := strings.TrimSpace(strings.SplitN(strings.TrimPrefix(body, "Tu contraseña temporal es: "), "\n", 2)[0])
+	if _, err := auth.Login("ana@loopi.co", password, "10.0.0.1"); err != nil {
+		t.Fatalf("Login: unexpected error %v", err)
+	}
+	if _, err := auth.SelectContext(1, 1, 1, 10); err != nil {
+		t.Errorf("Expected the new employee to select the store, got %v", err)
+	}
+}
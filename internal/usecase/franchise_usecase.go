@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"loopi-api/internal/calendar"
 	"loopi-api/internal/domain"
 	"loopi-api/internal/repository"
 	"loopi-api/internal/usecase/base"
@@ -142,6 +143,9 @@ func (uc *franchiseUseCase) GetActiveFranchises() ([]domain.Franchise, error) {
 
 // ValidateFranchiseData validates franchise data according to business rules
 func (uc *franchiseUseCase) ValidateFranchiseData(franchise *domain.Franchise) error {
+	// Business rule: the holiday calendar country defaults to calendar.DefaultCountry
+	franchise.Country = calendar.NormalizeCountry(franchise.Country)
+
 	// Basic entity validation
 	if err := uc.validator.ValidateEntity(franchise); err != nil {
 		uc.logger.LogValidation("ValidateFranchiseData", "entity", "failed", map[string]interface{}{
@@ -158,6 +162,13 @@ func (uc *franchiseUseCase) ValidateFranchiseData(franchise *domain.Franchise) e
 		return err
 	}
 
+	if err := ValidateHolidayCountry(franchise.Country); err != nil {
+		uc.logger.LogValidation("ValidateFranchiseData", "country", "failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return uc.errorHandler.HandleValidationError("ValidateFranchiseData", err)
+	}
+
 	uc.logger.LogValidation("ValidateFranchiseData", "all_fields", "passed", nil)
 	return nil
 }
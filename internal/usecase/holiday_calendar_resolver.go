@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/calendar"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/utils"
+	"sort"
+	"time"
+)
+
+// CalendarScope identifies whose holiday calendar is requested: the computed calendar of
+// Country plus the custom holidays that apply to FranchiseID and StoreID (zero = none)
+type CalendarScope struct {
+	Country     string `json:"country"`
+	FranchiseID int    `json:"franchise_id,omitempty"`
+	StoreID     int    `json:"store_id,omitempty"`
+}
+
+// HolidayCalendarResolver selects the holiday calendar of a store or employee.
+// A store uses its own country when set and falls back to its franchise's country.
+type HolidayCalendarResolver struct {
+	storeRepo     repository.StoreRepository
+	franchiseRepo repository.FranchiseRepository
+	holidayRepo   repository.HolidayRepository
+	errorHandler  *base.ErrorHandler
+	logger        *base.Logger
+}
+
+// NewHolidayCalendarResolver creates a resolver backed by the store, franchise and custom holiday repositories
+func NewHolidayCalendarResolver(
+	storeRepo repository.StoreRepository,
+	franchiseRepo repository.FranchiseRepository,
+	holidayRepo repository.HolidayRepository,
+) *HolidayCalendarResolver {
+	return &HolidayCalendarResolver{
+		storeRepo:     storeRepo,
+		franchiseRepo: franchiseRepo,
+		holidayRepo:   holidayRepo,
+		errorHandler:  base.NewErrorHandler("HolidayCalendar"),
+		logger:        base.NewLogger("HolidayCalendar"),
+	}
+}
+
+// ScopeForStore returns the holiday calendar scope of a store
+func (r *HolidayCalendarResolver) ScopeForStore(operation string, storeID int) (CalendarScope, error) {
+	store, err := r.storeRepo.GetByID(storeID)
+	if err != nil {
+		r.logger.LogError(operation, err, map[string]interface{}{"store_id": storeID})
+		return CalendarScope{}, r.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	scope := CalendarScope{
+		Country:     calendar.NormalizeCountry(store.Country),
+		FranchiseID: int(store.FranchiseID),
+		StoreID:     storeID,
+	}
+	if store.Country != "" {
+		return scope, nil
+	}
+
+	franchise, err := r.franchiseRepo.GetById(int(store.FranchiseID))
+	if err != nil {
+		r.logger.LogError(operation, err, map[string]interface{}{
+			"store_id":     storeID,
+			"franchise_id": store.FranchiseID,
+		})
+		return CalendarScope{}, r.errorHandler.HandleRepositoryError(operation, err)
+	}
+	scope.Country = calendar.NormalizeCountry(franchise.Country)
+	return scope, nil
+}
+
+// ScopeForEmployee returns the holiday calendar scope of an employee where no store applies,
+// such as the days of an absence. An employee of one store uses that store's scope; of several
+// stores, their shared country and franchise without store holidays. Stores in several countries
+// are rejected, since the calendar then depends on the store of each shift.
+// Employees without a store use calendar.DefaultCountry and global custom holidays only.
+func (r *HolidayCalendarResolver) ScopeForEmployee(operation string, employeeID int) (CalendarScope, error) {
+	stores, err := r.storeRepo.GetByUserID(employeeID)
+	if err != nil {
+		r.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return CalendarScope{}, r.errorHandler.HandleRepositoryError(operation, err)
+	}
+	if len(stores) == 0 {
+		return CalendarScope{Country: calendar.DefaultCountry}, nil
+	}
+	if len(stores) == 1 {
+		return r.ScopeForStore(operation, int(stores[0].ID))
+	}
+
+	var scope CalendarScope
+	for i, store := range stores {
+		storeScope, err := r.ScopeForStore(operation, int(store.ID))
+		if err != nil {
+			return CalendarScope{}, err
+		}
+		if i == 0 {
+			scope = CalendarScope{Country: storeScope.Country, FranchiseID: storeScope.FranchiseID}
+			continue
+		}
+		if storeScope.Country != scope.Country {
+			r.logger.LogBusinessRule(operation, "employee_calendar", "ambiguous", map[string]interface{}{
+				"employee_id": employeeID,
+				"countries":   []string{scope.Country, storeScope.Country},
+			})
+			return CalendarScope{}, r.errorHandler.HandleBusinessRuleViolation(operation, "employee_calendar",
+				fmt.Sprintf("employee %d works in stores of several countries (%s, %s); the holiday calendar must come from a store",
+					employeeID, scope.Country, storeScope.Country))
+		}
+		if storeScope.FranchiseID != scope.FranchiseID {
+			scope.FranchiseID = 0
+		}
+	}
+	return scope, nil
+}
+
+// LocationForStore returns the time zone of the store's calendar country, used to read
+// punched instants as the store's wall-clock time
+func (r *HolidayCalendarResolver) LocationForStore(operation string, storeID int) (*time.Location, error) {
+	scope, err := r.ScopeForStore(operation, storeID)
+	if err != nil {
+		return nil, err
+	}
+	return calendar.Location(scope.Country), nil
+}
+
+// ResolveScope picks the scope of a calendar request: the store's scope when a store
+// is given, otherwise the explicit country, otherwise calendar.DefaultCountry
+func (r *HolidayCalendarResolver) ResolveScope(operation string, country string, storeID int) (CalendarScope, error) {
+	if storeID > 0 {
+		return r.ScopeForStore(operation, storeID)
+	}
+	if err := ValidateHolidayCountry(country); err != nil {
+		return CalendarScope{}, r.errorHandler.HandleValidationError(operation, err)
+	}
+	return CalendarScope{Country: calendar.NormalizeCountry(country)}, nil
+}
+
+// HolidayDetails returns the computed holidays of the scope's country merged with the custom
+// holidays that apply to it, sorted by date. A zero month means the whole year.
+func (r *HolidayCalendarResolver) HolidayDetails(operation string, scope CalendarScope, year, month int) ([]calendar.Holiday, error) {
+	var computed []calendar.Holiday
+	var from, to time.Time
+	if month == 0 {
+		computed = calendar.GetHolidayDetailsCached(scope.Country, year)
+		from = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(1, 0, -1)
+	} else {
+		computed = calendar.GetHolidayDetailsByMonthCached(scope.Country, year, month)
+		from = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(0, 1, -1)
+	}
+
+	custom, err := r.holidayRepo.GetApplicable(scope.Country, scope.FranchiseID, scope.StoreID,
+		from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		r.logger.LogError(operation, err, map[string]interface{}{
+			"country":  scope.Country,
+			"store_id": scope.StoreID,
+		})
+		return nil, r.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	holidays := append([]calendar.Holiday{}, computed...)
+	for _, holiday := range custom {
+		date, err := time.Parse("2006-01-02", holiday.Date)
+		if err != nil {
+			continue
+		}
+		holidays = append(holidays, calendar.Holiday{
+			Date:     date,
+			Name:     holiday.Name,
+			Category: calendar.CategoryCustom,
+		})
+	}
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+
+	return holidays, nil
+}
+
+// Holidays returns the holiday dates of the scope, sorted and without duplicates.
+// A zero month means the whole year.
+func (r *HolidayCalendarResolver) Holidays(operation string, scope CalendarScope, year, month int) ([]time.Time, error) {
+	details, err := r.HolidayDetails(operation, scope, year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays := make([]time.Time, 0, len(details))
+	for _, holiday := range details {
+		if n := len(holidays); n > 0 && holidays[n-1].Equal(holiday.Date) {
+			continue
+		}
+		holidays = append(holidays, holiday.Date)
+	}
+	return holidays, nil
+}
+
+// HolidaysByMonth returns the holidays of the scope in a month keyed by "YYYY-MM-DD"
+func (r *HolidayCalendarResolver) HolidaysByMonth(operation string, scope CalendarScope, year, month int) (map[string]bool, error) {
+	holidays, err := r.Holidays(operation, scope, year, month)
+	if err != nil {
+		return nil, err
+	}
+	return utils.HolidaysToMap(holidays), nil
+}
+
+// ValidateHolidayCountry rejects countries without a registered holiday calendar.
+// An empty country is valid and means calendar.DefaultCountry.
+func ValidateHolidayCountry(country string) error {
+	if !calendar.IsSupportedCountry(country) {
+		return fmt.Errorf("unsupported country: %q. Supported: %v", country, calendar.SupportedCountries())
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"loopi-api/internal/domain"
+	repotesting "loopi-api/internal/repository/testing"
+)
+
+// MockHolidayRepository for testing; GetApplicable filters like the MySQL query
+type MockHolidayRepository struct {
+	holidays []domain.Holiday
+}
+
+func (m *MockHolidayRepository) GetByID(id int) (*domain.Holiday, error) {
+	for i := range m.holidays {
+		if int(m.holidays[i].ID) == id {
+			holiday := m.holidays[i]
+			return &holiday, nil
+		}
+	}
+	return nil, errors.New("holiday not found")
+}
+func (m *MockHolidayRepository) GetByDateRange(from, to string) ([]domain.Holiday, error) {
+	return m.holidays, nil
+}
+func (m *MockHolidayRepository) Create(holiday *domain.Holiday) error {
+	holiday.ID = uint(len(m.holidays) + 1)
+	m.holidays = append(m.holidays, *holiday)
+	return nil
+}
+func (m *MockHolidayRepository) Update(holiday *domain.Holiday) error { return nil }
+func (m *MockHolidayRepository) Delete(id int) error                  { return nil }
+
+func (m *MockHolidayRepository) GetApplicable(country string, franchiseID, storeID int, from, to string) ([]domain.Holiday, error) {
+	var applicable []domain.Holiday
+	for _, h := range m.holidays {
+		if h.Date < from || h.Date > to {
+			continue
+		}
+		switch {
+		case h.Scope == domain.HolidayScopeGlobal && (h.Country == "" || h.Country == country),
+			h.Scope == domain.HolidayScopeFranchise && int(*h.FranchiseID) == franchiseID,
+			h.Scope == domain.HolidayScopeStore && int(*h.StoreID) == storeID:
+			applicable = append(applicable, h)
+		}
+	}
+	return applicable, nil
+}
+
+func TestHolidayCalendarResolver_MergesCustomHolidays(t *testing.T) {
+	storeRepo := repotesting.NewMockStoreRepository()
+	storeRepo.SeedData([]domain.Store{
+		{BaseEntity: domain.BaseEntity{ID: 1}, FranchiseID: 1, Name: "Medellín"},
+		{BaseEntity: domain.BaseEntity{ID: 2}, FranchiseID: 1, Name: "Quito", Country: "EC"},
+	})
+	franchiseRepo := &MockFranchiseRepository{franchises: []domain.Franchise{
+		{BaseEntity: domain.BaseEntity{ID: 1}, Name: "Franchise A", Country: "CO"},
+	}}
+	one, two := uint(1), uint(2)
+	holidayRepo := &MockHolidayRepository{holidays: []domain.Holiday{
+		{Date: "2025-10-01", Name: "Día cívico", Scope: domain.HolidayScopeStore, StoreID: &one},
+		{Date: "2025-10-02", Name: "Cierre Quito", Scope: domain.HolidayScopeStore, StoreID: &two},
+		{Date: "2025-10-03", Name: "Aniversario", Scope: domain.HolidayScopeFranchise, FranchiseID: &one},
+		{Date: "2025-10-04", Name: "Día nacional", Scope: domain.HolidayScopeGlobal, Country: "CO"},
+		{Date: "2025-10-13", Name: "Duplicado", Scope: domain.HolidayScopeGlobal}, // already a Colombian holiday
+	}}
+	resolver := NewHolidayCalendarResolver(storeRepo, franchiseRepo, holidayRepo)
+
+	scope, err := resolver.ScopeForStore("test", 1)
+	if err != nil {
+		t.Fatalf("ScopeForStore: unexpected error %v", err)
+	}
+	if scope.Country != "CO" {
+		t.Errorf("store 1: expected franchise country CO, got %s", scope.Country)
+	}
+
+	holidays, err := resolver.HolidaysByMonth("test", scope, 2025, 10)
+	if err != nil {
+		t.Fatalf("HolidaysByMonth: unexpected error %v", err)
+	}
+	// Computed: Oct 13 (Día de la Raza); custom: Oct 1, 3 and 4
+	expected := []string{"2025-10-01", "2025-10-03", "2025-10-04", "2025-10-13"}
+	if len(holidays) != len(expected) {
+		t.Errorf("store 1: expected %d holidays, got %d: %v", len(expected), len(holidays), holidays)
+	}
+	for _, date := range expected {
+		if !holidays[date] {
+			t.Errorf("store 1: expected holiday on %s", date)
+		}
+	}
+
+	scope, _ = resolver.ScopeForStore("test", 2)
+	if scope.Country != "EC" {
+		t.Errorf("store 2: expected own country EC, got %s", scope.Country)
+	}
+	holidays, _ = resolver.HolidaysByMonth("test", scope, 2025, 10)
+	if holidays["2025-10-01"] || holidays["2025-10-04"] || !holidays["2025-10-02"] || !holidays["2025-10-03"] {
+		t.Errorf("store 2: unexpected custom holidays %v", holidays)
+	}
+}
+
+func TestHolidayCalendarResolver_ScopeForEmployee(t *testing.T) {
+	storeRepo := repotesting.NewMockStoreRepository()
+	storeRepo.SeedData([]domain.Store{
+		{BaseEntity: domain.BaseEntity{ID: 1}, FranchiseID: 1, Name: "Medellín", Country: "CO"},
+		{BaseEntity: domain.BaseEntity{ID: 2}, FranchiseID: 1, Name: "Bogotá", Country: "CO"},
+		{BaseEntity: domain.BaseEntity{ID: 3}, FranchiseID: 1, Name: "Quito", Country: "EC"},
+	})
+	storeRepo.AssignUser(7, 1)
+	storeRepo.AssignUser(8, 1)
+	storeRepo.AssignUser(8, 2)
+	storeRepo.AssignUser(9, 1)
+	storeRepo.AssignUser(9, 3)
+	resolver := NewHolidayCalendarResolver(storeRepo, &MockFranchiseRepository{}, &MockHolidayRepository{})
+
+	if scope, err := resolver.ScopeForEmployee("test", 7); err != nil || scope.StoreID != 1 {
+		t.Errorf("single store: expected the store's scope, got %+v, %v", scope, err)
+	}
+
+	// Stores of one country share the country and franchise, but not store holidays
+	scope, err := resolver.ScopeForEmployee("test", 8)
+	if err != nil {
+		t.Fatalf("ScopeForEmployee: unexpected error %v", err)
+	}
+	if scope.Country != "CO" || scope.FranchiseID != 1 || scope.StoreID != 0 {
+		t.Errorf("same country: unexpected scope %+v", scope)
+	}
+
+	if _, err := resolver.ScopeForEmployee("test", 9); domainStatus(t, err) != 422 {
+		t.Errorf("several countries: expected 422, got %v", err)
+	}
+}
@@ -0,0 +1,307 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/calendar"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"strings"
+	"time"
+)
+
+type HolidayUseCase interface {
+	// Standard CRUD operations
+	GetByID(scope domain.TenantScope, id int) (*domain.Holiday, error)
+	GetByYear(scope domain.TenantScope, year int) ([]domain.Holiday, error)
+	Create(scope domain.TenantScope, req dto.HolidayRequest) (*domain.Holiday, error)
+	Update(scope domain.TenantScope, id int, req dto.HolidayRequest) (*domain.Holiday, error)
+	Delete(scope domain.TenantScope, id int) error
+
+	// Business-specific operations
+	ValidateHolidayRequest(scope domain.TenantScope, req dto.HolidayRequest) (*domain.Holiday, error)
+}
+
+// holidayUseCase manages custom holidays. Callers see global holidays and those of their
+// franchise and stores; global holidays can only be changed with domain.PermissionPlatform.
+type holidayUseCase struct {
+	holidayRepo  repository.HolidayRepository
+	storeRepo    repository.StoreRepository
+	tenant       *TenantGuard
+	errorHandler *base.ErrorHandler
+	validator    *base.Validator
+	logger       *base.Logger
+}
+
+func NewHolidayUseCase(
+	holidayRepo repository.HolidayRepository,
+	storeRepo repository.StoreRepository,
+	tenant *TenantGuard,
+) HolidayUseCase {
+	return &holidayUseCase{
+		holidayRepo:  holidayRepo,
+		storeRepo:    storeRepo,
+		tenant:       tenant,
+		errorHandler: base.NewErrorHandler("Holiday"),
+		validator:    base.NewValidator(),
+		logger:       base.NewLogger("Holiday"),
+	}
+}
+
+// ✅ Enhanced CRUD operations with logging, validation, and error handling
+
+// GetByID retrieves a custom holiday visible to the scope; others are reported as not found
+func (uc *holidayUseCase) GetByID(scope domain.TenantScope, id int) (*domain.Holiday, error) {
+	uc.logger.LogOperation("GetByID", "start", map[string]interface{}{"id": id})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByID", err)
+	}
+
+	holiday, err := uc.holidayRepo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("GetByID", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByID", err)
+	}
+
+	switch {
+	case holiday.StoreID != nil:
+		if err := uc.tenant.RequireStore("GetByID", scope, int(*holiday.StoreID)); err != nil {
+			return nil, err
+		}
+	case holiday.FranchiseID != nil:
+		if int(*holiday.FranchiseID) != scope.FranchiseID {
+			return nil, uc.errorHandler.HandleNotFound("GetByID", fmt.Sprintf("holiday not found with ID: %d", id))
+		}
+	}
+
+	uc.logger.LogOperation("GetByID", "success", map[string]interface{}{"id": id})
+	return holiday, nil
+}
+
+// GetByYear retrieves the custom holidays of a year that are global or belong to the
+// scope's franchise or to its stores within the scope
+func (uc *holidayUseCase) GetByYear(scope domain.TenantScope, year int) ([]domain.Holiday, error) {
+	uc.logger.LogOperation("GetByYear", "start", map[string]interface{}{
+		"year":         year,
+		"franchise_id": scope.FranchiseID,
+	})
+
+	if year < 2000 || year > 2100 {
+		err := fmt.Errorf("invalid year: %d. Must be between 2000-2100", year)
+		return nil, uc.errorHandler.HandleValidationError("GetByYear", err)
+	}
+
+	holidays, err := uc.holidayRepo.GetByDateRange(fmt.Sprintf("%d-01-01", year), fmt.Sprintf("%d-12-31", year))
+	if err != nil {
+		uc.logger.LogError("GetByYear", err, map[string]interface{}{"year": year})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByYear", err)
+	}
+
+	stores, err := uc.storeRepo.GetByFranchiseID(scope.FranchiseID)
+	if err != nil {
+		uc.logger.LogError("GetByYear", err, map[string]interface{}{"franchise_id": scope.FranchiseID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByYear", err)
+	}
+	storesInScope := make(map[uint]bool, len(stores))
+	for _, store := range stores {
+		if scope.AllowsStore(int(store.ID)) {
+			storesInScope[store.ID] = true
+		}
+	}
+
+	visible := make([]domain.Holiday, 0, len(holidays))
+	for _, holiday := range holidays {
+		switch {
+		case holiday.StoreID != nil:
+			if !storesInScope[*holiday.StoreID] {
+				continue
+			}
+		case holiday.FranchiseID != nil:
+			if int(*holiday.FranchiseID) != scope.FranchiseID {
+				continue
+			}
+		}
+		visible = append(visible, holiday)
+	}
+
+	uc.logger.LogOperation("GetByYear", "success", map[string]interface{}{
+		"year":  year,
+		"count": len(visible),
+	})
+
+	return visible, nil
+}
+
+// Create registers a custom holiday merged into the calendar of its scope
+func (uc *holidayUseCase) Create(scope domain.TenantScope, req dto.HolidayRequest) (*domain.Holiday, error) {
+	uc.logger.LogOperation("Create", "start", map[string]interface{}{
+		"date":  req.Date,
+		"scope": req.Scope,
+	})
+
+	holiday, err := uc.ValidateHolidayRequest(scope, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireWritable("Create", scope, holiday); err != nil {
+		return nil, err
+	}
+
+	if err := uc.holidayRepo.Create(holiday); err != nil {
+		uc.logger.LogError("Create", err, map[string]interface{}{"date": req.Date})
+		return nil, uc.errorHandler.HandleRepositoryError("Create", err)
+	}
+
+	uc.logger.LogOperation("Create", "success", map[string]interface{}{
+		"id":    holiday.ID,
+		"date":  holiday.Date,
+		"scope": holiday.Scope,
+	})
+
+	return holiday, nil
+}
+
+// Update replaces the date, name and scope of a custom holiday
+func (uc *holidayUseCase) Update(scope domain.TenantScope, id int, req dto.HolidayRequest) (*domain.Holiday, error) {
+	uc.logger.LogOperation("Update", "start", map[string]interface{}{
+		"id":    id,
+		"date":  req.Date,
+		"scope": req.Scope,
+	})
+
+	current, err := uc.GetByID(scope, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireWritable("Update", scope, current); err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.ValidateHolidayRequest(scope, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireWritable("Update", scope, updated); err != nil {
+		return nil, err
+	}
+	updated.ID = current.ID
+	updated.CreatedAt = current.CreatedAt
+	updated.UpdatedAt = time.Now()
+
+	if err := uc.holidayRepo.Update(updated); err != nil {
+		uc.logger.LogError("Update", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Update", err)
+	}
+
+	uc.logger.LogOperation("Update", "success", map[string]interface{}{"id": id})
+	return updated, nil
+}
+
+// Delete removes a custom holiday
+func (uc *holidayUseCase) Delete(scope domain.TenantScope, id int) error {
+	uc.logger.LogOperation("Delete", "start", map[string]interface{}{"id": id})
+
+	current, err := uc.GetByID(scope, id)
+	if err != nil {
+		return err
+	}
+	if err := uc.requireWritable("Delete", scope, current); err != nil {
+		return err
+	}
+
+	if err := uc.holidayRepo.Delete(id); err != nil {
+		uc.logger.LogError("Delete", err, map[string]interface{}{"id": id})
+		return uc.errorHandler.HandleRepositoryError("Delete", err)
+	}
+
+	uc.logger.LogOperation("Delete", "success", map[string]interface{}{"id": id})
+	return nil
+}
+
+// ✅ Business-specific operations with enhanced validation and logging
+
+// ValidateHolidayRequest validates a custom holiday request and builds the entity.
+// A franchise holiday belongs to the scope's franchise; a store holiday to a store within
+// the scope, the selected one for store-restricted callers when store_id is omitted.
+func (uc *holidayUseCase) ValidateHolidayRequest(scope domain.TenantScope, req dto.HolidayRequest) (*domain.Holiday, error) {
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		err := fmt.Errorf("invalid date: %q. Expected format: YYYY-MM-DD", req.Date)
+		uc.logger.LogValidation("ValidateHolidayRequest", "date", "failed", map[string]interface{}{"error": err.Error()})
+		return nil, uc.errorHandler.HandleValidationError("ValidateHolidayRequest", err)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if err := uc.validator.ValidateString(name, "name", "required", "min:3", "max:100"); err != nil {
+		uc.logger.LogValidation("ValidateHolidayRequest", "name", "failed", map[string]interface{}{"error": err.Error()})
+		return nil, uc.errorHandler.HandleValidationError("ValidateHolidayRequest", err)
+	}
+
+	holiday := &domain.Holiday{
+		Date:  req.Date,
+		Name:  name,
+		Scope: req.Scope,
+	}
+
+	switch req.Scope {
+	case domain.HolidayScopeGlobal:
+		if req.Country != "" {
+			if err := ValidateHolidayCountry(req.Country); err != nil {
+				return nil, uc.errorHandler.HandleValidationError("ValidateHolidayRequest", err)
+			}
+			holiday.Country = calendar.NormalizeCountry(req.Country)
+		}
+
+	case domain.HolidayScopeFranchise:
+		if req.FranchiseID != 0 && req.FranchiseID != scope.FranchiseID {
+			return nil, uc.errorHandler.HandleNotFound("ValidateHolidayRequest",
+				fmt.Sprintf("franchise not found with ID: %d", req.FranchiseID))
+		}
+		franchiseID := uint(scope.FranchiseID)
+		holiday.FranchiseID = &franchiseID
+
+	case domain.HolidayScopeStore:
+		storeID := req.StoreID
+		if storeID == 0 && scope.RestrictedToStore() {
+			storeID = scope.StoreID
+		}
+		if err := uc.validator.ValidateID(storeID); err != nil {
+			return nil, uc.errorHandler.HandleValidationError("ValidateHolidayRequest", fmt.Errorf("store_id: %w", err))
+		}
+		if err := uc.tenant.RequireStore("ValidateHolidayRequest", scope, storeID); err != nil {
+			return nil, err
+		}
+		scopedStoreID := uint(storeID)
+		holiday.StoreID = &scopedStoreID
+
+	default:
+		err := fmt.Errorf("invalid scope: %q. Must be one of: %s, %s, %s", req.Scope,
+			domain.HolidayScopeGlobal, domain.HolidayScopeFranchise, domain.HolidayScopeStore)
+		uc.logger.LogValidation("ValidateHolidayRequest", "scope", "failed", map[string]interface{}{"error": err.Error()})
+		return nil, uc.errorHandler.HandleValidationError("ValidateHolidayRequest", err)
+	}
+
+	uc.logger.LogValidation("ValidateHolidayRequest", "all_fields", "passed", nil)
+	return holiday, nil
+}
+
+// requireWritable checks that the caller may change a holiday of its scope: global holidays
+// need domain.PermissionPlatform and franchise holidays a caller not restricted to a store.
+// Store holidays were already checked against the scope.
+func (uc *holidayUseCase) requireWritable(operation string, scope domain.TenantScope, holiday *domain.Holiday) error {
+	switch {
+	case holiday.StoreID != nil:
+		return nil
+	case holiday.FranchiseID != nil:
+		return uc.tenant.RequireAllStores(operation, scope)
+	default:
+		if err := uc.tenant.RequirePlatform(operation, scope); err != nil {
+			uc.logger.LogBusinessRule(operation, "global_holiday", "rejected", map[string]interface{}{
+				"franchise_id": scope.FranchiseID,
+			})
+			return err
+		}
+		return nil
+	}
+}
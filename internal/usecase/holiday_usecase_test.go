@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"testing"
+
+	"loopi-api/internal/domain"
+	repotesting "loopi-api/internal/repository/testing"
+	"loopi-api/internal/usecase/dto"
+)
+
+func newTestHolidayUseCase() (HolidayUseCase, *MockHolidayRepository) {
+	storeRepo := repotesting.NewMockStoreRepository()
+	storeRepo.SeedData([]domain.Store{
+		{BaseEntity: domain.BaseEntity{ID: 10}, FranchiseID: 1, Name: "Centro"},
+		{BaseEntity: domain.BaseEntity{ID: 11}, FranchiseID: 1, Name: "Norte"},
+		{BaseEntity: domain.BaseEntity{ID: 20}, FranchiseID: 2, Name: "Otra franquicia"},
+	})
+	tenant := NewTenantGuard(&MockTenantRepository{storeFranchises: map[int]int{10: 1, 11: 1, 20: 2}})
+	one, two, ten, twenty := uint(1), uint(2), uint(10), uint(20)
+	holidayRepo := &MockHolidayRepository{holidays: []domain.Holiday{
+		{BaseEntity: domain.BaseEntity{ID: 1}, Date: "2025-10-01", Name: "Festivo global", Scope: domain.HolidayScopeGlobal},
+		{BaseEntity: domain.BaseEntity{ID: 2}, Date: "2025-10-02", Name: "Aniversario", Scope: domain.HolidayScopeFranchise, FranchiseID: &one},
+		{BaseEntity: domain.BaseEntity{ID: 3}, Date: "2025-10-03", Name: "Aniversario ajeno", Scope: domain.HolidayScopeFranchise, FranchiseID: &two},
+		{BaseEntity: domain.BaseEntity{ID: 4}, Date: "2025-10-04", Name: "Día cívico", Scope: domain.HolidayScopeStore, StoreID: &ten},
+		{BaseEntity: domain.BaseEntity{ID: 5}, Date: "2025-10-05", Name: "Cierre ajeno", Scope: domain.HolidayScopeStore, StoreID: &twenty},
+	}}
+	return NewHolidayUseCase(holidayRepo, storeRepo, tenant), holidayRepo
+}
+
+func TestHolidayUseCase_ReadsAreScopedToTheFranchise(t *testing.T) {
+	uc, _ := newTestHolidayUseCase()
+
+	holidays, err := uc.GetByYear(franchiseScope(1), 2025)
+	if err != nil {
+		t.Fatalf("GetByYear: unexpected error %v", err)
+	}
+	if len(holidays) != 3 {
+		t.Errorf("Expected the global, franchise and store holidays of franchise 1, got %+v", holidays)
+	}
+
+	// A store-restricted caller does not see the holidays of the other stores
+	holidays, _ = uc.GetByYear(domain.TenantScope{FranchiseID: 1, StoreID: 11}, 2025)
+	if len(holidays) != 2 {
+		t.Errorf("Expected the global and franchise holidays, got %+v", holidays)
+	}
+
+	for _, id := range []int{3, 5} {
+		if _, err := uc.GetByID(franchiseScope(1), id); domainStatus(t, err) != 404 {
+			t.Errorf("Expected holiday %d of another franchise to be reported as not found, got %v", id, err)
+		}
+	}
+}
+
+func TestHolidayUseCase_WritesAreForcedToTheScope(t *testing.T) {
+	uc, _ := newTestHolidayUseCase()
+	scope := franchiseScope(1)
+
+	// The franchise always comes from the scope
+	holiday, err := uc.Create(scope, dto.HolidayRequest{Date: "2025-11-03", Name: "Inventario", Scope: domain.HolidayScopeFranchise})
+	if err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	if holiday.FranchiseID == nil || *holiday.FranchiseID != 1 {
+		t.Errorf("Expected the holiday to belong to franchise 1, got %+v", holiday.FranchiseID)
+	}
+	if _, err := uc.Create(scope, dto.HolidayRequest{Date: "2025-11-03", Name: "Inventario", Scope: domain.HolidayScopeFranchise, FranchiseID: 2}); domainStatus(t, err) != 404 {
+		t.Errorf("Expected another franchise to be rejected, got %v", err)
+	}
+	if _, err := uc.Create(scope, dto.HolidayRequest{Date: "2025-11-03", Name: "Inventario", Scope: domain.HolidayScopeStore, StoreID: 20}); domainStatus(t, err) != 404 {
+		t.Errorf("Expected a store of another franchise to be rejected, got %v", err)
+	}
+
+	// Store-restricted callers only write holidays of their store
+	storeScope := domain.TenantScope{FranchiseID: 1, StoreID: 10}
+	holiday, err = uc.Create(storeScope, dto.HolidayRequest{Date: "2025-11-04", Name: "Remodelación", Scope: domain.HolidayScopeStore})
+	if err != nil {
+		t.Fatalf("Create: unexpected error %v", err)
+	}
+	if holiday.StoreID == nil || *holiday.StoreID != 10 {
+		t.Errorf("Expected the holiday to belong to store 10, got %+v", holiday.StoreID)
+	}
+	if _, err := uc.Create(storeScope, dto.HolidayRequest{Date: "2025-11-04", Name: "Inventario", Scope: domain.HolidayScopeFranchise}); domainStatus(t, err) != 403 {
+		t.Errorf("Expected a franchise holiday from a store-restricted caller to be rejected, got %v", err)
+	}
+
+	// Global holidays are reserved to the platform
+	global := dto.HolidayRequest{Date: "2025-11-05", Name: "Festivo nuevo", Scope: domain.HolidayScopeGlobal}
+	if _, err := uc.Create(scope, global); domainStatus(t, err) != 403 {
+		t.Errorf("Expected a global holiday to need the platform permission, got %v", err)
+	}
+	if err := uc.Delete(scope, 1); domainStatus(t, err) != 403 {
+		t.Errorf("Expected deleting a global holiday to need the platform permission, got %v", err)
+	}
+	if _, err := uc.Update(scope, 2, global); domainStatus(t, err) != 403 {
+		t.Errorf("Expected turning a franchise holiday into a global one to be rejected, got %v", err)
+	}
+	platform := domain.TenantScope{FranchiseID: 1, Platform: true}
+	if _, err := uc.Create(platform, global); err != nil {
+		t.Errorf("Expected the platform to create global holidays, got %v", err)
+	}
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/utils"
+	"time"
+)
+
+// LaborRulesValidator checks an employee's schedule against labor limits across days.
+// Rules are registered in a base.BusinessRuleEngine for the utils.EmployeeSchedule entity.
+type LaborRulesValidator struct {
+	assignedRepo   repository.AssignedShiftRepository
+	workConfigRepo repository.WorkConfigRepository
+	engine         *base.BusinessRuleEngine
+	errorHandler   *base.ErrorHandler
+	logger         *base.Logger
+}
+
+// NewLaborRulesValidator creates a validator with the labor rules for the given limits.
+// Weekly ordinary hours are read from the work configuration in force each week.
+func NewLaborRulesValidator(
+	assignedRepo repository.AssignedShiftRepository,
+	workConfigRepo repository.WorkConfigRepository,
+	limits utils.LaborLimits,
+) *LaborRulesValidator {
+	logger := base.NewLogger("LaborRules")
+	engine := base.NewBusinessRuleEngine(logger)
+	for _, rule := range LaborBusinessRules(limits) {
+		engine.RegisterRule("EmployeeSchedule", rule)
+	}
+
+	return &LaborRulesValidator{
+		assignedRepo:   assignedRepo,
+		workConfigRepo: workConfigRepo,
+		engine:         engine,
+		errorHandler:   base.NewErrorHandler("LaborRules"),
+		logger:         logger,
+	}
+}
+
+// workConfigsContextKey carries the utils.WorkConfigTimeline for the schedule being validated
+const workConfigsContextKey = "work_configs"
+
+// LaborBusinessRules returns the labor rules applied to an employee schedule
+func LaborBusinessRules(limits utils.LaborLimits) []base.BusinessRule {
+	return []base.BusinessRule{
+		base.CreateContextRule("max_weekly_hours", "Weekly ordinary hours cannot exceed the legal maximum",
+			func(entity interface{}, context map[string]interface{}) error {
+				configs, _ := context[workConfigsContextKey].(utils.WorkConfigTimeline)
+				return utils.CheckWeeklyHours(*entity.(*utils.EmployeeSchedule), configs)
+			}),
+		base.CreateContextRule("weekly_rest_day", "Employees must rest at least one day per week",
+			func(entity interface{}, context map[string]interface{}) error {
+				return utils.CheckWeeklyRestDay(*entity.(*utils.EmployeeSchedule))
+			}),
+		base.CreateContextRule("minimum_rest_between_shifts", "Consecutive shifts must leave the minimum rest gap",
+			func(entity interface{}, context map[string]interface{}) error {
+				return utils.CheckMinimumRest(*entity.(*utils.EmployeeSchedule), limits)
+			}),
+	}
+}
+
+// Validate rejects proposed shifts that break a labor rule once merged with the employee's
+// current schedule. Only the weeks and rest gaps that include a proposed shift are checked,
+// so an existing violation does not block unrelated assignments. replacedID is an assignment
+// being edited, ignored from the stored schedule.
+func (v *LaborRulesValidator) Validate(operation string, employeeID int, proposed []domain.AssignedShift, replacedID uint) error {
+	violations, err := v.Collect(operation, employeeID, proposed, replacedID)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return v.errorHandler.HandleBusinessRuleViolation(operation, violations[0].Rule, violations[0].Message)
+	}
+	return nil
+}
+
+// Collect returns every labor rule the proposed shifts break in the merged schedule, without rejecting it
+func (v *LaborRulesValidator) Collect(operation string, employeeID int, proposed []domain.AssignedShift, replacedID uint) ([]base.RuleViolation, error) {
+	if len(proposed) == 0 {
+		return nil, nil
+	}
+
+	schedule, err := v.buildSchedule(operation, employeeID, proposed, replacedID)
+	if err != nil {
+		return nil, err
+	}
+
+	first, last := scheduleBounds(schedule.Proposed)
+	configs, err := v.workConfigRepo.GetConfigsForPeriod(utils.WeekStart(first), last)
+	if err != nil {
+		v.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return nil, v.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	context := map[string]interface{}{
+		workConfigsContextKey: utils.NewWorkConfigTimeline(configs),
+	}
+	return v.engine.CollectViolations(schedule, context), nil
+}
+
+// buildSchedule merges the proposed shifts with the stored ones in the weeks they touch,
+// plus one day on each side so the rest gap across week boundaries is checked too
+func (v *LaborRulesValidator) buildSchedule(operation string, employeeID int, proposed []domain.AssignedShift, replacedID uint) (*utils.EmployeeSchedule, error) {
+	for _, shift := range proposed {
+		if _, err := time.Parse("2006-01-02", shift.Date); err != nil {
+			return nil, v.errorHandler.HandleValidationError(operation, err)
+		}
+	}
+	first, last := scheduleBounds(proposed)
+
+	from := utils.WeekStart(first).AddDate(0, 0, -1).Format("2006-01-02")
+	to := utils.WeekStart(last).AddDate(0, 0, 7).Format("2006-01-02")
+
+	stored, err := v.assignedRepo.GetByEmployeeAndDateRange(employeeID, from, to)
+	if err != nil {
+		v.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return nil, v.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	shifts := make([]domain.AssignedShift, 0, len(stored))
+	for _, shift := range stored {
+		if replacedID != 0 && shift.ID == replacedID {
+			continue
+		}
+		shifts = append(shifts, shift)
+	}
+
+	return &utils.EmployeeSchedule{EmployeeID: employeeID, Shifts: shifts, Proposed: proposed}, nil
+}
+
+// scheduleBounds returns the first and last dates of a set of shifts with valid dates
+func scheduleBounds(shifts []domain.AssignedShift) (time.Time, time.Time) {
+	var first, last time.Time
+	for _, shift := range shifts {
+		day, err := time.Parse("2006-01-02", shift.Date)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if last.IsZero() || day.After(last) {
+			last = day
+		}
+	}
+	return first, last
+}
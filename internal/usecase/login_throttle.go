@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"loopi-api/internal/cache"
+	"loopi-api/internal/usecase/base"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LockoutPolicy configures progressive lockout: after MaxFailures failed logins the key is
+// locked for BaseLock, doubling with every further failure up to MaxLock
+type LockoutPolicy struct {
+	MaxFailures int
+	BaseLock    time.Duration
+	MaxLock     time.Duration
+}
+
+// Default lockout policies. The per-IP limit is looser because several users may share an address.
+var (
+	DefaultAccountLockout = LockoutPolicy{MaxFailures: 5, BaseLock: time.Minute, MaxLock: time.Hour}
+	DefaultIPLockout      = LockoutPolicy{MaxFailures: 50, BaseLock: time.Minute, MaxLock: time.Hour}
+)
+
+// LockDuration returns how long a key is locked after failures consecutive failed logins
+func (p LockoutPolicy) LockDuration(failures int) time.Duration {
+	if failures < p.MaxFailures {
+		return 0
+	}
+
+	lock := p.BaseLock
+	for i := p.MaxFailures; i < failures && lock < p.MaxLock; i++ {
+		lock *= 2
+	}
+	if lock > p.MaxLock {
+		lock = p.MaxLock
+	}
+	return lock
+}
+
+// LoginAttempts is the failed-login state of an account or IP address
+type LoginAttempts struct {
+	Failures    int       `json:"failures"`
+	LockedUntil time.Time `json:"locked_until,omitempty"`
+}
+
+// lockSweepInterval is how often locks that already expired are dropped from memory
+const lockSweepInterval = time.Minute
+
+// LoginThrottle tracks failed logins per account (email) and per IP address.
+// Its store is a cache.Cache, in-memory by default; a shared cache makes the limits hold
+// across replicas. Entries expire with the cache TTL, which resets the failure count.
+// A bounded cache may evict entries when full, so locked keys are also kept in memory
+// until their lock expires: filling the cache with other keys cannot lift a lock.
+type LoginThrottle struct {
+	store     cache.Cache
+	locked    map[string]LoginAttempts
+	nextSweep time.Time
+	account   LockoutPolicy
+	ip        LockoutPolicy
+	mu        sync.Mutex
+	now       func() time.Time
+	logger    *base.Logger
+}
+
+// NewLoginThrottle creates a throttle with the default lockout policies
+func NewLoginThrottle(store cache.Cache) *LoginThrottle {
+	return &LoginThrottle{
+		store:   store,
+		locked:  make(map[string]LoginAttempts),
+		account: DefaultAccountLockout,
+		ip:      DefaultIPLockout,
+		now:     time.Now,
+		logger:  base.NewLogger("LoginThrottle"),
+	}
+}
+
+// Locked reports whether the account or the IP is locked and for how much longer
+func (t *LoginThrottle) Locked(email, ip string) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	var remaining time.Duration
+	for _, key := range t.keys(email, ip) {
+		if until := t.get(key).LockedUntil; until.After(now) && until.Sub(now) > remaining {
+			remaining = until.Sub(now)
+		}
+	}
+	return remaining, remaining > 0
+}
+
+// RegisterFailure counts a failed login for the account and the IP, locking them when
+// their policy is exceeded
+func (t *LoginThrottle) RegisterFailure(email, ip string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.fail(accountKey(email), t.account, now)
+	if ip != "" {
+		t.fail(ipKey(ip), t.ip, now)
+	}
+}
+
+// RegisterSuccess clears the failures of the account. The IP keeps its count so that
+// one valid account cannot be used to reset it.
+func (t *LoginThrottle) RegisterSuccess(email string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.delete(accountKey(email))
+}
+
+// Status returns the failed-login state of the account
+func (t *LoginThrottle) Status(email string) LoginAttempts {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.get(accountKey(email))
+}
+
+// Unlock clears the failures of the account and, when given, of the IP
+func (t *LoginThrottle) Unlock(email, ip string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, key := range t.keys(email, ip) {
+		t.delete(key)
+	}
+}
+
+func (t *LoginThrottle) fail(key string, policy LockoutPolicy, now time.Time) {
+	t.sweep(now)
+
+	attempts := t.get(key)
+	attempts.Failures++
+	if lock := policy.LockDuration(attempts.Failures); lock > 0 {
+		attempts.LockedUntil = now.Add(lock)
+		t.locked[key] = attempts
+	}
+	if err := t.store.Set(key, attempts); err != nil {
+		t.logger.LogError("RegisterFailure", err, map[string]interface{}{"key": key})
+	}
+}
+
+// get returns the state of key, falling back to the locks kept in memory when the store
+// lost the entry; an entry that cannot be read counts as no failures
+func (t *LoginThrottle) get(key string) LoginAttempts {
+	var attempts LoginAttempts
+	ok, err := t.store.Get(key, &attempts)
+	if err != nil {
+		t.logger.LogError("Status", err, map[string]interface{}{"key": key})
+		return t.locked[key]
+	}
+	if !ok {
+		return t.locked[key]
+	}
+	return attempts
+}
+
+func (t *LoginThrottle) delete(key string) {
+	t.store.Delete(key)
+	delete(t.locked, key)
+}
+
+// sweep drops the locks that already expired; their failures stay in the store
+func (t *LoginThrottle) sweep(now time.Time) {
+	if now.Before(t.nextSweep) {
+		return
+	}
+	for key, attempts := range t.locked {
+		if !attempts.LockedUntil.After(now) {
+			delete(t.locked, key)
+		}
+	}
+	t.nextSweep = now.Add(lockSweepInterval)
+}
+
+func (t *LoginThrottle) keys(email, ip string) []string {
+	keys := make([]string, 0, 2)
+	if email != "" {
+		keys = append(keys, accountKey(email))
+	}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+	return keys
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/cache"
+	"testing"
+	"time"
+)
+
+func TestLockoutPolicy_LockDuration(t *testing.T) {
+	policy := LockoutPolicy{MaxFailures: 3, BaseLock: time.Minute, MaxLock: 10 * time.Minute}
+
+	tests := []struct {
+		failures int
+		expected time.Duration
+	}{
+		{2, 0},
+		{3, time.Minute},
+		{4, 2 * time.Minute},
+		{5, 4 * time.Minute},
+		{7, 10 * time.Minute}, // capped
+	}
+
+	for _, tt := range tests {
+		if got := policy.LockDuration(tt.failures); got != tt.expected {
+			t.Errorf("LockDuration(%d): expected %s, got %s", tt.failures, tt.expected, got)
+		}
+	}
+}
+
+func TestLoginThrottle_LocksAccountAndUnlocks(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	throttle := NewLoginThrottle(cache.NewMemory(64, time.Hour))
+	throttle.now = func() time.Time { return now }
+
+	for i := 0; i < DefaultAccountLockout.MaxFailures; i++ {
+		throttle.RegisterFailure("Ana@Loopi.co", "10.0.0.1")
+	}
+	if _, locked := throttle.Locked("ana@loopi.co", "10.0.0.2"); !locked {
+		t.Fatal("Expected the account to be locked from any IP")
+	}
+	if _, locked := throttle.Locked("other@loopi.co", "10.0.0.1"); locked {
+		t.Error("Expected other accounts on the same IP to stay unlocked")
+	}
+
+	now = now.Add(DefaultAccountLockout.BaseLock)
+	if _, locked := throttle.Locked("ana@loopi.co", ""); locked {
+		t.Error("Expected the lock to expire")
+	}
+
+	throttle.RegisterFailure("ana@loopi.co", "10.0.0.1")
+	if remaining, _ := throttle.Locked("ana@loopi.co", ""); remaining != 2*DefaultAccountLockout.BaseLock {
+		t.Errorf("Expected a doubled lock, got %s", remaining)
+	}
+
+	throttle.Unlock("ana@loopi.co", "")
+	if status := throttle.Status("ana@loopi.co"); status.Failures != 0 {
+		t.Errorf("Expected no failures after unlock, got %+v", status)
+	}
+}
+
+func TestLoginThrottle_LockSurvivesFullCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	throttle := NewLoginThrottle(cache.NewMemory(8, time.Hour))
+	throttle.now = func() time.Time { return now }
+
+	for i := 0; i < DefaultAccountLockout.MaxFailures; i++ {
+		throttle.RegisterFailure("ana@loopi.co", "")
+	}
+
+	// Spraying more accounts than the cache holds evicts the locked entry from it
+	for i := 0; i < 100; i++ {
+		throttle.RegisterFailure(fmt.Sprintf("user%d@loopi.co", i), "")
+	}
+	if remaining, locked := throttle.Locked("ana@loopi.co", ""); !locked || remaining != DefaultAccountLockout.BaseLock {
+		t.Fatalf("Expected the lock to hold for %s, got %s", DefaultAccountLockout.BaseLock, remaining)
+	}
+	if status := throttle.Status("ana@loopi.co"); status.Failures != DefaultAccountLockout.MaxFailures {
+		t.Errorf("Expected %d failures, got %+v", DefaultAccountLockout.MaxFailures, status)
+	}
+
+	throttle.Unlock("ana@loopi.co", "")
+	if _, locked := throttle.Locked("ana@loopi.co", ""); locked {
+		t.Error("Expected unlock to lift the kept lock")
+	}
+
+	// Expired locks are swept from memory
+	for i := 0; i < DefaultAccountLockout.MaxFailures; i++ {
+		throttle.RegisterFailure("bob@loopi.co", "")
+	}
+	now = now.Add(DefaultAccountLockout.BaseLock + lockSweepInterval)
+	throttle.RegisterFailure("carl@loopi.co", "")
+	if len(throttle.locked) != 0 {
+		t.Errorf("Expected expired locks to be swept, got %v", throttle.locked)
+	}
+}
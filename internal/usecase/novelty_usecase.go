@@ -10,14 +10,14 @@ import (
 )
 
 type NoveltyUseCase interface {
-	// Standard CRUD operations
-	Create(novelty domain.Novelty) error
-	GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.Novelty, error)
+	// Standard CRUD operations, scoped to the caller's franchise
+	Create(scope domain.TenantScope, novelty domain.Novelty) error
+	GetByEmployeeAndMonth(scope domain.TenantScope, employeeID, year, month int) ([]domain.Novelty, error)
 
 	// Business-specific operations
-	GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.Novelty, error)
-	GetTotalHoursByEmployeeAndType(employeeID, year, month int, noveltyType string) (float64, error)
-	GetNoveltyTypesSummary(employeeID, year, month int) ([]repository.NoveltyTypeSummary, error)
+	GetByEmployeeAndDateRange(scope domain.TenantScope, employeeID int, from, to time.Time) ([]domain.Novelty, error)
+	GetTotalHoursByEmployeeAndType(scope domain.TenantScope, employeeID, year, month int, noveltyType string) (float64, error)
+	GetNoveltyTypesSummary(scope domain.TenantScope, employeeID, year, month int) ([]repository.NoveltyTypeSummary, error)
 	ValidateNoveltyData(novelty *domain.Novelty) error
 	ValidateNoveltyType(noveltyType string) error
 	ValidateNoveltyDate(date time.Time) error
@@ -25,14 +25,16 @@ type NoveltyUseCase interface {
 
 type noveltyUseCase struct {
 	repo         repository.NoveltyRepository
+	tenant       *TenantGuard
 	errorHandler *base.ErrorHandler
 	validator    *base.Validator
 	logger       *base.Logger
 }
 
-func NewNoveltyUseCase(repo repository.NoveltyRepository) NoveltyUseCase {
+func NewNoveltyUseCase(repo repository.NoveltyRepository, tenant *TenantGuard) NoveltyUseCase {
 	return &noveltyUseCase{
 		repo:         repo,
+		tenant:       tenant,
 		errorHandler: base.NewErrorHandler("Novelty"),
 		validator:    base.NewValidator(),
 		logger:       base.NewLogger("Novelty"),
@@ -42,7 +44,7 @@ func NewNoveltyUseCase(repo repository.NoveltyRepository) NoveltyUseCase {
 // ✅ Enhanced CRUD operations with logging, validation, and error handling
 
 // Create creates a new novelty with validation and business rules
-func (uc *noveltyUseCase) Create(novelty domain.Novelty) error {
+func (uc *noveltyUseCase) Create(scope domain.TenantScope, novelty domain.Novelty) error {
 	uc.logger.LogOperation("Create", "start", map[string]interface{}{
 		"employee_id": novelty.EmployeeID,
 		"date":        novelty.Date.Format("2006-01-02"),
@@ -55,6 +57,10 @@ func (uc *noveltyUseCase) Create(novelty domain.Novelty) error {
 		return err
 	}
 
+	if err := uc.tenant.RequireEmployee("Create", scope, novelty.EmployeeID); err != nil {
+		return err
+	}
+
 	// Validate novelty type
 	if err := uc.ValidateNoveltyType(novelty.Type); err != nil {
 		return err
@@ -65,6 +71,10 @@ func (uc *noveltyUseCase) Create(novelty domain.Novelty) error {
 		return err
 	}
 
+	// Business rule: Only the time clock generates novelties from attendance exceptions
+	novelty.AssignedShiftID = nil
+	novelty.Source = ""
+
 	// Set timestamps
 	novelty.CreatedAt = time.Now()
 	novelty.UpdatedAt = time.Now()
@@ -90,7 +100,7 @@ func (uc *noveltyUseCase) Create(novelty domain.Novelty) error {
 }
 
 // GetByEmployeeAndMonth retrieves novelties by employee and month with validation
-func (uc *noveltyUseCase) GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.Novelty, error) {
+func (uc *noveltyUseCase) GetByEmployeeAndMonth(scope domain.TenantScope, employeeID, year, month int) ([]domain.Novelty, error) {
 	uc.logger.LogOperation("GetByEmployeeAndMonth", "start", map[string]interface{}{
 		"employee_id": employeeID,
 		"year":        year,
@@ -105,6 +115,10 @@ func (uc *noveltyUseCase) GetByEmployeeAndMonth(employeeID, year, month int) ([]
 		return nil, uc.errorHandler.HandleValidationError("GetByEmployeeAndMonth", err)
 	}
 
+	if err := uc.tenant.RequireEmployee("GetByEmployeeAndMonth", scope, employeeID); err != nil {
+		return nil, err
+	}
+
 	// Validate year and month (reuse validation logic from absence)
 	if year < 2000 || year > 2100 {
 		err := fmt.Errorf("invalid year: %d. Must be between 2000-2100", year)
@@ -145,7 +159,7 @@ func (uc *noveltyUseCase) GetByEmployeeAndMonth(employeeID, year, month int) ([]
 // ✅ Business-specific operations with enhanced features
 
 // GetByEmployeeAndDateRange retrieves novelties by employee within a custom date range
-func (uc *noveltyUseCase) GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.Novelty, error) {
+func (uc *noveltyUseCase) GetByEmployeeAndDateRange(scope domain.TenantScope, employeeID int, from, to time.Time) ([]domain.Novelty, error) {
 	// Start performance timer
 	timer := uc.logger.StartTimer("GetByEmployeeAndDateRange", map[string]interface{}{
 		"employee_id": employeeID,
@@ -162,6 +176,10 @@ func (uc *noveltyUseCase) GetByEmployeeAndDateRange(employeeID int, from, to tim
 		return nil, uc.errorHandler.HandleValidationError("GetByEmployeeAndDateRange", err)
 	}
 
+	if err := uc.tenant.RequireEmployee("GetByEmployeeAndDateRange", scope, employeeID); err != nil {
+		return nil, err
+	}
+
 	// Validate date range
 	if from.After(to) {
 		err := fmt.Errorf("from date (%s) cannot be after to date (%s)", from.Format("2006-01-02"), to.Format("2006-01-02"))
@@ -194,7 +212,7 @@ func (uc *noveltyUseCase) GetByEmployeeAndDateRange(employeeID int, from, to tim
 }
 
 // GetTotalHoursByEmployeeAndType retrieves total novelty hours by type for an employee in a specific month
-func (uc *noveltyUseCase) GetTotalHoursByEmployeeAndType(employeeID, year, month int, noveltyType string) (float64, error) {
+func (uc *noveltyUseCase) GetTotalHoursByEmployeeAndType(scope domain.TenantScope, employeeID, year, month int, noveltyType string) (float64, error) {
 	uc.logger.LogOperation("GetTotalHoursByEmployeeAndType", "start", map[string]interface{}{
 		"employee_id":  employeeID,
 		"year":         year,
@@ -210,6 +228,10 @@ func (uc *noveltyUseCase) GetTotalHoursByEmployeeAndType(employeeID, year, month
 		return 0, uc.errorHandler.HandleValidationError("GetTotalHoursByEmployeeAndType", err)
 	}
 
+	if err := uc.tenant.RequireEmployee("GetTotalHoursByEmployeeAndType", scope, employeeID); err != nil {
+		return 0, err
+	}
+
 	// Validate novelty type
 	if err := uc.ValidateNoveltyType(noveltyType); err != nil {
 		return 0, err // Error already logged by ValidateNoveltyType
@@ -256,7 +278,7 @@ func (uc *noveltyUseCase) GetTotalHoursByEmployeeAndType(employeeID, year, month
 }
 
 // GetNoveltyTypesSummary retrieves a comprehensive summary of novelty types for an employee in a month
-func (uc *noveltyUseCase) GetNoveltyTypesSummary(employeeID, year, month int) ([]repository.NoveltyTypeSummary, error) {
+func (uc *noveltyUseCase) GetNoveltyTypesSummary(scope domain.TenantScope, employeeID, year, month int) ([]repository.NoveltyTypeSummary, error) {
 	uc.logger.LogOperation("GetNoveltyTypesSummary", "start", map[string]interface{}{
 		"employee_id": employeeID,
 		"year":        year,
@@ -271,6 +293,10 @@ func (uc *noveltyUseCase) GetNoveltyTypesSummary(employeeID, year, month int) ([
 		return nil, uc.errorHandler.HandleValidationError("GetNoveltyTypesSummary", err)
 	}
 
+	if err := uc.tenant.RequireEmployee("GetNoveltyTypesSummary", scope, employeeID); err != nil {
+		return nil, err
+	}
+
 	// Validate year and month
 	if year < 2000 || year > 2100 {
 		err := fmt.Errorf("invalid year: %d. Must be between 2000-2100", year)
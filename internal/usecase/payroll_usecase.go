@@ -0,0 +1,317 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
+	"sort"
+	"time"
+)
+
+type PayrollUseCase interface {
+	// Standard operations
+	GetConfigs() ([]domain.PayrollConfig, error)
+	CreateConfig(req dto.CreatePayrollConfigRequest) (*domain.PayrollConfig, error)
+
+	// Business-specific operations, scoped to the caller's franchise
+	GetEmployeePayroll(scope domain.TenantScope, employeeID, year, month int) (*domain.EmployeePayroll, error)
+	GetStorePayroll(scope domain.TenantScope, storeID, year, month int) ([]domain.EmployeePayroll, error)
+}
+
+type payrollUseCase struct {
+	employeeHours     EmployeeHoursUseCase
+	userRepo          repository.UserRepository
+	payrollConfigRepo repository.PayrollConfigRepository
+	workConfigRepo    repository.WorkConfigRepository
+	tenant            *TenantGuard
+	errorHandler      *base.ErrorHandler
+	validator         *base.Validator
+	logger            *base.Logger
+}
+
+func NewPayrollUseCase(
+	employeeHours EmployeeHoursUseCase,
+	userRepo repository.UserRepository,
+	payrollConfigRepo repository.PayrollConfigRepository,
+	workConfigRepo repository.WorkConfigRepository,
+	tenant *TenantGuard,
+) PayrollUseCase {
+	return &payrollUseCase{
+		employeeHours:     employeeHours,
+		userRepo:          userRepo,
+		payrollConfigRepo: payrollConfigRepo,
+		workConfigRepo:    workConfigRepo,
+		tenant:            tenant,
+		errorHandler:      base.NewErrorHandler("Payroll"),
+		validator:         base.NewValidator(),
+		logger:            base.NewLogger("Payroll"),
+	}
+}
+
+// ✅ Enhanced CRUD operations with logging, validation, and error handling
+
+// GetConfigs retrieves every payroll configuration ordered by effective date
+func (uc *payrollUseCase) GetConfigs() ([]domain.PayrollConfig, error) {
+	uc.logger.LogOperation("GetConfigs", "start", nil)
+
+	configs, err := uc.payrollConfigRepo.GetAllConfigs()
+	if err != nil {
+		uc.logger.LogError("GetConfigs", err, nil)
+		return nil, uc.errorHandler.HandleRepositoryError("GetConfigs", err)
+	}
+
+	uc.logger.LogOperation("GetConfigs", "success", map[string]interface{}{
+		"count": len(configs),
+	})
+
+	return configs, nil
+}
+
+// CreateConfig registers new effective-dated surcharge percentages
+func (uc *payrollUseCase) CreateConfig(req dto.CreatePayrollConfigRequest) (*domain.PayrollConfig, error) {
+	uc.logger.LogOperation("CreateConfig", "start", map[string]interface{}{
+		"effective_from": req.EffectiveFrom,
+	})
+
+	effectiveFrom, err := time.Parse("2006-01-02", req.EffectiveFrom)
+	if err != nil {
+		err := fmt.Errorf("invalid effective_from: %q. Expected format: YYYY-MM-DD", req.EffectiveFrom)
+		uc.logger.LogValidation("CreateConfig", "effective_from", "failed", map[string]interface{}{"error": err.Error()})
+		return nil, uc.errorHandler.HandleValidationError("CreateConfig", err)
+	}
+
+	if err := uc.validator.ValidateNumber(req.NightSurcharge, "night_surcharge", "non_negative", "max:5"); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("CreateConfig", err)
+	}
+	if err := uc.validator.ValidateNumber(req.SundayHolidaySurcharge, "sunday_holiday_surcharge", "non_negative", "max:5"); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("CreateConfig", err)
+	}
+	if err := uc.validator.ValidateNumber(req.ExtraDiurnalMultiplier, "extra_diurnal_multiplier", "min:1", "max:5"); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("CreateConfig", err)
+	}
+	if err := uc.validator.ValidateNumber(req.ExtraNocturnalMultiplier, "extra_nocturnal_multiplier", "min:1", "max:5"); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("CreateConfig", err)
+	}
+
+	// Business rule: one configuration per effective date
+	existing, err := uc.payrollConfigRepo.GetAllConfigs()
+	if err != nil {
+		uc.logger.LogError("CreateConfig", err, nil)
+		return nil, uc.errorHandler.HandleRepositoryError("CreateConfig", err)
+	}
+	for _, config := range existing {
+		if config.EffectiveFrom.Format("2006-01-02") == req.EffectiveFrom {
+			return nil, uc.errorHandler.HandleConflict("CreateConfig",
+				fmt.Sprintf("a payroll configuration effective from %s already exists", req.EffectiveFrom))
+		}
+	}
+
+	config := domain.PayrollConfig{
+		EffectiveFrom:            effectiveFrom,
+		NightSurcharge:           req.NightSurcharge,
+		SundayHolidaySurcharge:   req.SundayHolidaySurcharge,
+		ExtraDiurnalMultiplier:   req.ExtraDiurnalMultiplier,
+		ExtraNocturnalMultiplier: req.ExtraNocturnalMultiplier,
+		IsActive:                 true,
+	}
+
+	if err := uc.payrollConfigRepo.Create(&config); err != nil {
+		uc.logger.LogError("CreateConfig", err, map[string]interface{}{"effective_from": req.EffectiveFrom})
+		return nil, uc.errorHandler.HandleRepositoryError("CreateConfig", err)
+	}
+
+	uc.logger.LogOperation("CreateConfig", "success", map[string]interface{}{
+		"id":             config.ID,
+		"effective_from": req.EffectiveFrom,
+	})
+
+	return &config, nil
+}
+
+// ✅ Business-specific operations with enhanced features
+
+// GetEmployeePayroll calculates the monthly payroll breakdown of an employee
+func (uc *payrollUseCase) GetEmployeePayroll(scope domain.TenantScope, employeeID, year, month int) (*domain.EmployeePayroll, error) {
+	timer := uc.logger.StartTimer("GetEmployeePayroll", map[string]interface{}{
+		"employee_id": employeeID,
+		"year":        year,
+		"month":       month,
+	})
+	defer timer.Stop()
+
+	if err := uc.tenant.RequireEmployee("GetEmployeePayroll", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(employeeID)
+	if err != nil {
+		uc.logger.LogError("GetEmployeePayroll", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetEmployeePayroll", err)
+	}
+	if user == nil {
+		return nil, uc.errorHandler.HandleNotFound("GetEmployeePayroll", fmt.Sprintf("employee not found with ID: %d", employeeID))
+	}
+
+	periods, err := uc.configPeriods("GetEmployeePayroll", year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.calculate("GetEmployeePayroll", scope, user, year, month, periods)
+}
+
+// GetStorePayroll calculates the monthly payroll of every active employee of a store
+func (uc *payrollUseCase) GetStorePayroll(scope domain.TenantScope, storeID, year, month int) ([]domain.EmployeePayroll, error) {
+	timer := uc.logger.StartTimer("GetStorePayroll", map[string]interface{}{
+		"store_id": storeID,
+		"year":     year,
+		"month":    month,
+	})
+	defer timer.Stop()
+
+	if err := uc.validator.ValidateID(storeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetStorePayroll", err)
+	}
+
+	if err := uc.tenant.RequireStore("GetStorePayroll", scope, storeID); err != nil {
+		return nil, err
+	}
+
+	users, err := uc.userRepo.GetByStore(storeID)
+	if err != nil {
+		uc.logger.LogError("GetStorePayroll", err, map[string]interface{}{"store_id": storeID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetStorePayroll", err)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	periods, err := uc.configPeriods("GetStorePayroll", year, month)
+	if err != nil {
+		return nil, err
+	}
+
+	payrolls := make([]domain.EmployeePayroll, 0, len(users))
+	for i := range users {
+		if !users[i].IsActive {
+			continue
+		}
+		payroll, err := uc.calculate("GetStorePayroll", scope, &users[i], year, month, periods)
+		if err != nil {
+			return nil, err
+		}
+		payrolls = append(payrolls, *payroll)
+	}
+
+	return payrolls, nil
+}
+
+// configPeriod is a part of the month with the same work and payroll configuration
+type configPeriod struct {
+	from, to      time.Time
+	workConfig    domain.WorkConfig
+	payrollConfig domain.PayrollConfig
+}
+
+// configPeriods splits the month at every work or payroll configuration that takes effect
+// after its first day. Without such a change it returns a single period.
+func (uc *payrollUseCase) configPeriods(operation string, year, month int) ([]configPeriod, error) {
+	periodStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, -1)
+
+	workConfigs, err := uc.workConfigRepo.GetConfigsForPeriod(periodStart, periodEnd)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"year": year, "month": month})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	payrollConfigs, err := uc.payrollConfigRepo.GetAllConfigs()
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"year": year, "month": month})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	changes := make(map[time.Time]bool)
+	addChange := func(from time.Time) {
+		day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+		if day.After(periodStart) && !day.After(periodEnd) {
+			changes[day] = true
+		}
+	}
+	for _, config := range workConfigs {
+		addChange(config.EffectiveFrom)
+	}
+	for _, config := range payrollConfigs {
+		if config.IsActive {
+			addChange(config.EffectiveFrom)
+		}
+	}
+	starts := make([]time.Time, 0, len(changes))
+	for day := range changes {
+		starts = append(starts, day)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	timeline := utils.NewWorkConfigTimeline(workConfigs)
+	periods := make([]configPeriod, 0, len(starts)+1)
+	from := periodStart
+	for _, next := range append(starts, periodEnd.AddDate(0, 0, 1)) {
+		periods = append(periods, configPeriod{
+			from:          from,
+			to:            next.AddDate(0, 0, -1),
+			workConfig:    timeline.At(from),
+			payrollConfig: uc.payrollConfigRepo.GetEffectiveConfig(from),
+		})
+		from = next
+	}
+	return periods, nil
+}
+
+// calculate applies the configurations in force during the month to the hours summary. When
+// the work or payroll configuration changes mid-month, the hours of each period are priced
+// with the configuration of that period.
+func (uc *payrollUseCase) calculate(operation string, scope domain.TenantScope, user *domain.User, year, month int, periods []configPeriod) (*domain.EmployeePayroll, error) {
+	var payroll domain.EmployeePayroll
+	if len(periods) == 1 {
+		summary, err := uc.employeeHours.GetMonthlySummary(scope, int(user.ID), year, month)
+		if err != nil {
+			return nil, err
+		}
+		payroll = utils.CalculatePayroll(summary, user.Salary, periods[0].workConfig, periods[0].payrollConfig)
+	} else {
+		starts := make([]time.Time, 0, len(periods)-1)
+		for _, period := range periods[1:] {
+			starts = append(starts, period.from)
+		}
+		summaries, err := uc.employeeHours.GetMonthlySummaryByPeriods(scope, int(user.ID), year, month, starts)
+		if err != nil {
+			return nil, err
+		}
+
+		segments := make([]utils.PayrollSegment, len(periods))
+		for i, period := range periods {
+			segments[i] = utils.PayrollSegment{
+				From:       period.from,
+				To:         period.to,
+				Summary:    summaries[i],
+				WorkConfig: period.workConfig,
+				Config:     period.payrollConfig,
+			}
+		}
+		payroll = utils.CalculateSplitPayroll(user.Salary, segments)
+
+		uc.logger.LogBusinessRule(operation, "config_change", "split", map[string]interface{}{
+			"employee_id": user.ID,
+			"periods":     len(periods),
+		})
+	}
+
+	uc.logger.LogOperation(operation, "calculated", map[string]interface{}{
+		"employee_id":      user.ID,
+		"hourly_rate":      payroll.HourlyRate,
+		"total_surcharges": payroll.TotalSurcharges,
+		"total":            payroll.Total,
+	})
+
+	return &payroll, nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/cache"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"time"
+)
+
+// permissionCacheTTL bounds how long a role change takes to reach other replicas
+const permissionCacheTTL = time.Minute
+
+// PermissionResolver resolves the permissions a user holds through their roles.
+// Lookups are cached per user and franchise; role and permission changes clear the cache.
+type PermissionResolver struct {
+	roleRepo     repository.RoleRepository
+	cache        cache.Cache
+	errorHandler *base.ErrorHandler
+	logger       *base.Logger
+}
+
+// NewPermissionResolver creates a resolver backed by the role repository
+func NewPermissionResolver(roleRepo repository.RoleRepository) *PermissionResolver {
+	return &PermissionResolver{
+		roleRepo:     roleRepo,
+		cache:        cache.NewMemory(cache.DefaultMaxEntries, permissionCacheTTL),
+		errorHandler: base.NewErrorHandler("Permission"),
+		logger:       base.NewLogger("Permission"),
+	}
+}
+
+// UserPermissions returns the permissions of the user's roles in the franchise.
+// Without a selected franchise (0) the roles of every franchise apply.
+func (r *PermissionResolver) UserPermissions(userID, franchiseID int) ([]string, error) {
+	key := fmt.Sprintf("%d-%d", userID, franchiseID)
+	var cached []string
+	ok, err := r.cache.Get(key, &cached)
+	if err != nil {
+		r.logger.LogWarning("UserPermissions", "unreadable cache entry", map[string]interface{}{
+			"key":   key,
+			"error": err.Error(),
+		})
+	} else if ok {
+		return cached, nil
+	}
+
+	permissions, err := r.roleRepo.GetUserPermissionNames(userID, franchiseID)
+	if err != nil {
+		r.logger.LogError("UserPermissions", err, map[string]interface{}{
+			"user_id":      userID,
+			"franchise_id": franchiseID,
+		})
+		return nil, r.errorHandler.HandleRepositoryError("UserPermissions", err)
+	}
+
+	if err := r.cache.Set(key, permissions); err != nil {
+		r.logger.LogWarning("UserPermissions", "permissions not cached", map[string]interface{}{
+			"key":   key,
+			"error": err.Error(),
+		})
+	}
+	return permissions, nil
+}
+
+// HasPermission reports whether the user holds required in the franchise
+func (r *PermissionResolver) HasPermission(userID, franchiseID int, required string) (bool, error) {
+	permissions, err := r.UserPermissions(userID, franchiseID)
+	if err != nil {
+		return false, err
+	}
+	return domain.HasPermission(permissions, required), nil
+}
+
+// Invalidate drops every cached lookup after a role or permission change
+func (r *PermissionResolver) Invalidate() {
+	r.cache.Clear()
+}
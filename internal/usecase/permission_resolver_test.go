@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"loopi-api/internal/domain"
+)
+
+// MockRoleRepository for testing; permissions are keyed by user and franchise
+type MockRoleRepository struct {
+	roles           []domain.Role
+	rolePermissions map[int][]domain.Permission
+	userPermissions map[[2]int][]string
+	userRoles       map[[3]int]bool
+	lookups         int
+}
+
+func (m *MockRoleRepository) GetAll() ([]domain.Role, error) { return m.roles, nil }
+func (m *MockRoleRepository) GetByID(id int) (*domain.Role, error) {
+	for i := range m.roles {
+		if int(m.roles[i].ID) == id {
+			return &m.roles[i], nil
+		}
+	}
+	return nil, errors.New("role not found")
+}
+func (m *MockRoleRepository) Create(role *domain.Role) error { return nil }
+func (m *MockRoleRepository) Update(role *domain.Role) error { return nil }
+func (m *MockRoleRepository) Delete(id int) error            { return nil }
+func (m *MockRoleRepository) GetByName(name string) (*domain.Role, error) {
+	for i := range m.roles {
+		if m.roles[i].Name == name {
+			return &m.roles[i], nil
+		}
+	}
+	return nil, errors.New("role not found")
+}
+func (m *MockRoleRepository) GetPermissions(roleID int) ([]domain.Permission, error) {
+	return m.rolePermissions[roleID], nil
+}
+func (m *MockRoleRepository) SetPermissions(roleID int, permissionIDs []int) error { return nil }
+
+func (m *MockRoleRepository) AssignToUser(userID, roleID, franchiseID int) error {
+	if m.userRoles == nil {
+		m.userRoles = map[[3]int]bool{}
+	}
+	m.userRoles[[3]int{userID, roleID, franchiseID}] = true
+	return nil
+}
+
+func (m *MockRoleRepository) RevokeFromUser(userID, roleID, franchiseID int) error {
+	key := [3]int{userID, roleID, franchiseID}
+	if !m.userRoles[key] {
+		return errors.New("user role not found")
+	}
+	delete(m.userRoles, key)
+	return nil
+}
+
+func (m *MockRoleRepository) GetUserPermissionNames(userID, franchiseID int) ([]string, error) {
+	m.lookups++
+	return m.userPermissions[[2]int{userID, franchiseID}], nil
+}
+
+func TestPermissionResolver_HasPermission(t *testing.T) {
+	roles := &MockRoleRepository{userPermissions: map[[2]int][]string{
+		{1, 1}: {"*"},                            // admin
+		{2, 1}: {"shifts:read", "assignments:*"}, // store manager
+		{2, 2}: {"shifts:read", "shifts:write"},  // same user, another franchise
+	}}
+	resolver := NewPermissionResolver(roles)
+
+	tests := []struct {
+		userID, franchiseID int
+		permission          string
+		expected            bool
+	}{
+		{1, 1, "roles:write", true},
+		{2, 1, "shifts:read", true},
+		{2, 1, "shifts:write", false}, // only granted in franchise 2
+		{2, 2, "shifts:write", true},
+		{2, 1, "assignments:write", true},
+		{2, 1, "payroll:read", false},
+		{1, 1, "platform:admin", false}, // not granted by "*"
+	}
+
+	for _, tt := range tests {
+		ok, err := resolver.HasPermission(tt.userID, tt.franchiseID, tt.permission)
+		if err != nil {
+			t.Fatalf("HasPermission: unexpected error %v", err)
+		}
+		if ok != tt.expected {
+			t.Errorf("user %d franchise %d %s: expected %v, got %v", tt.userID, tt.franchiseID, tt.permission, tt.expected, ok)
+		}
+	}
+
+	// Lookups are cached per user and franchise until invalidated
+	if roles.lookups != 3 {
+		t.Errorf("Expected 3 repository lookups, got %d", roles.lookups)
+	}
+	resolver.Invalidate()
+	resolver.UserPermissions(1, 1)
+	if roles.lookups != 4 {
+		t.Errorf("Expected a new lookup after invalidation, got %d lookups", roles.lookups)
+	}
+}
+
+func TestPermissionUseCase_GetUserPermissionsRequiresFranchise(t *testing.T) {
+	roles := &MockRoleRepository{userPermissions: map[[2]int][]string{
+		{2, 0}: {"*"}, // roles of every franchise merged
+		{2, 1}: {"shifts:read"},
+	}}
+	uc := NewPermissionUseCase(nil, NewPermissionResolver(roles))
+
+	if _, err := uc.GetUserPermissions(2, 0); domainStatus(t, err) != 403 {
+		t.Errorf("Expected 403 without a selected franchise, got %v", err)
+	}
+	permissions, err := uc.GetUserPermissions(2, 1)
+	if err != nil {
+		t.Fatalf("GetUserPermissions: unexpected error %v", err)
+	}
+	if len(permissions) != 1 || permissions[0] != "shifts:read" {
+		t.Errorf("Expected the permissions of franchise 1, got %v", permissions)
+	}
+}
+
+func TestValidatePermissionName(t *testing.T) {
+	valid := []string{"shifts:write", " Shifts:Read ", "employee_hours:read", "shifts:*", "*"}
+	for _, name := range valid {
+		if _, err := ValidatePermissionName(name); err != nil {
+			t.Errorf("%q: unexpected error %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "shifts", "shifts:", ":write", "shifts:write:all", "*:write"}
+	for _, name := range invalid {
+		if _, err := ValidatePermissionName(name); err == nil {
+			t.Errorf("%q: expected validation error", name)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"regexp"
+	"strings"
+)
+
+// permissionNamePattern accepts "resource:action", "resource:*" and "*"
+var permissionNamePattern = regexp.MustCompile(`^(\*|[a-z][a-z0-9_-]*:([a-z][a-z0-9_-]*|\*))$`)
+
+type PermissionUseCase interface {
+	// Standard CRUD operations
+	GetAll() ([]domain.Permission, error)
+	GetByID(id int) (*domain.Permission, error)
+	Create(req dto.PermissionRequest) (*domain.Permission, error)
+	Update(id int, req dto.PermissionRequest) (*domain.Permission, error)
+	Delete(id int) error
+
+	// Business-specific operations
+	GetUserPermissions(userID, franchiseID int) ([]string, error)
+}
+
+type permissionUseCase struct {
+	permissionRepo repository.PermissionRepository
+	permissions    *PermissionResolver
+	errorHandler   *base.ErrorHandler
+	validator      *base.Validator
+	logger         *base.Logger
+}
+
+func NewPermissionUseCase(permissionRepo repository.PermissionRepository, permissions *PermissionResolver) PermissionUseCase {
+	return &permissionUseCase{
+		permissionRepo: permissionRepo,
+		permissions:    permissions,
+		errorHandler:   base.NewErrorHandler("Permission"),
+		validator:      base.NewValidator(),
+		logger:         base.NewLogger("Permission"),
+	}
+}
+
+// ✅ Enhanced CRUD operations with logging, validation, and error handling
+
+// GetAll retrieves every permission
+func (uc *permissionUseCase) GetAll() ([]domain.Permission, error) {
+	uc.logger.LogOperation("GetAll", "start", nil)
+
+	permissions, err := uc.permissionRepo.GetAll()
+	if err != nil {
+		uc.logger.LogError("GetAll", err, nil)
+		return nil, uc.errorHandler.HandleRepositoryError("GetAll", err)
+	}
+
+	uc.logger.LogOperation("GetAll", "success", map[string]interface{}{"count": len(permissions)})
+	return permissions, nil
+}
+
+// GetByID retrieves a permission by ID with validation
+func (uc *permissionUseCase) GetByID(id int) (*domain.Permission, error) {
+	uc.logger.LogOperation("GetByID", "start", map[string]interface{}{"id": id})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByID", err)
+	}
+
+	permission, err := uc.permissionRepo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("GetByID", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByID", err)
+	}
+
+	return permission, nil
+}
+
+// Create registers a new permission
+func (uc *permissionUseCase) Create(req dto.PermissionRequest) (*domain.Permission, error) {
+	uc.logger.LogOperation("Create", "start", map[string]interface{}{"name": req.Name})
+
+	name, err := ValidatePermissionName(req.Name)
+	if err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Create", err)
+	}
+
+	permission := &domain.Permission{Name: name, Description: strings.TrimSpace(req.Description)}
+	if err := uc.permissionRepo.Create(permission); err != nil {
+		uc.logger.LogError("Create", err, map[string]interface{}{"name": name})
+		return nil, uc.errorHandler.HandleRepositoryError("Create", err)
+	}
+
+	uc.logger.LogOperation("Create", "success", map[string]interface{}{
+		"id":   permission.ID,
+		"name": permission.Name,
+	})
+
+	return permission, nil
+}
+
+// Update renames or describes a permission; roles that grant it follow the new name
+func (uc *permissionUseCase) Update(id int, req dto.PermissionRequest) (*domain.Permission, error) {
+	uc.logger.LogOperation("Update", "start", map[string]interface{}{"id": id})
+
+	permission, err := uc.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := ValidatePermissionName(req.Name)
+	if err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Update", err)
+	}
+
+	if permission.Name == domain.PermissionWildcard && name != domain.PermissionWildcard {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation("Update", "wildcard_permission", "the wildcard permission cannot be renamed")
+	}
+
+	permission.Name = name
+	permission.Description = strings.TrimSpace(req.Description)
+	if err := uc.permissionRepo.Update(permission); err != nil {
+		uc.logger.LogError("Update", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Update", err)
+	}
+	uc.permissions.Invalidate()
+
+	uc.logger.LogOperation("Update", "success", map[string]interface{}{
+		"id":   permission.ID,
+		"name": permission.Name,
+	})
+
+	return permission, nil
+}
+
+// Delete removes a permission from every role
+func (uc *permissionUseCase) Delete(id int) error {
+	uc.logger.LogOperation("Delete", "start", map[string]interface{}{"id": id})
+
+	permission, err := uc.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if permission.Name == domain.PermissionWildcard {
+		return uc.errorHandler.HandleBusinessRuleViolation("Delete", "wildcard_permission", "the wildcard permission cannot be deleted")
+	}
+
+	if err := uc.permissionRepo.Delete(id); err != nil {
+		uc.logger.LogError("Delete", err, map[string]interface{}{"id": id})
+		return uc.errorHandler.HandleRepositoryError("Delete", err)
+	}
+	uc.permissions.Invalidate()
+
+	uc.logger.LogOperation("Delete", "success", map[string]interface{}{"id": id})
+	return nil
+}
+
+// ✅ Business-specific operations with enhanced validation and logging
+
+// GetUserPermissions returns the permissions of a user in the selected franchise.
+// Without a selected franchise it is rejected rather than merging the roles of every franchise.
+func (uc *permissionUseCase) GetUserPermissions(userID, franchiseID int) ([]string, error) {
+	if err := uc.validator.ValidateID(userID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetUserPermissions", err)
+	}
+	if franchiseID <= 0 {
+		return nil, uc.errorHandler.HandleForbidden("GetUserPermissions", "a franchise must be selected")
+	}
+	return uc.permissions.UserPermissions(userID, franchiseID)
+}
+
+// ValidatePermissionName normalizes a permission name and checks its "resource:action" format
+func ValidatePermissionName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if !permissionNamePattern.MatchString(name) || len(name) > 100 {
+		return "", fmt.Errorf("invalid permission name: %q. Use \"resource:action\" (e.g. shifts:write), \"resource:*\" or \"*\"", name)
+	}
+	return name, nil
+}
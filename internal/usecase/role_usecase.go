@@ -0,0 +1,386 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"strings"
+)
+
+// AdminRoleName is the built-in role that holds every permission; it cannot be deleted
+const AdminRoleName = "admin"
+
+type RoleUseCase interface {
+	// Standard CRUD operations
+	GetAll() ([]domain.Role, error)
+	GetByID(id int) (*dto.RoleWithPermissions, error)
+	Create(req dto.RoleRequest) (*domain.Role, error)
+	Update(id int, req dto.RoleRequest) (*domain.Role, error)
+	Delete(id int) error
+
+	// Business-specific operations
+	SetPermissions(id int, req dto.RolePermissionsRequest) (*dto.RoleWithPermissions, error)
+
+	// User role assignments, scoped to the caller's franchise
+	AssignToUser(scope domain.TenantScope, userID int, req dto.UserRoleRequest) error
+	RevokeFromUser(scope domain.TenantScope, userID int, req dto.UserRoleRequest) error
+}
+
+type roleUseCase struct {
+	roleRepo       repository.RoleRepository
+	permissionRepo repository.PermissionRepository
+	permissions    *PermissionResolver
+	tenant         *TenantGuard
+	errorHandler   *base.ErrorHandler
+	validator      *base.Validator
+	logger         *base.Logger
+}
+
+func NewRoleUseCase(
+	roleRepo repository.RoleRepository,
+	permissionRepo repository.PermissionRepository,
+	permissions *PermissionResolver,
+	tenant *TenantGuard,
+) RoleUseCase {
+	return &roleUseCase{
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+		permissions:    permissions,
+		tenant:         tenant,
+		errorHandler:   base.NewErrorHandler("Role"),
+		validator:      base.NewValidator(),
+		logger:         base.NewLogger("Role"),
+	}
+}
+
+// ✅ Enhanced CRUD operations with logging, validation, and error handling
+
+// GetAll retrieves every role
+func (uc *roleUseCase) GetAll() ([]domain.Role, error) {
+	uc.logger.LogOperation("GetAll", "start", nil)
+
+	roles, err := uc.roleRepo.GetAll()
+	if err != nil {
+		uc.logger.LogError("GetAll", err, nil)
+		return nil, uc.errorHandler.HandleRepositoryError("GetAll", err)
+	}
+
+	uc.logger.LogOperation("GetAll", "success", map[string]interface{}{"count": len(roles)})
+	return roles, nil
+}
+
+// GetByID retrieves a role with the permissions it grants
+func (uc *roleUseCase) GetByID(id int) (*dto.RoleWithPermissions, error) {
+	uc.logger.LogOperation("GetByID", "start", map[string]interface{}{"id": id})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByID", err)
+	}
+
+	role, err := uc.roleRepo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("GetByID", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByID", err)
+	}
+
+	return uc.withPermissions("GetByID", role)
+}
+
+// Create registers a new role without permissions
+func (uc *roleUseCase) Create(req dto.RoleRequest) (*domain.Role, error) {
+	uc.logger.LogOperation("Create", "start", map[string]interface{}{"name": req.Name})
+
+	name, err := uc.validateRoleName(req.Name)
+	if err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Create", err)
+	}
+
+	role := &domain.Role{Name: name, Description: strings.TrimSpace(req.Description), IsActive: true}
+	if req.IsActive != nil {
+		role.IsActive = *req.IsActive
+	}
+
+	if err := uc.roleRepo.Create(role); err != nil {
+		uc.logger.LogError("Create", err, map[string]interface{}{"name": name})
+		return nil, uc.errorHandler.HandleRepositoryError("Create", err)
+	}
+
+	uc.logger.LogOperation("Create", "success", map[string]interface{}{
+		"id":   role.ID,
+		"name": role.Name,
+	})
+
+	return role, nil
+}
+
+// Update renames, describes or (de)activates a role; inactive roles grant no permissions
+func (uc *roleUseCase) Update(id int, req dto.RoleRequest) (*domain.Role, error) {
+	uc.logger.LogOperation("Update", "start", map[string]interface{}{"id": id})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Update", err)
+	}
+
+	role, err := uc.roleRepo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("Update", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Update", err)
+	}
+
+	name, err := uc.validateRoleName(req.Name)
+	if err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Update", err)
+	}
+
+	// Business rule: The admin role keeps its name and stays active
+	if role.Name == AdminRoleName && (name != AdminRoleName || (req.IsActive != nil && !*req.IsActive)) {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation("Update", "admin_role", "the admin role cannot be renamed or deactivated")
+	}
+
+	role.Name = name
+	role.Description = strings.TrimSpace(req.Description)
+	if req.IsActive != nil {
+		role.IsActive = *req.IsActive
+	}
+
+	if err := uc.roleRepo.Update(role); err != nil {
+		uc.logger.LogError("Update", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Update", err)
+	}
+	uc.permissions.Invalidate()
+
+	uc.logger.LogOperation("Update", "success", map[string]interface{}{
+		"id":        role.ID,
+		"name":      role.Name,
+		"is_active": role.IsActive,
+	})
+
+	return role, nil
+}
+
+// Delete removes a role and its user assignments
+func (uc *roleUseCase) Delete(id int) error {
+	uc.logger.LogOperation("Delete", "start", map[string]interface{}{"id": id})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		return uc.errorHandler.HandleValidationError("Delete", err)
+	}
+
+	role, err := uc.roleRepo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("Delete", err, map[string]interface{}{"id": id})
+		return uc.errorHandler.HandleRepositoryError("Delete", err)
+	}
+
+	if role.Name == AdminRoleName {
+		return uc.errorHandler.HandleBusinessRuleViolation("Delete", "admin_role", "the admin role cannot be deleted")
+	}
+
+	if err := uc.roleRepo.Delete(id); err != nil {
+		uc.logger.LogError("Delete", err, map[string]interface{}{"id": id})
+		return uc.errorHandler.HandleRepositoryError("Delete", err)
+	}
+	uc.permissions.Invalidate()
+
+	uc.logger.LogOperation("Delete", "success", map[string]interface{}{"id": id})
+	return nil
+}
+
+// ✅ Business-specific operations with enhanced validation and logging
+
+// SetPermissions replaces the permissions granted by a role
+func (uc *roleUseCase) SetPermissions(id int, req dto.RolePermissionsRequest) (*dto.RoleWithPermissions, error) {
+	uc.logger.LogOperation("SetPermissions", "start", map[string]interface{}{
+		"id":          id,
+		"permissions": req.PermissionIDs,
+	})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("SetPermissions", err)
+	}
+
+	role, err := uc.roleRepo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("SetPermissions", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("SetPermissions", err)
+	}
+
+	ids := uniqueIDs(req.PermissionIDs)
+	found, err := uc.permissionRepo.GetByIDs(ids)
+	if err != nil {
+		return nil, uc.errorHandler.HandleRepositoryError("SetPermissions", err)
+	}
+	if len(found) != len(ids) {
+		err := fmt.Errorf("unknown permission IDs in %v", ids)
+		return nil, uc.errorHandler.HandleValidationError("SetPermissions", err)
+	}
+
+	// Business rule: The admin role always keeps the wildcard permission
+	if role.Name == AdminRoleName && !containsPermission(found, domain.PermissionWildcard) {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation("SetPermissions", "admin_role",
+			fmt.Sprintf("the admin role must keep the %q permission", domain.PermissionWildcard))
+	}
+
+	if err := uc.roleRepo.SetPermissions(id, ids); err != nil {
+		uc.logger.LogError("SetPermissions", err, map[string]interface{}{"id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("SetPermissions", err)
+	}
+	uc.permissions.Invalidate()
+
+	uc.logger.LogOperation("SetPermissions", "success", map[string]interface{}{
+		"id":    id,
+		"count": len(ids),
+	})
+
+	return uc.withPermissions("SetPermissions", role)
+}
+
+// AssignToUser grants a role to a user of the caller's franchise in that franchise
+func (uc *roleUseCase) AssignToUser(scope domain.TenantScope, userID int, req dto.UserRoleRequest) error {
+	uc.logger.LogOperation("AssignToUser", "start", map[string]interface{}{
+		"user_id":      userID,
+		"role_id":      req.RoleID,
+		"franchise_id": req.FranchiseID,
+	})
+
+	franchiseID, err := uc.validateUserRole("AssignToUser", scope, userID, req)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.roleRepo.AssignToUser(userID, req.RoleID, franchiseID); err != nil {
+		uc.logger.LogError("AssignToUser", err, map[string]interface{}{"user_id": userID, "role_id": req.RoleID})
+		return uc.errorHandler.HandleRepositoryError("AssignToUser", err)
+	}
+	uc.permissions.Invalidate()
+
+	uc.logger.LogOperation("AssignToUser", "success", map[string]interface{}{
+		"user_id":      userID,
+		"role_id":      req.RoleID,
+		"franchise_id": franchiseID,
+	})
+	return nil
+}
+
+// RevokeFromUser removes a role of a user of the caller's franchise in that franchise
+func (uc *roleUseCase) RevokeFromUser(scope domain.TenantScope, userID int, req dto.UserRoleRequest) error {
+	uc.logger.LogOperation("RevokeFromUser", "start", map[string]interface{}{
+		"user_id":      userID,
+		"role_id":      req.RoleID,
+		"franchise_id": req.FranchiseID,
+	})
+
+	franchiseID, err := uc.validateUserRole("RevokeFromUser", scope, userID, req)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.roleRepo.RevokeFromUser(userID, req.RoleID, franchiseID); err != nil {
+		uc.logger.LogError("RevokeFromUser", err, map[string]interface{}{"user_id": userID, "role_id": req.RoleID})
+		return uc.errorHandler.HandleRepositoryError("RevokeFromUser", err)
+	}
+	uc.permissions.Invalidate()
+
+	uc.logger.LogOperation("RevokeFromUser", "success", map[string]interface{}{
+		"user_id":      userID,
+		"role_id":      req.RoleID,
+		"franchise_id": franchiseID,
+	})
+	return nil
+}
+
+// validateUserRole checks a role assignment and returns its franchise. Roles are only assigned
+// in the caller's franchise to its users, and roles with platform permissions only by the platform.
+func (uc *roleUseCase) validateUserRole(operation string, scope domain.TenantScope, userID int, req dto.UserRoleRequest) (int, error) {
+	if err := uc.validator.ValidateID(userID); err != nil {
+		return 0, uc.errorHandler.HandleValidationError(operation, err)
+	}
+	if err := uc.validator.ValidateID(req.RoleID); err != nil {
+		return 0, uc.errorHandler.HandleValidationError(operation, fmt.Errorf("invalid role_id: %v", err))
+	}
+
+	franchiseID := req.FranchiseID
+	if franchiseID == 0 {
+		franchiseID = scope.FranchiseID
+	}
+	if franchiseID != scope.FranchiseID {
+		return 0, uc.errorHandler.HandleForbidden(operation,
+			fmt.Sprintf("roles can only be assigned in the selected franchise %d", scope.FranchiseID))
+	}
+	if err := uc.tenant.RequireEmployee(operation, scope, userID); err != nil {
+		return 0, err
+	}
+
+	role, err := uc.roleRepo.GetByID(req.RoleID)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"role_id": req.RoleID})
+		return 0, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	permissions, err := uc.roleRepo.GetPermissions(int(role.ID))
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"role_id": req.RoleID})
+		return 0, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	// Business rule: A franchise admin cannot hand out platform permissions
+	for _, permission := range permissions {
+		if domain.IsPlatformPermission(permission.Name) {
+			if err := uc.tenant.RequirePlatform(operation, scope); err != nil {
+				uc.logger.LogBusinessRule(operation, "platform_role", "violated", map[string]interface{}{
+					"user_id": userID,
+					"role_id": req.RoleID,
+				})
+				return 0, err
+			}
+			break
+		}
+	}
+
+	return franchiseID, nil
+}
+
+// withPermissions loads the permissions granted by a role
+func (uc *roleUseCase) withPermissions(operation string, role *domain.Role) (*dto.RoleWithPermissions, error) {
+	permissions, err := uc.roleRepo.GetPermissions(int(role.ID))
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"id": role.ID})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	return &dto.RoleWithPermissions{Role: *role, Permissions: permissions}, nil
+}
+
+// validateRoleName normalizes a role name to lower case and checks its length
+func (uc *roleUseCase) validateRoleName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", fmt.Errorf("name is required")
+	}
+	if len(name) > 50 {
+		return "", fmt.Errorf("name must be at most 50 characters")
+	}
+	return name, nil
+}
+
+// uniqueIDs drops duplicated IDs keeping the first occurrence
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func containsPermission(permissions []domain.Permission, name string) bool {
+	for _, permission := range permissions {
+		if permission.Name == name {
+			return true
+		}
+	}
+	return false
+}
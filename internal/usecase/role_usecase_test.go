@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"net/http"
+	"testing"
+
+	"loopi-api/internal/domain"
+	"loopi-api/internal/usecase/dto"
+)
+
+func newTestRoleUseCase() (RoleUseCase, *MockRoleRepository) {
+	roles := &MockRoleRepository{
+		roles: []domain.Role{
+			{ID: 1, Name: AdminRoleName, IsActive: true},
+			{ID: 2, Name: domain.RoleEmployee, IsActive: true},
+			{ID: 3, Name: "platform_admin", IsActive: true},
+		},
+		rolePermissions: map[int][]domain.Permission{
+			1: {{ID: 1, Name: domain.PermissionWildcard}},
+			2: {{ID: 2, Name: "shifts:read"}},
+			3: {{ID: 3, Name: domain.PermissionPlatform}},
+		},
+	}
+	tenant := NewTenantGuard(&MockTenantRepository{employeeFranchises: map[int]int{7: 1, 8: 2}})
+	return NewRoleUseCase(roles, nil, NewPermissionResolver(roles), tenant), roles
+}
+
+func TestRoleUseCase_AssignToUser(t *testing.T) {
+	uc, roles := newTestRoleUseCase()
+
+	// Franchise 0 in the request means the franchise of the token
+	if err := uc.AssignToUser(franchiseScope(1), 7, dto.UserRoleRequest{RoleID: 1}); err != nil {
+		t.Fatalf("Expected the admin role to be assigned, got %v", err)
+	}
+	if !roles.userRoles[[3]int{7, 1, 1}] {
+		t.Errorf("Expected user 7 to hold role 1 in franchise 1, got %v", roles.userRoles)
+	}
+
+	err := uc.AssignToUser(franchiseScope(1), 7, dto.UserRoleRequest{RoleID: 2, FranchiseID: 2})
+	if status := domainStatus(t, err); status != http.StatusForbidden {
+		t.Errorf("Expected 403 for another franchise, got %d", status)
+	}
+
+	err = uc.AssignToUser(franchiseScope(1), 8, dto.UserRoleRequest{RoleID: 2})
+	if status := domainStatus(t, err); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for a user of another franchise, got %d", status)
+	}
+}
+
+func TestRoleUseCase_FranchiseAdminCannotGrantPlatformRole(t *testing.T) {
+	uc, roles := newTestRoleUseCase()
+
+	err := uc.AssignToUser(franchiseScope(1), 7, dto.UserRoleRequest{RoleID: 3})
+	if status := domainStatus(t, err); status != http.StatusForbidden {
+		t.Errorf("Expected 403 granting platform_admin as a franchise admin, got %d", status)
+	}
+	if roles.userRoles[[3]int{7, 3, 1}] {
+		t.Error("Expected platform_admin not to be granted")
+	}
+
+	platform := domain.TenantScope{FranchiseID: 1, Platform: true}
+	if err := uc.AssignToUser(platform, 7, dto.UserRoleRequest{RoleID: 3}); err != nil {
+		t.Fatalf("Expected the platform to grant platform_admin, got %v", err)
+	}
+
+	// Nor can a franchise admin take it away
+	err = uc.RevokeFromUser(franchiseScope(1), 7, dto.UserRoleRequest{RoleID: 3})
+	if status := domainStatus(t, err); status != http.StatusForbidden {
+		t.Errorf("Expected 403 revoking platform_admin as a franchise admin, got %d", status)
+	}
+	if err := uc.RevokeFromUser(platform, 7, dto.UserRoleRequest{RoleID: 3}); err != nil {
+		t.Fatalf("Expected the platform to revoke platform_admin, got %v", err)
+	}
+	if roles.userRoles[[3]int{7, 3, 1}] {
+		t.Error("Expected platform_admin to be revoked")
+	}
+}
@@ -0,0 +1,358 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
+	"sort"
+	"time"
+)
+
+type RosterGeneratorUseCase interface {
+	// Standard operations, scoped to the caller's franchise
+	Preview(scope domain.TenantScope, req dto.RosterGenerationRequest) (*dto.RosterProposal, error)
+	Commit(scope domain.TenantScope, req dto.RosterGenerationRequest) (*dto.RosterProposal, error)
+
+	// Business-specific operations
+	ValidateGenerationRequest(req *dto.RosterGenerationRequest) error
+}
+
+type rosterGeneratorUseCase struct {
+	assignedRepo repository.AssignedShiftRepository
+	shiftRepo    repository.ShiftRepository
+	userRepo     repository.UserRepository
+	laborRules   *LaborRulesValidator
+	availability *AvailabilityChecker
+	holidays     *HolidayCalendarResolver
+	tenant       *TenantGuard
+	errorHandler *base.ErrorHandler
+	validator    *base.Validator
+	logger       *base.Logger
+}
+
+func NewRosterGeneratorUseCase(
+	assignedRepo repository.AssignedShiftRepository,
+	shiftRepo repository.ShiftRepository,
+	userRepo repository.UserRepository,
+	workConfigRepo repository.WorkConfigRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	holidays *HolidayCalendarResolver,
+	tenant *TenantGuard,
+) RosterGeneratorUseCase {
+	return &rosterGeneratorUseCase{
+		assignedRepo: assignedRepo,
+		shiftRepo:    shiftRepo,
+		userRepo:     userRepo,
+		laborRules:   NewLaborRulesValidator(assignedRepo, workConfigRepo, utils.DefaultLaborLimits()),
+		availability: NewAvailabilityChecker(availabilityRepo),
+		holidays:     holidays,
+		tenant:       tenant,
+		errorHandler: base.NewErrorHandler("RosterGenerator"),
+		validator:    base.NewValidator(),
+		logger:       base.NewLogger("RosterGenerator"),
+	}
+}
+
+// ✅ Enhanced operations with logging, validation, and error handling
+
+// Preview builds the proposed monthly roster of a store without persisting it
+func (uc *rosterGeneratorUseCase) Preview(scope domain.TenantScope, req dto.RosterGenerationRequest) (*dto.RosterProposal, error) {
+	uc.logger.LogOperation("Preview", "start", map[string]interface{}{
+		"store_id": req.StoreID,
+		"year":     req.Year,
+		"month":    req.Month,
+	})
+
+	proposal, _, err := uc.generate("Preview", scope, req)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.LogOperation("Preview", "success", map[string]interface{}{
+		"store_id":    req.StoreID,
+		"assignments": len(proposal.Assignments),
+		"uncovered":   len(proposal.Uncovered),
+		"violations":  len(proposal.Violations),
+	})
+
+	return proposal, nil
+}
+
+// Commit generates the roster and persists every proposed assignment atomically.
+// Generation is deterministic, so committing right after a preview stores the same roster.
+// A roster that breaks a labor rule is rejected; Preview reports the violations instead.
+func (uc *rosterGeneratorUseCase) Commit(scope domain.TenantScope, req dto.RosterGenerationRequest) (*dto.RosterProposal, error) {
+	timer := uc.logger.StartTimer("Commit", map[string]interface{}{
+		"store_id": req.StoreID,
+		"year":     req.Year,
+		"month":    req.Month,
+	})
+	defer timer.Stop()
+
+	proposal, assignments, err := uc.generate("Commit", scope, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(proposal.Violations) > 0 {
+		violation := proposal.Violations[0]
+		return nil, uc.errorHandler.HandleBusinessRuleViolation("Commit", violation.Rule,
+			fmt.Sprintf("%s (%d labor rule violations in total, see preview)", violation.Message, len(proposal.Violations)))
+	}
+
+	if err := uc.assignedRepo.CreateBatch(assignments); err != nil {
+		uc.logger.LogError("Commit", err, map[string]interface{}{
+			"store_id": req.StoreID,
+			"count":    len(assignments),
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("Commit", err)
+	}
+	proposal.Committed = true
+
+	uc.logger.LogOperation("Commit", "success", map[string]interface{}{
+		"store_id":  req.StoreID,
+		"created":   len(assignments),
+		"uncovered": len(proposal.Uncovered),
+	})
+
+	return proposal, nil
+}
+
+// ✅ Business-specific operations with enhanced validation and logging
+
+// ValidateGenerationRequest validates a roster generation request
+func (uc *rosterGeneratorUseCase) ValidateGenerationRequest(req *dto.RosterGenerationRequest) error {
+	if err := uc.validator.ValidateID(req.StoreID); err != nil {
+		uc.logger.LogValidation("ValidateGenerationRequest", "store_id", "failed", map[string]interface{}{
+			"error":    err.Error(),
+			"store_id": req.StoreID,
+		})
+		return uc.errorHandler.HandleValidationError("ValidateGenerationRequest", err)
+	}
+
+	if req.Year < 2000 || req.Year > 2030 {
+		err := fmt.Errorf("year must be between 2000 and 2030, got: %d", req.Year)
+		uc.logger.LogValidation("ValidateGenerationRequest", "year_range", "failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return uc.errorHandler.HandleValidationError("ValidateGenerationRequest", err)
+	}
+
+	if req.Month < 1 || req.Month > 12 {
+		err := fmt.Errorf("month must be between 1 and 12, got: %d", req.Month)
+		uc.logger.LogValidation("ValidateGenerationRequest", "month_range", "failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return uc.errorHandler.HandleValidationError("ValidateGenerationRequest", err)
+	}
+
+	return nil
+}
+
+// generate loads shifts, employees and the current roster, then runs the generator
+func (uc *rosterGeneratorUseCase) generate(operation string, scope domain.TenantScope, req dto.RosterGenerationRequest) (*dto.RosterProposal, []domain.AssignedShift, error) {
+	if err := uc.ValidateGenerationRequest(&req); err != nil {
+		return nil, nil, err
+	}
+
+	if err := uc.tenant.RequireStore(operation, scope, req.StoreID); err != nil {
+		return nil, nil, err
+	}
+
+	shifts, err := uc.shiftRepo.GetActiveShiftsByStore(req.StoreID)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"store_id": req.StoreID})
+		return nil, nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	if len(shifts) == 0 {
+		return nil, nil, uc.errorHandler.HandleBusinessRuleViolation(operation, "active_shifts_required",
+			fmt.Sprintf("store %d has no active shifts to schedule", req.StoreID))
+	}
+
+	users, err := uc.userRepo.GetByStore(req.StoreID)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"store_id": req.StoreID})
+		return nil, nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	var employeeIDs []int
+	for _, user := range users {
+		if user.IsActive {
+			employeeIDs = append(employeeIDs, int(user.ID))
+		}
+	}
+	if len(employeeIDs) == 0 {
+		return nil, nil, uc.errorHandler.HandleBusinessRuleViolation(operation, "active_employees_required",
+			fmt.Sprintf("store %d has no active employees to schedule", req.StoreID))
+	}
+
+	existing, err := uc.loadExistingAssignments(operation, req, employeeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	calendarScope, err := uc.holidays.ScopeForStore(operation, req.StoreID)
+	if err != nil {
+		return nil, nil, err
+	}
+	holidayMap, err := uc.holidays.HolidaysByMonth(operation, calendarScope, req.Year, req.Month)
+	if err != nil {
+		return nil, nil, err
+	}
+	days := utils.BuildCalendarDays(req.Year, req.Month, holidayMap)
+
+	availability, err := uc.loadAvailability(operation, req, employeeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slots, loads := utils.GenerateRoster(days, shifts, employeeIDs, existing, availability)
+
+	proposal := &dto.RosterProposal{
+		StoreID:     req.StoreID,
+		Year:        req.Year,
+		Month:       req.Month,
+		Assignments: make([]dto.ProposedAssignment, 0, len(slots)),
+		Uncovered:   make([]dto.UncoveredSlot, 0),
+		Workload:    make([]dto.EmployeeWorkload, 0, len(loads)),
+		Violations:  make([]dto.LaborViolation, 0),
+	}
+	assignments := make([]domain.AssignedShift, 0, len(slots))
+
+	for _, slot := range slots {
+		date := slot.Date.Format("2006-01-02")
+		if slot.EmployeeID == 0 {
+			proposal.Uncovered = append(proposal.Uncovered, dto.UncoveredSlot{
+				Date:      date,
+				DayType:   string(slot.DayType),
+				ShiftID:   int(slot.Shift.ID),
+				ShiftName: slot.Shift.Name,
+			})
+			continue
+		}
+
+		proposal.Assignments = append(proposal.Assignments, dto.ProposedAssignment{
+			Date:              date,
+			DayType:           string(slot.DayType),
+			ShiftID:           int(slot.Shift.ID),
+			ShiftName:         slot.Shift.Name,
+			StartTime:         slot.Shift.StartTime,
+			EndTime:           slot.Shift.EndTime,
+			EmployeeID:        slot.EmployeeID,
+			AgainstPreference: slot.AgainstPreference,
+		})
+		assignments = append(assignments, buildAssignment(slot.EmployeeID, &slot.Shift, date))
+	}
+
+	for employeeID, load := range loads {
+		proposal.Workload = append(proposal.Workload, dto.EmployeeWorkload{
+			EmployeeID:          employeeID,
+			TotalShifts:         load.Total,
+			SundayHolidayShifts: load.SundayHoliday,
+		})
+	}
+	sort.Slice(proposal.Workload, func(i, j int) bool {
+		return proposal.Workload[i].EmployeeID < proposal.Workload[j].EmployeeID
+	})
+
+	violations, err := uc.checkLaborRules(operation, assignments)
+	if err != nil {
+		return nil, nil, err
+	}
+	proposal.Violations = append(proposal.Violations, violations...)
+
+	if len(proposal.Uncovered) > 0 {
+		uc.logger.LogWarning(operation, "some shifts could not be covered", map[string]interface{}{
+			"store_id":  req.StoreID,
+			"uncovered": len(proposal.Uncovered),
+		})
+	}
+
+	return proposal, assignments, nil
+}
+
+// loadExistingAssignments returns what is already scheduled in the month, both in the
+// store and for its employees elsewhere, so the generator never double-books anyone
+func (uc *rosterGeneratorUseCase) loadExistingAssignments(operation string, req dto.RosterGenerationRequest, employeeIDs []int) ([]domain.AssignedShift, error) {
+	first := time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+
+	storeAssignments, err := uc.assignedRepo.GetByStoreAndDateRange(req.StoreID, first.Format("2006-01-02"), last.Format("2006-01-02"))
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"store_id": req.StoreID})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	seen := make(map[uint]bool, len(storeAssignments))
+	existing := make([]domain.AssignedShift, 0, len(storeAssignments))
+	for _, a := range storeAssignments {
+		seen[a.ID] = true
+		existing = append(existing, a)
+	}
+
+	for _, employeeID := range employeeIDs {
+		assignments, err := uc.assignedRepo.GetByEmployeeAndMonth(employeeID, req.Year, req.Month)
+		if err != nil {
+			uc.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+			return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+		}
+		for _, a := range assignments {
+			if !seen[a.ID] {
+				seen[a.ID] = true
+				existing = append(existing, a)
+			}
+		}
+	}
+
+	return existing, nil
+}
+
+// loadAvailability returns the weekly windows of each employee and their exceptions in the
+// month, including the first day of the next one for overnight shifts
+func (uc *rosterGeneratorUseCase) loadAvailability(operation string, req dto.RosterGenerationRequest, employeeIDs []int) (map[int]domain.EmployeeAvailability, error) {
+	first := time.Date(req.Year, time.Month(req.Month), 1, 0, 0, 0, 0, time.UTC)
+	next := first.AddDate(0, 1, 0)
+
+	availability := make(map[int]domain.EmployeeAvailability, len(employeeIDs))
+	for _, employeeID := range employeeIDs {
+		a, err := uc.availability.Load(operation, employeeID, first.Format("2006-01-02"), next.Format("2006-01-02"))
+		if err != nil {
+			return nil, err
+		}
+		availability[employeeID] = a
+	}
+	return availability, nil
+}
+
+// checkLaborRules validates each employee's proposed shifts together with what is already scheduled
+func (uc *rosterGeneratorUseCase) checkLaborRules(operation string, assignments []domain.AssignedShift) ([]dto.LaborViolation, error) {
+	byEmployee := make(map[int][]domain.AssignedShift)
+	for _, a := range assignments {
+		byEmployee[a.EmployeeID] = append(byEmployee[a.EmployeeID], a)
+	}
+
+	employeeIDs := make([]int, 0, len(byEmployee))
+	for employeeID := range byEmployee {
+		employeeIDs = append(employeeIDs, employeeID)
+	}
+	sort.Ints(employeeIDs)
+
+	var violations []dto.LaborViolation
+	for _, employeeID := range employeeIDs {
+		found, err := uc.laborRules.Collect(operation, employeeID, byEmployee[employeeID], 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range found {
+			violations = append(violations, dto.LaborViolation{
+				EmployeeID: employeeID,
+				Rule:       v.Rule,
+				Message:    v.Message,
+			})
+		}
+	}
+
+	return violations, nil
+}
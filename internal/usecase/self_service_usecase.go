@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+)
+
+// SelfServiceUseCase lets employees read their own data and request absences. The employee
+// is always the authenticated user, never an ID taken from the request.
+type SelfServiceUseCase interface {
+	GetProfile(scope domain.TenantScope, userID int) (*domain.User, error)
+	GetShifts(scope domain.TenantScope, userID, year, month int) ([]domain.AssignedShift, error)
+	GetHoursSummary(scope domain.TenantScope, userID, year, month int) (domain.EmployeeHourSummary, error)
+	GetAbsences(scope domain.TenantScope, userID, year, month int) ([]domain.Absence, error)
+	RequestAbsence(scope domain.TenantScope, userID int, absence domain.Absence) (*domain.Absence, error)
+	GetNovelties(scope domain.TenantScope, userID, year, month int) ([]domain.Novelty, error)
+	GetVacationLedger(scope domain.TenantScope, userID int) (*domain.VacationLedger, error)
+	GetAvailability(scope domain.TenantScope, userID int, from, to string) (*domain.EmployeeAvailability, error)
+	SetAvailabilityWindows(scope domain.TenantScope, userID int, req dto.AvailabilityWindowsRequest) ([]domain.AvailabilityWindow, error)
+	AddAvailabilityException(scope domain.TenantScope, userID int, req dto.AvailabilityExceptionRequest) (*domain.AvailabilityException, error)
+	DeleteAvailabilityException(scope domain.TenantScope, userID, id int) error
+}
+
+type selfServiceUseCase struct {
+	employees     EmployeeUseCase
+	assignments   AssignmentUseCase
+	employeeHours EmployeeHoursUseCase
+	absences      AbsenceUseCase
+	novelties     NoveltyUseCase
+	vacations     VacationUseCase
+	availability  AvailabilityUseCase
+	errorHandler  *base.ErrorHandler
+	logger        *base.Logger
+}
+
+// NewSelfServiceUseCase creates the self-service use case on top of the tenant-scoped use cases
+func NewSelfServiceUseCase(
+	employees EmployeeUseCase,
+	assignments AssignmentUseCase,
+	employeeHours EmployeeHoursUseCase,
+	absences AbsenceUseCase,
+	novelties NoveltyUseCase,
+	vacations VacationUseCase,
+	availability AvailabilityUseCase,
+) SelfServiceUseCase {
+	return &selfServiceUseCase{
+		employees:     employees,
+		assignments:   assignments,
+		employeeHours: employeeHours,
+		absences:      absences,
+		novelties:     novelties,
+		vacations:     vacations,
+		availability:  availability,
+		errorHandler:  base.NewErrorHandler("SelfService"),
+		logger:        base.NewLogger("SelfService"),
+	}
+}
+
+// GetProfile returns the caller's employee profile
+func (uc *selfServiceUseCase) GetProfile(scope domain.TenantScope, userID int) (*domain.User, error) {
+	if err := uc.requireUser("GetProfile", userID); err != nil {
+		return nil, err
+	}
+	return uc.employees.FindByID(scope, userID)
+}
+
+// GetShifts returns the caller's assigned shifts in a month
+func (uc *selfServiceUseCase) GetShifts(scope domain.TenantScope, userID, year, month int) ([]domain.AssignedShift, error) {
+	if err := uc.requireUser("GetShifts", userID); err != nil {
+		return nil, err
+	}
+	return uc.assignments.GetByEmployeeAndMonth(scope, userID, year, month)
+}
+
+// GetHoursSummary returns the caller's monthly hours summary
+func (uc *selfServiceUseCase) GetHoursSummary(scope domain.TenantScope, userID, year, month int) (domain.EmployeeHourSummary, error) {
+	if err := uc.requireUser("GetHoursSummary", userID); err != nil {
+		return domain.EmployeeHourSummary{}, err
+	}
+	return uc.employeeHours.GetMonthlySummary(scope, userID, year, month)
+}
+
+// GetAbsences returns the caller's absences in a month
+func (uc *selfServiceUseCase) GetAbsences(scope domain.TenantScope, userID, year, month int) ([]domain.Absence, error) {
+	if err := uc.requireUser("GetAbsences", userID); err != nil {
+		return nil, err
+	}
+	return uc.absences.GetByEmployeeAndMonth(scope, userID, year, month)
+}
+
+// RequestAbsence submits an absence request of the caller for review
+func (uc *selfServiceUseCase) RequestAbsence(scope domain.TenantScope, userID int, absence domain.Absence) (*domain.Absence, error) {
+	if err := uc.requireUser("RequestAbsence", userID); err != nil {
+		return nil, err
+	}
+	absence.EmployeeID = userID
+	return uc.absences.Create(scope, userID, absence)
+}
+
+// GetNovelties returns the caller's novelties in a month
+func (uc *selfServiceUseCase) GetNovelties(scope domain.TenantScope, userID, year, month int) ([]domain.Novelty, error) {
+	if err := uc.requireUser("GetNovelties", userID); err != nil {
+		return nil, err
+	}
+	return uc.novelties.GetByEmployeeAndMonth(scope, userID, year, month)
+}
+
+// GetVacationLedger returns the caller's vacation balance and movements
+func (uc *selfServiceUseCase) GetVacationLedger(scope domain.TenantScope, userID int) (*domain.VacationLedger, error) {
+	if err := uc.requireUser("GetVacationLedger", userID); err != nil {
+		return nil, err
+	}
+	return uc.vacations.GetLedger(scope, userID)
+}
+
+// GetAvailability returns the caller's weekly windows and date exceptions
+func (uc *selfServiceUseCase) GetAvailability(scope domain.TenantScope, userID int, from, to string) (*domain.EmployeeAvailability, error) {
+	if err := uc.requireUser("GetAvailability", userID); err != nil {
+		return nil, err
+	}
+	return uc.availability.GetAvailability(scope, userID, from, to)
+}
+
+// SetAvailabilityWindows replaces the caller's weekly windows
+func (uc *selfServiceUseCase) SetAvailabilityWindows(scope domain.TenantScope, userID int, req dto.AvailabilityWindowsRequest) ([]domain.AvailabilityWindow, error) {
+	if err := uc.requireUser("SetAvailabilityWindows", userID); err != nil {
+		return nil, err
+	}
+	return uc.availability.SetWindows(scope, userID, req)
+}
+
+// AddAvailabilityException records a change of the caller's availability on a date
+func (uc *selfServiceUseCase) AddAvailabilityException(scope domain.TenantScope, userID int, req dto.AvailabilityExceptionRequest) (*domain.AvailabilityException, error) {
+	if err := uc.requireUser("AddAvailabilityException", userID); err != nil {
+		return nil, err
+	}
+	return uc.availability.CreateException(scope, userID, userID, req)
+}
+
+// DeleteAvailabilityException removes one of the caller's own date exceptions
+func (uc *selfServiceUseCase) DeleteAvailabilityException(scope domain.TenantScope, userID, id int) error {
+	if err := uc.requireUser("DeleteAvailabilityException", userID); err != nil {
+		return err
+	}
+
+	exception, err := uc.availability.GetException(scope, id)
+	if err != nil {
+		return err
+	}
+	if exception.UserID != userID {
+		return uc.errorHandler.HandleNotFound("DeleteAvailabilityException", fmt.Sprintf("availability exception %d not found", id))
+	}
+	return uc.availability.DeleteException(scope, id)
+}
+
+// requireUser rejects requests without an authenticated user
+func (uc *selfServiceUseCase) requireUser(operation string, userID int) error {
+	uc.logger.LogOperation(operation, "start", map[string]interface{}{"user_id": userID})
+	if userID <= 0 {
+		return uc.errorHandler.HandleUnauthorized(operation, "missing authenticated user")
+	}
+	return nil
+}
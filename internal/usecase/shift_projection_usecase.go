@@ -2,29 +2,32 @@ package usecase
 
 import (
 	"fmt"
-	"loopi-api/internal/calendar"
 	"loopi-api/internal/domain"
 	"loopi-api/internal/repository"
 	"loopi-api/internal/usecase/base"
 	"loopi-api/internal/usecase/dto"
 	"loopi-api/internal/usecase/utils"
+	"time"
 )
 
 type ShiftProjectionUseCase interface {
-	// Standard operations
-	PreviewHours(req dto.ShiftProjectionRequest) (domain.ExtraHourSummary, error)
+	// Standard operations, scoped to the caller's franchise
+	PreviewHours(scope domain.TenantScope, req dto.ShiftProjectionRequest) (domain.ExtraHourSummary, error)
 
 	// Business-specific operations
 	ValidateProjectionRequest(req *dto.ShiftProjectionRequest) error
-	GetShiftProjectionSummary(shiftID, year, month int) (domain.ExtraHourSummary, error)
-	CalculateProjectedDays(shiftID, year, month int) (int, error)
-	ValidateShiftExists(shiftID int) (*domain.Shift, error)
+	GetShiftProjectionSummary(scope domain.TenantScope, shiftID, year, month int) (domain.ExtraHourSummary, error)
+	CalculateProjectedDays(scope domain.TenantScope, shiftID, year, month int) (int, error)
+	ValidateShiftExists(scope domain.TenantScope, shiftID int) (*domain.Shift, error)
 	GetWorkConfig() (domain.WorkConfig, error)
+	GetWorkConfigsForMonth(year, month int) (utils.WorkConfigTimeline, error)
 }
 
 type shiftProjectionUseCase struct {
 	shiftRepo      repository.ShiftRepository
 	workConfigRepo repository.WorkConfigRepository
+	holidays       *HolidayCalendarResolver
+	tenant         *TenantGuard
 	errorHandler   *base.ErrorHandler
 	validator      *base.Validator
 	logger         *base.Logger
@@ -33,10 +36,14 @@ type shiftProjectionUseCase struct {
 func NewShiftProjectionUseCase(
 	shiftRepo repository.ShiftRepository,
 	workConfigRepo repository.WorkConfigRepository,
+	holidays *HolidayCalendarResolver,
+	tenant *TenantGuard,
 ) ShiftProjectionUseCase {
 	return &shiftProjectionUseCase{
 		shiftRepo:      shiftRepo,
 		workConfigRepo: workConfigRepo,
+		holidays:       holidays,
+		tenant:         tenant,
 		errorHandler:   base.NewErrorHandler("ShiftProjection"),
 		validator:      base.NewValidator(),
 		logger:         base.NewLogger("ShiftProjection"),
@@ -46,7 +53,7 @@ func NewShiftProjectionUseCase(
 // ✅ Enhanced operations with logging, validation, and error handling
 
 // PreviewHours generates projected hours summary based on shift and period
-func (uc *shiftProjectionUseCase) PreviewHours(req dto.ShiftProjectionRequest) (domain.ExtraHourSummary, error) {
+func (uc *shiftProjectionUseCase) PreviewHours(scope domain.TenantScope, req dto.ShiftProjectionRequest) (domain.ExtraHourSummary, error) {
 	uc.logger.LogOperation("PreviewHours", "start", map[string]interface{}{
 		"shift_id": req.ShiftID,
 		"year":     req.Year,
@@ -59,25 +66,31 @@ func (uc *shiftProjectionUseCase) PreviewHours(req dto.ShiftProjectionRequest) (
 	}
 
 	// Validate shift exists
-	shift, err := uc.ValidateShiftExists(req.ShiftID)
+	shift, err := uc.ValidateShiftExists(scope, req.ShiftID)
 	if err != nil {
 		return domain.ExtraHourSummary{}, err
 	}
 
-	// Get work configuration
-	workConfig, err := uc.GetWorkConfig()
+	// Get the work configurations in force during the period
+	workConfigs, err := uc.GetWorkConfigsForMonth(req.Year, req.Month)
 	if err != nil {
 		return domain.ExtraHourSummary{}, err
 	}
 
-	// Build holiday map for the period
-	holidayMap := utils.HolidaysToMap(
-		calendar.GetColombianHolidaysByMonthCached(req.Year, req.Month),
-	)
+	// Build holiday map for the period from the shift's store calendar
+	calendarScope, err := uc.holidays.ScopeForStore("PreviewHours", shift.StoreID)
+	if err != nil {
+		return domain.ExtraHourSummary{}, err
+	}
+	holidayMap, err := uc.holidays.HolidaysByMonth("PreviewHours", calendarScope, req.Year, req.Month)
+	if err != nil {
+		return domain.ExtraHourSummary{}, err
+	}
 
 	uc.logger.LogOperation("PreviewHours", "data_prepared", map[string]interface{}{
 		"shift_id":   req.ShiftID,
 		"shift_name": shift.Name,
+		"country":    calendarScope.Country,
 		"holidays":   len(holidayMap),
 	})
 
@@ -85,7 +98,7 @@ func (uc *shiftProjectionUseCase) PreviewHours(req dto.ShiftProjectionRequest) (
 	calendarDays := utils.BuildCalendarDays(req.Year, req.Month, holidayMap)
 
 	// Apply shift to calendar and calculate projection
-	projected := utils.ApplyShiftToCalendar(calendarDays, *shift, workConfig)
+	projected := utils.ApplyShiftToCalendar(calendarDays, *shift, workConfigs)
 	summary := utils.SummarizeProjection(projected)
 
 	// Set period information
@@ -154,12 +167,16 @@ func (uc *shiftProjectionUseCase) ValidateProjectionRequest(req *dto.ShiftProjec
 	return nil
 }
 
-// ValidateShiftExists validates that a shift exists and returns it
-func (uc *shiftProjectionUseCase) ValidateShiftExists(shiftID int) (*domain.Shift, error) {
+// ValidateShiftExists validates that a shift of the franchise exists and returns it
+func (uc *shiftProjectionUseCase) ValidateShiftExists(scope domain.TenantScope, shiftID int) (*domain.Shift, error) {
 	uc.logger.LogOperation("ValidateShiftExists", "start", map[string]interface{}{
 		"shift_id": shiftID,
 	})
 
+	if err := uc.tenant.RequireShift("ValidateShiftExists", scope, shiftID); err != nil {
+		return nil, err
+	}
+
 	// Get shift from repository
 	shift, err := uc.shiftRepo.GetByID(shiftID)
 	if err != nil {
@@ -223,8 +240,37 @@ func (uc *shiftProjectionUseCase) GetWorkConfig() (domain.WorkConfig, error) {
 	return workConfig, nil
 }
 
+// GetWorkConfigsForMonth retrieves the work configurations in force during a month,
+// so each day is computed with the limits that applied on that date
+func (uc *shiftProjectionUseCase) GetWorkConfigsForMonth(year, month int) (utils.WorkConfigTimeline, error) {
+	from := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, -1)
+
+	configs, err := uc.workConfigRepo.GetConfigsForPeriod(from, to)
+	if err != nil {
+		uc.logger.LogError("GetWorkConfigsForMonth", err, map[string]interface{}{
+			"year":  year,
+			"month": month,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("GetWorkConfigsForMonth", err)
+	}
+
+	for _, config := range configs {
+		if config.DiurnalStart == "" || config.DiurnalEnd == "" || config.DailyOrdinaryHours <= 0 {
+			err := fmt.Errorf("invalid work config effective from %s: diurnal=%s-%s, daily_ordinary_hours=%.2f",
+				config.EffectiveFrom.Format("2006-01-02"), config.DiurnalStart, config.DiurnalEnd, config.DailyOrdinaryHours)
+			uc.logger.LogValidation("GetWorkConfigsForMonth", "work_config", "failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return nil, uc.errorHandler.HandleBusinessRuleViolation("GetWorkConfigsForMonth", "work_config_validation", err.Error())
+		}
+	}
+
+	return utils.NewWorkConfigTimeline(configs), nil
+}
+
 // GetShiftProjectionSummary provides a comprehensive projection summary
-func (uc *shiftProjectionUseCase) GetShiftProjectionSummary(shiftID, year, month int) (domain.ExtraHourSummary, error) {
+func (uc *shiftProjectionUseCase) GetShiftProjectionSummary(scope domain.TenantScope, shiftID, year, month int) (domain.ExtraHourSummary, error) {
 	uc.logger.LogOperation("GetShiftProjectionSummary", "start", map[string]interface{}{
 		"shift_id": shiftID,
 		"year":     year,
@@ -239,7 +285,7 @@ func (uc *shiftProjectionUseCase) GetShiftProjectionSummary(shiftID, year, month
 	}
 
 	// Use existing PreviewHours method
-	summary, err := uc.PreviewHours(req)
+	summary, err := uc.PreviewHours(scope, req)
 	if err != nil {
 		return domain.ExtraHourSummary{}, err // Error already logged by PreviewHours
 	}
@@ -255,7 +301,7 @@ func (uc *shiftProjectionUseCase) GetShiftProjectionSummary(shiftID, year, month
 }
 
 // CalculateProjectedDays calculates the number of projected working days
-func (uc *shiftProjectionUseCase) CalculateProjectedDays(shiftID, year, month int) (int, error) {
+func (uc *shiftProjectionUseCase) CalculateProjectedDays(scope domain.TenantScope, shiftID, year, month int) (int, error) {
 	uc.logger.LogOperation("CalculateProjectedDays", "start", map[string]interface{}{
 		"shift_id": shiftID,
 		"year":     year,
@@ -274,26 +320,38 @@ func (uc *shiftProjectionUseCase) CalculateProjectedDays(shiftID, year, month in
 	}
 
 	// Validate shift exists
-	shift, err := uc.ValidateShiftExists(shiftID)
+	shift, err := uc.ValidateShiftExists(scope, shiftID)
 	if err != nil {
 		return 0, err
 	}
 
-	// Get work configuration
-	workConfig, err := uc.GetWorkConfig()
+	// Get the work configurations in force during the period
+	workConfigs, err := uc.GetWorkConfigsForMonth(year, month)
 	if err != nil {
 		return 0, err
 	}
 
-	// Build calendar for the period
-	holidayMap := utils.HolidaysToMap(
-		calendar.GetColombianHolidaysByMonthCached(year, month),
-	)
+	// Build calendar for the period from the shift's store calendar
+	calendarScope, err := uc.holidays.ScopeForStore("CalculateProjectedDays", shift.StoreID)
+	if err != nil {
+		return 0, err
+	}
+	holidayMap, err := uc.holidays.HolidaysByMonth("CalculateProjectedDays", calendarScope, year, month)
+	if err != nil {
+		return 0, err
+	}
 	calendarDays := utils.BuildCalendarDays(year, month, holidayMap)
 
 	// Apply shift to calendar to get projected days
-	projected := utils.ApplyShiftToCalendar(calendarDays, *shift, workConfig)
-	projectedDays := len(projected)
+	// Only days with extra hours count as projected days
+	projected := utils.ApplyShiftToCalendar(calendarDays, *shift, workConfigs)
+	daysWithExtra := make(map[time.Time]bool)
+	for _, day := range projected {
+		if day.HasExtra() {
+			daysWithExtra[day.Date] = true
+		}
+	}
+	projectedDays := len(daysWithExtra)
 
 	uc.logger.LogOperation("CalculateProjectedDays", "success", map[string]interface{}{
 		"shift_id":       shiftID,
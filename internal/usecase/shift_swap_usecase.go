@@ -0,0 +1,731 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
+)
+
+// shiftSwapHistoryDays is how far back an employee's own swaps are listed
+const shiftSwapHistoryDays = 30
+
+// ShiftSwapUseCase lets employees trade assigned shifts with colleagues of the same store. An
+// employee offers a shift, a colleague accepts it (optionally giving one of their shifts in
+// return) and a manager approves the swap, which reassigns the shifts.
+type ShiftSwapUseCase interface {
+	// Workflow of the authenticated employee
+	Offer(scope domain.TenantScope, employeeID int, req dto.ShiftSwapOfferRequest) (*domain.ShiftSwap, error)
+	GetOpen(scope domain.TenantScope, employeeID int) ([]domain.ShiftSwap, error)
+	GetMine(scope domain.TenantScope, employeeID int) ([]domain.ShiftSwap, error)
+	Accept(scope domain.TenantScope, employeeID, id int, req dto.ShiftSwapAcceptRequest) (*domain.ShiftSwap, error)
+	Cancel(scope domain.TenantScope, employeeID, id int) (*domain.ShiftSwap, error)
+
+	// Approval workflow, scoped to the caller's franchise
+	GetByID(scope domain.TenantScope, id int) (*domain.ShiftSwap, error)
+	GetPending(scope domain.TenantScope) ([]domain.ShiftSwap, error)
+	Approve(scope domain.TenantScope, id, reviewerID int, note string) (*domain.ShiftSwap, error)
+	Reject(scope domain.TenantScope, id, reviewerID int, note string) (*domain.ShiftSwap, error)
+
+	// Audit trail of who worked each shift of a store
+	GetByStoreAndDateRange(scope domain.TenantScope, storeID int, from, to string) ([]domain.ShiftSwap, error)
+}
+
+type shiftSwapUseCase struct {
+	repo         repository.ShiftSwapRepository
+	assignedRepo repository.AssignedShiftRepository
+	storeRepo    repository.StoreRepository
+	laborRules   *LaborRulesValidator
+	availability *AvailabilityChecker
+	holidays     *HolidayCalendarResolver
+	tenant       *TenantGuard
+	now          func() time.Time
+	errorHandler *base.ErrorHandler
+	validator    *base.Validator
+	logger       *base.Logger
+}
+
+func NewShiftSwapUseCase(
+	repo repository.ShiftSwapRepository,
+	assignedRepo repository.AssignedShiftRepository,
+	storeRepo repository.StoreRepository,
+	workConfigRepo repository.WorkConfigRepository,
+	availabilityRepo repository.AvailabilityRepository,
+	holidays *HolidayCalendarResolver,
+	tenant *TenantGuard,
+) ShiftSwapUseCase {
+	return &shiftSwapUseCase{
+		repo:         repo,
+		assignedRepo: assignedRepo,
+		storeRepo:    storeRepo,
+		laborRules:   NewLaborRulesValidator(assignedRepo, workConfigRepo, utils.DefaultLaborLimits()),
+		availability: NewAvailabilityChecker(availabilityRepo),
+		holidays:     holidays,
+		tenant:       tenant,
+		now:          time.Now,
+		errorHandler: base.NewErrorHandler("ShiftSwap"),
+		validator:    base.NewValidator(),
+		logger:       base.NewLogger("ShiftSwap"),
+	}
+}
+
+// ✅ Workflow of the authenticated employee
+
+// Offer puts one of the employee's upcoming shifts up for a colleague of the store to take
+func (uc *shiftSwapUseCase) Offer(scope domain.TenantScope, employeeID int, req dto.ShiftSwapOfferRequest) (*domain.ShiftSwap, error) {
+	uc.logger.LogOperation("Offer", "start", map[string]interface{}{
+		"employee_id":       employeeID,
+		"assigned_shift_id": req.AssignedShiftID,
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Offer", err)
+	}
+	if err := uc.validator.ValidateID(req.AssignedShiftID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Offer", fmt.Errorf("assigned_shift_id: %w", err))
+	}
+	if req.Note != "" {
+		if err := uc.validator.ValidateString(req.Note, "note", "max:255"); err != nil {
+			return nil, uc.errorHandler.HandleValidationError("Offer", err)
+		}
+	}
+
+	shift, err := uc.getShift("Offer", scope, req.AssignedShiftID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Business rule: Employees offer only their own upcoming shifts, one swap at a time
+	if err := uc.requireOwner("Offer", shift, employeeID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireNotStarted("Offer", shift); err != nil {
+		return nil, err
+	}
+	if err := uc.requireNotInSwap("Offer", shift.ID); err != nil {
+		return nil, err
+	}
+
+	swap := domain.ShiftSwap{
+		StoreID:          shift.StoreID,
+		OfferedShiftID:   int(shift.ID),
+		OfferedBy:        employeeID,
+		OfferedDate:      shift.Date,
+		OfferedStartTime: shift.StartTime,
+		OfferedEndTime:   shift.EndTime,
+		OfferedHours:     utils.RoundTo2(utils.ShiftWorkedHours(*shift)),
+		Note:             req.Note,
+		Status:           domain.ShiftSwapOpen,
+	}
+
+	if err := uc.repo.Create(&swap); err != nil {
+		uc.logger.LogError("Offer", err, map[string]interface{}{"assigned_shift_id": shift.ID})
+		return nil, uc.errorHandler.HandleRepositoryError("Offer", err)
+	}
+
+	uc.logger.LogOperation("Offer", "success", map[string]interface{}{
+		"swap_id":           swap.ID,
+		"assigned_shift_id": shift.ID,
+		"employee_id":       employeeID,
+		"date":              shift.Date,
+	})
+
+	return &swap, nil
+}
+
+// GetOpen lists the upcoming offers of colleagues at the employee's stores
+func (uc *shiftSwapUseCase) GetOpen(scope domain.TenantScope, employeeID int) ([]domain.ShiftSwap, error) {
+	uc.logger.LogOperation("GetOpen", "start", map[string]interface{}{"employee_id": employeeID})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetOpen", err)
+	}
+	if err := uc.tenant.RequireEmployee("GetOpen", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	stores, err := uc.storeRepo.GetByUserID(employeeID)
+	if err != nil {
+		uc.logger.LogError("GetOpen", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetOpen", err)
+	}
+	storeIDs := make([]int, 0, len(stores))
+	for _, store := range stores {
+		if int(store.FranchiseID) == scope.FranchiseID && scope.AllowsStore(int(store.ID)) {
+			storeIDs = append(storeIDs, int(store.ID))
+		}
+	}
+
+	// Yesterday's overnight shifts may not have started in the store's time zone yet
+	fromDate := uc.now().AddDate(0, 0, -1).Format("2006-01-02")
+	offers, err := uc.repo.GetOpenByStores(storeIDs, fromDate)
+	if err != nil {
+		uc.logger.LogError("GetOpen", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetOpen", err)
+	}
+
+	open := make([]domain.ShiftSwap, 0, len(offers))
+	for _, offer := range offers {
+		if offer.OfferedBy == employeeID {
+			continue
+		}
+		started, err := uc.started("GetOpen", offeredShift(offer))
+		if err != nil {
+			return nil, err
+		}
+		if !started {
+			open = append(open, offer)
+		}
+	}
+
+	uc.logger.LogOperation("GetOpen", "success", map[string]interface{}{
+		"employee_id": employeeID,
+		"stores":      len(storeIDs),
+		"count":       len(open),
+	})
+
+	return open, nil
+}
+
+// GetMine lists the swaps the employee offered or accepted for shifts of the last shiftSwapHistoryDays on
+func (uc *shiftSwapUseCase) GetMine(scope domain.TenantScope, employeeID int) ([]domain.ShiftSwap, error) {
+	uc.logger.LogOperation("GetMine", "start", map[string]interface{}{"employee_id": employeeID})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetMine", err)
+	}
+	if err := uc.tenant.RequireEmployee("GetMine", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	fromDate := uc.now().AddDate(0, 0, -shiftSwapHistoryDays).Format("2006-01-02")
+	swaps, err := uc.repo.GetByEmployee(employeeID, fromDate)
+	if err != nil {
+		uc.logger.LogError("GetMine", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetMine", err)
+	}
+
+	uc.logger.LogOperation("GetMine", "success", map[string]interface{}{
+		"employee_id": employeeID,
+		"count":       len(swaps),
+	})
+
+	return swaps, nil
+}
+
+// Accept takes an open offer for the employee, optionally giving one of their own shifts in
+// return. Labor rules are checked now so the manager only reviews feasible swaps.
+func (uc *shiftSwapUseCase) Accept(scope domain.TenantScope, employeeID, id int, req dto.ShiftSwapAcceptRequest) (*domain.ShiftSwap, error) {
+	uc.logger.LogOperation("Accept", "start", map[string]interface{}{
+		"swap_id":     id,
+		"employee_id": employeeID,
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Accept", err)
+	}
+
+	swap, err := uc.GetByID(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if swap.Status != domain.ShiftSwapOpen {
+		return nil, uc.errorHandler.HandleConflict("Accept",
+			fmt.Sprintf("shift swap %d was already %s", id, swap.Status))
+	}
+	if employeeID == swap.OfferedBy {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation("Accept", "self_accept",
+			"employees cannot accept their own offer")
+	}
+
+	offered, err := uc.currentShift("Accept", scope, swap.OfferedShiftID, swap.OfferedBy, offeredShift(*swap))
+	if err != nil {
+		return nil, err
+	}
+
+	var returned *domain.AssignedShift
+	if req.ReturnShiftID != nil {
+		if returned, err = uc.returnShift("Accept", scope, swap, employeeID, *req.ReturnShiftID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := uc.now()
+	swap.AcceptedBy = &employeeID
+	swap.AcceptedAt = &now
+	if returned != nil {
+		returnID := int(returned.ID)
+		swap.ReturnShiftID = &returnID
+		swap.ReturnDate = returned.Date
+		swap.ReturnStartTime = returned.StartTime
+		swap.ReturnEndTime = returned.EndTime
+		swap.ReturnHours = utils.RoundTo2(utils.ShiftWorkedHours(*returned))
+	}
+
+	if _, err := uc.validateSwap("Accept", swap, offered, returned); err != nil {
+		return nil, err
+	}
+
+	swap.Status = domain.ShiftSwapAccepted
+	if err := uc.repo.Accept(swap); err != nil {
+		uc.logger.LogError("Accept", err, map[string]interface{}{"swap_id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Accept", err)
+	}
+
+	uc.logger.LogOperation("Accept", "success", map[string]interface{}{
+		"swap_id":     id,
+		"offered_by":  swap.OfferedBy,
+		"accepted_by": employeeID,
+		"exchange":    returned != nil,
+	})
+
+	return swap, nil
+}
+
+// Cancel withdraws an offer that has not been reviewed yet
+func (uc *shiftSwapUseCase) Cancel(scope domain.TenantScope, employeeID, id int) (*domain.ShiftSwap, error) {
+	uc.logger.LogOperation("Cancel", "start", map[string]interface{}{
+		"swap_id":     id,
+		"employee_id": employeeID,
+	})
+
+	swap, err := uc.GetByID(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !swap.IsActive() {
+		return nil, uc.errorHandler.HandleConflict("Cancel",
+			fmt.Sprintf("shift swap %d was already %s", id, swap.Status))
+	}
+	if employeeID != swap.OfferedBy {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation("Cancel", "swap_owner",
+			"only the employee who offered the shift can cancel the swap")
+	}
+
+	swap.Status = domain.ShiftSwapCancelled
+	if err := uc.repo.Cancel(swap); err != nil {
+		uc.logger.LogError("Cancel", err, map[string]interface{}{"swap_id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Cancel", err)
+	}
+
+	uc.logger.LogOperation("Cancel", "success", map[string]interface{}{"swap_id": id})
+	return swap, nil
+}
+
+// ✅ Approval workflow
+
+// GetByID retrieves a swap of a store in scope
+func (uc *shiftSwapUseCase) GetByID(scope domain.TenantScope, id int) (*domain.ShiftSwap, error) {
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByID", err)
+	}
+
+	swap, err := uc.repo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("GetByID", err, map[string]interface{}{"swap_id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByID", err)
+	}
+
+	if err := uc.tenant.RequireStore("GetByID", scope, swap.StoreID); err != nil {
+		return nil, err
+	}
+
+	return swap, nil
+}
+
+// GetPending lists the accepted swaps awaiting approval in scope, oldest first
+func (uc *shiftSwapUseCase) GetPending(scope domain.TenantScope) ([]domain.ShiftSwap, error) {
+	uc.logger.LogOperation("GetPending", "start", map[string]interface{}{
+		"franchise_id": scope.FranchiseID,
+		"store_id":     scope.StoreID,
+	})
+
+	if err := uc.tenant.RequireFranchise("GetPending", scope, scope.FranchiseID); err != nil {
+		return nil, err
+	}
+
+	swaps, err := uc.repo.GetPending(scope)
+	if err != nil {
+		uc.logger.LogError("GetPending", err, map[string]interface{}{
+			"franchise_id": scope.FranchiseID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("GetPending", err)
+	}
+
+	uc.logger.LogOperation("GetPending", "success", map[string]interface{}{
+		"franchise_id": scope.FranchiseID,
+		"count":        len(swaps),
+	})
+
+	return swaps, nil
+}
+
+// Approve applies an accepted swap: the shifts are re-validated against the labor rules and
+// reassigned, so the hours summaries and payroll follow the new schedule
+func (uc *shiftSwapUseCase) Approve(scope domain.TenantScope, id, reviewerID int, note string) (*domain.ShiftSwap, error) {
+	return uc.review("Approve", scope, id, reviewerID, domain.ShiftSwapApproved, note)
+}
+
+// Reject declines an accepted swap; the shifts stay with their employees
+func (uc *shiftSwapUseCase) Reject(scope domain.TenantScope, id, reviewerID int, note string) (*domain.ShiftSwap, error) {
+	return uc.review("Reject", scope, id, reviewerID, domain.ShiftSwapRejected, note)
+}
+
+// review records the manager's decision on an accepted swap
+func (uc *shiftSwapUseCase) review(operation string, scope domain.TenantScope, id, reviewerID int, status, note string) (*domain.ShiftSwap, error) {
+	uc.logger.LogOperation(operation, "start", map[string]interface{}{
+		"swap_id":     id,
+		"reviewer_id": reviewerID,
+	})
+
+	if note != "" {
+		if err := uc.validator.ValidateString(note, "note", "max:255"); err != nil {
+			return nil, uc.errorHandler.HandleValidationError(operation, err)
+		}
+	}
+
+	swap, err := uc.GetByID(scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Business rule: Only accepted swaps are reviewed, and never by one of the two employees
+	if swap.Status != domain.ShiftSwapAccepted || swap.AcceptedBy == nil {
+		return nil, uc.errorHandler.HandleConflict(operation,
+			fmt.Sprintf("shift swap %d is %s, not awaiting approval", id, swap.Status))
+	}
+	if reviewerID == swap.OfferedBy || reviewerID == *swap.AcceptedBy {
+		uc.logger.LogBusinessRule(operation, "self_review", "rejected", map[string]interface{}{
+			"swap_id":     id,
+			"reviewer_id": reviewerID,
+		})
+		return nil, uc.errorHandler.HandleBusinessRuleViolation(operation, "self_review",
+			"a shift swap cannot be reviewed by one of the employees involved")
+	}
+
+	// The schedule may have changed since the swap was accepted
+	var reassigned []domain.AssignedShift
+	if status == domain.ShiftSwapApproved {
+		offered, err := uc.currentShift(operation, scope, swap.OfferedShiftID, swap.OfferedBy, offeredShift(*swap))
+		if err != nil {
+			return nil, err
+		}
+		var returned *domain.AssignedShift
+		if swap.ReturnShiftID != nil {
+			returned, err = uc.currentShift(operation, scope, *swap.ReturnShiftID, *swap.AcceptedBy, returnedShift(*swap))
+			if err != nil {
+				return nil, err
+			}
+		}
+		if reassigned, err = uc.validateSwap(operation, swap, offered, returned); err != nil {
+			return nil, err
+		}
+	}
+
+	now := uc.now()
+	swap.Status = status
+	swap.ReviewedBy = &reviewerID
+	swap.ReviewedAt = &now
+	swap.ReviewNote = note
+
+	if err := uc.repo.Review(swap, reassigned); err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"swap_id": id})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	uc.logger.LogOperation(operation, "success", map[string]interface{}{
+		"swap_id":     id,
+		"offered_by":  swap.OfferedBy,
+		"accepted_by": *swap.AcceptedBy,
+		"reviewer_id": reviewerID,
+		"status":      status,
+		"reassigned":  len(reassigned),
+	})
+
+	return swap, nil
+}
+
+// ✅ Audit trail
+
+// GetByStoreAndDateRange lists the swaps of a store with a shift between two dates (inclusive).
+// Approved swaps record who worked each shift instead of who was first scheduled.
+func (uc *shiftSwapUseCase) GetByStoreAndDateRange(scope domain.TenantScope, storeID int, from, to string) ([]domain.ShiftSwap, error) {
+	uc.logger.LogOperation("GetByStoreAndDateRange", "start", map[string]interface{}{
+		"store_id": storeID,
+		"from":     from,
+		"to":       to,
+	})
+
+	if err := uc.validator.ValidateID(storeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByStoreAndDateRange", err)
+	}
+	if err := uc.tenant.RequireStore("GetByStoreAndDateRange", scope, storeID); err != nil {
+		return nil, err
+	}
+
+	fromDate, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByStoreAndDateRange",
+			fmt.Errorf("invalid from date: %q. Expected format: YYYY-MM-DD", from))
+	}
+	toDate, err := time.Parse("2006-01-02", to)
+	if err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByStoreAndDateRange",
+			fmt.Errorf("invalid to date: %q. Expected format: YYYY-MM-DD", to))
+	}
+	if fromDate.After(toDate) {
+		return nil, uc.errorHandler.HandleValidationError("GetByStoreAndDateRange",
+			fmt.Errorf("from date (%s) cannot be after to date (%s)", from, to))
+	}
+
+	swaps, err := uc.repo.GetByStoreAndDateRange(storeID, from, to)
+	if err != nil {
+		uc.logger.LogError("GetByStoreAndDateRange", err, map[string]interface{}{"store_id": storeID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByStoreAndDateRange", err)
+	}
+
+	uc.logger.LogOperation("GetByStoreAndDateRange", "success", map[string]interface{}{
+		"store_id": storeID,
+		"count":    len(swaps),
+	})
+
+	return swaps, nil
+}
+
+// validateSwap checks the schedules and availability both employees would have after the swap
+// and returns the shifts with their new employees: the acceptor takes the offered shift and,
+// in an exchange, the offerer takes the returned one. Swaps cannot override unavailability.
+func (uc *shiftSwapUseCase) validateSwap(operation string, swap *domain.ShiftSwap, offered, returned *domain.AssignedShift) ([]domain.AssignedShift, error) {
+	acceptorID := *swap.AcceptedBy
+
+	// The returned shift leaves the acceptor's schedule
+	var givenID uint
+	if returned != nil {
+		givenID = returned.ID
+	}
+
+	toAcceptor := *offered
+	toAcceptor.EmployeeID = acceptorID
+	if err := uc.requireStoreMember(operation, acceptorID, swap.StoreID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireDateAvailable(operation, acceptorID, toAcceptor.Date, givenID); err != nil {
+		return nil, err
+	}
+	if err := uc.laborRules.Validate(operation, acceptorID, []domain.AssignedShift{toAcceptor}, givenID); err != nil {
+		return nil, err
+	}
+	if err := uc.availability.Validate(operation, acceptorID, []domain.AssignedShift{toAcceptor}, ""); err != nil {
+		return nil, err
+	}
+
+	reassigned := []domain.AssignedShift{toAcceptor}
+	if returned == nil {
+		return reassigned, nil
+	}
+
+	toOfferer := *returned
+	toOfferer.EmployeeID = swap.OfferedBy
+	if err := uc.requireStoreMember(operation, swap.OfferedBy, swap.StoreID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireDateAvailable(operation, swap.OfferedBy, toOfferer.Date, offered.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.laborRules.Validate(operation, swap.OfferedBy, []domain.AssignedShift{toOfferer}, offered.ID); err != nil {
+		return nil, err
+	}
+	if err := uc.availability.Validate(operation, swap.OfferedBy, []domain.AssignedShift{toOfferer}, ""); err != nil {
+		return nil, err
+	}
+
+	return append(reassigned, toOfferer), nil
+}
+
+// returnShift loads the acceptor's shift given in exchange and checks it can be swapped
+func (uc *shiftSwapUseCase) returnShift(operation string, scope domain.TenantScope, swap *domain.ShiftSwap, employeeID, id int) (*domain.AssignedShift, error) {
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError(operation, fmt.Errorf("return_shift_id: %w", err))
+	}
+
+	shift, err := uc.getShift(operation, scope, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.requireOwner(operation, shift, employeeID); err != nil {
+		return nil, err
+	}
+
+	// Business rule: Shifts are exchanged within a store
+	if shift.StoreID != swap.StoreID {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation(operation, "same_store",
+			fmt.Sprintf("shift %d is at store %d, the offered shift at store %d", id, shift.StoreID, swap.StoreID))
+	}
+	if err := uc.requireNotStarted(operation, shift); err != nil {
+		return nil, err
+	}
+	if err := uc.requireNotInSwap(operation, shift.ID); err != nil {
+		return nil, err
+	}
+
+	return shift, nil
+}
+
+// currentShift reloads a shift of the swap and rejects it when it was reassigned, rescheduled
+// or has started since it was offered or accepted
+func (uc *shiftSwapUseCase) currentShift(operation string, scope domain.TenantScope, id, employeeID int, snapshot domain.AssignedShift) (*domain.AssignedShift, error) {
+	shift, err := uc.getShift(operation, scope, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if shift.EmployeeID != employeeID || shift.Date != snapshot.Date ||
+		shift.StartTime != snapshot.StartTime || shift.EndTime != snapshot.EndTime {
+		uc.logger.LogBusinessRule(operation, "unchanged_shift", "violated", map[string]interface{}{
+			"assigned_shift_id": id,
+		})
+		return nil, uc.errorHandler.HandleConflict(operation,
+			fmt.Sprintf("shift %d changed after it was included in the swap", id))
+	}
+
+	if err := uc.requireNotStarted(operation, shift); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// getShift loads an assigned shift of a store in scope
+func (uc *shiftSwapUseCase) getShift(operation string, scope domain.TenantScope, id int) (*domain.AssignedShift, error) {
+	shift, err := uc.assignedRepo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"assigned_shift_id": id})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	if err := uc.tenant.RequireStore(operation, scope, shift.StoreID); err != nil {
+		return nil, err
+	}
+	return shift, nil
+}
+
+// requireOwner rejects shifts assigned to another employee
+func (uc *shiftSwapUseCase) requireOwner(operation string, shift *domain.AssignedShift, employeeID int) error {
+	if shift.EmployeeID != employeeID {
+		return uc.errorHandler.HandleBusinessRuleViolation(operation, "shift_owner",
+			fmt.Sprintf("shift %d is not assigned to employee %d", shift.ID, employeeID))
+	}
+	return nil
+}
+
+// requireNotStarted rejects shifts that already started at the store
+func (uc *shiftSwapUseCase) requireNotStarted(operation string, shift *domain.AssignedShift) error {
+	started, err := uc.started(operation, *shift)
+	if err != nil {
+		return err
+	}
+	if started {
+		return uc.errorHandler.HandleBusinessRuleViolation(operation, "shift_started",
+			fmt.Sprintf("shift %d on %s already started and cannot be swapped", shift.ID, shift.Date))
+	}
+	return nil
+}
+
+// started reports whether a shift already started in the store's time zone
+func (uc *shiftSwapUseCase) started(operation string, shift domain.AssignedShift) (bool, error) {
+	day, err := time.Parse("2006-01-02", shift.Date)
+	if err != nil {
+		return false, uc.errorHandler.HandleValidationError(operation, err)
+	}
+	location, err := uc.holidays.LocationForStore(operation, shift.StoreID)
+	if err != nil {
+		return false, err
+	}
+
+	start, _ := shiftBounds(day, shift, location)
+	return !uc.now().Before(start), nil
+}
+
+// requireNotInSwap rejects shifts already offered or given in another open or accepted swap
+func (uc *shiftSwapUseCase) requireNotInSwap(operation string, assignedShiftID uint) error {
+	active, err := uc.repo.GetActiveByAssignedShift(int(assignedShiftID))
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"assigned_shift_id": assignedShiftID})
+		return uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	if active != nil {
+		return uc.errorHandler.HandleConflict(operation,
+			fmt.Sprintf("shift %d is already in shift swap %d", assignedShiftID, active.ID))
+	}
+	return nil
+}
+
+// requireStoreMember rejects employees not assigned to the store of the swap
+func (uc *shiftSwapUseCase) requireStoreMember(operation string, employeeID, storeID int) error {
+	stores, err := uc.storeRepo.GetByUserID(employeeID)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	for _, store := range stores {
+		if int(store.ID) == storeID {
+			return nil
+		}
+	}
+	uc.logger.LogValidation(operation, "store_membership", "failed", map[string]interface{}{
+		"employee_id": employeeID,
+		"store_id":    storeID,
+	})
+	return uc.errorHandler.HandleBusinessRuleViolation(operation, "store_membership",
+		fmt.Sprintf("employee %d does not belong to store %d", employeeID, storeID))
+}
+
+// requireDateAvailable enforces one assignment per employee and date, ignoring the shift the
+// employee gives away in the swap
+func (uc *shiftSwapUseCase) requireDateAvailable(operation string, employeeID int, date string, exceptID uint) error {
+	existing, err := uc.assignedRepo.GetByEmployeeAndDate(employeeID, date)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{
+			"employee_id": employeeID,
+			"date":        date,
+		})
+		return uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	if existing != nil && existing.ID != exceptID {
+		uc.logger.LogBusinessRule(operation, "one_assignment_per_day", "violated", map[string]interface{}{
+			"employee_id":   employeeID,
+			"date":          date,
+			"assignment_id": existing.ID,
+		})
+		return uc.errorHandler.HandleConflict(operation,
+			fmt.Sprintf("employee %d already has a shift assigned on %s", employeeID, date))
+	}
+	return nil
+}
+
+// offeredShift rebuilds the offered shift as it was when offered
+func offeredShift(swap domain.ShiftSwap) domain.AssignedShift {
+	return domain.AssignedShift{
+		EmployeeID: swap.OfferedBy,
+		StoreID:    swap.StoreID,
+		Date:       swap.OfferedDate,
+		StartTime:  swap.OfferedStartTime,
+		EndTime:    swap.OfferedEndTime,
+	}
+}
+
+// returnedShift rebuilds the shift given in exchange as it was when accepted
+func returnedShift(swap domain.ShiftSwap) domain.AssignedShift {
+	return domain.AssignedShift{
+		StoreID:   swap.StoreID,
+		Date:      swap.ReturnDate,
+		StartTime: swap.ReturnStartTime,
+		EndTime:   swap.ReturnEndTime,
+	}
+}
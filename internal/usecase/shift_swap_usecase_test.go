@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"loopi-api/internal/domain"
+	"loopi-api/internal/usecase/dto"
+)
+
+// MockShiftSwapRepository keeps swaps in insertion order and applies approved reassignments to
+// the assigned shifts, as the transaction does
+type MockShiftSwapRepository struct {
+	swaps    []domain.ShiftSwap
+	assigned *MockAssignedShiftRepository
+}
+
+func (m *MockShiftSwapRepository) find(id uint) *domain.ShiftSwap {
+	for i := range m.swaps {
+		if m.swaps[i].ID == id {
+			return &m.swaps[i]
+		}
+	}
+	return nil
+}
+func (m *MockShiftSwapRepository) GetByID(id int) (*domain.ShiftSwap, error) {
+	if swap := m.find(uint(id)); swap != nil {
+		copied := *swap
+		return &copied, nil
+	}
+	return nil, errors.New("shift swap not found")
+}
+func (m *MockShiftSwapRepository) Create(swap *domain.ShiftSwap) error {
+	swap.ID = uint(len(m.swaps) + 1)
+	m.swaps = append(m.swaps, *swap)
+	return nil
+}
+func (m *MockShiftSwapRepository) GetActiveByAssignedShift(assignedShiftID int) (*domain.ShiftSwap, error) {
+	for _, swap := range m.swaps {
+		if swap.IsActive() && swap.Involves(assignedShiftID) {
+			return &swap, nil
+		}
+	}
+	return nil, nil
+}
+func (m *MockShiftSwapRepository) GetOpenByStores(storeIDs []int, fromDate string) ([]domain.ShiftSwap, error) {
+	var swaps []domain.ShiftSwap
+	for _, swap := range m.swaps {
+		for _, storeID := range storeIDs {
+			if swap.StoreID == storeID && swap.Status == domain.ShiftSwapOpen && swap.OfferedDate >= fromDate {
+				swaps = append(swaps, swap)
+			}
+		}
+	}
+	return swaps, nil
+}
+func (m *MockShiftSwapRepository) GetByEmployee(employeeID int, fromDate string) ([]domain.ShiftSwap, error) {
+	return nil, nil
+}
+func (m *MockShiftSwapRepository) Accept(swap *domain.ShiftSwap) error {
+	*m.find(swap.ID) = *swap
+	return nil
+}
+func (m *MockShiftSwapRepository) Cancel(swap *domain.ShiftSwap) error {
+	m.find(swap.ID).Status = swap.Status
+	return nil
+}
+func (m *MockShiftSwapRepository) Review(swap *domain.ShiftSwap, reassigned []domain.AssignedShift) error {
+	*m.find(swap.ID) = *swap
+	for _, shift := range reassigned {
+		for i := range m.assigned.shifts {
+			if m.assigned.shifts[i].ID == shift.ID {
+				m.assigned.shifts[i].EmployeeID = shift.EmployeeID
+			}
+		}
+	}
+	return nil
+}
+func (m *MockShiftSwapRepository) GetPending(scope domain.TenantScope) ([]domain.ShiftSwap, error) {
+	return nil, nil
+}
+func (m *MockShiftSwapRepository) GetByStoreAndDateRange(storeID int, from, to string) ([]domain.ShiftSwap, error) {
+	return nil, nil
+}
+
+// newTestShiftSwapUseCase works on store 10 with employees 7 and 8, employee 9 of store 20 and manager 50
+func newTestShiftSwapUseCase(assigned *MockAssignedShiftRepository, now time.Time) (*shiftSwapUseCase, *MockShiftSwapRepository) {
+	stores := newTestStores()
+	stores.AssignUser(8, 10)
+	stores.AssignUser(9, 20)
+	holidays := NewHolidayCalendarResolver(stores, &MockFranchiseRepository{}, &MockHolidayRepository{})
+	tenant := NewTenantGuard(&MockTenantRepository{
+		storeFranchises:    map[int]int{10: 1, 20: 1},
+		employeeFranchises: map[int]int{7: 1, 8: 1, 9: 1, 50: 1},
+	})
+	workConfig := &MockWorkConfigRepository{config: domain.WorkConfig{DailyOrdinaryHours: 8, WeeklyOrdinaryHours: 46}}
+	swaps := &MockShiftSwapRepository{assigned: assigned}
+
+	uc := NewShiftSwapUseCase(swaps, assigned, stores, workConfig, &MockAvailabilityRepository{}, holidays, tenant).(*shiftSwapUseCase)
+	uc.now = func() time.Time { return now }
+	return uc, swaps
+}
+
+func TestShiftSwapUseCase_OfferAcceptApprove(t *testing.T) {
+	assigned := &MockAssignedShiftRepository{shifts: []domain.AssignedShift{
+		{BaseEntity: domain.BaseEntity{ID: 1}, EmployeeID: 7, StoreID: 10, Date: "2025-03-05", StartTime: "08:00", EndTime: "17:00", LunchMinutes: 60},
+		{BaseEntity: domain.BaseEntity{ID: 2}, EmployeeID: 8, StoreID: 10, Date: "2025-03-06", StartTime: "09:00", EndTime: "15:00"},
+		{BaseEntity: domain.BaseEntity{ID: 3}, EmployeeID: 7, StoreID: 10, Date: "2025-03-03", StartTime: "06:00", EndTime: "14:00"},
+	}}
+	// 07:00 in Bogotá
+	uc, swaps := newTestShiftSwapUseCase(assigned, time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC))
+	scope := franchiseScope(1)
+
+	if _, err := uc.Offer(scope, 8, dto.ShiftSwapOfferRequest{AssignedShiftID: 1}); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 offering a colleague's shift, got %v", err)
+	}
+	if _, err := uc.Offer(scope, 7, dto.ShiftSwapOfferRequest{AssignedShiftID: 3}); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 offering a shift that already started, got %v", err)
+	}
+
+	swap, err := uc.Offer(scope, 7, dto.ShiftSwapOfferRequest{AssignedShiftID: 1, Note: "Cita médica"})
+	if err != nil {
+		t.Fatalf("Offer: unexpected error %v", err)
+	}
+	if swap.Status != domain.ShiftSwapOpen || swap.OfferedHours != 8 || swap.OfferedDate != "2025-03-05" {
+		t.Errorf("Expected an open offer of 8 hours on 2025-03-05, got %+v", swap)
+	}
+	if _, err := uc.Offer(scope, 7, dto.ShiftSwapOfferRequest{AssignedShiftID: 1}); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 offering the same shift twice, got %v", err)
+	}
+
+	open, err := uc.GetOpen(scope, 8)
+	if err != nil || len(open) != 1 {
+		t.Errorf("Expected the offer listed for a colleague, got %v (%v)", open, err)
+	}
+	if open, _ := uc.GetOpen(scope, 7); len(open) != 0 {
+		t.Errorf("Expected own offers not listed as open, got %v", open)
+	}
+
+	id := int(swap.ID)
+	if _, err := uc.Accept(scope, 7, id, dto.ShiftSwapAcceptRequest{}); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 accepting an own offer, got %v", err)
+	}
+	if _, err := uc.Accept(scope, 9, id, dto.ShiftSwapAcceptRequest{}); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 for a colleague of another store, got %v", err)
+	}
+	other := 3
+	if _, err := uc.Accept(scope, 8, id, dto.ShiftSwapAcceptRequest{ReturnShiftID: &other}); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 giving a shift of another employee in return, got %v", err)
+	}
+
+	returnID := 2
+	accepted, err := uc.Accept(scope, 8, id, dto.ShiftSwapAcceptRequest{ReturnShiftID: &returnID})
+	if err != nil {
+		t.Fatalf("Accept: unexpected error %v", err)
+	}
+	if accepted.Status != domain.ShiftSwapAccepted || *accepted.AcceptedBy != 8 || accepted.ReturnHours != 6 {
+		t.Errorf("Expected the swap accepted by 8 with a 6-hour shift in return, got %+v", accepted)
+	}
+
+	if _, err := uc.Approve(scope, id, 8, ""); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 when an involved employee approves, got %v", err)
+	}
+
+	approved, err := uc.Approve(scope, id, 50, "OK")
+	if err != nil {
+		t.Fatalf("Approve: unexpected error %v", err)
+	}
+	if approved.Status != domain.ShiftSwapApproved || approved.ReviewedBy == nil || *approved.ReviewedBy != 50 {
+		t.Errorf("Expected the swap approved by 50, got %+v", approved)
+	}
+	if assigned.shifts[0].EmployeeID != 8 || assigned.shifts[1].EmployeeID != 7 {
+		t.Errorf("Expected the shifts exchanged, got %+v", assigned.shifts[:2])
+	}
+
+	// The record keeps who was first scheduled on each shift
+	if stored := swaps.swaps[0]; stored.OfferedBy != 7 || stored.ReturnDate != "2025-03-06" {
+		t.Errorf("Expected the audit trail of the original schedule, got %+v", stored)
+	}
+	if _, err := uc.Approve(scope, id, 50, ""); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 approving twice, got %v", err)
+	}
+}
+
+func TestShiftSwapUseCase_RevalidatedOnApproval(t *testing.T) {
+	assigned := &MockAssignedShiftRepository{shifts: []domain.AssignedShift{
+		{BaseEntity: domain.BaseEntity{ID: 1}, EmployeeID: 7, StoreID: 10, Date: "2025-03-05", StartTime: "06:00", EndTime: "14:00"},
+		{BaseEntity: domain.BaseEntity{ID: 2}, EmployeeID: 8, StoreID: 10, Date: "2025-03-05", StartTime: "14:00", EndTime: "22:00"},
+	}}
+	uc, _ := newTestShiftSwapUseCase(assigned, time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC))
+	scope := franchiseScope(1)
+
+	swap, err := uc.Offer(scope, 7, dto.ShiftSwapOfferRequest{AssignedShiftID: 1})
+	if err != nil {
+		t.Fatalf("Offer: unexpected error %v", err)
+	}
+	id := int(swap.ID)
+
+	// Employee 8 already works on 2025-03-05 unless that shift is given in return
+	if _, err := uc.Accept(scope, 8, id, dto.ShiftSwapAcceptRequest{}); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 taking a second shift on the same day, got %v", err)
+	}
+	returnID := 2
+	if _, err := uc.Accept(scope, 8, id, dto.ShiftSwapAcceptRequest{ReturnShiftID: &returnID}); err != nil {
+		t.Fatalf("Accept: unexpected error %v", err)
+	}
+
+	// A late shift assigned to employee 8 the day before leaves 7 hours of rest
+	assigned.shifts = append(assigned.shifts, domain.AssignedShift{
+		BaseEntity: domain.BaseEntity{ID: 3}, EmployeeID: 8, StoreID: 10, Date: "2025-03-04", StartTime: "14:00", EndTime: "23:00",
+	})
+	if _, err := uc.Approve(scope, id, 50, ""); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 when the new schedule breaks the minimum rest, got %v", err)
+	}
+	if assigned.shifts[0].EmployeeID != 7 || assigned.shifts[1].EmployeeID != 8 {
+		t.Errorf("Expected the shifts unchanged after a failed approval, got %+v", assigned.shifts[:2])
+	}
+
+	// A rescheduled shift voids the acceptance
+	assigned.shifts = assigned.shifts[:2]
+	assigned.shifts[0].StartTime = "07:00"
+	if _, err := uc.Approve(scope, id, 50, ""); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 when the offered shift changed, got %v", err)
+	}
+
+	if _, err := uc.Cancel(scope, 8, id); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 when the acceptor cancels, got %v", err)
+	}
+	cancelled, err := uc.Cancel(scope, 7, id)
+	if err != nil || cancelled.Status != domain.ShiftSwapCancelled {
+		t.Errorf("Expected the offer cancelled, got %+v (%v)", cancelled, err)
+	}
+	if _, err := uc.Approve(scope, id, 50, ""); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 approving a cancelled swap, got %v", err)
+	}
+}
@@ -11,31 +11,33 @@ import (
 )
 
 type ShiftUseCase interface {
-	// Standard CRUD operations
-	GetAll() ([]domain.Shift, error)
-	GetByID(id int) (*domain.Shift, error)
-	GetByStore(storeID int) ([]domain.Shift, error)
-	Create(shift domain.Shift) error
-	Update(shift domain.Shift) error
-	Delete(id int) error
+	// Standard CRUD operations, scoped to the caller's franchise
+	GetAll(scope domain.TenantScope) ([]domain.Shift, error)
+	GetByID(scope domain.TenantScope, id int) (*domain.Shift, error)
+	GetByStore(scope domain.TenantScope, storeID int) ([]domain.Shift, error)
+	Create(scope domain.TenantScope, shift domain.Shift) error
+	Update(scope domain.TenantScope, shift domain.Shift) error
+	Delete(scope domain.TenantScope, id int) error
 
 	// Business-specific operations
-	GetActiveShiftsByStore(storeID int) ([]domain.Shift, error)
+	GetActiveShiftsByStore(scope domain.TenantScope, storeID int) ([]domain.Shift, error)
 	ValidateShiftData(shift *domain.Shift) error
 	ValidateShiftTiming(shift *domain.Shift) error
-	GetShiftStatistics(storeID int) (*repository.ShiftStatistics, error)
+	GetShiftStatistics(scope domain.TenantScope, storeID int) (*repository.ShiftStatistics, error)
 }
 
 type shiftUseCase struct {
 	repo         repository.ShiftRepository
+	tenant       *TenantGuard
 	errorHandler *base.ErrorHandler
 	validator    *base.Validator
 	logger       *base.Logger
 }
 
-func NewShiftUseCase(repo repository.ShiftRepository) ShiftUseCase {
+func NewShiftUseCase(repo repository.ShiftRepository, tenant *TenantGuard) ShiftUseCase {
 	return &shiftUseCase{
 		repo:         repo,
+		tenant:       tenant,
 		errorHandler: base.NewErrorHandler("Shift"),
 		validator:    base.NewValidator(),
 		logger:       base.NewLogger("Shift"),
@@ -44,13 +46,23 @@ func NewShiftUseCase(repo repository.ShiftRepository) ShiftUseCase {
 
 // ✅ Enhanced CRUD operations with logging, validation, and error handling
 
-// GetAll retrieves all shifts with proper error handling and logging
-func (uc *shiftUseCase) GetAll() ([]domain.Shift, error) {
-	uc.logger.LogOperation("GetAll", "start", nil)
+// GetAll retrieves all shifts of the franchise with proper error handling and logging
+func (uc *shiftUseCase) GetAll(scope domain.TenantScope) ([]domain.Shift, error) {
+	uc.logger.LogOperation("GetAll", "start", map[string]interface{}{"franchise_id": scope.FranchiseID})
 
-	shifts, err := uc.repo.ListAll()
+	if err := uc.tenant.RequireFranchise("GetAll", scope, scope.FranchiseID); err != nil {
+		return nil, err
+	}
+
+	var shifts []domain.Shift
+	var err error
+	if scope.RestrictedToStore() {
+		shifts, err = uc.repo.ListByStore(scope.StoreID)
+	} else {
+		shifts, err = uc.repo.ListByFranchise(scope.FranchiseID)
+	}
 	if err != nil {
-		uc.logger.LogError("GetAll", err, nil)
+		uc.logger.LogError("GetAll", err, map[string]interface{}{"franchise_id": scope.FranchiseID})
 		return nil, uc.errorHandler.HandleRepositoryError("GetAll", err)
 	}
 
@@ -67,7 +79,7 @@ func (uc *shiftUseCase) GetAll() ([]domain.Shift, error) {
 }
 
 // GetByID retrieves a shift by ID with validation and error handling
-func (uc *shiftUseCase) GetByID(id int) (*domain.Shift, error) {
+func (uc *shiftUseCase) GetByID(scope domain.TenantScope, id int) (*domain.Shift, error) {
 	uc.logger.LogOperation("GetByID", "start", map[string]interface{}{"id": id})
 
 	// Validate ID
@@ -76,6 +88,10 @@ func (uc *shiftUseCase) GetByID(id int) (*domain.Shift, error) {
 		return nil, uc.errorHandler.HandleValidationError("GetByID", err)
 	}
 
+	if err := uc.tenant.RequireShift("GetByID", scope, id); err != nil {
+		return nil, err
+	}
+
 	shift, err := uc.repo.GetByID(id)
 	if err != nil {
 		uc.logger.LogError("GetByID", err, map[string]interface{}{"id": id})
@@ -87,7 +103,7 @@ func (uc *shiftUseCase) GetByID(id int) (*domain.Shift, error) {
 }
 
 // GetByStore retrieves shifts by store ID with validation and error handling
-func (uc *shiftUseCase) GetByStore(storeID int) ([]domain.Shift, error) {
+func (uc *shiftUseCase) GetByStore(scope domain.TenantScope, storeID int) ([]domain.Shift, error) {
 	uc.logger.LogOperation("GetByStore", "start", map[string]interface{}{"store_id": storeID})
 
 	// Validate store ID
@@ -96,6 +112,10 @@ func (uc *shiftUseCase) GetByStore(storeID int) ([]domain.Shift, error) {
 		return nil, uc.errorHandler.HandleValidationError("GetByStore", err)
 	}
 
+	if err := uc.tenant.RequireStore("GetByStore", scope, storeID); err != nil {
+		return nil, err
+	}
+
 	shifts, err := uc.repo.ListByStore(storeID)
 	if err != nil {
 		uc.logger.LogError("GetByStore", err, map[string]interface{}{"store_id": storeID})
@@ -111,12 +131,17 @@ func (uc *shiftUseCase) GetByStore(storeID int) ([]domain.Shift, error) {
 }
 
 // Create creates a new shift with validation and business rules
-func (uc *shiftUseCase) Create(shift domain.Shift) error {
+func (uc *shiftUseCase) Create(scope domain.TenantScope, shift domain.Shift) error {
 	uc.logger.LogOperation("Create", "start", map[string]interface{}{
 		"shift_name": shift.Name,
 		"store_id":   shift.StoreID,
 	})
 
+	// Business rule: Shifts can only be created for stores of the caller's franchise
+	if err := uc.tenant.RequireStore("Create", scope, shift.StoreID); err != nil {
+		return err
+	}
+
 	// Validate business rules
 	if err := uc.ValidateShiftData(&shift); err != nil {
 		return err
@@ -149,7 +174,7 @@ func (uc *shiftUseCase) Create(shift domain.Shift) error {
 }
 
 // Update modifies an existing shift with business validation
-func (uc *shiftUseCase) Update(shift domain.Shift) error {
+func (uc *shiftUseCase) Update(scope domain.TenantScope, shift domain.Shift) error {
 	uc.logger.LogOperation("Update", "start", map[string]interface{}{
 		"id":       shift.ID,
 		"name":     shift.Name,
@@ -165,6 +190,14 @@ func (uc *shiftUseCase) Update(shift domain.Shift) error {
 		return uc.errorHandler.HandleValidationError("Update", err)
 	}
 
+	// Business rule: Both the shift and its target store must belong to the caller's franchise
+	if err := uc.tenant.RequireShift("Update", scope, int(shift.ID)); err != nil {
+		return err
+	}
+	if err := uc.tenant.RequireStore("Update", scope, shift.StoreID); err != nil {
+		return err
+	}
+
 	// Validate shift data
 	if err := uc.ValidateShiftData(&shift); err != nil {
 		uc.logger.LogValidation("Update", "data", "failed", map[string]interface{}{
@@ -229,7 +262,7 @@ func (uc *shiftUseCase) Update(shift domain.Shift) error {
 }
 
 // Delete removes a shift by ID with business validation
-func (uc *shiftUseCase) Delete(id int) error {
+func (uc *shiftUseCase) Delete(scope domain.TenantScope, id int) error {
 	uc.logger.LogOperation("Delete", "start", map[string]interface{}{"id": id})
 
 	// Validate ID
@@ -242,6 +275,10 @@ func (uc *shiftUseCase) Delete(id int) error {
 		return uc.errorHandler.HandleValidationError("Delete", err)
 	}
 
+	if err := uc.tenant.RequireShift("Delete", scope, id); err != nil {
+		return err
+	}
+
 	// Check if shift exists before deletion
 	shift, err := uc.repo.GetByID(id)
 	if err != nil {
@@ -277,7 +314,7 @@ func (uc *shiftUseCase) Delete(id int) error {
 // ✅ Business-specific operations with enhanced features
 
 // GetActiveShiftsByStore retrieves only active shifts for a store with business rule filtering
-func (uc *shiftUseCase) GetActiveShiftsByStore(storeID int) ([]domain.Shift, error) {
+func (uc *shiftUseCase) GetActiveShiftsByStore(scope domain.TenantScope, storeID int) ([]domain.Shift, error) {
 	// Start performance timer
 	timer := uc.logger.StartTimer("GetActiveShiftsByStore", map[string]interface{}{"store_id": storeID})
 	defer timer.Stop()
@@ -288,6 +325,10 @@ func (uc *shiftUseCase) GetActiveShiftsByStore(storeID int) ([]domain.Shift, err
 		return nil, uc.errorHandler.HandleValidationError("GetActiveShiftsByStore", err)
 	}
 
+	if err := uc.tenant.RequireStore("GetActiveShiftsByStore", scope, storeID); err != nil {
+		return nil, err
+	}
+
 	// Get all shifts for the store
 	shifts, err := uc.repo.ListByStore(storeID)
 	if err != nil {
@@ -434,7 +475,7 @@ func (uc *shiftUseCase) ValidateShiftTiming(shift *domain.Shift) error {
 }
 
 // GetShiftStatistics retrieves comprehensive shift statistics for a store
-func (uc *shiftUseCase) GetShiftStatistics(storeID int) (*repository.ShiftStatistics, error) {
+func (uc *shiftUseCase) GetShiftStatistics(scope domain.TenantScope, storeID int) (*repository.ShiftStatistics, error) {
 	uc.logger.LogOperation("GetShiftStatistics", "start", map[string]interface{}{"store_id": storeID})
 
 	// Validate store ID
@@ -443,6 +484,10 @@ func (uc *shiftUseCase) GetShiftStatistics(storeID int) (*repository.ShiftStatis
 		return nil, uc.errorHandler.HandleValidationError("GetShiftStatistics", err)
 	}
 
+	if err := uc.tenant.RequireStore("GetShiftStatistics", scope, storeID); err != nil {
+		return nil, err
+	}
+
 	// Use repository method for statistics (delegating to repository for better performance)
 	stats, err := uc.repo.GetShiftStatistics(storeID)
 	if err != nil {
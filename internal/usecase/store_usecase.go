@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"fmt"
+	"loopi-api/internal/calendar"
 	"loopi-api/internal/domain"
 	"loopi-api/internal/repository"
 	"loopi-api/internal/usecase/base"
@@ -10,18 +11,18 @@ import (
 )
 
 type StoreUseCase interface {
-	// Standard CRUD operations
-	GetAll() ([]domain.Store, error)
-	GetByID(id int) (domain.Store, error)
-	GetByFranchiseID(franchiseID int) ([]domain.Store, error)
-	Create(store *domain.Store) error
-	Update(store *domain.Store) error
-	Delete(id int) error
+	// Standard CRUD operations, scoped to the caller's franchise
+	GetAll(scope domain.TenantScope) ([]domain.Store, error)
+	GetByID(scope domain.TenantScope, id int) (domain.Store, error)
+	GetByFranchiseID(scope domain.TenantScope, franchiseID int) ([]domain.Store, error)
+	Create(scope domain.TenantScope, store *domain.Store) error
+	Update(scope domain.TenantScope, store *domain.Store) error
+	Delete(scope domain.TenantScope, id int) error
 
 	// Business-specific operations
-	GetActiveStoresByFranchise(franchiseID int) ([]domain.Store, error)
-	GetStoresWithEmployeeCount(franchiseID int) ([]repository.StoreWithEmployeeCount, error)
-	GetStoreStatistics(storeID int) (*repository.StoreStatistics, error)
+	GetActiveStoresByFranchise(scope domain.TenantScope, franchiseID int) ([]domain.Store, error)
+	GetStoresWithEmployeeCount(scope domain.TenantScope, franchiseID int) ([]repository.StoreWithEmployeeCount, error)
+	GetStoreStatistics(scope domain.TenantScope, storeID int) (*repository.StoreStatistics, error)
 	ValidateStoreData(store *domain.Store) error
 	ValidateStoreCode(code string) error
 	ValidateUpdateFields(fields map[string]interface{}) (map[string]interface{}, error)
@@ -29,14 +30,16 @@ type StoreUseCase interface {
 
 type storeUseCase struct {
 	repo         repository.StoreRepository
+	tenant       *TenantGuard
 	errorHandler *base.ErrorHandler
 	validator    *base.Validator
 	logger       *base.Logger
 }
 
-func NewStoreUseCase(repo repository.StoreRepository) StoreUseCase {
+func NewStoreUseCase(repo repository.StoreRepository, tenant *TenantGuard) StoreUseCase {
 	return &storeUseCase{
 		repo:         repo,
+		tenant:       tenant,
 		errorHandler: base.NewErrorHandler("Store"),
 		validator:    base.NewValidator(),
 		logger:       base.NewLogger("Store"),
@@ -45,15 +48,20 @@ func NewStoreUseCase(repo repository.StoreRepository) StoreUseCase {
 
 // ✅ Enhanced CRUD operations with logging, validation, and error handling
 
-// GetAll retrieves all stores with proper error handling and logging
-func (uc *storeUseCase) GetAll() ([]domain.Store, error) {
-	uc.logger.LogOperation("GetAll", "start", nil)
+// GetAll retrieves all stores of the franchise with proper error handling and logging
+func (uc *storeUseCase) GetAll(scope domain.TenantScope) ([]domain.Store, error) {
+	uc.logger.LogOperation("GetAll", "start", map[string]interface{}{"franchise_id": scope.FranchiseID})
 
-	stores, err := uc.repo.GetAll()
+	if err := uc.tenant.RequireFranchise("GetAll", scope, scope.FranchiseID); err != nil {
+		return nil, err
+	}
+
+	stores, err := uc.repo.GetByFranchiseID(scope.FranchiseID)
 	if err != nil {
-		uc.logger.LogError("GetAll", err, nil)
+		uc.logger.LogError("GetAll", err, map[string]interface{}{"franchise_id": scope.FranchiseID})
 		return nil, uc.errorHandler.HandleRepositoryError("GetAll", err)
 	}
+	stores = storesInScope(scope, stores)
 
 	if len(stores) == 0 {
 		uc.logger.LogOperation("GetAll", "no_stores_found", nil)
@@ -68,7 +76,7 @@ func (uc *storeUseCase) GetAll() ([]domain.Store, error) {
 }
 
 // GetByID retrieves a store by ID with validation and error handling
-func (uc *storeUseCase) GetByID(id int) (domain.Store, error) {
+func (uc *storeUseCase) GetByID(scope domain.TenantScope, id int) (domain.Store, error) {
 	uc.logger.LogOperation("GetByID", "start", map[string]interface{}{"id": id})
 
 	// Validate ID
@@ -77,6 +85,10 @@ func (uc *storeUseCase) GetByID(id int) (domain.Store, error) {
 		return domain.Store{}, uc.errorHandler.HandleValidationError("GetByID", err)
 	}
 
+	if err := uc.tenant.RequireStore("GetByID", scope, id); err != nil {
+		return domain.Store{}, err
+	}
+
 	store, err := uc.repo.GetByID(id)
 	if err != nil {
 		uc.logger.LogError("GetByID", err, map[string]interface{}{"id": id})
@@ -88,7 +100,7 @@ func (uc *storeUseCase) GetByID(id int) (domain.Store, error) {
 }
 
 // GetByFranchiseID retrieves stores by franchise ID with validation and error handling
-func (uc *storeUseCase) GetByFranchiseID(franchiseID int) ([]domain.Store, error) {
+func (uc *storeUseCase) GetByFranchiseID(scope domain.TenantScope, franchiseID int) ([]domain.Store, error) {
 	uc.logger.LogOperation("GetByFranchiseID", "start", map[string]interface{}{"franchise_id": franchiseID})
 
 	// Validate franchise ID
@@ -97,11 +109,16 @@ func (uc *storeUseCase) GetByFranchiseID(franchiseID int) ([]domain.Store, error
 		return nil, uc.errorHandler.HandleValidationError("GetByFranchiseID", err)
 	}
 
+	if err := uc.tenant.RequireFranchise("GetByFranchiseID", scope, franchiseID); err != nil {
+		return nil, err
+	}
+
 	stores, err := uc.repo.GetByFranchiseID(franchiseID)
 	if err != nil {
 		uc.logger.LogError("GetByFranchiseID", err, map[string]interface{}{"franchise_id": franchiseID})
 		return nil, uc.errorHandler.HandleRepositoryError("GetByFranchiseID", err)
 	}
+	stores = storesInScope(scope, stores)
 
 	uc.logger.LogOperation("GetByFranchiseID", "success", map[string]interface{}{
 		"franchise_id": franchiseID,
@@ -112,12 +129,20 @@ func (uc *storeUseCase) GetByFranchiseID(franchiseID int) ([]domain.Store, error
 }
 
 // Create creates a new store with validation and business rules
-func (uc *storeUseCase) Create(store *domain.Store) error {
+func (uc *storeUseCase) Create(scope domain.TenantScope, store *domain.Store) error {
 	uc.logger.LogOperation("Create", "start", map[string]interface{}{
 		"store_name": store.Name,
 		"store_code": store.Code,
 	})
 
+	// Business rule: Stores are created in the caller's franchise, never by store-restricted roles
+	if err := uc.tenant.RequireAllStores("Create", scope); err != nil {
+		return err
+	}
+	if err := uc.scopeToFranchise("Create", scope, store); err != nil {
+		return err
+	}
+
 	// Validate business rules
 	if err := uc.ValidateStoreData(store); err != nil {
 		return err
@@ -145,7 +170,7 @@ func (uc *storeUseCase) Create(store *domain.Store) error {
 }
 
 // Update updates an existing store with validation and business rules
-func (uc *storeUseCase) Update(store *domain.Store) error {
+func (uc *storeUseCase) Update(scope domain.TenantScope, store *domain.Store) error {
 	uc.logger.LogOperation("Update", "start", map[string]interface{}{
 		"store_id":   store.ID,
 		"store_name": store.Name,
@@ -157,6 +182,15 @@ func (uc *storeUseCase) Update(store *domain.Store) error {
 		return uc.errorHandler.HandleValidationError("Update", err)
 	}
 
+	if err := uc.tenant.RequireStore("Update", scope, int(store.ID)); err != nil {
+		return err
+	}
+
+	// Business rule: A store cannot be moved to another franchise
+	if err := uc.scopeToFranchise("Update", scope, store); err != nil {
+		return err
+	}
+
 	// Validate business rules
 	if err := uc.ValidateStoreData(store); err != nil {
 		return err
@@ -180,7 +214,7 @@ func (uc *storeUseCase) Update(store *domain.Store) error {
 }
 
 // Delete removes a store by ID with validation and business rules
-func (uc *storeUseCase) Delete(id int) error {
+func (uc *storeUseCase) Delete(scope domain.TenantScope, id int) error {
 	uc.logger.LogOperation("Delete", "start", map[string]interface{}{"id": id})
 
 	// Validate ID
@@ -189,6 +223,13 @@ func (uc *storeUseCase) Delete(id int) error {
 		return uc.errorHandler.HandleValidationError("Delete", err)
 	}
 
+	if err := uc.tenant.RequireAllStores("Delete", scope); err != nil {
+		return err
+	}
+	if err := uc.tenant.RequireStore("Delete", scope, id); err != nil {
+		return err
+	}
+
 	// Execute deletion
 	if err := uc.repo.Delete(id); err != nil {
 		uc.logger.LogError("Delete", err, map[string]interface{}{"id": id})
@@ -202,7 +243,7 @@ func (uc *storeUseCase) Delete(id int) error {
 // ✅ Business-specific operations with enhanced features
 
 // GetActiveStoresByFranchise retrieves only active stores for a franchise with business rule filtering
-func (uc *storeUseCase) GetActiveStoresByFranchise(franchiseID int) ([]domain.Store, error) {
+func (uc *storeUseCase) GetActiveStoresByFranchise(scope domain.TenantScope, franchiseID int) ([]domain.Store, error) {
 	// Start performance timer
 	timer := uc.logger.StartTimer("GetActiveStoresByFranchise", map[string]interface{}{"franchise_id": franchiseID})
 	defer timer.Stop()
@@ -213,12 +254,17 @@ func (uc *storeUseCase) GetActiveStoresByFranchise(franchiseID int) ([]domain.St
 		return nil, uc.errorHandler.HandleValidationError("GetActiveStoresByFranchise", err)
 	}
 
+	if err := uc.tenant.RequireFranchise("GetActiveStoresByFranchise", scope, franchiseID); err != nil {
+		return nil, err
+	}
+
 	// Use repository method for active stores
 	stores, err := uc.repo.GetActiveStoresByFranchise(franchiseID)
 	if err != nil {
 		uc.logger.LogError("GetActiveStoresByFranchise", err, map[string]interface{}{"franchise_id": franchiseID})
 		return nil, uc.errorHandler.HandleRepositoryError("GetActiveStoresByFranchise", err)
 	}
+	stores = storesInScope(scope, stores)
 
 	uc.logger.LogBusinessRule("GetActiveStoresByFranchise", "filter_active_only", "applied", map[string]interface{}{
 		"franchise_id":  franchiseID,
@@ -234,7 +280,7 @@ func (uc *storeUseCase) GetActiveStoresByFranchise(franchiseID int) ([]domain.St
 }
 
 // GetStoresWithEmployeeCount retrieves stores with their employee counts
-func (uc *storeUseCase) GetStoresWithEmployeeCount(franchiseID int) ([]repository.StoreWithEmployeeCount, error) {
+func (uc *storeUseCase) GetStoresWithEmployeeCount(scope domain.TenantScope, franchiseID int) ([]repository.StoreWithEmployeeCount, error) {
 	uc.logger.LogOperation("GetStoresWithEmployeeCount", "start", map[string]interface{}{"franchise_id": franchiseID})
 
 	// Validate franchise ID
@@ -243,12 +289,25 @@ func (uc *storeUseCase) GetStoresWithEmployeeCount(franchiseID int) ([]repositor
 		return nil, uc.errorHandler.HandleValidationError("GetStoresWithEmployeeCount", err)
 	}
 
+	if err := uc.tenant.RequireFranchise("GetStoresWithEmployeeCount", scope, franchiseID); err != nil {
+		return nil, err
+	}
+
 	// Use repository method for stores with employee count
 	stores, err := uc.repo.GetStoresWithEmployeeCount(franchiseID)
 	if err != nil {
 		uc.logger.LogError("GetStoresWithEmployeeCount", err, map[string]interface{}{"franchise_id": franchiseID})
 		return nil, uc.errorHandler.HandleRepositoryError("GetStoresWithEmployeeCount", err)
 	}
+	if scope.RestrictedToStore() {
+		scoped := stores[:0]
+		for _, store := range stores {
+			if scope.AllowsStore(int(store.ID)) {
+				scoped = append(scoped, store)
+			}
+		}
+		stores = scoped
+	}
 
 	uc.logger.LogOperation("GetStoresWithEmployeeCount", "success", map[string]interface{}{
 		"franchise_id": franchiseID,
@@ -259,7 +318,7 @@ func (uc *storeUseCase) GetStoresWithEmployeeCount(franchiseID int) ([]repositor
 }
 
 // GetStoreStatistics retrieves comprehensive store statistics
-func (uc *storeUseCase) GetStoreStatistics(storeID int) (*repository.StoreStatistics, error) {
+func (uc *storeUseCase) GetStoreStatistics(scope domain.TenantScope, storeID int) (*repository.StoreStatistics, error) {
 	uc.logger.LogOperation("GetStoreStatistics", "start", map[string]interface{}{"store_id": storeID})
 
 	// Validate store ID
@@ -268,6 +327,10 @@ func (uc *storeUseCase) GetStoreStatistics(storeID int) (*repository.StoreStatis
 		return nil, uc.errorHandler.HandleValidationError("GetStoreStatistics", err)
 	}
 
+	if err := uc.tenant.RequireStore("GetStoreStatistics", scope, storeID); err != nil {
+		return nil, err
+	}
+
 	// Use repository method for statistics
 	stats, err := uc.repo.GetStoreStatistics(storeID)
 	if err != nil {
@@ -284,6 +347,29 @@ func (uc *storeUseCase) GetStoreStatistics(storeID int) (*repository.StoreStatis
 	return stats, nil
 }
 
+// scopeToFranchise assigns the caller's franchise to a store that names none and
+// rejects stores that name another franchise
+func (uc *storeUseCase) scopeToFranchise(operation string, scope domain.TenantScope, store *domain.Store) error {
+	if store.FranchiseID == 0 {
+		store.FranchiseID = uint(scope.FranchiseID)
+	}
+	return uc.tenant.RequireFranchise(operation, scope, int(store.FranchiseID))
+}
+
+// storesInScope drops the stores a store-restricted caller cannot see
+func storesInScope(scope domain.TenantScope, stores []domain.Store) []domain.Store {
+	if !scope.RestrictedToStore() {
+		return stores
+	}
+	scoped := stores[:0]
+	for _, store := range stores {
+		if scope.AllowsStore(int(store.ID)) {
+			scoped = append(scoped, store)
+		}
+	}
+	return scoped
+}
+
 // ValidateStoreData validates store data according to business rules
 func (uc *storeUseCase) ValidateStoreData(store *domain.Store) error {
 	// Basic entity validation
@@ -335,6 +421,17 @@ func (uc *storeUseCase) ValidateStoreData(store *domain.Store) error {
 		}
 	}
 
+	// Validate country (optional; empty uses the franchise's holiday calendar)
+	if store.Country != "" {
+		store.Country = calendar.NormalizeCountry(store.Country)
+		if err := ValidateHolidayCountry(store.Country); err != nil {
+			uc.logger.LogValidation("ValidateStoreData", "country", "failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+			return uc.errorHandler.HandleValidationError("ValidateStoreData", err)
+		}
+	}
+
 	uc.logger.LogValidation("ValidateStoreData", "all_fields", "passed", nil)
 	return nil
 }
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+)
+
+// TenantGuard keeps use cases inside the caller's tenant scope: the franchise selected in the
+// token and, for store-restricted roles, the selected store. Records outside the scope are
+// reported as not found so their IDs are not disclosed.
+type TenantGuard struct {
+	tenantRepo   repository.TenantRepository
+	errorHandler *base.ErrorHandler
+	logger       *base.Logger
+}
+
+// NewTenantGuard creates a guard backed by the tenant ownership repository
+func NewTenantGuard(tenantRepo repository.TenantRepository) *TenantGuard {
+	return &TenantGuard{
+		tenantRepo:   tenantRepo,
+		errorHandler: base.NewErrorHandler("Tenant"),
+		logger:       base.NewLogger("Tenant"),
+	}
+}
+
+// RequireFranchise rejects callers without a selected franchise and requests for another franchise
+func (g *TenantGuard) RequireFranchise(operation string, scope domain.TenantScope, requestedID int) error {
+	if scope.FranchiseID <= 0 {
+		return g.errorHandler.HandleForbidden(operation, "a franchise must be selected")
+	}
+	if requestedID != scope.FranchiseID {
+		return g.errorHandler.HandleNotFound(operation, fmt.Sprintf("franchise not found with ID: %d", requestedID))
+	}
+	return nil
+}
+
+// RequireStore checks that the store is within the scope
+func (g *TenantGuard) RequireStore(operation string, scope domain.TenantScope, storeID int) error {
+	return g.require(operation, "store", scope, storeID, g.tenantRepo.StoreInScope)
+}
+
+// RequireEmployee checks that the employee is within the scope
+func (g *TenantGuard) RequireEmployee(operation string, scope domain.TenantScope, employeeID int) error {
+	return g.require(operation, "employee", scope, employeeID, g.tenantRepo.EmployeeInScope)
+}
+
+// RequireShift checks that the shift template is within the scope
+func (g *TenantGuard) RequireShift(operation string, scope domain.TenantScope, shiftID int) error {
+	return g.require(operation, "shift", scope, shiftID, g.tenantRepo.ShiftInScope)
+}
+
+// RequireAssignedShift checks that the shift assignment is within the scope
+func (g *TenantGuard) RequireAssignedShift(operation string, scope domain.TenantScope, assignedShiftID int) error {
+	return g.require(operation, "assignment", scope, assignedShiftID, g.tenantRepo.AssignedShiftInScope)
+}
+
+// RequireAllStores rejects store-restricted callers from franchise-wide changes
+func (g *TenantGuard) RequireAllStores(operation string, scope domain.TenantScope) error {
+	if scope.RestrictedToStore() {
+		return g.errorHandler.HandleForbidden(operation, "not allowed for a store-restricted role")
+	}
+	return nil
+}
+
+// RequirePlatform rejects changes to global data from callers without domain.PermissionPlatform
+func (g *TenantGuard) RequirePlatform(operation string, scope domain.TenantScope) error {
+	if !scope.Platform {
+		return g.errorHandler.HandleForbidden(operation, "global data can only be changed with the "+domain.PermissionPlatform+" permission")
+	}
+	return nil
+}
+
+func (g *TenantGuard) require(operation, entity string, scope domain.TenantScope, id int, inScope func(domain.TenantScope, int) (bool, error)) error {
+	if scope.FranchiseID <= 0 {
+		return g.errorHandler.HandleForbidden(operation, "a franchise must be selected")
+	}
+
+	ok, err := inScope(scope, id)
+	if err != nil {
+		g.logger.LogError(operation, err, map[string]interface{}{
+			"franchise_id": scope.FranchiseID,
+			"store_scope":  scope.StoreID,
+			entity + "_id": id,
+		})
+		return g.errorHandler.HandleRepositoryError(operation, err)
+	}
+	if !ok {
+		return g.errorHandler.HandleNotFound(operation, fmt.Sprintf("%s not found with ID: %d", entity, id))
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"errors"
+	appErr "loopi-api/internal/common/errors"
+	"loopi-api/internal/domain"
+	"testing"
+)
+
+// MockTenantRepository owns stores and employees by franchise; shifts and assignments are not used here
+type MockTenantRepository struct {
+	storeFranchises         map[int]int // store ID → franchise ID
+	employeeFranchises      map[int]int // employee ID → franchise ID
+	shiftFranchises         map[int]int // shift template ID → franchise ID
+	assignedShiftFranchises map[int]int // assignment ID → franchise ID
+	fail                    bool
+}
+
+func (m *MockTenantRepository) StoreInScope(scope domain.TenantScope, storeID int) (bool, error) {
+	if m.fail {
+		return false, errors.New("db down")
+	}
+	return m.storeFranchises[storeID] == scope.FranchiseID && scope.AllowsStore(storeID), nil
+}
+func (m *MockTenantRepository) EmployeeInScope(scope domain.TenantScope, employeeID int) (bool, error) {
+	return m.employeeFranchises[employeeID] == scope.FranchiseID, nil
+}
+func (m *MockTenantRepository) ShiftInScope(scope domain.TenantScope, shiftID int) (bool, error) {
+	return m.shiftFranchises[shiftID] == scope.FranchiseID, nil
+}
+func (m *MockTenantRepository) AssignedShiftInScope(scope domain.TenantScope, assignedShiftID int) (bool, error) {
+	return m.assignedShiftFranchises[assignedShiftID] == scope.FranchiseID, nil
+}
+
+func domainStatus(t *testing.T, err error) int {
+	t.Helper()
+	var domainErr appErr.DomainError
+	if !errors.As(err, &domainErr) {
+		t.Fatalf("Expected a domain error, got %v", err)
+	}
+	return domainErr.Status()
+}
+
+func franchiseScope(franchiseID int) domain.TenantScope {
+	return domain.TenantScope{FranchiseID: franchiseID}
+}
+
+func TestTenantGuard_RequireStore(t *testing.T) {
+	guard := NewTenantGuard(&MockTenantRepository{storeFranchises: map[int]int{10: 1, 20: 2}})
+
+	if err := guard.RequireStore("Get", franchiseScope(1), 10); err != nil {
+		t.Errorf("Expected own store to be allowed, got %v", err)
+	}
+	if status := domainStatus(t, guard.RequireStore("Get", franchiseScope(1), 20)); status != 404 {
+		t.Errorf("Expected 404 for a store of another franchise, got %d", status)
+	}
+	if status := domainStatus(t, guard.RequireStore("Get", franchiseScope(0), 10)); status != 403 {
+		t.Errorf("Expected 403 without a selected franchise, got %d", status)
+	}
+}
+
+func TestTenantGuard_StoreRestrictedScope(t *testing.T) {
+	guard := NewTenantGuard(&MockTenantRepository{storeFranchises: map[int]int{10: 1, 11: 1}})
+	manager := domain.TenantScope{FranchiseID: 1, StoreID: 10}
+
+	if err := guard.RequireStore("Get", manager, 10); err != nil {
+		t.Errorf("Expected the manager's store to be allowed, got %v", err)
+	}
+	if status := domainStatus(t, guard.RequireStore("Get", manager, 11)); status != 404 {
+		t.Errorf("Expected 404 for another store of the same franchise, got %d", status)
+	}
+	if status := domainStatus(t, guard.RequireAllStores("Create", manager)); status != 403 {
+		t.Errorf("Expected 403 for franchise-wide changes, got %d", status)
+	}
+	if err := guard.RequireAllStores("Create", franchiseScope(1)); err != nil {
+		t.Errorf("Expected franchise-wide changes for an unrestricted scope, got %v", err)
+	}
+}
+
+func TestTenantGuard_RequireFranchise(t *testing.T) {
+	guard := NewTenantGuard(&MockTenantRepository{})
+
+	if err := guard.RequireFranchise("Get", franchiseScope(1), 1); err != nil {
+		t.Errorf("Expected own franchise to be allowed, got %v", err)
+	}
+	if status := domainStatus(t, guard.RequireFranchise("Get", franchiseScope(1), 2)); status != 404 {
+		t.Errorf("Expected 404 for another franchise, got %d", status)
+	}
+}
+
+func TestTenantGuard_RepositoryFailure(t *testing.T) {
+	guard := NewTenantGuard(&MockTenantRepository{fail: true})
+
+	if status := domainStatus(t, guard.RequireStore("Get", franchiseScope(1), 10)); status != 500 {
+		t.Errorf("Expected 500 when ownership cannot be checked, got %d", status)
+	}
+}
@@ -0,0 +1,753 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
+)
+
+// TimeClockUseCase records the actual attendance of employees: punches of the authenticated
+// employee and the manager corrections of missed punches
+type TimeClockUseCase interface {
+	// Punches of the authenticated employee
+	ClockIn(scope domain.TenantScope, employeeID int, req dto.ClockInRequest) (*domain.TimeClockEntry, error)
+	ClockOut(scope domain.TenantScope, employeeID int) (*domain.TimeClockEntry, error)
+
+	// Records and missed punches, scoped to the caller's franchise
+	GetByEmployeeAndDateRange(scope domain.TenantScope, employeeID int, from, to time.Time) ([]domain.TimeClockEntry, error)
+	GetMissed(scope domain.TenantScope) ([]domain.TimeClockEntry, error)
+	CreateEntry(scope domain.TenantScope, managerID int, req dto.TimeClockEntryRequest) (*domain.TimeClockEntry, error)
+	Correct(scope domain.TenantScope, managerID, id int, req dto.TimeClockEntryRequest) (*domain.TimeClockEntry, error)
+
+	// Attendance exceptions of a store's day against the assigned shifts; an empty date is today
+	GetExceptions(scope domain.TenantScope, storeID int, date string) (domain.AttendanceExceptionReport, error)
+	ApplyExceptionNovelties(scope domain.TenantScope, storeID int, date string) (domain.AttendanceExceptionReport, error)
+
+	// Business-specific operations
+	ValidateEntryTimes(clockIn, clockOut time.Time) error
+}
+
+type timeClockUseCase struct {
+	repo           repository.TimeClockRepository
+	assignedRepo   repository.AssignedShiftRepository
+	storeRepo      repository.StoreRepository
+	absenceRepo    repository.AbsenceRepository
+	noveltyRepo    repository.NoveltyRepository
+	workConfigRepo repository.WorkConfigRepository
+	holidays       *HolidayCalendarResolver
+	tenant         *TenantGuard
+	now            func() time.Time
+	errorHandler   *base.ErrorHandler
+	validator      *base.Validator
+	logger         *base.Logger
+}
+
+func NewTimeClockUseCase(
+	repo repository.TimeClockRepository,
+	assignedRepo repository.AssignedShiftRepository,
+	storeRepo repository.StoreRepository,
+	absenceRepo repository.AbsenceRepository,
+	noveltyRepo repository.NoveltyRepository,
+	workConfigRepo repository.WorkConfigRepository,
+	holidays *HolidayCalendarResolver,
+	tenant *TenantGuard,
+) TimeClockUseCase {
+	return &timeClockUseCase{
+		repo:           repo,
+		assignedRepo:   assignedRepo,
+		storeRepo:      storeRepo,
+		absenceRepo:    absenceRepo,
+		noveltyRepo:    noveltyRepo,
+		workConfigRepo: workConfigRepo,
+		holidays:       holidays,
+		tenant:         tenant,
+		now:            time.Now,
+		errorHandler:   base.NewErrorHandler("TimeClock"),
+		validator:      base.NewValidator(),
+		logger:         base.NewLogger("TimeClock"),
+	}
+}
+
+// ✅ Punches
+
+// ClockIn opens an entry for the employee. An entry left open for more than
+// domain.MaxClockedHours is marked as a missed clock-out first.
+func (uc *timeClockUseCase) ClockIn(scope domain.TenantScope, employeeID int, req dto.ClockInRequest) (*domain.TimeClockEntry, error) {
+	uc.logger.LogOperation("ClockIn", "start", map[string]interface{}{
+		"employee_id": employeeID,
+		"store_id":    req.StoreID,
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("ClockIn", err)
+	}
+	if err := uc.tenant.RequireEmployee("ClockIn", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	storeID, err := uc.resolveStore("ClockIn", scope, employeeID, req.StoreID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	open, err := uc.repo.GetOpenByEmployee(employeeID)
+	if err != nil {
+		uc.logger.LogError("ClockIn", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("ClockIn", err)
+	}
+	if open != nil {
+		// Business rule: One open entry per employee; a stale one is a forgotten clock-out
+		if !isStale(open, now) {
+			return nil, uc.errorHandler.HandleConflict("ClockIn",
+				fmt.Sprintf("employee %d is already clocked in since %s", employeeID, open.ClockIn.Format(time.RFC3339)))
+		}
+		if err := uc.markMissed("ClockIn", open); err != nil {
+			return nil, err
+		}
+	}
+
+	entry := domain.TimeClockEntry{
+		EmployeeID: employeeID,
+		StoreID:    storeID,
+		ClockIn:    now,
+		Status:     domain.TimeClockOpen,
+	}
+	if err := uc.linkAssignedShift("ClockIn", &entry); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Create(&entry); err != nil {
+		uc.logger.LogError("ClockIn", err, map[string]interface{}{
+			"employee_id": employeeID,
+			"store_id":    storeID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("ClockIn", err)
+	}
+
+	uc.logger.LogOperation("ClockIn", "success", map[string]interface{}{
+		"entry_id":    entry.ID,
+		"employee_id": employeeID,
+		"store_id":    storeID,
+	})
+
+	return &entry, nil
+}
+
+// ClockOut closes the employee's open entry. Without a recent open entry the punch is rejected
+// and a manager registers the missed clock-in.
+func (uc *timeClockUseCase) ClockOut(scope domain.TenantScope, employeeID int) (*domain.TimeClockEntry, error) {
+	uc.logger.LogOperation("ClockOut", "start", map[string]interface{}{
+		"employee_id": employeeID,
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("ClockOut", err)
+	}
+	if err := uc.tenant.RequireEmployee("ClockOut", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	entry, err := uc.repo.GetOpenByEmployee(employeeID)
+	if err != nil {
+		uc.logger.LogError("ClockOut", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("ClockOut", err)
+	}
+	if entry == nil {
+		return nil, uc.errorHandler.HandleConflict("ClockOut",
+			fmt.Sprintf("employee %d is not clocked in; a manager must register the missed clock-in", employeeID))
+	}
+
+	// Business rule: An entry cannot last more than domain.MaxClockedHours
+	if isStale(entry, now) {
+		if err := uc.markMissed("ClockOut", entry); err != nil {
+			return nil, err
+		}
+		return nil, uc.errorHandler.HandleConflict("ClockOut",
+			fmt.Sprintf("the clock-in of %s is older than %d hours and was registered as a missed punch for a manager to correct",
+				entry.ClockIn.Format(time.RFC3339), domain.MaxClockedHours))
+	}
+
+	entry.ClockOut = &now
+	entry.Status = domain.TimeClockClosed
+	if err := uc.repo.Close(entry); err != nil {
+		uc.logger.LogError("ClockOut", err, map[string]interface{}{"entry_id": entry.ID})
+		return nil, uc.errorHandler.HandleRepositoryError("ClockOut", err)
+	}
+
+	uc.logger.LogOperation("ClockOut", "success", map[string]interface{}{
+		"entry_id":     entry.ID,
+		"employee_id":  employeeID,
+		"worked_hours": entry.WorkedHours(),
+	})
+
+	return entry, nil
+}
+
+// ✅ Records and missed punches
+
+// GetByEmployeeAndDateRange retrieves the entries of an employee clocked in between two dates (inclusive)
+func (uc *timeClockUseCase) GetByEmployeeAndDateRange(scope domain.TenantScope, employeeID int, from, to time.Time) ([]domain.TimeClockEntry, error) {
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetByEmployeeAndDateRange", err)
+	}
+	if err := uc.tenant.RequireEmployee("GetByEmployeeAndDateRange", scope, employeeID); err != nil {
+		return nil, err
+	}
+	if from.After(to) {
+		return nil, uc.errorHandler.HandleValidationError("GetByEmployeeAndDateRange",
+			fmt.Errorf("from date (%s) cannot be after to date (%s)", from.Format("2006-01-02"), to.Format("2006-01-02")))
+	}
+
+	entries, err := uc.repo.GetByEmployeeAndDateRange(employeeID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		uc.logger.LogError("GetByEmployeeAndDateRange", err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetByEmployeeAndDateRange", err)
+	}
+	return entries, nil
+}
+
+// GetMissed lists the entries in scope without a clock-out, oldest first
+func (uc *timeClockUseCase) GetMissed(scope domain.TenantScope) ([]domain.TimeClockEntry, error) {
+	if err := uc.tenant.RequireFranchise("GetMissed", scope, scope.FranchiseID); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.repo.GetMissed(scope, uc.now().Add(-domain.MaxClockedHours*time.Hour))
+	if err != nil {
+		uc.logger.LogError("GetMissed", err, map[string]interface{}{"franchise_id": scope.FranchiseID})
+		return nil, uc.errorHandler.HandleRepositoryError("GetMissed", err)
+	}
+
+	uc.logger.LogOperation("GetMissed", "success", map[string]interface{}{
+		"franchise_id": scope.FranchiseID,
+		"count":        len(entries),
+	})
+
+	return entries, nil
+}
+
+// CreateEntry registers a worked period whose punches were missed entirely
+func (uc *timeClockUseCase) CreateEntry(scope domain.TenantScope, managerID int, req dto.TimeClockEntryRequest) (*domain.TimeClockEntry, error) {
+	uc.logger.LogOperation("CreateEntry", "start", map[string]interface{}{
+		"employee_id": req.EmployeeID,
+		"store_id":    req.StoreID,
+		"manager_id":  managerID,
+	})
+
+	if err := uc.validator.ValidateID(req.EmployeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("CreateEntry", fmt.Errorf("invalid employee_id: %v", err))
+	}
+	if err := uc.validator.ValidateID(req.StoreID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("CreateEntry", fmt.Errorf("invalid store_id: %v", err))
+	}
+	if err := uc.validateCorrection(req); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("CreateEntry", err)
+	}
+	if err := uc.tenant.RequireEmployee("CreateEntry", scope, req.EmployeeID); err != nil {
+		return nil, err
+	}
+	if _, err := uc.resolveStore("CreateEntry", scope, req.EmployeeID, req.StoreID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireOtherEmployee("CreateEntry", managerID, req.EmployeeID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireNoOverlap("CreateEntry", req.EmployeeID, 0, req.ClockIn, req.ClockOut); err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	clockOut := req.ClockOut
+	entry := domain.TimeClockEntry{
+		EmployeeID:     req.EmployeeID,
+		StoreID:        req.StoreID,
+		ClockIn:        req.ClockIn,
+		ClockOut:       &clockOut,
+		Status:         domain.TimeClockClosed,
+		CorrectedBy:    &managerID,
+		CorrectedAt:    &now,
+		CorrectionNote: req.Note,
+	}
+	if err := uc.linkAssignedShift("CreateEntry", &entry); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Create(&entry); err != nil {
+		uc.logger.LogError("CreateEntry", err, map[string]interface{}{"employee_id": req.EmployeeID})
+		return nil, uc.errorHandler.HandleRepositoryError("CreateEntry", err)
+	}
+
+	uc.logger.LogOperation("CreateEntry", "success", map[string]interface{}{
+		"entry_id":    entry.ID,
+		"employee_id": req.EmployeeID,
+		"manager_id":  managerID,
+	})
+
+	return &entry, nil
+}
+
+// Correct sets both punches of an entry, typically a missed clock-out, and closes it
+func (uc *timeClockUseCase) Correct(scope domain.TenantScope, managerID, id int, req dto.TimeClockEntryRequest) (*domain.TimeClockEntry, error) {
+	uc.logger.LogOperation("Correct", "start", map[string]interface{}{
+		"entry_id":   id,
+		"manager_id": managerID,
+	})
+
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Correct", err)
+	}
+	if err := uc.validateCorrection(req); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Correct", err)
+	}
+
+	entry, err := uc.repo.GetByID(id)
+	if err != nil {
+		uc.logger.LogError("Correct", err, map[string]interface{}{"entry_id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Correct", err)
+	}
+	if err := uc.tenant.RequireEmployee("Correct", scope, entry.EmployeeID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireOtherEmployee("Correct", managerID, entry.EmployeeID); err != nil {
+		return nil, err
+	}
+	if err := uc.requireNoOverlap("Correct", entry.EmployeeID, int(entry.ID), req.ClockIn, req.ClockOut); err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	clockOut := req.ClockOut
+	entry.ClockIn = req.ClockIn
+	entry.ClockOut = &clockOut
+	entry.Status = domain.TimeClockClosed
+	entry.CorrectedBy = &managerID
+	entry.CorrectedAt = &now
+	entry.CorrectionNote = req.Note
+
+	if err := uc.repo.Update(entry); err != nil {
+		uc.logger.LogError("Correct", err, map[string]interface{}{"entry_id": id})
+		return nil, uc.errorHandler.HandleRepositoryError("Correct", err)
+	}
+
+	uc.logger.LogOperation("Correct", "success", map[string]interface{}{
+		"entry_id":    id,
+		"employee_id": entry.EmployeeID,
+		"manager_id":  managerID,
+	})
+
+	return entry, nil
+}
+
+// ✅ Attendance exceptions
+
+// GetExceptions reports lateness, early departures, missed punches and no-shows of the shifts
+// assigned at a store on a date
+func (uc *timeClockUseCase) GetExceptions(scope domain.TenantScope, storeID int, date string) (domain.AttendanceExceptionReport, error) {
+	uc.logger.LogOperation("GetExceptions", "start", map[string]interface{}{
+		"store_id": storeID,
+		"date":     date,
+	})
+
+	report, _, err := uc.exceptions("GetExceptions", scope, storeID, date)
+	if err != nil {
+		return domain.AttendanceExceptionReport{}, err
+	}
+
+	uc.logger.LogOperation("GetExceptions", "success", map[string]interface{}{
+		"store_id":   report.StoreID,
+		"date":       report.Date,
+		"shifts":     report.Shifts,
+		"exceptions": len(report.Exceptions),
+	})
+
+	return report, nil
+}
+
+// ApplyExceptionNovelties records the lateness and early departures of a store's day as negative
+// novelties, so the hours summaries discount them. Exceptions already converted are skipped.
+func (uc *timeClockUseCase) ApplyExceptionNovelties(scope domain.TenantScope, storeID int, date string) (domain.AttendanceExceptionReport, error) {
+	uc.logger.LogOperation("ApplyExceptionNovelties", "start", map[string]interface{}{
+		"store_id": storeID,
+		"date":     date,
+	})
+
+	report, day, err := uc.exceptions("ApplyExceptionNovelties", scope, storeID, date)
+	if err != nil {
+		return domain.AttendanceExceptionReport{}, err
+	}
+
+	created := 0
+	for i := range report.Exceptions {
+		exception := &report.Exceptions[i]
+		if exception.NoveltyID != nil {
+			continue // Already converted
+		}
+
+		var comment string
+		switch exception.Type {
+		case domain.AttendanceLate:
+			comment = fmt.Sprintf("Llegada tarde: %d min (turno %s, entrada %s)", exception.Minutes, exception.Scheduled, exception.Punched)
+		case domain.AttendanceEarlyLeave:
+			comment = fmt.Sprintf("Salida anticipada: %d min (turno %s, salida %s)", exception.Minutes, exception.Scheduled, exception.Punched)
+		default:
+			continue // Missed punches and no-shows need a manager's decision
+		}
+
+		shiftID := exception.AssignedShiftID
+		novelty := domain.Novelty{
+			EmployeeID:      exception.EmployeeID,
+			Date:            day,
+			Hours:           utils.RoundTo2(float64(exception.Minutes) / 60.0),
+			Type:            "negative",
+			Comment:         comment,
+			AssignedShiftID: &shiftID,
+			Source:          exception.Type,
+		}
+		if err := uc.noveltyRepo.Create(&novelty); err != nil {
+			uc.logger.LogError("ApplyExceptionNovelties", err, map[string]interface{}{
+				"employee_id":       exception.EmployeeID,
+				"assigned_shift_id": shiftID,
+				"type":              exception.Type,
+			})
+			return domain.AttendanceExceptionReport{}, uc.errorHandler.HandleRepositoryError("ApplyExceptionNovelties", err)
+		}
+
+		noveltyID := int(novelty.ID)
+		exception.NoveltyID = &noveltyID
+		created++
+	}
+
+	uc.logger.LogOperation("ApplyExceptionNovelties", "success", map[string]interface{}{
+		"store_id":  report.StoreID,
+		"date":      report.Date,
+		"novelties": created,
+	})
+
+	return report, nil
+}
+
+// exceptions compares the punches linked to each shift of a store's day with its schedule and
+// returns the report with the day it covers
+func (uc *timeClockUseCase) exceptions(operation string, scope domain.TenantScope, storeID int, date string) (domain.AttendanceExceptionReport, time.Time, error) {
+	if storeID == 0 && scope.RestrictedToStore() {
+		storeID = scope.StoreID
+	}
+	if err := uc.validator.ValidateID(storeID); err != nil {
+		return domain.AttendanceExceptionReport{}, time.Time{}, uc.errorHandler.HandleValidationError(operation, fmt.Errorf("invalid store_id: %v", err))
+	}
+	if err := uc.tenant.RequireStore(operation, scope, storeID); err != nil {
+		return domain.AttendanceExceptionReport{}, time.Time{}, err
+	}
+
+	location, err := uc.holidays.LocationForStore(operation, storeID)
+	if err != nil {
+		return domain.AttendanceExceptionReport{}, time.Time{}, err
+	}
+
+	now := uc.now()
+	var day time.Time
+	if date == "" {
+		local := now.In(location)
+		day = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	} else if day, err = time.Parse("2006-01-02", date); err != nil {
+		return domain.AttendanceExceptionReport{}, time.Time{}, uc.errorHandler.HandleValidationError(operation,
+			fmt.Errorf("invalid date: %q. Expected format: YYYY-MM-DD", date))
+	}
+	dateKey := day.Format("2006-01-02")
+
+	shifts, err := uc.assignedRepo.GetByStoreAndDateRange(storeID, dateKey, dateKey)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"store_id": storeID, "date": dateKey})
+		return domain.AttendanceExceptionReport{}, time.Time{}, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	// Grace period in force on the date
+	configs, err := uc.workConfigRepo.GetConfigsForPeriod(day, day)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"date": dateKey})
+		return domain.AttendanceExceptionReport{}, time.Time{}, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	graceMinutes := utils.NewWorkConfigTimeline(configs).At(day).GraceMinutes
+
+	shiftIDs := make([]int, 0, len(shifts))
+	for _, shift := range shifts {
+		shiftIDs = append(shiftIDs, int(shift.ID))
+	}
+	entries, err := uc.repo.GetByAssignedShifts(shiftIDs)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"store_id": storeID, "date": dateKey})
+		return domain.AttendanceExceptionReport{}, time.Time{}, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	novelties, err := uc.noveltyRepo.GetByAssignedShifts(shiftIDs)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"store_id": storeID, "date": dateKey})
+		return domain.AttendanceExceptionReport{}, time.Time{}, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	entriesByShift := make(map[int][]domain.TimeClockEntry)
+	for _, entry := range entries {
+		entriesByShift[*entry.AssignedShiftID] = append(entriesByShift[*entry.AssignedShiftID], entry)
+	}
+	noveltyIDs := make(map[string]*int)
+	for _, novelty := range novelties {
+		id := int(novelty.ID)
+		noveltyIDs[fmt.Sprintf("%d:%s", *novelty.AssignedShiftID, novelty.Source)] = &id
+	}
+
+	report := domain.AttendanceExceptionReport{
+		StoreID:      storeID,
+		Date:         dateKey,
+		GraceMinutes: graceMinutes,
+		Shifts:       len(shifts),
+		Exceptions:   make([]domain.AttendanceException, 0),
+	}
+	grace := time.Duration(graceMinutes) * time.Minute
+
+	for _, shift := range shifts {
+		shiftID := int(shift.ID)
+		start, end := shiftBounds(day, shift, location)
+		exception := func(exceptionType, scheduled string, punched *time.Time, minutes int) domain.AttendanceException {
+			result := domain.AttendanceException{
+				EmployeeID:      shift.EmployeeID,
+				AssignedShiftID: shiftID,
+				Type:            exceptionType,
+				Scheduled:       scheduled,
+				Minutes:         minutes,
+				NoveltyID:       noveltyIDs[fmt.Sprintf("%d:%s", shiftID, exceptionType)],
+			}
+			if punched != nil {
+				result.Punched = punched.In(location).Format("15:04")
+			}
+			return result
+		}
+
+		shiftEntries := entriesByShift[shiftID]
+		if len(shiftEntries) == 0 {
+			// Business rule: A finished shift without punches is a no-show unless an absence covers it
+			if !now.After(end) {
+				continue
+			}
+			absent, err := uc.hasApprovedAbsence(operation, shift.EmployeeID, day)
+			if err != nil {
+				return domain.AttendanceExceptionReport{}, time.Time{}, err
+			}
+			if !absent {
+				report.Exceptions = append(report.Exceptions, exception(domain.AttendanceNoShow, shift.StartTime, nil, 0))
+			}
+			continue
+		}
+
+		// Split punches: the first clock-in and the last clock-out are compared with the shift
+		firstIn := shiftEntries[0].ClockIn
+		var lastOut *time.Time
+		var missed *domain.TimeClockEntry
+		inProgress := false
+		for i := range shiftEntries {
+			entry := &shiftEntries[i]
+			if entry.ClockIn.Before(firstIn) {
+				firstIn = entry.ClockIn
+			}
+			switch {
+			case entry.Status == domain.TimeClockMissedOut || (entry.Status == domain.TimeClockOpen && isStale(entry, now)):
+				missed = entry
+			case entry.Status == domain.TimeClockOpen:
+				inProgress = true
+			case entry.ClockOut != nil && (lastOut == nil || entry.ClockOut.After(*lastOut)):
+				lastOut = entry.ClockOut
+			}
+		}
+
+		if late := firstIn.Sub(start); late > grace {
+			report.Exceptions = append(report.Exceptions, exception(domain.AttendanceLate, shift.StartTime, &firstIn, int(late/time.Minute)))
+		}
+		switch {
+		case missed != nil:
+			report.Exceptions = append(report.Exceptions, exception(domain.AttendanceMissedPunch, shift.EndTime, &missed.ClockIn, 0))
+		case inProgress || lastOut == nil:
+			// Still clocked in
+		default:
+			if early := end.Sub(*lastOut); early > grace {
+				report.Exceptions = append(report.Exceptions, exception(domain.AttendanceEarlyLeave, shift.EndTime, lastOut, int(early/time.Minute)))
+			}
+		}
+	}
+
+	return report, day, nil
+}
+
+// hasApprovedAbsence reports whether an approved absence covers the employee's day
+func (uc *timeClockUseCase) hasApprovedAbsence(operation string, employeeID int, day time.Time) (bool, error) {
+	absences, err := uc.absenceRepo.GetByEmployeeAndDateRange(employeeID, day, day)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return false, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	for _, absence := range absences {
+		if absence.IsApproved() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// shiftBounds returns the instants a shift starts and ends at the store; an overnight shift ends the next day
+func shiftBounds(day time.Time, shift domain.AssignedShift, location *time.Location) (time.Time, time.Time) {
+	startHour := utils.ParseHour(shift.StartTime)
+	endHour := utils.ParseHour(shift.EndTime)
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), startHour.Hour(), startHour.Minute(), 0, 0, location)
+	end := time.Date(day.Year(), day.Month(), day.Day(), endHour.Hour(), endHour.Minute(), 0, 0, location)
+	if !end.After(start) {
+		end = end.AddDate(0, 0, 1)
+	}
+	return start, end
+}
+
+// ✅ Business-specific operations
+
+// ValidateEntryTimes validates a worked period: it ends after it starts, lasts at most
+// domain.MaxClockedHours and is not in the future
+func (uc *timeClockUseCase) ValidateEntryTimes(clockIn, clockOut time.Time) error {
+	if clockIn.IsZero() || clockOut.IsZero() {
+		return fmt.Errorf("clock_in and clock_out are required")
+	}
+	if !clockOut.After(clockIn) {
+		return fmt.Errorf("clock_out (%s) must be after clock_in (%s)", clockOut.Format(time.RFC3339), clockIn.Format(time.RFC3339))
+	}
+	if hours := clockOut.Sub(clockIn).Hours(); hours > domain.MaxClockedHours {
+		return fmt.Errorf("an entry cannot last more than %d hours, got: %.2f", domain.MaxClockedHours, hours)
+	}
+	if clockOut.After(uc.now()) {
+		return fmt.Errorf("clock_out cannot be in the future: %s", clockOut.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// validateCorrection validates the times and the mandatory note of a manager correction
+func (uc *timeClockUseCase) validateCorrection(req dto.TimeClockEntryRequest) error {
+	if err := uc.ValidateEntryTimes(req.ClockIn, req.ClockOut); err != nil {
+		return err
+	}
+	return uc.validator.ValidateString(req.Note, "note", "required", "min:3", "max:255")
+}
+
+// resolveStore picks the store of a punch: the requested one, else the token's store, else the
+// employee's only store. The employee must be assigned to it.
+func (uc *timeClockUseCase) resolveStore(operation string, scope domain.TenantScope, employeeID, storeID int) (int, error) {
+	if storeID == 0 && scope.RestrictedToStore() {
+		storeID = scope.StoreID
+	}
+
+	stores, err := uc.storeRepo.GetByUserID(employeeID)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return 0, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	if storeID == 0 {
+		if len(stores) != 1 {
+			return 0, uc.errorHandler.HandleValidationError(operation,
+				fmt.Errorf("store_id is required for employees of %d stores", len(stores)))
+		}
+		storeID = int(stores[0].ID)
+	}
+
+	if err := uc.tenant.RequireStore(operation, scope, storeID); err != nil {
+		return 0, err
+	}
+
+	// Business rule: Employees punch only at the stores they are assigned to
+	for _, store := range stores {
+		if int(store.ID) == storeID {
+			return storeID, nil
+		}
+	}
+	return 0, uc.errorHandler.HandleBusinessRuleViolation(operation, "store_assignment",
+		fmt.Sprintf("employee %d is not assigned to store %d", employeeID, storeID))
+}
+
+// linkAssignedShift links the entry to the employee's shift at the store on the local date of
+// the clock-in and copies its lunch, which is not punched
+func (uc *timeClockUseCase) linkAssignedShift(operation string, entry *domain.TimeClockEntry) error {
+	location, err := uc.holidays.LocationForStore(operation, entry.StoreID)
+	if err != nil {
+		return err
+	}
+
+	date := entry.ClockIn.In(location).Format("2006-01-02")
+	shift, err := uc.assignedRepo.GetByEmployeeAndDate(entry.EmployeeID, date)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{
+			"employee_id": entry.EmployeeID,
+			"date":        date,
+		})
+		return uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	if shift != nil && shift.StoreID == entry.StoreID {
+		shiftID := int(shift.ID)
+		entry.AssignedShiftID = &shiftID
+		entry.LunchMinutes = shift.LunchMinutes
+	}
+	return nil
+}
+
+// markMissed flags an entry left open as a missed clock-out
+func (uc *timeClockUseCase) markMissed(operation string, entry *domain.TimeClockEntry) error {
+	entry.Status = domain.TimeClockMissedOut
+	if err := uc.repo.Update(entry); err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"entry_id": entry.ID})
+		return uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	uc.logger.LogBusinessRule(operation, "missed_clock_out", "flagged", map[string]interface{}{
+		"entry_id":    entry.ID,
+		"employee_id": entry.EmployeeID,
+		"clock_in":    entry.ClockIn.Format(time.RFC3339),
+	})
+	return nil
+}
+
+// requireOtherEmployee rejects corrections of the manager's own punches
+func (uc *timeClockUseCase) requireOtherEmployee(operation string, managerID, employeeID int) error {
+	if managerID == employeeID {
+		return uc.errorHandler.HandleBusinessRuleViolation(operation, "self_correction",
+			"punches cannot be registered or corrected by the employee who made them")
+	}
+	return nil
+}
+
+// requireNoOverlap rejects periods that overlap another entry of the employee; entries without
+// a clock-out occupy the instant of their clock-in
+func (uc *timeClockUseCase) requireNoOverlap(operation string, employeeID, excludeID int, clockIn, clockOut time.Time) error {
+	entries, err := uc.repo.GetByEmployeeAndDateRange(employeeID, clockIn.Add(-domain.MaxClockedHours*time.Hour), clockOut)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	for _, other := range entries {
+		if int(other.ID) == excludeID {
+			continue
+		}
+		otherEnd := other.ClockIn.Add(time.Nanosecond)
+		if other.ClockOut != nil {
+			otherEnd = *other.ClockOut
+		}
+		if other.ClockIn.Before(clockOut) && otherEnd.After(clockIn) {
+			return uc.errorHandler.HandleConflict(operation,
+				fmt.Sprintf("employee %d already has entry %d from %s", employeeID, other.ID, other.ClockIn.Format(time.RFC3339)))
+		}
+	}
+	return nil
+}
+
+// isStale reports whether an open entry exceeds domain.MaxClockedHours at now
+func isStale(entry *domain.TimeClockEntry, now time.Time) bool {
+	return now.Sub(entry.ClockIn) > domain.MaxClockedHours*time.Hour
+}
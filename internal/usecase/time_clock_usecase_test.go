@@ -0,0 +1,517 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"loopi-api/internal/domain"
+	repotesting "loopi-api/internal/repository/testing"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
+)
+
+// MockTimeClockRepository keeps entries in insertion order
+type MockTimeClockRepository struct {
+	entries []domain.TimeClockEntry
+}
+
+func (m *MockTimeClockRepository) GetByID(id int) (*domain.TimeClockEntry, error) {
+	for i := range m.entries {
+		if int(m.entries[i].ID) == id {
+			entry := m.entries[i]
+			return &entry, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+func (m *MockTimeClockRepository) Create(entry *domain.TimeClockEntry) error {
+	entry.ID = uint(len(m.entries) + 1)
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+func (m *MockTimeClockRepository) Update(entry *domain.TimeClockEntry) error {
+	m.entries[entry.ID-1] = *entry
+	return nil
+}
+func (m *MockTimeClockRepository) GetOpenByEmployee(employeeID int) (*domain.TimeClockEntry, error) {
+	for i := range m.entries {
+		if m.entries[i].EmployeeID == employeeID && m.entries[i].Status == domain.TimeClockOpen {
+			entry := m.entries[i]
+			return &entry, nil
+		}
+	}
+	return nil, nil
+}
+func (m *MockTimeClockRepository) Close(entry *domain.TimeClockEntry) error {
+	if m.entries[entry.ID-1].Status != domain.TimeClockOpen {
+		return errors.New("record not found")
+	}
+	return m.Update(entry)
+}
+func (m *MockTimeClockRepository) GetByEmployeeAndDateRange(employeeID int, from, to time.Time) ([]domain.TimeClockEntry, error) {
+	var entries []domain.TimeClockEntry
+	for _, entry := range m.entries {
+		if entry.EmployeeID == employeeID && !entry.ClockIn.Before(from) && entry.ClockIn.Before(to) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+func (m *MockTimeClockRepository) GetMissed(scope domain.TenantScope, openBefore time.Time) ([]domain.TimeClockEntry, error) {
+	var entries []domain.TimeClockEntry
+	for _, entry := range m.entries {
+		if entry.Status == domain.TimeClockMissedOut || (entry.Status == domain.TimeClockOpen && entry.ClockIn.Before(openBefore)) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+func (m *MockTimeClockRepository) GetByAssignedShifts(assignedShiftIDs []int) ([]domain.TimeClockEntry, error) {
+	var entries []domain.TimeClockEntry
+	for _, entry := range m.entries {
+		for _, id := range assignedShiftIDs {
+			if entry.AssignedShiftID != nil && *entry.AssignedShiftID == id {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// MockAssignedShiftRepository serves the shifts of the attendance and swap tests; writes are not used here
+type MockAssignedShiftRepository struct {
+	shifts []domain.AssignedShift
+}
+
+func (m *MockAssignedShiftRepository) GetByID(id int) (*domain.AssignedShift, error) {
+	for i := range m.shifts {
+		if int(m.shifts[i].ID) == id {
+			shift := m.shifts[i]
+			return &shift, nil
+		}
+	}
+	return nil, errors.New("assigned shift not found")
+}
+func (m *MockAssignedShiftRepository) Create(assignedShift *domain.AssignedShift) error {
+	return nil
+}
+func (m *MockAssignedShiftRepository) CreateBatch(assignedShifts []domain.AssignedShift) error {
+	return nil
+}
+func (m *MockAssignedShiftRepository) Update(assignedShift *domain.AssignedShift) error {
+	return nil
+}
+func (m *MockAssignedShiftRepository) Delete(id int) error { return nil }
+func (m *MockAssignedShiftRepository) GetByEmployeeAndMonth(employeeID, year, month int) ([]domain.AssignedShift, error) {
+	return nil, nil
+}
+func (m *MockAssignedShiftRepository) GetByEmployeeAndDate(employeeID int, date string) (*domain.AssignedShift, error) {
+	for i := range m.shifts {
+		if m.shifts[i].EmployeeID == employeeID && m.shifts[i].Date == date {
+			return &m.shifts[i], nil
+		}
+	}
+	return nil, nil
+}
+func (m *MockAssignedShiftRepository) GetByEmployeeAndDateRange(employeeID int, from, to string) ([]domain.AssignedShift, error) {
+	var shifts []domain.AssignedShift
+	for _, shift := range m.shifts {
+		if shift.EmployeeID == employeeID && shift.Date >= from && shift.Date <= to {
+			shifts = append(shifts, shift)
+		}
+	}
+	return shifts, nil
+}
+func (m *MockAssignedShiftRepository) GetByStoreAndDateRange(storeID int, from, to string) ([]domain.AssignedShift, error) {
+	var shifts []domain.AssignedShift
+	for _, shift := range m.shifts {
+		if shift.StoreID == storeID && shift.Date >= from && shift.Date <= to {
+			shifts = append(shifts, shift)
+		}
+	}
+	return shifts, nil
+}
+
+// MockWorkConfigRepository has a single configuration in force
+type MockWorkConfigRepository struct {
+	config domain.WorkConfig
+}
+
+func (m *MockWorkConfigRepository) GetActiveConfig() domain.WorkConfig { return m.config }
+func (m *MockWorkConfigRepository) GetConfigsForPeriod(from, to time.Time) ([]domain.WorkConfig, error) {
+	return []domain.WorkConfig{m.config}, nil
+}
+func (m *MockWorkConfigRepository) GetAllConfigs() ([]domain.WorkConfig, error) {
+	return []domain.WorkConfig{m.config}, nil
+}
+func (m *MockWorkConfigRepository) Create(config *domain.WorkConfig) error { return nil }
+
+// newTestStores has Colombian stores 10 and 20 of franchise 1; employee 7 works at store 10 and manager 50 at both
+func newTestStores() *repotesting.MockStoreRepository {
+	stores := repotesting.NewMockStoreRepository()
+	stores.SeedData([]domain.Store{
+		{BaseEntity: domain.BaseEntity{ID: 10}, FranchiseID: 1, Country: "CO"},
+		{BaseEntity: domain.BaseEntity{ID: 20}, FranchiseID: 1, Country: "CO"},
+	})
+	stores.AssignUser(7, 10)
+	stores.AssignUser(50, 10)
+	stores.AssignUser(50, 20)
+	return stores
+}
+
+func newTestTimeClockUseCase(clock *MockTimeClockRepository, assigned *MockAssignedShiftRepository, now *time.Time) *timeClockUseCase {
+	stores := newTestStores()
+	holidays := NewHolidayCalendarResolver(stores, &MockFranchiseRepository{}, &MockHolidayRepository{})
+	tenant := NewTenantGuard(&MockTenantRepository{
+		storeFranchises:    map[int]int{10: 1, 20: 1},
+		employeeFranchises: map[int]int{7: 1, 50: 1},
+	})
+	workConfig := &MockWorkConfigRepository{config: domain.WorkConfig{DiurnalStart: "06:00", DiurnalEnd: "21:00", DailyOrdinaryHours: 8, GraceMinutes: 5}}
+	uc := NewTimeClockUseCase(clock, assigned, stores, repotesting.NewMockAbsenceRepository(), repotesting.NewMockNoveltyRepository(),
+		workConfig, holidays, tenant).(*timeClockUseCase)
+	uc.now = func() time.Time { return *now }
+	return uc
+}
+
+func TestTimeClockUseCase_ClockInOut(t *testing.T) {
+	clock := &MockTimeClockRepository{}
+	assigned := &MockAssignedShiftRepository{shifts: []domain.AssignedShift{
+		{BaseEntity: domain.BaseEntity{ID: 3}, EmployeeID: 7, StoreID: 10, Date: "2025-03-03", StartTime: "08:00", EndTime: "17:00", LunchMinutes: 60},
+	}}
+	// 07:58 in Bogotá
+	now := time.Date(2025, 3, 3, 12, 58, 0, 0, time.UTC)
+	uc := newTestTimeClockUseCase(clock, assigned, &now)
+
+	if _, err := uc.ClockOut(franchiseScope(1), 7); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 clocking out without a clock-in, got %v", err)
+	}
+	if _, err := uc.ClockIn(franchiseScope(1), 7, dto.ClockInRequest{StoreID: 20}); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 at a store the employee is not assigned to, got %v", err)
+	}
+
+	entry, err := uc.ClockIn(franchiseScope(1), 7, dto.ClockInRequest{})
+	if err != nil {
+		t.Fatalf("ClockIn: unexpected error %v", err)
+	}
+	if entry.StoreID != 10 || entry.AssignedShiftID == nil || *entry.AssignedShiftID != 3 || entry.LunchMinutes != 60 {
+		t.Errorf("Expected the entry linked to the day's shift at the only store, got %+v", entry)
+	}
+	if _, err := uc.ClockIn(franchiseScope(1), 7, dto.ClockInRequest{}); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 clocking in twice, got %v", err)
+	}
+
+	now = now.Add(9*time.Hour + 30*time.Minute)
+	closed, err := uc.ClockOut(franchiseScope(1), 7)
+	if err != nil {
+		t.Fatalf("ClockOut: unexpected error %v", err)
+	}
+	if closed.Status != domain.TimeClockClosed || closed.WorkedHours() != 8.5 {
+		t.Errorf("Expected a closed entry of 8.5 hours, got %+v", closed)
+	}
+
+	if _, err := uc.ClockIn(franchiseScope(2), 7, dto.ClockInRequest{}); domainStatus(t, err) != 404 {
+		t.Errorf("Expected 404 from another franchise, got %v", err)
+	}
+}
+
+func TestTimeClockUseCase_MissedPunches(t *testing.T) {
+	clock := &MockTimeClockRepository{}
+	now := time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)
+	uc := newTestTimeClockUseCase(clock, &MockAssignedShiftRepository{}, &now)
+
+	forgotten, err := uc.ClockIn(franchiseScope(1), 7, dto.ClockInRequest{})
+	if err != nil {
+		t.Fatalf("ClockIn: unexpected error %v", err)
+	}
+
+	// Next morning the forgotten clock-out is flagged and the new clock-in goes through
+	now = now.Add(20 * time.Hour)
+	if _, err := uc.ClockIn(franchiseScope(1), 7, dto.ClockInRequest{}); err != nil {
+		t.Fatalf("Expected a clock-in after a forgotten clock-out, got %v", err)
+	}
+	missed, err := uc.GetMissed(franchiseScope(1))
+	if err != nil {
+		t.Fatalf("GetMissed: unexpected error %v", err)
+	}
+	if len(missed) != 1 || missed[0].ID != forgotten.ID || missed[0].Status != domain.TimeClockMissedOut {
+		t.Fatalf("Expected the forgotten entry as missed, got %+v", missed)
+	}
+
+	correction := dto.TimeClockEntryRequest{
+		ClockIn:  forgotten.ClockIn,
+		ClockOut: forgotten.ClockIn.Add(8 * time.Hour),
+		Note:     "Olvidó marcar la salida",
+	}
+	if _, err := uc.Correct(franchiseScope(1), 7, int(forgotten.ID), correction); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 correcting one's own entry, got %v", err)
+	}
+	if _, err := uc.Correct(franchiseScope(1), 50, int(forgotten.ID), dto.TimeClockEntryRequest{ClockIn: correction.ClockIn, ClockOut: correction.ClockOut}); domainStatus(t, err) != 400 {
+		t.Errorf("Expected 400 without a note, got %v", err)
+	}
+	now = now.Add(time.Hour)
+	overlapping := correction
+	overlapping.ClockIn = now.Add(-8 * time.Hour)
+	overlapping.ClockOut = now
+	if _, err := uc.Correct(franchiseScope(1), 50, int(forgotten.ID), overlapping); domainStatus(t, err) != 409 {
+		t.Errorf("Expected 409 overlapping the open entry, got %v", err)
+	}
+
+	corrected, err := uc.Correct(franchiseScope(1), 50, int(forgotten.ID), correction)
+	if err != nil {
+		t.Fatalf("Correct: unexpected error %v", err)
+	}
+	if corrected.Status != domain.TimeClockClosed || corrected.CorrectedBy == nil || *corrected.CorrectedBy != 50 || corrected.WorkedHours() != 8 {
+		t.Errorf("Expected a closed 8 hour entry corrected by the manager, got %+v", corrected)
+	}
+	if missed, _ := uc.GetMissed(franchiseScope(1)); len(missed) != 0 {
+		t.Errorf("Expected no missed entries after the correction, got %+v", missed)
+	}
+}
+
+func TestTimeClockUseCase_CreateEntryValidation(t *testing.T) {
+	now := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	uc := newTestTimeClockUseCase(&MockTimeClockRepository{}, &MockAssignedShiftRepository{}, &now)
+	clockIn := time.Date(2025, 3, 3, 13, 0, 0, 0, time.UTC)
+
+	for _, req := range []dto.TimeClockEntryRequest{
+		{EmployeeID: 7, StoreID: 10, ClockIn: clockIn, ClockOut: clockIn, Note: "Sin marcaciones"},
+		{EmployeeID: 7, StoreID: 10, ClockIn: clockIn, ClockOut: clockIn.Add(17 * time.Hour), Note: "Sin marcaciones"},
+		{EmployeeID: 7, StoreID: 10, ClockIn: now, ClockOut: now.Add(time.Hour), Note: "Sin marcaciones"},
+		{StoreID: 10, ClockIn: clockIn, ClockOut: clockIn.Add(8 * time.Hour), Note: "Sin marcaciones"},
+	} {
+		if _, err := uc.CreateEntry(franchiseScope(1), 50, req); domainStatus(t, err) != 400 {
+			t.Errorf("Expected 400 for %+v, got %v", req, err)
+		}
+	}
+
+	entry, err := uc.CreateEntry(franchiseScope(1), 50, dto.TimeClockEntryRequest{
+		EmployeeID: 7, StoreID: 10, ClockIn: clockIn, ClockOut: clockIn.Add(8 * time.Hour), Note: "Sin marcaciones",
+	})
+	if err != nil {
+		t.Fatalf("CreateEntry: unexpected error %v", err)
+	}
+	if entry.Status != domain.TimeClockClosed || entry.CorrectionNote != "Sin marcaciones" {
+		t.Errorf("Expected a closed entry with its note, got %+v", entry)
+	}
+}
+
+func TestTimeClockUseCase_Exceptions(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*60*60)
+	local := func(hour, minute int) time.Time {
+		return time.Date(2025, 3, 3, hour, minute, 0, 0, bogota).UTC()
+	}
+	shiftID := func(id int) *int { return &id }
+	closedEntry := func(employeeID, shift int, in, out time.Time) domain.TimeClockEntry {
+		return domain.TimeClockEntry{EmployeeID: employeeID, StoreID: 10, AssignedShiftID: shiftID(shift), ClockIn: in, ClockOut: &out, Status: domain.TimeClockClosed}
+	}
+
+	clock := &MockTimeClockRepository{}
+	for _, entry := range []domain.TimeClockEntry{
+		closedEntry(7, 1, local(8, 17), local(16, 50)),
+		// Within the 5 minute grace period, with a split for lunch
+		closedEntry(8, 2, local(8, 4), local(12, 0)),
+		closedEntry(8, 2, local(13, 0), local(16, 56)),
+		{EmployeeID: 12, StoreID: 10, AssignedShiftID: shiftID(5), ClockIn: local(14, 0), Status: domain.TimeClockMissedOut},
+	} {
+		entry := entry
+		clock.Create(&entry)
+	}
+	shift := func(id, employeeID int, start, end string) domain.AssignedShift {
+		return domain.AssignedShift{BaseEntity: domain.BaseEntity{ID: uint(id)}, EmployeeID: employeeID, StoreID: 10, Date: "2025-03-03", StartTime: start, EndTime: end}
+	}
+	assigned := &MockAssignedShiftRepository{shifts: []domain.AssignedShift{
+		shift(1, 7, "08:00", "17:00"),
+		shift(2, 8, "08:00", "17:00"),
+		shift(3, 9, "08:00", "17:00"),
+		shift(4, 11, "08:00", "17:00"),
+		shift(5, 12, "14:00", "22:00"),
+	}}
+	now := time.Date(2025, 3, 4, 12, 0, 0, 0, time.UTC)
+	uc := newTestTimeClockUseCase(clock, assigned, &now)
+	absences := repotesting.NewMockAbsenceRepository()
+	absences.SeedAbsenceData([]domain.Absence{{EmployeeID: 11, Date: ymd(2025, 3, 3), Hours: 8, Status: domain.AbsenceStatusApproved}})
+	uc.absenceRepo = absences
+	novelties := repotesting.NewMockNoveltyRepository()
+	uc.noveltyRepo = novelties
+
+	manager := domain.TenantScope{FranchiseID: 1, StoreID: 10}
+	report, err := uc.GetExceptions(manager, 0, "2025-03-03")
+	if err != nil {
+		t.Fatalf("GetExceptions: unexpected error %v", err)
+	}
+	expected := []domain.AttendanceException{
+		{EmployeeID: 7, AssignedShiftID: 1, Type: domain.AttendanceLate, Scheduled: "08:00", Punched: "08:17", Minutes: 17},
+		{EmployeeID: 7, AssignedShiftID: 1, Type: domain.AttendanceEarlyLeave, Scheduled: "17:00", Punched: "16:50", Minutes: 10},
+		{EmployeeID: 9, AssignedShiftID: 3, Type: domain.AttendanceNoShow, Scheduled: "08:00"},
+		{EmployeeID: 12, AssignedShiftID: 5, Type: domain.AttendanceMissedPunch, Scheduled: "22:00", Punched: "14:00"},
+	}
+	if report.StoreID != 10 || report.Shifts != 5 || report.GraceMinutes != 5 || len(report.Exceptions) != len(expected) {
+		t.Fatalf("Unexpected report %+v", report)
+	}
+	for i, exception := range expected {
+		if report.Exceptions[i] != exception {
+			t.Errorf("Exception %d: expected %+v, got %+v", i, exception, report.Exceptions[i])
+		}
+	}
+
+	applied, err := uc.ApplyExceptionNovelties(manager, 10, "2025-03-03")
+	if err != nil {
+		t.Fatalf("ApplyExceptionNovelties: unexpected error %v", err)
+	}
+	if applied.Exceptions[0].NoveltyID == nil || applied.Exceptions[1].NoveltyID == nil || applied.Exceptions[2].NoveltyID != nil {
+		t.Errorf("Expected novelties for the lateness and the early departure only, got %+v", applied.Exceptions)
+	}
+	generated, _ := novelties.GetByEmployeeAndMonth(7, 2025, 3)
+	total := 0.0
+	for _, novelty := range generated {
+		if novelty.Type != "negative" || novelty.AssignedShiftID == nil || *novelty.AssignedShiftID != 1 {
+			t.Errorf("Expected negative novelties linked to the shift, got %+v", novelty)
+		}
+		total += novelty.Hours
+	}
+	if len(generated) != 2 || utils.RoundTo2(total) != 0.45 {
+		t.Errorf("Expected 0.28 + 0.17 hours, got %+v", generated)
+	}
+
+	// Applying twice does not duplicate the novelties
+	if _, err := uc.ApplyExceptionNovelties(manager, 10, "2025-03-03"); err != nil {
+		t.Fatalf("ApplyExceptionNovelties: unexpected error %v", err)
+	}
+	if generated, _ := novelties.GetByEmployeeAndMonth(7, 2025, 3); len(generated) != 2 {
+		t.Errorf("Expected the novelties not to be duplicated, got %+v", generated)
+	}
+
+	if _, err := uc.GetExceptions(franchiseScope(2), 10, "2025-03-03"); domainStatus(t, err) != 404 {
+		t.Errorf("Expected 404 for a store of another franchise, got %v", err)
+	}
+	if _, err := uc.GetExceptions(manager, 10, "03/03/2025"); domainStatus(t, err) != 400 {
+		t.Errorf("Expected 400 for an invalid date, got %v", err)
+	}
+}
+
+func TestEmployeeHoursUseCase_AttendanceSummary(t *testing.T) {
+	bogota := time.FixedZone("COT", -5*60*60)
+	local := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 3, day, hour, minute, 0, 0, bogota).UTC()
+	}
+	closedEntry := func(in, out time.Time, lunch int) domain.TimeClockEntry {
+		return domain.TimeClockEntry{EmployeeID: 7, StoreID: 10, ClockIn: in, ClockOut: &out, LunchMinutes: lunch, Status: domain.TimeClockClosed}
+	}
+
+	clock := &MockTimeClockRepository{}
+	for _, entry := range []domain.TimeClockEntry{
+		closedEntry(local(3, 7, 55), local(3, 17, 30), 60),
+		{EmployeeID: 7, StoreID: 10, ClockIn: local(4, 8, 0), Status: domain.TimeClockMissedOut},
+		// A split day without a shift shares its ordinary hours
+		closedEntry(local(5, 8, 0), local(5, 12, 0), 0),
+		closedEntry(local(5, 13, 0), local(5, 18, 0), 0),
+	} {
+		entry := entry
+		clock.Create(&entry)
+	}
+	assigned := &MockAssignedShiftRepository{shifts: []domain.AssignedShift{
+		{EmployeeID: 7, StoreID: 10, Date: "2025-03-03", StartTime: "08:00", EndTime: "17:00", LunchMinutes: 60},
+		{EmployeeID: 7, StoreID: 10, Date: "2025-03-04", StartTime: "08:00", EndTime: "17:00", LunchMinutes: 60},
+	}}
+	workConfig := &MockWorkConfigRepository{config: domain.WorkConfig{DiurnalStart: "06:00", DiurnalEnd: "21:00", DailyOrdinaryHours: 8}}
+
+	stores := newTestStores()
+	holidays := NewHolidayCalendarResolver(stores, &MockFranchiseRepository{}, &MockHolidayRepository{})
+	tenant := NewTenantGuard(&MockTenantRepository{employeeFranchises: map[int]int{7: 1}})
+	uc := NewEmployeeHoursUseCase(assigned, repotesting.NewMockAbsenceRepository(), repotesting.NewMockNoveltyRepository(),
+		&MockUserRepository{}, workConfig, clock, holidays, tenant).(*employeeHoursUseCase)
+	uc.now = func() time.Time { return time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC) }
+
+	summary, err := uc.GetAttendanceSummary(franchiseScope(1), 7, 2025, 3)
+	if err != nil {
+		t.Fatalf("GetAttendanceSummary: unexpected error %v", err)
+	}
+	if summary.PlannedHours != 16 || summary.ActualHours != 17.58 || summary.VarianceHours != 1.58 || summary.MissedPunches != 1 {
+		t.Errorf("Unexpected totals %+v", summary)
+	}
+	if len(summary.Days) != 3 {
+		t.Fatalf("Expected 3 days, got %+v", summary.Days)
+	}
+	if day := summary.Days[0]; day.Date != "2025-03-03" || day.Variance != 0.58 {
+		t.Errorf("Expected 35 minutes over the plan on Mar 3, got %+v", day)
+	}
+	if day := summary.Days[1]; day.ActualHours != 0 || day.MissedPunches != 1 {
+		t.Errorf("Expected the missed clock-out not to count on Mar 4, got %+v", day)
+	}
+	if summary.Planned.Ordinary.OrdinaryDiurnal != 16 || summary.Planned.Ordinary.DiurnalExtra != 0 {
+		t.Errorf("Unexpected planned block %+v", summary.Planned.Ordinary)
+	}
+	if summary.Actual.Ordinary.OrdinaryDiurnal != 16 || summary.Actual.Ordinary.DiurnalExtra != 1.58 {
+		t.Errorf("Unexpected actual block %+v", summary.Actual.Ordinary)
+	}
+}
+
+func TestEmployeeHoursUseCase_MonthlySummaryByPeriods(t *testing.T) {
+	assigned := &MockAssignedShiftRepository{shifts: []domain.AssignedShift{
+		{EmployeeID: 7, StoreID: 10, Date: "2025-03-04", StartTime: "08:00", EndTime: "17:00", LunchMinutes: 60},
+		{EmployeeID: 7, StoreID: 10, Date: "2025-03-18", StartTime: "08:00", EndTime: "17:00", LunchMinutes: 60},
+		{EmployeeID: 7, StoreID: 10, Date: "2025-03-19", StartTime: "08:00", EndTime: "17:00", LunchMinutes: 60},
+	}}
+	workConfig := &MockWorkConfigRepository{config: domain.WorkConfig{DiurnalStart: "06:00", DiurnalEnd: "21:00", DailyOrdinaryHours: 8}}
+
+	stores := newTestStores()
+	holidays := NewHolidayCalendarResolver(stores, &MockFranchiseRepository{}, &MockHolidayRepository{})
+	tenant := NewTenantGuard(&MockTenantRepository{employeeFranchises: map[int]int{7: 1}})
+	uc := NewEmployeeHoursUseCase(assigned, repotesting.NewMockAbsenceRepository(), repotesting.NewMockNoveltyRepository(),
+		&MockUserRepository{}, workConfig, &MockTimeClockRepository{}, holidays, tenant)
+
+	// A new configuration takes effect on March 16
+	split := time.Date(2025, 3, 16, 0, 0, 0, 0, time.UTC)
+	summaries, err := uc.GetMonthlySummaryByPeriods(franchiseScope(1), 7, 2025, 3, []time.Time{split})
+	if err != nil {
+		t.Fatalf("GetMonthlySummaryByPeriods: unexpected error %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("Expected 2 periods, got %d", len(summaries))
+	}
+	if hours := summaries[0].Ordinary.OrdinaryDiurnal; hours != 8 {
+		t.Errorf("Expected 8 hours before March 16, got %.2f", hours)
+	}
+	if hours := summaries[1].Ordinary.OrdinaryDiurnal; hours != 16 {
+		t.Errorf("Expected 16 hours from March 16, got %.2f", hours)
+	}
+
+	outside := time.Date(2025, 4, 2, 0, 0, 0, 0, time.UTC)
+	if _, err := uc.GetMonthlySummaryByPeriods(franchiseScope(1), 7, 2025, 3, []time.Time{outside}); domainStatus(t, err) != 400 {
+		t.Errorf("Expected a period start outside the month to be rejected, got: %v", err)
+	}
+}
+
+func TestEmployeeHoursUseCase_ShiftsUseTheirStoreCalendar(t *testing.T) {
+	stores := repotesting.NewMockStoreRepository()
+	stores.SeedData([]domain.Store{
+		{BaseEntity: domain.BaseEntity{ID: 10}, FranchiseID: 1, Country: "CO"},
+		{BaseEntity: domain.BaseEntity{ID: 30}, FranchiseID: 1, Country: "EC"},
+	})
+	stores.AssignUser(7, 10)
+	stores.AssignUser(7, 30)
+	quito := uint(30)
+	holidayRepo := &MockHolidayRepository{holidays: []domain.Holiday{
+		{Date: "2025-03-12", Name: "Cierre Quito", Scope: domain.HolidayScopeStore, StoreID: &quito},
+	}}
+	assigned := &MockAssignedShiftRepository{shifts: []domain.AssignedShift{
+		{EmployeeID: 7, StoreID: 30, Date: "2025-03-12", StartTime: "08:00", EndTime: "17:00", LunchMinutes: 60},
+		{EmployeeID: 7, StoreID: 10, Date: "2025-03-13", StartTime: "08:00", EndTime: "17:00", LunchMinutes: 60},
+	}}
+	workConfig := &MockWorkConfigRepository{config: domain.WorkConfig{DiurnalStart: "06:00", DiurnalEnd: "21:00", DailyOrdinaryHours: 8}}
+
+	holidays := NewHolidayCalendarResolver(stores, &MockFranchiseRepository{}, holidayRepo)
+	tenant := NewTenantGuard(&MockTenantRepository{employeeFranchises: map[int]int{7: 1}})
+	uc := NewEmployeeHoursUseCase(assigned, repotesting.NewMockAbsenceRepository(), repotesting.NewMockNoveltyRepository(),
+		&MockUserRepository{}, workConfig, &MockTimeClockRepository{}, holidays, tenant)
+
+	// The employee works in two countries: each shift is classified by its own store
+	summary, err := uc.GetMonthlySummary(franchiseScope(1), 7, 2025, 3)
+	if err != nil {
+		t.Fatalf("GetMonthlySummary: unexpected error %v", err)
+	}
+	if summary.Holiday.OrdinaryDiurnal != 8 || summary.Ordinary.OrdinaryDiurnal != 8 {
+		t.Errorf("Expected 8 holiday and 8 ordinary hours, got %+v / %+v", summary.Holiday, summary.Ordinary)
+	}
+}
@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"time"
+)
+
+const minutesPerDay = 24 * 60
+
+// AvailabilityConflict es el cruce de un turno con una franja semanal o una excepción
+// en la que el empleado no puede o prefiere no trabajar
+type AvailabilityConflict struct {
+	Date      string `json:"date"`
+	Type      string `json:"type"`   // unavailable | prefer_not
+	Source    string `json:"source"` // weekly | exception
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Detail    string `json:"detail,omitempty"` // nota de la franja o motivo de la excepción
+}
+
+// Blocking indica si el cruce impide asignar el turno sin justificarlo
+func (c AvailabilityConflict) Blocking() bool {
+	return c.Type == domain.AvailabilityUnavailable
+}
+
+// ParseClockMinutes convierte una hora "HH:MM" en minutos desde la medianoche.
+// Acepta "24:00" como final del día.
+func ParseClockMinutes(value string) (int, error) {
+	if value == "24:00" {
+		return minutesPerDay, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time: %q. Expected format: HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// ShiftAvailabilityConflicts devuelve las franjas y excepciones que se cruzan con un turno que
+// empieza en date. Un turno que cruza la medianoche se evalúa en los dos días que toca. En cada
+// día prevalecen las excepciones: una "unavailable" siempre cuenta y una "available" que cubre el
+// tramo del turno anula las franjas semanales de ese día.
+func ShiftAvailabilityConflicts(
+	date time.Time,
+	startTime, endTime string,
+	windows []domain.AvailabilityWindow,
+	exceptions []domain.AvailabilityException,
+) []AvailabilityConflict {
+	start := ParseHour(startTime)
+	end := ParseHour(endTime)
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if to <= from {
+		to += minutesPerDay
+	}
+
+	conflicts := dayAvailabilityConflicts(date, from, min(to, minutesPerDay), windows, exceptions)
+	if to > minutesPerDay {
+		next := date.AddDate(0, 0, 1)
+		conflicts = append(conflicts, dayAvailabilityConflicts(next, 0, to-minutesPerDay, windows, exceptions)...)
+	}
+	return conflicts
+}
+
+// dayAvailabilityConflicts evalúa el tramo [from, to) en minutos de un día
+func dayAvailabilityConflicts(
+	day time.Time,
+	from, to int,
+	windows []domain.AvailabilityWindow,
+	exceptions []domain.AvailabilityException,
+) []AvailabilityConflict {
+	dayKey := day.Format("2006-01-02")
+
+	var conflicts []AvailabilityConflict
+	overridden := false
+	for _, e := range exceptions {
+		if e.Date != dayKey {
+			continue
+		}
+		exFrom, exTo := 0, minutesPerDay
+		if !e.WholeDay() {
+			exFrom, _ = ParseClockMinutes(e.StartTime)
+			exTo, _ = ParseClockMinutes(e.EndTime)
+		}
+
+		switch e.Type {
+		case domain.AvailabilityUnavailable:
+			if from < exTo && exFrom < to {
+				conflicts = append(conflicts, AvailabilityConflict{
+					Date:      dayKey,
+					Type:      e.Type,
+					Source:    "exception",
+					StartTime: formatClockMinutes(exFrom),
+					EndTime:   formatClockMinutes(exTo),
+					Detail:    e.Reason,
+				})
+			}
+		case domain.AvailabilityAvailable:
+			if exFrom <= from && to <= exTo {
+				overridden = true
+			}
+		}
+	}
+	if overridden {
+		return conflicts
+	}
+
+	for _, w := range windows {
+		if w.Weekday != int(day.Weekday()) {
+			continue
+		}
+		wFrom, _ := ParseClockMinutes(w.StartTime)
+		wTo, _ := ParseClockMinutes(w.EndTime)
+		if from < wTo && wFrom < to {
+			conflicts = append(conflicts, AvailabilityConflict{
+				Date:      dayKey,
+				Type:      w.Type,
+				Source:    "weekly",
+				StartTime: w.StartTime,
+				EndTime:   w.EndTime,
+				Detail:    w.Note,
+			})
+		}
+	}
+	return conflicts
+}
+
+func formatClockMinutes(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
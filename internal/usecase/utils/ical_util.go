@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ICalEvent Evento VEVENT de un calendario iCalendar (RFC 5545).
+// Un evento de día completo usa solo la fecha de Start y End (exclusivo).
+type ICalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Categories  []string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+}
+
+// ICalendar Calendario iCalendar con su nombre visible y sus eventos
+type ICalendar struct {
+	Name   string
+	Events []ICalEvent
+}
+
+// Render Serializa el calendario en formato RFC 5545: líneas CRLF, texto escapado
+// y líneas de más de 75 octetos plegadas
+func (c ICalendar) Render(now time.Time) string {
+	var b strings.Builder
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	writeICalLine(&b, "BEGIN:VCALENDAR")
+	writeICalLine(&b, "VERSION:2.0")
+	writeICalLine(&b, "PRODID:-//Loopi//Loopi API//ES")
+	writeICalLine(&b, "CALSCALE:GREGORIAN")
+	writeICalLine(&b, "METHOD:PUBLISH")
+	writeICalLine(&b, "X-WR-CALNAME:"+escapeICalText(c.Name))
+
+	for _, event := range c.Events {
+		writeICalLine(&b, "BEGIN:VEVENT")
+		writeICalLine(&b, "UID:"+event.UID)
+		writeICalLine(&b, "DTSTAMP:"+stamp)
+		if event.AllDay {
+			writeICalLine(&b, "DTSTART;VALUE=DATE:"+event.Start.Format("20060102"))
+			writeICalLine(&b, "DTEND;VALUE=DATE:"+event.End.Format("20060102"))
+			writeICalLine(&b, "TRANSP:TRANSPARENT")
+		} else {
+			writeICalLine(&b, "DTSTART:"+event.Start.UTC().Format("20060102T150405Z"))
+			writeICalLine(&b, "DTEND:"+event.End.UTC().Format("20060102T150405Z"))
+		}
+		writeICalLine(&b, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&b, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		if len(event.Categories) > 0 {
+			categories := make([]string, len(event.Categories))
+			for i, category := range event.Categories {
+				categories[i] = escapeICalText(category)
+			}
+			writeICalLine(&b, "CATEGORIES:"+strings.Join(categories, ","))
+		}
+		writeICalLine(&b, "END:VEVENT")
+	}
+
+	writeICalLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+// AllDayICalEvent Evento de día completo en date
+func AllDayICalEvent(uid, summary string, date time.Time) ICalEvent {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return ICalEvent{
+		UID:     uid,
+		Summary: summary,
+		Start:   day,
+		End:     day.AddDate(0, 0, 1),
+		AllDay:  true,
+	}
+}
+
+// ShiftICalEvent Evento de un turno en date ("YYYY-MM-DD") de start a end ("HH:MM") en la zona
+// location; si end no es posterior a start, el turno termina al día siguiente
+func ShiftICalEvent(uid, summary, date, start, end string, location *time.Location) (ICalEvent, error) {
+	startsAt, err := time.ParseInLocation("2006-01-02 15:04", date+" "+start, location)
+	if err != nil {
+		return ICalEvent{}, fmt.Errorf("invalid shift start %s %s: %w", date, start, err)
+	}
+	endsAt, err := time.ParseInLocation("2006-01-02 15:04", date+" "+end, location)
+	if err != nil {
+		return ICalEvent{}, fmt.Errorf("invalid shift end %s %s: %w", date, end, err)
+	}
+	if !endsAt.After(startsAt) {
+		endsAt = endsAt.AddDate(0, 0, 1)
+	}
+
+	return ICalEvent{
+		UID:     uid,
+		Summary: summary,
+		Start:   startsAt,
+		End:     endsAt,
+	}, nil
+}
+
+// escapeICalText Escapa un valor TEXT (RFC 5545 §3.3.11)
+func escapeICalText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// writeICalLine Escribe una línea de contenido plegada a 75 octetos (RFC 5545 §3.1)
+// sin partir caracteres UTF-8
+func writeICalLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Start(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = 74 // the leading space of a continuation line counts
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isUTF8Start(c byte) bool {
+	return c&0xC0 != 0x80
+}
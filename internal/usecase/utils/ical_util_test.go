@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShiftICalEvent_Overnight(t *testing.T) {
+	bogota := time.FixedZone("America/Bogota", -5*60*60)
+
+	event, err := ShiftICalEvent("shift-1@loopi-api", "Night", "2025-06-14", "22:00", "06:00", bogota)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// 22:00 to 06:00 local ends on the next day; UTC-5 puts both ends 5 hours later
+	if got := event.Start.UTC().Format("2006-01-02 15:04"); got != "2025-06-15 03:00" {
+		t.Errorf("Expected start 2025-06-15 03:00 UTC, got %s", got)
+	}
+	if got := event.End.UTC().Format("2006-01-02 15:04"); got != "2025-06-15 11:00" {
+		t.Errorf("Expected end 2025-06-15 11:00 UTC, got %s", got)
+	}
+
+	if _, err := ShiftICalEvent("shift-2@loopi-api", "Bad", "2025-06-14", "25:00", "06:00", bogota); err == nil {
+		t.Error("Expected error for invalid start time")
+	}
+}
+
+func TestICalendarRender(t *testing.T) {
+	holiday := AllDayICalEvent("holiday-CO-20250720-0@loopi-api", "Independence Day; Colombia, 1810", time.Date(2025, 7, 20, 0, 0, 0, 0, time.UTC))
+	long := AllDayICalEvent("absence-7@loopi-api", "Absence: "+strings.Repeat("cita médica ", 10), time.Date(2025, 7, 21, 0, 0, 0, 0, time.UTC))
+
+	out := ICalendar{Name: "Holidays CO", Events: []ICalEvent{holiday, long}}.
+		Render(time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTAMP:20250701T120000Z\r\n",
+		"DTSTART;VALUE=DATE:20250720\r\n",
+		"DTEND;VALUE=DATE:20250721\r\n",
+		`SUMMARY:Independence Day\; Colombia\, 1810` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q", want)
+		}
+	}
+
+	// Every line fits in 75 octets, continuation lines start with a space and no UTF-8 rune is split
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("Line longer than 75 octets: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:Absence: "+strings.Repeat("cita médica ", 10)) {
+		t.Error("Expected folded summary to unfold to the original text")
+	}
+}
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"fmt"
+	"loopi-api/internal/domain"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LaborLimits Límites que no dependen de la configuración laboral vigente.
+// El máximo semanal sale de WorkConfig.WeeklyOrdinaryHours en vigor cada semana.
+type LaborLimits struct {
+	MinRestHours float64 // descanso mínimo entre el fin de un turno y el inicio del siguiente
+}
+
+// DefaultLaborLimits Límites por defecto: 10 h de descanso entre turnos
+func DefaultLaborLimits() LaborLimits {
+	return LaborLimits{
+		MinRestHours: 10,
+	}
+}
+
+// EmployeeSchedule Turnos de un empleado que se validan juntos contra las normas laborales.
+// Si hay turnos propuestos, solo se informan las semanas y los descansos entre turnos que
+// incluyen alguno, de modo que una infracción ya existente no bloquea turnos ajenos a ella.
+type EmployeeSchedule struct {
+	EmployeeID int
+	Shifts     []domain.AssignedShift // turnos ya asignados
+	Proposed   []domain.AssignedShift // turnos por asignar
+}
+
+// allShifts Turnos asignados y propuestos
+func (s EmployeeSchedule) allShifts() []domain.AssignedShift {
+	shifts := make([]domain.AssignedShift, 0, len(s.Shifts)+len(s.Proposed))
+	shifts = append(shifts, s.Shifts...)
+	return append(shifts, s.Proposed...)
+}
+
+// reportsWeek Indica si se informan las infracciones de la semana: sin turnos propuestos
+// se informan todas; con ellos, solo las de semanas con algún turno propuesto
+func (s EmployeeSchedule) reportsWeek(week string) bool {
+	if len(s.Proposed) == 0 {
+		return true
+	}
+	for _, shift := range s.Proposed {
+		day, err := time.Parse("2006-01-02", shift.Date)
+		if err == nil && WeekStart(day).Format("2006-01-02") == week {
+			return true
+		}
+	}
+	return false
+}
+
+// ShiftWorkedHours Horas trabajadas en un turno asignado descontando el almuerzo
+func ShiftWorkedHours(shift domain.AssignedShift) float64 {
+	start := ParseHour(shift.StartTime)
+	end := ParseHour(shift.EndTime)
+	return DurationInHours(start, end) - float64(shift.LunchMinutes)/60.0
+}
+
+// ShiftBounds Inicio y fin reales del turno; si termina antes de empezar, cruza la medianoche
+func ShiftBounds(shift domain.AssignedShift) (time.Time, time.Time, error) {
+	day, err := time.Parse("2006-01-02", shift.Date)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid shift date %q", shift.Date)
+	}
+	start := ParseHour(shift.StartTime)
+	end := ParseHour(shift.EndTime)
+
+	startAt := day.Add(time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute)
+	endAt := startAt.Add(time.Duration(DurationInHours(start, end) * float64(time.Hour)))
+	return startAt, endAt, nil
+}
+
+// WeekStart Lunes de la semana a la que pertenece la fecha
+func WeekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7 // lunes = 0
+	return time.Date(date.Year(), date.Month(), date.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// CheckWeeklyHours Devuelve las semanas en que se superan las horas ordinarias máximas
+// vigentes al inicio de cada semana
+func CheckWeeklyHours(schedule EmployeeSchedule, configs WorkConfigTimeline) error {
+	hoursByWeek := make(map[string]float64)
+	for _, shift := range schedule.allShifts() {
+		day, err := time.Parse("2006-01-02", shift.Date)
+		if err != nil {
+			return fmt.Errorf("invalid shift date %q", shift.Date)
+		}
+		hoursByWeek[WeekStart(day).Format("2006-01-02")] += ShiftWorkedHours(shift)
+	}
+
+	var exceeded []string
+	for week, hours := range hoursByWeek {
+		if !schedule.reportsWeek(week) {
+			continue
+		}
+		weekStart, _ := time.Parse("2006-01-02", week)
+		limit := configs.At(weekStart).WeeklyOrdinaryHours
+		if RoundTo2(hours) > limit {
+			exceeded = append(exceeded, fmt.Sprintf("week of %s has %.2fh (max %.2fh)", week, RoundTo2(hours), limit))
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+
+	sort.Strings(exceeded)
+	return fmt.Errorf("employee %d exceeds the weekly ordinary hours: %s",
+		schedule.EmployeeID, strings.Join(exceeded, "; "))
+}
+
+// CheckWeeklyRestDay Devuelve las semanas (lunes a domingo) en que el empleado trabaja los 7 días
+func CheckWeeklyRestDay(schedule EmployeeSchedule) error {
+	daysByWeek := make(map[string]map[string]bool)
+	for _, shift := range schedule.allShifts() {
+		day, err := time.Parse("2006-01-02", shift.Date)
+		if err != nil {
+			return fmt.Errorf("invalid shift date %q", shift.Date)
+		}
+		week := WeekStart(day).Format("2006-01-02")
+		if daysByWeek[week] == nil {
+			daysByWeek[week] = make(map[string]bool)
+		}
+		daysByWeek[week][shift.Date] = true
+	}
+
+	var withoutRest []string
+	for week, days := range daysByWeek {
+		if len(days) >= 7 && schedule.reportsWeek(week) {
+			withoutRest = append(withoutRest, week)
+		}
+	}
+	if len(withoutRest) == 0 {
+		return nil
+	}
+
+	sort.Strings(withoutRest)
+	return fmt.Errorf("employee %d has no rest day in the week(s) of: %s",
+		schedule.EmployeeID, strings.Join(withoutRest, ", "))
+}
+
+// CheckMinimumRest Devuelve los pares de turnos consecutivos con menos descanso del mínimo
+func CheckMinimumRest(schedule EmployeeSchedule, limits LaborLimits) error {
+	type bounds struct {
+		date       string
+		start, end time.Time
+		reported   bool
+	}
+
+	// Sin turnos propuestos se informan todos los descansos; con ellos, los que tocan alguno
+	ordered := make([]bounds, 0, len(schedule.Shifts)+len(schedule.Proposed))
+	for i, shift := range schedule.allShifts() {
+		start, end, err := ShiftBounds(shift)
+		if err != nil {
+			return err
+		}
+		reported := len(schedule.Proposed) == 0 || i >= len(schedule.Shifts)
+		ordered = append(ordered, bounds{date: shift.Date, start: start, end: end, reported: reported})
+	}
+	sort.Slice(ordered, func(i, j int) bool { return ordered[i].start.Before(ordered[j].start) })
+
+	var short []string
+	for i := 1; i < len(ordered); i++ {
+		if !ordered[i-1].reported && !ordered[i].reported {
+			continue
+		}
+		rest := ordered[i].start.Sub(ordered[i-1].end).Hours()
+		if rest < limits.MinRestHours {
+			short = append(short, fmt.Sprintf("%s → %s (%.2fh)", ordered[i-1].date, ordered[i].date, RoundTo2(rest)))
+		}
+	}
+	if len(short) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("employee %d rests less than %.2fh between shifts: %s",
+		schedule.EmployeeID, limits.MinRestHours, strings.Join(short, "; "))
+}
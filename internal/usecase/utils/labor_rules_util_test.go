@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"loopi-api/internal/domain"
+)
+
+// consecutiveShifts builds one shift per day starting at the given date
+func consecutiveShifts(from string, days int, start, end string, lunch int) []domain.AssignedShift {
+	first, _ := time.Parse("2006-01-02", from)
+	shifts := make([]domain.AssignedShift, 0, days)
+	for i := 0; i < days; i++ {
+		shifts = append(shifts, domain.AssignedShift{
+			EmployeeID:   1,
+			Date:         first.AddDate(0, 0, i).Format("2006-01-02"),
+			StartTime:    start,
+			EndTime:      end,
+			LunchMinutes: lunch,
+		})
+	}
+	return shifts
+}
+
+func TestCheckWeeklyHours(t *testing.T) {
+	configs := NewWorkConfigTimeline([]domain.WorkConfig{
+		{WeeklyOrdinaryHours: 48},
+		{EffectiveFrom: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), WeeklyOrdinaryHours: 44},
+	})
+
+	// Monday 2025-06-02: 6 × 7h20 = 44h, within the limit
+	ok := EmployeeSchedule{EmployeeID: 1, Shifts: consecutiveShifts("2025-06-02", 6, "08:00", "16:20", 60)}
+	if err := CheckWeeklyHours(ok, configs); err != nil {
+		t.Errorf("Expected no violation, got: %v", err)
+	}
+
+	// 6 × 8h = 48h exceeds the limit
+	over := EmployeeSchedule{EmployeeID: 1, Shifts: consecutiveShifts("2025-06-02", 6, "08:00", "17:00", 60)}
+	if err := CheckWeeklyHours(over, configs); err == nil {
+		t.Error("Expected weekly hours violation")
+	}
+
+	// The same 48h week was legal while the 48h limit was in force
+	before := EmployeeSchedule{EmployeeID: 1, Shifts: consecutiveShifts("2025-05-19", 6, "08:00", "17:00", 60)}
+	if err := CheckWeeklyHours(before, configs); err != nil {
+		t.Errorf("Expected no violation under the previous limit, got: %v", err)
+	}
+}
+
+func TestCheckWeeklyRestDay(t *testing.T) {
+	// Monday to Sunday without a day off
+	noRest := EmployeeSchedule{EmployeeID: 1, Shifts: consecutiveShifts("2025-06-02", 7, "08:00", "12:00", 0)}
+	if err := CheckWeeklyRestDay(noRest); err == nil {
+		t.Error("Expected weekly rest day violation")
+	}
+
+	// Seven days spread over two weeks (Wednesday to Tuesday) is allowed
+	split := EmployeeSchedule{EmployeeID: 1, Shifts: consecutiveShifts("2025-06-04", 7, "08:00", "12:00", 0)}
+	if err := CheckWeeklyRestDay(split); err != nil {
+		t.Errorf("Expected no violation, got: %v", err)
+	}
+}
+
+func TestCheckMinimumRest(t *testing.T) {
+	limits := DefaultLaborLimits()
+
+	// Close at 22:00 and open at 06:00 the next day leaves only 8h
+	shifts := []domain.AssignedShift{
+		{EmployeeID: 1, Date: "2025-06-02", StartTime: "14:00", EndTime: "22:00"},
+		{EmployeeID: 1, Date: "2025-06-03", StartTime: "06:00", EndTime: "14:00"},
+	}
+	if err := CheckMinimumRest(EmployeeSchedule{EmployeeID: 1, Shifts: shifts}, limits); err == nil {
+		t.Error("Expected minimum rest violation")
+	}
+
+	// An overnight shift ending 06:00 followed by a 18:00 start leaves 12h
+	overnight := []domain.AssignedShift{
+		{EmployeeID: 1, Date: "2025-06-02", StartTime: "22:00", EndTime: "06:00"},
+		{EmployeeID: 1, Date: "2025-06-03", StartTime: "18:00", EndTime: "23:00"},
+	}
+	if err := CheckMinimumRest(EmployeeSchedule{EmployeeID: 1, Shifts: overnight}, limits); err != nil {
+		t.Errorf("Expected no violation, got: %v", err)
+	}
+}
+
+func TestLaborRules_OnlyReportProposedShifts(t *testing.T) {
+	configs := NewWorkConfigTimeline([]domain.WorkConfig{{WeeklyOrdinaryHours: 44}})
+	limits := DefaultLaborLimits()
+
+	// The week of 2025-06-02 already breaks every rule: 7 × 9h with 6h rest after the first day
+	stored := consecutiveShifts("2025-06-02", 7, "08:00", "17:00", 0)
+	stored[0].EndTime = "02:00"
+
+	// A shift in the following week is not blocked by them
+	unrelated := EmployeeSchedule{EmployeeID: 1, Shifts: stored, Proposed: consecutiveShifts("2025-06-11", 1, "08:00", "17:00", 0)}
+	if err := CheckWeeklyHours(unrelated, configs); err != nil {
+		t.Errorf("Expected the existing weekly hours violation to be ignored, got: %v", err)
+	}
+	if err := CheckWeeklyRestDay(unrelated); err != nil {
+		t.Errorf("Expected the existing rest day violation to be ignored, got: %v", err)
+	}
+	if err := CheckMinimumRest(unrelated, limits); err != nil {
+		t.Errorf("Expected the existing rest gap violation to be ignored, got: %v", err)
+	}
+
+	// A shift in the violating week, or right after its last shift, is reported
+	sameWeek := EmployeeSchedule{EmployeeID: 1, Shifts: stored[1:6], Proposed: stored[6:]}
+	if err := CheckWeeklyHours(sameWeek, configs); err == nil {
+		t.Error("Expected the proposed shift's week to be reported")
+	}
+	adjacent := EmployeeSchedule{EmployeeID: 1, Shifts: stored, Proposed: []domain.AssignedShift{
+		{EmployeeID: 1, Date: "2025-06-09", StartTime: "00:00", EndTime: "06:00"},
+	}}
+	if err := CheckMinimumRest(adjacent, limits); err == nil {
+		t.Error("Expected the rest gap before the proposed shift to be reported")
+	}
+}
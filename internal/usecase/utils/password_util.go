@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"unicode"
+)
+
+// MinPasswordLength Longitud mínima de una contraseña elegida por el usuario
+const MinPasswordLength = 8
+
+// temporaryPasswordAlphabet Caracteres de las contraseñas temporales (sin 0/O ni 1/l/I ambiguos)
+const temporaryPasswordAlphabet = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// ValidatePasswordStrength Exige MinPasswordLength caracteres con al menos una letra y un número
+func ValidatePasswordStrength(password string) error {
+	if len(password) < MinPasswordLength {
+		return fmt.Errorf("password must be at least %d characters long", MinPasswordLength)
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	if !hasLetter || !hasDigit {
+		return fmt.Errorf("password must contain at least one letter and one number")
+	}
+	return nil
+}
+
+// GenerateTemporaryPassword Contraseña aleatoria de 12 caracteres que cumple ValidatePasswordStrength;
+// se entrega al nuevo empleado, que debe cambiarla en su primer login
+func GenerateTemporaryPassword() (string, error) {
+	max := big.NewInt(int64(len(temporaryPasswordAlphabet)))
+	for {
+		buf := make([]byte, 12)
+		for i := range buf {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			buf[i] = temporaryPasswordAlphabet[n.Int64()]
+		}
+		if password := string(buf); ValidatePasswordStrength(password) == nil {
+			return password, nil
+		}
+	}
+}
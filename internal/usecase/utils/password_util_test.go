@@ -0,0 +1,36 @@
+package utils
+
+import "testing"
+
+func TestValidatePasswordStrength(t *testing.T) {
+	tests := []struct {
+		password string
+		valid    bool
+	}{
+		{"loopi2025", true},
+		{"short1", false},      // too short
+		{"onlyletters", false}, // no number
+		{"1234567890", false},  // no letter
+	}
+
+	for _, tt := range tests {
+		if err := ValidatePasswordStrength(tt.password); (err == nil) != tt.valid {
+			t.Errorf("ValidatePasswordStrength(%q): expected valid=%v, got %v", tt.password, tt.valid, err)
+		}
+	}
+}
+
+func TestGenerateTemporaryPassword(t *testing.T) {
+	first, err := GenerateTemporaryPassword()
+	if err != nil {
+		t.Fatalf("GenerateTemporaryPassword: unexpected error %v", err)
+	}
+	second, _ := GenerateTemporaryPassword()
+
+	if err := ValidatePasswordStrength(first); err != nil {
+		t.Errorf("Expected a strong temporary password, got %q: %v", first, err)
+	}
+	if first == second {
+		t.Error("Expected different temporary passwords")
+	}
+}
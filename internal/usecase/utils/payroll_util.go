@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"loopi-api/internal/domain"
+	"time"
+)
+
+// MonthlyOrdinaryHours Horas ordinarias mensuales: jornada semanal × 5 (48 h → 240, 44 h → 220)
+func MonthlyOrdinaryHours(workConfig domain.WorkConfig) float64 {
+	return workConfig.WeeklyOrdinaryHours * 5
+}
+
+// HourlyRate Valor de la hora ordinaria a partir del salario mensual
+func HourlyRate(salary float64, workConfig domain.WorkConfig) float64 {
+	hours := MonthlyOrdinaryHours(workConfig)
+	if hours <= 0 {
+		return 0
+	}
+	return salary / hours
+}
+
+// payrollBucket Horas de un concepto y el multiplicador sobre la hora ordinaria
+type payrollBucket struct {
+	concept    string
+	hours      float64
+	multiplier float64
+}
+
+// CalculatePayroll Liquida el mes de un empleado a partir de su resumen de horas.
+// La jornada y los recargos son los vigentes al inicio del periodo. Las horas ordinarias
+// ya están pagadas en el salario, así que de ellas solo se liquida el recargo (nocturno,
+// dominical/festivo o ambos); las horas extra en domingo o festivo suman el recargo
+// dominical/festivo al multiplicador de la extra.
+func CalculatePayroll(summary domain.EmployeeHourSummary, salary float64, workConfig domain.WorkConfig, config domain.PayrollConfig) domain.EmployeePayroll {
+	rate := HourlyRate(salary, workConfig)
+
+	payroll := newEmployeePayroll(summary, salary, workConfig)
+	items, surcharges := surchargeLineItems(summary, rate, config)
+	payroll.LineItems = append(payroll.LineItems, items...)
+
+	payroll.TotalSurcharges = RoundTo2(surcharges)
+	payroll.Total = RoundTo2(payroll.Salary + payroll.TotalSurcharges)
+	return payroll
+}
+
+// PayrollSegment Tramo del mes con una misma jornada y unos mismos recargos, con el resumen
+// de las horas trabajadas entre From y To (inclusive)
+type PayrollSegment struct {
+	From, To   time.Time
+	Summary    domain.EmployeeHourSummary
+	WorkConfig domain.WorkConfig
+	Config     domain.PayrollConfig
+}
+
+// CalculateSplitPayroll Liquida un mes en el que la jornada o los recargos entran en vigor a
+// mitad de mes. El salario base es el del mes; los recargos y horas extra de cada tramo se
+// liquidan con su propio valor hora y sus multiplicadores. Con un solo tramo equivale a CalculatePayroll.
+func CalculateSplitPayroll(salary float64, segments []PayrollSegment) domain.EmployeePayroll {
+	if len(segments) == 0 {
+		return domain.EmployeePayroll{}
+	}
+
+	payroll := newEmployeePayroll(segments[0].Summary, salary, segments[0].WorkConfig)
+	total := 0.0
+	for _, segment := range segments {
+		rate := HourlyRate(salary, segment.WorkConfig)
+		items, surcharges := surchargeLineItems(segment.Summary, rate, segment.Config)
+
+		payroll.LineItems = append(payroll.LineItems, items...)
+		payroll.Periods = append(payroll.Periods, domain.PayrollPeriod{
+			From:            segment.From.Format("2006-01-02"),
+			To:              segment.To.Format("2006-01-02"),
+			MonthlyHours:    RoundTo2(MonthlyOrdinaryHours(segment.WorkConfig)),
+			HourlyRate:      RoundTo2(rate),
+			LineItems:       items,
+			TotalSurcharges: RoundTo2(surcharges),
+		})
+		total += surcharges
+	}
+
+	payroll.TotalSurcharges = RoundTo2(total)
+	payroll.Total = RoundTo2(payroll.Salary + payroll.TotalSurcharges)
+	return payroll
+}
+
+// newEmployeePayroll Liquidación con el salario base del mes y sin recargos
+func newEmployeePayroll(summary domain.EmployeeHourSummary, salary float64, workConfig domain.WorkConfig) domain.EmployeePayroll {
+	return domain.EmployeePayroll{
+		Employee:     summary.Employee,
+		Period:       summary.Period,
+		Salary:       RoundTo2(salary),
+		MonthlyHours: RoundTo2(MonthlyOrdinaryHours(workConfig)),
+		HourlyRate:   RoundTo2(HourlyRate(salary, workConfig)),
+		LineItems: []domain.PayrollLineItem{{
+			Concept:    "base_salary",
+			Hours:      RoundTo2(MonthlyOrdinaryHours(workConfig)),
+			Multiplier: 1,
+			Amount:     RoundTo2(salary),
+		}},
+	}
+}
+
+// surchargeLineItems Recargos y horas extra de un resumen de horas al valor hora rate
+func surchargeLineItems(summary domain.EmployeeHourSummary, rate float64, config domain.PayrollConfig) ([]domain.PayrollLineItem, float64) {
+	buckets := []payrollBucket{
+		{"ordinary_nocturnal_surcharge", summary.Ordinary.OrdinaryNocturnal, config.NightSurcharge},
+		{"sunday_diurnal_surcharge", summary.Sunday.OrdinaryDiurnal, config.SundayHolidaySurcharge},
+		{"sunday_nocturnal_surcharge", summary.Sunday.OrdinaryNocturnal, config.SundayHolidaySurcharge + config.NightSurcharge},
+		{"holiday_diurnal_surcharge", summary.Holiday.OrdinaryDiurnal, config.SundayHolidaySurcharge},
+		{"holiday_nocturnal_surcharge", summary.Holiday.OrdinaryNocturnal, config.SundayHolidaySurcharge + config.NightSurcharge},
+		{"ordinary_diurnal_extra", summary.Ordinary.DiurnalExtra, config.ExtraDiurnalMultiplier},
+		{"ordinary_nocturnal_extra", summary.Ordinary.NocturnalExtra, config.ExtraNocturnalMultiplier},
+		{"sunday_diurnal_extra", summary.Sunday.DiurnalExtra, config.ExtraDiurnalMultiplier + config.SundayHolidaySurcharge},
+		{"sunday_nocturnal_extra", summary.Sunday.NocturnalExtra, config.ExtraNocturnalMultiplier + config.SundayHolidaySurcharge},
+		{"holiday_diurnal_extra", summary.Holiday.DiurnalExtra, config.ExtraDiurnalMultiplier + config.SundayHolidaySurcharge},
+		{"holiday_nocturnal_extra", summary.Holiday.NocturnalExtra, config.ExtraNocturnalMultiplier + config.SundayHolidaySurcharge},
+	}
+
+	var items []domain.PayrollLineItem
+	total := 0.0
+	for _, bucket := range buckets {
+		if bucket.hours <= 0 {
+			continue
+		}
+		amount := RoundTo2(bucket.hours * bucket.multiplier * rate)
+		items = append(items, domain.PayrollLineItem{
+			Concept:    bucket.concept,
+			Hours:      RoundTo2(bucket.hours),
+			Multiplier: RoundTo2(bucket.multiplier),
+			Amount:     amount,
+		})
+		total += amount
+	}
+	return items, total
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"loopi-api/internal/domain"
+)
+
+func TestCalculatePayroll(t *testing.T) {
+	workConfig := domain.WorkConfig{WeeklyOrdinaryHours: 44}
+	config := domain.PayrollConfig{
+		NightSurcharge:           0.35,
+		SundayHolidaySurcharge:   0.75,
+		ExtraDiurnalMultiplier:   1.25,
+		ExtraNocturnalMultiplier: 1.75,
+	}
+	summary := domain.EmployeeHourSummary{
+		Employee: domain.EmployeeInfo{ID: 1, FullName: "Ana Pérez"},
+		Period:   domain.Period{Year: 2025, Month: 8},
+		Ordinary: domain.EmployeeHourBlock{OrdinaryDiurnal: 150, OrdinaryNocturnal: 10, DiurnalExtra: 4, NocturnalExtra: 2},
+		Holiday:  domain.EmployeeHourBlock{OrdinaryDiurnal: 8, NocturnalExtra: 1},
+	}
+
+	// 2.200.000 / 220 h = 10.000 por hora
+	payroll := CalculatePayroll(summary, 2200000, workConfig, config)
+
+	if payroll.HourlyRate != 10000 {
+		t.Fatalf("Expected hourly rate 10000, got %.2f", payroll.HourlyRate)
+	}
+
+	expected := map[string]float64{
+		"base_salary":                  2200000,
+		"ordinary_nocturnal_surcharge": 35000, // 10 × 0.35
+		"holiday_diurnal_surcharge":    60000, // 8 × 0.75
+		"ordinary_diurnal_extra":       50000, // 4 × 1.25
+		"ordinary_nocturnal_extra":     35000, // 2 × 1.75
+		"holiday_nocturnal_extra":      25000, // 1 × (1.75 + 0.75)
+	}
+	if len(payroll.LineItems) != len(expected) {
+		t.Fatalf("Expected %d line items, got %d: %+v", len(expected), len(payroll.LineItems), payroll.LineItems)
+	}
+	for _, item := range payroll.LineItems {
+		if amount, ok := expected[item.Concept]; !ok || amount != item.Amount {
+			t.Errorf("Unexpected line item %s: %.2f (expected %.2f)", item.Concept, item.Amount, amount)
+		}
+	}
+
+	if payroll.TotalSurcharges != 205000 {
+		t.Errorf("Expected surcharges 205000, got %.2f", payroll.TotalSurcharges)
+	}
+	if payroll.Total != 2405000 {
+		t.Errorf("Expected total 2405000, got %.2f", payroll.Total)
+	}
+}
+
+func TestCalculateSplitPayroll(t *testing.T) {
+	// The weekly hours drop from 46 to 44 and the night surcharge rises on the 16th
+	before := domain.WorkConfig{WeeklyOrdinaryHours: 46}
+	after := domain.WorkConfig{WeeklyOrdinaryHours: 44}
+	config := domain.PayrollConfig{NightSurcharge: 0.35, ExtraDiurnalMultiplier: 1.25}
+	raised := domain.PayrollConfig{NightSurcharge: 0.5, ExtraDiurnalMultiplier: 1.25}
+
+	employee := domain.EmployeeInfo{ID: 1, FullName: "Ana Pérez"}
+	period := domain.Period{Year: 2025, Month: 7}
+	segments := []PayrollSegment{
+		{
+			From:       time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+			To:         time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC),
+			Summary:    domain.EmployeeHourSummary{Employee: employee, Period: period, Ordinary: domain.EmployeeHourBlock{OrdinaryNocturnal: 10}},
+			WorkConfig: before,
+			Config:     config,
+		},
+		{
+			From:       time.Date(2025, 7, 16, 0, 0, 0, 0, time.UTC),
+			To:         time.Date(2025, 7, 31, 0, 0, 0, 0, time.UTC),
+			Summary:    domain.EmployeeHourSummary{Employee: employee, Period: period, Ordinary: domain.EmployeeHourBlock{OrdinaryNocturnal: 10, DiurnalExtra: 2}},
+			WorkConfig: after,
+			Config:     raised,
+		},
+	}
+
+	// 2.300.000 / 230 h = 10.000 por hora; 2.300.000 / 220 h = 10.454,55 por hora
+	payroll := CalculateSplitPayroll(2300000, segments)
+
+	if payroll.HourlyRate != 10000 || payroll.MonthlyHours != 230 {
+		t.Errorf("Expected the rate at the start of the month, got %.2f for %.2f h", payroll.HourlyRate, payroll.MonthlyHours)
+	}
+	if len(payroll.Periods) != 2 {
+		t.Fatalf("Expected 2 periods, got %d", len(payroll.Periods))
+	}
+	if p := payroll.Periods[0]; p.From != "2025-07-01" || p.To != "2025-07-15" || p.TotalSurcharges != 35000 {
+		t.Errorf("Unexpected first period %+v", p) // 10 × 0.35 × 10.000
+	}
+	// 10 × 0.5 × 10.454,55 + 2 × 1.25 × 10.454,55
+	if p := payroll.Periods[1]; p.HourlyRate != 10454.55 || p.TotalSurcharges != 78409.09 {
+		t.Errorf("Unexpected second period %+v", p)
+	}
+
+	if len(payroll.LineItems) != 4 {
+		t.Fatalf("Expected the base salary and 3 surcharges, got %+v", payroll.LineItems)
+	}
+	if payroll.TotalSurcharges != 113409.09 || payroll.Total != 2413409.09 {
+		t.Errorf("Unexpected totals %.2f / %.2f", payroll.TotalSurcharges, payroll.Total)
+	}
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"loopi-api/internal/domain"
+	"sort"
+	"time"
+)
+
+// RosterSlot es un turno de un día concreto con el empleado propuesto.
+// EmployeeID = 0 indica que no había empleados disponibles para cubrirlo.
+// AgainstPreference indica que el empleado había pedido no trabajar en ese horario.
+type RosterSlot struct {
+	Date              time.Time
+	DayType           DayType
+	Shift             domain.Shift
+	EmployeeID        int
+	AgainstPreference bool
+}
+
+// RosterLoad lleva la carga acumulada de un empleado durante la generación
+type RosterLoad struct {
+	Total         int
+	SundayHoliday int
+}
+
+// GenerateRoster Propone una malla que cubre cada turno activo en cada día del mes.
+// Cada empleado trabaja como máximo un turno por día; los turnos ya asignados
+// (existing) se respetan y cuentan como carga. Para repartir de forma equitativa
+// se elige siempre al empleado con menos turnos, y en domingos/festivos al que
+// lleva menos domingos/festivos. Nadie recibe un turno en el que no está disponible,
+// y los que prefieren no trabajar en ese horario solo se eligen si no queda nadie más.
+// El resultado es determinista para una misma entrada.
+func GenerateRoster(
+	days []CalendarDay,
+	shifts []domain.Shift,
+	employeeIDs []int,
+	existing []domain.AssignedShift,
+	availability map[int]domain.EmployeeAvailability,
+) ([]RosterSlot, map[int]*RosterLoad) {
+	orderedShifts := append([]domain.Shift(nil), shifts...)
+	sort.SliceStable(orderedShifts, func(i, j int) bool {
+		if orderedShifts[i].StartTime != orderedShifts[j].StartTime {
+			return orderedShifts[i].StartTime < orderedShifts[j].StartTime
+		}
+		return orderedShifts[i].ID < orderedShifts[j].ID
+	})
+
+	orderedEmployees := append([]int(nil), employeeIDs...)
+	sort.Ints(orderedEmployees)
+
+	dayTypes := make(map[string]DayType, len(days))
+	for _, day := range days {
+		dayTypes[day.Date.Format("2006-01-02")] = day.DayType
+	}
+
+	loads := make(map[int]*RosterLoad, len(orderedEmployees))
+	for _, id := range orderedEmployees {
+		loads[id] = &RosterLoad{}
+	}
+
+	// Turnos ya programados: ocupan al empleado ese día y cubren el turno
+	busy := make(map[string]map[int]bool)
+	covered := make(map[string]map[int]bool)
+	for _, a := range existing {
+		if busy[a.Date] == nil {
+			busy[a.Date] = make(map[int]bool)
+			covered[a.Date] = make(map[int]bool)
+		}
+		busy[a.Date][a.EmployeeID] = true
+		covered[a.Date][a.ShiftID] = true
+
+		if load, ok := loads[a.EmployeeID]; ok {
+			load.Total++
+			if dt, ok := dayTypes[a.Date]; ok && dt != Ordinary {
+				load.SundayHoliday++
+			}
+		}
+	}
+
+	var slots []RosterSlot
+	for _, day := range days {
+		dayKey := day.Date.Format("2006-01-02")
+		special := day.DayType != Ordinary
+
+		for _, shift := range orderedShifts {
+			if covered[dayKey][int(shift.ID)] {
+				continue
+			}
+
+			unavailable, reluctant := shiftAvailability(day.Date, shift, orderedEmployees, availability)
+			employeeID := pickEmployee(orderedEmployees, loads, busy[dayKey], unavailable, reluctant, special)
+			if employeeID != 0 {
+				if busy[dayKey] == nil {
+					busy[dayKey] = make(map[int]bool)
+				}
+				busy[dayKey][employeeID] = true
+				loads[employeeID].Total++
+				if special {
+					loads[employeeID].SundayHoliday++
+				}
+			}
+
+			slots = append(slots, RosterSlot{
+				Date:              day.Date,
+				DayType:           day.DayType,
+				Shift:             shift,
+				EmployeeID:        employeeID,
+				AgainstPreference: reluctant[employeeID],
+			})
+		}
+	}
+
+	return slots, loads
+}
+
+// pickEmployee Elige al empleado libre con menor carga (0 si no hay ninguno libre).
+// Los empleados que prefieren no trabajar en el turno van después de todos los demás.
+func pickEmployee(employees []int, loads map[int]*RosterLoad, busy, unavailable, reluctant map[int]bool, special bool) int {
+	best := 0
+	for _, id := range employees {
+		if busy[id] || unavailable[id] {
+			continue
+		}
+		if best == 0 {
+			best = id
+			continue
+		}
+		if reluctant[id] != reluctant[best] {
+			if !reluctant[id] {
+				best = id
+			}
+			continue
+		}
+		if lessLoaded(loads[id], loads[best], special) {
+			best = id
+		}
+	}
+	return best
+}
+
+// shiftAvailability Separa a los empleados que no pueden hacer el turno ese día de los que
+// preferirían no hacerlo
+func shiftAvailability(date time.Time, shift domain.Shift, employees []int, availability map[int]domain.EmployeeAvailability) (map[int]bool, map[int]bool) {
+	unavailable := make(map[int]bool)
+	reluctant := make(map[int]bool)
+	for _, id := range employees {
+		a, ok := availability[id]
+		if !ok {
+			continue
+		}
+		for _, conflict := range ShiftAvailabilityConflicts(date, shift.StartTime, shift.EndTime, a.Windows, a.Exceptions) {
+			if conflict.Blocking() {
+				unavailable[id] = true
+			} else {
+				reluctant[id] = true
+			}
+		}
+	}
+	return unavailable, reluctant
+}
+
+func lessLoaded(a, b *RosterLoad, special bool) bool {
+	if special && a.SundayHoliday != b.SundayHoliday {
+		return a.SundayHoliday < b.SundayHoliday
+	}
+	return a.Total < b.Total
+}
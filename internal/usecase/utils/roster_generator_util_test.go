@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"testing"
+
+	"loopi-api/internal/domain"
+)
+
+func createTestRosterShifts() []domain.Shift {
+	return []domain.Shift{
+		{BaseEntity: domain.BaseEntity{ID: 2}, StoreID: 1, Name: "Tarde", StartTime: "14:00", EndTime: "22:00", IsActive: true},
+		{BaseEntity: domain.BaseEntity{ID: 1}, StoreID: 1, Name: "Mañana", StartTime: "06:00", EndTime: "14:00", IsActive: true},
+	}
+}
+
+func TestGenerateRoster_CoversEveryShiftOncePerEmployeePerDay(t *testing.T) {
+	days := BuildCalendarDays(2025, 6, map[string]bool{})
+	slots, _ := GenerateRoster(days, createTestRosterShifts(), []int{10, 20, 30}, nil, nil)
+
+	if len(slots) != len(days)*2 {
+		t.Fatalf("Expected %d slots, got %d", len(days)*2, len(slots))
+	}
+
+	perDay := make(map[string]map[int]bool)
+	for _, slot := range slots {
+		if slot.EmployeeID == 0 {
+			t.Fatalf("Expected every slot to be covered, %s shift %d is not", slot.Date.Format("2006-01-02"), slot.Shift.ID)
+		}
+		key := slot.Date.Format("2006-01-02")
+		if perDay[key] == nil {
+			perDay[key] = make(map[int]bool)
+		}
+		if perDay[key][slot.EmployeeID] {
+			t.Errorf("Employee %d assigned twice on %s", slot.EmployeeID, key)
+		}
+		perDay[key][slot.EmployeeID] = true
+	}
+
+	// Shifts are ordered by start time
+	if slots[0].Shift.ID != 1 {
+		t.Errorf("Expected first slot to be the morning shift, got shift %d", slots[0].Shift.ID)
+	}
+}
+
+func TestGenerateRoster_SpreadsSundayHolidayWorkFairly(t *testing.T) {
+	// June 2025: 5 Sundays plus holidays on the 2nd, 23rd and 30th
+	holidays := map[string]bool{"2025-06-02": true, "2025-06-23": true, "2025-06-30": true}
+	days := BuildCalendarDays(2025, 6, holidays)
+
+	_, loads := GenerateRoster(days, createTestRosterShifts(), []int{10, 20, 30, 40}, nil, nil)
+
+	min, max := -1, -1
+	for _, load := range loads {
+		if min == -1 || load.SundayHoliday < min {
+			min = load.SundayHoliday
+		}
+		if load.SundayHoliday > max {
+			max = load.SundayHoliday
+		}
+	}
+
+	// 8 special days × 2 shifts = 16 slots across 4 employees
+	if max-min > 1 {
+		t.Errorf("Expected Sunday/holiday load to differ by at most 1, got min=%d max=%d", min, max)
+	}
+}
+
+func TestGenerateRoster_RespectsExistingAssignments(t *testing.T) {
+	days := BuildCalendarDays(2025, 6, map[string]bool{})[:1] // 2025-06-01
+	existing := []domain.AssignedShift{
+		{EmployeeID: 10, ShiftID: 1, StoreID: 1, Date: "2025-06-01"},
+	}
+
+	slots, loads := GenerateRoster(days, createTestRosterShifts(), []int{10, 20}, existing, nil)
+
+	if len(slots) != 1 {
+		t.Fatalf("Expected only the uncovered shift to be proposed, got %d slots", len(slots))
+	}
+	if slots[0].Shift.ID != 2 || slots[0].EmployeeID != 20 {
+		t.Errorf("Expected shift 2 for employee 20, got shift %d for employee %d", slots[0].Shift.ID, slots[0].EmployeeID)
+	}
+	if loads[10].Total != 1 {
+		t.Errorf("Expected existing assignment to count as load, got %d", loads[10].Total)
+	}
+}
+
+func TestGenerateRoster_ReportsUncoveredSlots(t *testing.T) {
+	days := BuildCalendarDays(2025, 6, map[string]bool{})[:1]
+
+	slots, _ := GenerateRoster(days, createTestRosterShifts(), []int{10}, nil, nil)
+
+	if len(slots) != 2 {
+		t.Fatalf("Expected 2 slots, got %d", len(slots))
+	}
+	if slots[0].EmployeeID != 10 || slots[1].EmployeeID != 0 {
+		t.Errorf("Expected second shift to be uncovered, got employees %d and %d", slots[0].EmployeeID, slots[1].EmployeeID)
+	}
+}
+
+func TestGenerateRoster_RespectsAvailability(t *testing.T) {
+	days := BuildCalendarDays(2025, 6, map[string]bool{})[:1] // Sunday 2025-06-01
+	availability := map[int]domain.EmployeeAvailability{
+		// Employee 10 cannot work Sunday mornings; employee 20 prefers not to work Sunday afternoons
+		10: {Windows: []domain.AvailabilityWindow{{UserID: 10, Weekday: 0, StartTime: "06:00", EndTime: "12:00", Type: domain.AvailabilityUnavailable}}},
+		20: {Windows: []domain.AvailabilityWindow{{UserID: 20, Weekday: 0, StartTime: "14:00", EndTime: "24:00", Type: domain.AvailabilityPreferNot}}},
+	}
+
+	slots, _ := GenerateRoster(days, createTestRosterShifts(), []int{10, 20}, nil, availability)
+
+	if len(slots) != 2 {
+		t.Fatalf("Expected 2 slots, got %d", len(slots))
+	}
+	if slots[0].EmployeeID != 20 || slots[0].AgainstPreference {
+		t.Errorf("Expected the morning shift for employee 20, got employee %d", slots[0].EmployeeID)
+	}
+	if slots[1].EmployeeID != 10 {
+		t.Errorf("Expected the afternoon shift for employee 10, got employee %d", slots[1].EmployeeID)
+	}
+
+	// With only the reluctant employee free, the shift is still covered but flagged
+	slots, _ = GenerateRoster(days, createTestRosterShifts()[:1], []int{20}, nil, availability)
+	if slots[0].EmployeeID != 20 || !slots[0].AgainstPreference {
+		t.Errorf("Expected employee 20 against preference, got employee %d (against=%v)", slots[0].EmployeeID, slots[0].AgainstPreference)
+	}
+
+	// Nobody available leaves the slot uncovered
+	slots, _ = GenerateRoster(days, createTestRosterShifts()[1:], []int{10}, nil, availability)
+	if slots[0].EmployeeID != 0 {
+		t.Errorf("Expected the morning shift to be uncovered, got employee %d", slots[0].EmployeeID)
+	}
+}
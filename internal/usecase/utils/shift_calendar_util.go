@@ -27,6 +27,33 @@ func HolidaysToMap(holidays []time.Time) map[string]bool {
 	return m
 }
 
+// ClassifyDay Tipo de una fecha; el domingo prevalece sobre el festivo
+func ClassifyDay(date time.Time, holidays map[string]bool) DayType {
+	switch {
+	case date.Weekday() == time.Sunday:
+		return Sunday
+	case holidays[date.Format("2006-01-02")]:
+		return Holiday
+	default:
+		return Ordinary
+	}
+}
+
+// DayTypeLookup Clasificador de fechas a partir de los días del calendario; las fechas
+// fuera del calendario se clasifican con los festivos dados
+func DayTypeLookup(days []CalendarDay, holidays map[string]bool) func(time.Time) DayType {
+	types := make(map[string]DayType, len(days))
+	for _, day := range days {
+		types[day.Date.Format("2006-01-02")] = day.DayType
+	}
+	return func(date time.Time) DayType {
+		if dtype, ok := types[date.Format("2006-01-02")]; ok {
+			return dtype
+		}
+		return ClassifyDay(date, holidays)
+	}
+}
+
 // BuildCalendarDays Construye los días del mes clasificados por tipo
 func BuildCalendarDays(year int, month int, holidays map[string]bool) []CalendarDay {
 	var days []CalendarDay
@@ -34,18 +61,7 @@ func BuildCalendarDays(year int, month int, holidays map[string]bool) []Calendar
 	last := first.AddDate(0, 1, -1)
 
 	for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
-		dayKey := d.Format("2006-01-02")
-		var dtype DayType
-
-		switch {
-		case d.Weekday() == time.Sunday:
-			dtype = Sunday
-		case holidays[dayKey]:
-			dtype = Holiday
-		default:
-			dtype = Ordinary
-		}
-
+		dtype := ClassifyDay(d, holidays)
 		week := ((d.Day() - 1) / 7) + 1
 
 		days = append(days, CalendarDay{
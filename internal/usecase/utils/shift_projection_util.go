@@ -7,72 +7,185 @@ import (
 )
 
 type projectedDay struct {
-	Date           time.Time
-	Type           DayType
-	DiurnalExtra   float64
-	NocturnalExtra float64
+	Date              time.Time
+	Type              DayType
+	OrdinaryDiurnal   float64
+	OrdinaryNocturnal float64
+	DiurnalExtra      float64
+	NocturnalExtra    float64
 }
 
+// HourSplit Horas de un día repartidas entre ordinarias y extra, y cada una entre diurnas y nocturnas
+type HourSplit struct {
+	OrdinaryDiurnal   float64
+	OrdinaryNocturnal float64
+	DiurnalExtra      float64
+	NocturnalExtra    float64
+}
+
+// SplitWorkedHours Reparte las horas trabajadas entre ordinarias (hasta la jornada diaria) y extra,
+// y cada parte entre diurna y nocturna en proporción a la franja que ocupa el turno
+func SplitWorkedHours(start, end time.Time, worked float64, config domain.WorkConfig) HourSplit {
+	if worked <= 0 {
+		return HourSplit{}
+	}
+
+	ordinary := math.Min(worked, config.DailyOrdinaryHours)
+	extra := worked - ordinary
+
+	diurnal, nocturnal := SplitByFranja(start, end, ParseHour(config.DiurnalStart), ParseHour(config.DiurnalEnd))
+	return prorateSplit(ordinary, extra, diurnal, nocturnal)
+}
+
+// ShiftSegment Horas de un turno que caen en una misma fecha calendario
+type ShiftSegment struct {
+	Date    time.Time
+	DayType DayType
+	HourSplit
+}
+
+// SplitShiftByDay Reparte minuto a minuto un turno que empieza en date entre las fechas que
+// toca (un turno nocturno cruza la medianoche), clasificando cada fecha con dayTypeOf.
+// Ordinarias y extra se prorratean sobre cada tramo igual que en SplitWorkedHours.
+func SplitShiftByDay(
+	date time.Time,
+	start, end time.Time,
+	worked float64,
+	config domain.WorkConfig,
+	dayTypeOf func(time.Time) DayType,
+) []ShiftSegment {
+	if worked <= 0 {
+		return nil
+	}
+
+	ordinary := math.Min(worked, config.DailyOrdinaryHours)
+	extra := worked - ordinary
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	startAt := day.Add(time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute)
+	minutes := int(math.Round(DurationInHours(start, end) * 60))
+	diurnalStart := minuteOfDay(ParseHour(config.DiurnalStart))
+	diurnalEnd := minuteOfDay(ParseHour(config.DiurnalEnd))
+
+	type tally struct {
+		date               time.Time
+		diurnal, nocturnal float64
+	}
+	var tallies []tally
+	for i := 0; i < minutes; i++ {
+		t := startAt.Add(time.Duration(i) * time.Minute)
+		current := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		if len(tallies) == 0 || !tallies[len(tallies)-1].date.Equal(current) {
+			tallies = append(tallies, tally{date: current})
+		}
+		if isDiurnalMinute(minuteOfDay(t), diurnalStart, diurnalEnd) {
+			tallies[len(tallies)-1].diurnal += 1.0 / 60
+		} else {
+			tallies[len(tallies)-1].nocturnal += 1.0 / 60
+		}
+	}
+
+	total := float64(minutes) / 60
+	segments := make([]ShiftSegment, 0, len(tallies))
+	for _, t := range tallies {
+		share := (t.diurnal + t.nocturnal) / total
+		segments = append(segments, ShiftSegment{
+			Date:      t.date,
+			DayType:   dayTypeOf(t.date),
+			HourSplit: prorateSplit(ordinary*share, extra*share, t.diurnal, t.nocturnal),
+		})
+	}
+	return segments
+}
+
+// prorateSplit Reparte ordinarias y extra entre diurnas y nocturnas según la franja
+func prorateSplit(ordinary, extra, diurnal, nocturnal float64) HourSplit {
+	total := diurnal + nocturnal
+	if total <= 0 {
+		return HourSplit{}
+	}
+
+	return HourSplit{
+		OrdinaryDiurnal:   RoundTo2(ordinary * diurnal / total),
+		OrdinaryNocturnal: RoundTo2(ordinary * nocturnal / total),
+		DiurnalExtra:      RoundTo2(extra * diurnal / total),
+		NocturnalExtra:    RoundTo2(extra * nocturnal / total),
+	}
+}
+
+// ApplyShiftToCalendar Proyecta las horas ordinarias y extra del turno en cada día con la configuración
+// vigente ese día. Las horas que un turno nocturno pasa de la medianoche cuentan en el día siguiente,
+// incluido lo que el turno del día anterior al mes aporta al primer día.
 func ApplyShiftToCalendar(
 	days []CalendarDay,
 	shift domain.Shift,
-	config domain.WorkConfig,
+	configs WorkConfigTimeline,
 ) []projectedDay {
+	if len(days) == 0 {
+		return nil
+	}
+
 	shiftStart := ParseHour(shift.StartTime)
 	shiftEnd := ParseHour(shift.EndTime)
-	diurnalStart := ParseHour(config.DiurnalStart)
-	diurnalEnd := ParseHour(config.DiurnalEnd)
-	dailyLimit := 7.33
+	totalWorked := DurationInHours(shiftStart, shiftEnd) - float64(shift.LunchMinutes)/60.0 // Se restan los minutos de almuerzo
+
+	dayTypeOf := DayTypeLookup(days, nil)
+	first := days[0].Date
+	last := days[len(days)-1].Date
 
 	var result []projectedDay
 
-	for _, day := range days {
-		totalWorked := DurationInHours(shiftStart, shiftEnd) - float64(shift.LunchMinutes)/60.0 // Se restan los minutos de almuerzo
-		if totalWorked <= dailyLimit {
-			continue // no extra
+	for day := first.AddDate(0, 0, -1); !day.After(last); day = day.AddDate(0, 0, 1) {
+		segments := SplitShiftByDay(day, shiftStart, shiftEnd, totalWorked, configs.At(day), dayTypeOf)
+		for _, segment := range segments {
+			if segment.Date.Before(first) || segment.Date.After(last) {
+				continue
+			}
+			result = append(result, projectedDay{
+				Date:              segment.Date,
+				Type:              segment.DayType,
+				OrdinaryDiurnal:   segment.OrdinaryDiurnal,
+				OrdinaryNocturnal: segment.OrdinaryNocturnal,
+				DiurnalExtra:      segment.DiurnalExtra,
+				NocturnalExtra:    segment.NocturnalExtra,
+			})
 		}
-
-		extra := totalWorked - dailyLimit
-		diurnal, nocturnal := SplitByFranja(shiftStart, shiftEnd, diurnalStart, diurnalEnd)
-
-		scale := extra / (diurnal + nocturnal)
-		diurnalExtra := RoundTo2(scale * diurnal)
-		nocturnalExtra := RoundTo2(scale * nocturnal)
-
-		result = append(result, projectedDay{
-			Date:           day.Date,
-			Type:           day.DayType,
-			DiurnalExtra:   diurnalExtra,
-			NocturnalExtra: nocturnalExtra,
-		})
 	}
 
 	return result
 }
 
+// HasExtra Indica si el día proyectado tiene horas extra
+func (d projectedDay) HasExtra() bool {
+	return d.DiurnalExtra > 0 || d.NocturnalExtra > 0
+}
+
 func SummarizeProjection(days []projectedDay) domain.ExtraHourSummary {
 	var summary domain.ExtraHourSummary
 
 	for _, d := range days {
+		var block *domain.ExtraHourBlock
 		switch d.Type {
-		case Ordinary:
-			summary.Ordinary.DiurnalExtra += d.DiurnalExtra
-			summary.Ordinary.NocturnalExtra += d.NocturnalExtra
 		case Sunday:
-			summary.Sunday.DiurnalExtra += d.DiurnalExtra
-			summary.Sunday.NocturnalExtra += d.NocturnalExtra
+			block = &summary.Sunday
 		case Holiday:
-			summary.Holiday.DiurnalExtra += d.DiurnalExtra
-			summary.Holiday.NocturnalExtra += d.NocturnalExtra
+			block = &summary.Holiday
+		default:
+			block = &summary.Ordinary
 		}
+
+		block.OrdinaryDiurnal += d.OrdinaryDiurnal
+		block.OrdinaryNocturnal += d.OrdinaryNocturnal
+		block.DiurnalExtra += d.DiurnalExtra
+		block.NocturnalExtra += d.NocturnalExtra
 	}
 
-	summary.Ordinary.DiurnalExtra = RoundTo2(summary.Ordinary.DiurnalExtra)
-	summary.Ordinary.NocturnalExtra = RoundTo2(summary.Ordinary.NocturnalExtra)
-	summary.Sunday.DiurnalExtra = RoundTo2(summary.Sunday.DiurnalExtra)
-	summary.Sunday.NocturnalExtra = RoundTo2(summary.Sunday.NocturnalExtra)
-	summary.Holiday.DiurnalExtra = RoundTo2(summary.Holiday.DiurnalExtra)
-	summary.Holiday.NocturnalExtra = RoundTo2(summary.Holiday.NocturnalExtra)
+	for _, block := range []*domain.ExtraHourBlock{&summary.Ordinary, &summary.Sunday, &summary.Holiday} {
+		block.OrdinaryDiurnal = RoundTo2(block.OrdinaryDiurnal)
+		block.OrdinaryNocturnal = RoundTo2(block.OrdinaryNocturnal)
+		block.DiurnalExtra = RoundTo2(block.DiurnalExtra)
+		block.NocturnalExtra = RoundTo2(block.NocturnalExtra)
+	}
 
 	return summary
 }
@@ -98,16 +211,20 @@ func DurationInHours(start, end time.Time) float64 {
 	return dur
 }
 
+// SplitByFranja Horas diurnas y nocturnas de un turno; los minutos después de la medianoche
+// se clasifican por su hora del día
 func SplitByFranja(start, end, diurnalStart, diurnalEnd time.Time) (float64, float64) {
 	if end.Before(start) {
 		end = end.Add(24 * time.Hour)
 	}
+	from := minuteOfDay(diurnalStart)
+	to := minuteOfDay(diurnalEnd)
+
 	diurnal := 0.0
 	nocturnal := 0.0
 
 	for t := start; t.Before(end); t = t.Add(1 * time.Minute) {
-		isDiurnal := t.After(diurnalStart) && t.Before(diurnalEnd)
-		if isDiurnal {
+		if isDiurnalMinute(minuteOfDay(t), from, to) {
 			diurnal += 1.0 / 60
 		} else {
 			nocturnal += 1.0 / 60
@@ -116,6 +233,19 @@ func SplitByFranja(start, end, diurnalStart, diurnalEnd time.Time) (float64, flo
 	return diurnal, nocturnal
 }
 
+// minuteOfDay Minutos transcurridos desde la medianoche
+func minuteOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+// isDiurnalMinute Indica si el minuto del día cae en la franja diurna [from, to)
+func isDiurnalMinute(minute, from, to int) bool {
+	if from <= to {
+		return minute >= from && minute < to
+	}
+	return minute >= from || minute < to
+}
+
 func RoundTo2(val float64) float64 {
 	return math.Round(val*100) / 100
 }
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"loopi-api/internal/domain"
+)
+
+func TestSplitWorkedHours(t *testing.T) {
+	config := domain.WorkConfig{DiurnalStart: "06:00", DiurnalEnd: "21:00", DailyOrdinaryHours: 8}
+
+	// 13:00–23:00 sin almuerzo: 10 h, 8 diurnas y 2 nocturnas; 8 ordinarias y 2 extra
+	split := SplitWorkedHours(ParseHour("13:00"), ParseHour("23:00"), 10, config)
+
+	if split.OrdinaryDiurnal != 6.4 || split.OrdinaryNocturnal != 1.6 {
+		t.Errorf("Unexpected ordinary split: %+v", split)
+	}
+	if split.DiurnalExtra != 1.6 || split.NocturnalExtra != 0.4 {
+		t.Errorf("Unexpected extra split: %+v", split)
+	}
+}
+
+func TestSplitShiftByDay_CrossesIntoSunday(t *testing.T) {
+	config := domain.WorkConfig{DiurnalStart: "06:00", DiurnalEnd: "21:00", DailyOrdinaryHours: 8}
+	saturday := time.Date(2025, 6, 7, 0, 0, 0, 0, time.UTC)
+
+	// Sábado 22:00–06:00: 2 h el sábado y 6 h el domingo, todas nocturnas y ordinarias
+	segments := SplitShiftByDay(saturday, ParseHour("22:00"), ParseHour("06:00"), 8, config, DayTypeLookup(nil, nil))
+
+	if len(segments) != 2 {
+		t.Fatalf("Expected 2 segments, got %d: %+v", len(segments), segments)
+	}
+	if segments[0].DayType != Ordinary || segments[0].OrdinaryNocturnal != 2 {
+		t.Errorf("Unexpected Saturday segment: %+v", segments[0])
+	}
+	if segments[1].DayType != Sunday || segments[1].OrdinaryNocturnal != 6 || segments[1].OrdinaryDiurnal != 0 {
+		t.Errorf("Unexpected Sunday segment: %+v", segments[1])
+	}
+}
+
+func TestSplitShiftByDay_ProratesExtraAcrossDates(t *testing.T) {
+	config := domain.WorkConfig{DiurnalStart: "06:00", DiurnalEnd: "21:00", DailyOrdinaryHours: 8}
+	holidays := map[string]bool{"2026-01-01": true}
+	newYearsEve := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	// 20:00–08:00 sin almuerzo: 12 h, 4 en 2025 y 8 en el festivo de año nuevo (2 de ellas diurnas)
+	segments := SplitShiftByDay(newYearsEve, ParseHour("20:00"), ParseHour("08:00"), 12, config, DayTypeLookup(nil, holidays))
+
+	if len(segments) != 2 || segments[1].DayType != Holiday {
+		t.Fatalf("Expected an ordinary and a holiday segment, got %+v", segments)
+	}
+
+	holiday := segments[1]
+	if holiday.OrdinaryDiurnal != 1.33 || holiday.OrdinaryNocturnal != 4 {
+		t.Errorf("Unexpected holiday ordinary hours: %+v", holiday)
+	}
+	if holiday.DiurnalExtra != 0.67 || holiday.NocturnalExtra != 2 {
+		t.Errorf("Unexpected holiday extra hours: %+v", holiday)
+	}
+}
+
+func TestSplitByFranja_AfterMidnight(t *testing.T) {
+	// 22:00–08:00: 8 h nocturnas (22:00–06:00) y 2 diurnas (06:00–08:00)
+	diurnal, nocturnal := SplitByFranja(ParseHour("22:00"), ParseHour("08:00"), ParseHour("06:00"), ParseHour("21:00"))
+
+	if RoundTo2(diurnal) != 2 || RoundTo2(nocturnal) != 8 {
+		t.Errorf("Expected 2 diurnal and 8 nocturnal hours, got %.2f and %.2f", diurnal, nocturnal)
+	}
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"loopi-api/internal/domain"
+	"sort"
+	"time"
+)
+
+// WorkConfigTimeline Configuraciones laborales ordenadas por fecha de entrada en vigor
+type WorkConfigTimeline []domain.WorkConfig
+
+// NewWorkConfigTimeline Ordena las configuraciones por EffectiveFrom
+func NewWorkConfigTimeline(configs []domain.WorkConfig) WorkConfigTimeline {
+	timeline := append(WorkConfigTimeline(nil), configs...)
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].EffectiveFrom.Before(timeline[j].EffectiveFrom)
+	})
+	return timeline
+}
+
+// At Devuelve la configuración en vigor en la fecha indicada.
+// Si la fecha es anterior a todas, se usa la más antigua.
+func (t WorkConfigTimeline) At(date time.Time) domain.WorkConfig {
+	if len(t) == 0 {
+		return domain.WorkConfig{}
+	}
+
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	current := t[0]
+	for _, config := range t[1:] {
+		from := config.EffectiveFrom
+		if time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC).After(day) {
+			break
+		}
+		current = config
+	}
+	return current
+}
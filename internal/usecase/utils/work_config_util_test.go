@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"loopi-api/internal/domain"
+)
+
+func TestWorkConfigTimeline_At(t *testing.T) {
+	timeline := NewWorkConfigTimeline([]domain.WorkConfig{
+		{EffectiveFrom: time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC), DailyOrdinaryHours: 7.33},
+		{EffectiveFrom: time.Date(2024, 7, 15, 0, 0, 0, 0, time.UTC), DailyOrdinaryHours: 7.67},
+	})
+
+	tests := []struct {
+		date     time.Time
+		expected float64
+	}{
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 7.67}, // before every config: earliest one
+		{time.Date(2025, 7, 14, 0, 0, 0, 0, time.UTC), 7.67},
+		{time.Date(2025, 7, 15, 0, 0, 0, 0, time.UTC), 7.33}, // in force from its effective date
+		{time.Date(2025, 7, 15, 23, 0, 0, 0, time.UTC), 7.33},
+		{time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 7.33},
+	}
+
+	for _, tt := range tests {
+		if got := timeline.At(tt.date).DailyOrdinaryHours; got != tt.expected {
+			t.Errorf("At(%s): expected %.2f, got %.2f", tt.date.Format("2006-01-02"), tt.expected, got)
+		}
+	}
+}
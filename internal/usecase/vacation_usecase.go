@@ -0,0 +1,397 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	"loopi-api/internal/usecase/base"
+	"loopi-api/internal/usecase/dto"
+	"loopi-api/internal/usecase/utils"
+)
+
+// VacationUseCase keeps the vacation ledger of each employee. Accruals and taken days are derived
+// from the hire date and the approved vacation absences; only manual adjustments are stored.
+type VacationUseCase interface {
+	// Ledger operations, scoped to the caller's franchise
+	GetBalance(scope domain.TenantScope, employeeID int) (*domain.VacationBalance, error)
+	GetLedger(scope domain.TenantScope, employeeID int) (*domain.VacationLedger, error)
+	Adjust(scope domain.TenantScope, createdBy, employeeID int, req dto.VacationAdjustmentRequest) (*domain.VacationAdjustment, error)
+
+	// Business-specific operations
+	ValidateVacationRequest(absence *domain.Absence) error
+	ValidateAdjustmentRequest(req dto.VacationAdjustmentRequest) error
+}
+
+// maxVacationAdjustmentDays bounds a single manual adjustment
+const maxVacationAdjustmentDays = 365
+
+type vacationUseCase struct {
+	userRepo       repository.UserRepository
+	absenceRepo    repository.AbsenceRepository
+	typeRepo       repository.AbsenceTypeRepository
+	adjustmentRepo repository.VacationAdjustmentRepository
+	holidays       *HolidayCalendarResolver
+	tenant         *TenantGuard
+	now            func() time.Time
+	errorHandler   *base.ErrorHandler
+	validator      *base.Validator
+	logger         *base.Logger
+}
+
+func NewVacationUseCase(
+	userRepo repository.UserRepository,
+	absenceRepo repository.AbsenceRepository,
+	typeRepo repository.AbsenceTypeRepository,
+	adjustmentRepo repository.VacationAdjustmentRepository,
+	holidays *HolidayCalendarResolver,
+	tenant *TenantGuard,
+) VacationUseCase {
+	return &vacationUseCase{
+		userRepo:       userRepo,
+		absenceRepo:    absenceRepo,
+		typeRepo:       typeRepo,
+		adjustmentRepo: adjustmentRepo,
+		holidays:       holidays,
+		tenant:         tenant,
+		now:            time.Now,
+		errorHandler:   base.NewErrorHandler("Vacation"),
+		validator:      base.NewValidator(),
+		logger:         base.NewLogger("Vacation"),
+	}
+}
+
+// ✅ Ledger operations
+
+// GetBalance returns the employee's vacation balance as of today
+func (uc *vacationUseCase) GetBalance(scope domain.TenantScope, employeeID int) (*domain.VacationBalance, error) {
+	ledger, err := uc.GetLedger(scope, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	return &ledger.VacationBalance, nil
+}
+
+// GetLedger returns the employee's vacation balance with every movement up to today
+func (uc *vacationUseCase) GetLedger(scope domain.TenantScope, employeeID int) (*domain.VacationLedger, error) {
+	uc.logger.LogOperation("GetLedger", "start", map[string]interface{}{
+		"employee_id": employeeID,
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("GetLedger", err)
+	}
+	if err := uc.tenant.RequireEmployee("GetLedger", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	countDays, err := uc.workingDayCounter("GetLedger", employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger, err := uc.buildLedger("GetLedger", employeeID, dateOf(uc.now()), countDays)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.logger.LogOperation("GetLedger", "success", map[string]interface{}{
+		"employee_id": employeeID,
+		"balance":     ledger.Balance,
+		"movements":   len(ledger.Movements),
+	})
+
+	return ledger, nil
+}
+
+// Adjust records a manual change of the employee's balance
+func (uc *vacationUseCase) Adjust(scope domain.TenantScope, createdBy, employeeID int, req dto.VacationAdjustmentRequest) (*domain.VacationAdjustment, error) {
+	uc.logger.LogOperation("Adjust", "start", map[string]interface{}{
+		"employee_id": employeeID,
+		"days":        req.Days,
+		"created_by":  createdBy,
+	})
+
+	if err := uc.validator.ValidateID(employeeID); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Adjust", err)
+	}
+	if err := uc.ValidateAdjustmentRequest(req); err != nil {
+		return nil, uc.errorHandler.HandleValidationError("Adjust", err)
+	}
+	if err := uc.tenant.RequireEmployee("Adjust", scope, employeeID); err != nil {
+		return nil, err
+	}
+
+	date := dateOf(uc.now())
+	if req.Date != "" {
+		date, _ = time.Parse("2006-01-02", req.Date)
+	}
+
+	adjustment := domain.VacationAdjustment{
+		EmployeeID: employeeID,
+		Date:       date,
+		Days:       utils.RoundTo2(req.Days),
+		Reason:     req.Reason,
+		CreatedBy:  createdBy,
+	}
+
+	if err := uc.adjustmentRepo.Create(&adjustment); err != nil {
+		uc.logger.LogError("Adjust", err, map[string]interface{}{
+			"employee_id": employeeID,
+		})
+		return nil, uc.errorHandler.HandleRepositoryError("Adjust", err)
+	}
+
+	uc.logger.LogOperation("Adjust", "success", map[string]interface{}{
+		"adjustment_id": adjustment.ID,
+		"employee_id":   employeeID,
+		"days":          adjustment.Days,
+	})
+
+	return &adjustment, nil
+}
+
+// ✅ Business-specific operations
+
+// ValidateVacationRequest checks that a new vacation absence fits the employee's available days.
+// Days accrued until the vacation starts count, so vacations can be planned ahead. Callers scope
+// the employee.
+func (uc *vacationUseCase) ValidateVacationRequest(absence *domain.Absence) error {
+	uc.logger.LogOperation("ValidateVacationRequest", "start", map[string]interface{}{
+		"employee_id": absence.EmployeeID,
+		"date":        absence.Date.Format("2006-01-02"),
+		"end_date":    absence.LastDate().Format("2006-01-02"),
+	})
+
+	countDays, err := uc.workingDayCounter("ValidateVacationRequest", absence.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	requested, err := countDays(absence)
+	if err != nil {
+		return err
+	}
+
+	// Business rule: Vacations are counted in working days; a range without any is not a vacation
+	if requested == 0 {
+		return uc.errorHandler.HandleBusinessRuleViolation("ValidateVacationRequest", "no_working_days",
+			fmt.Sprintf("the vacation between %s and %s has no working days",
+				absence.Date.Format("2006-01-02"), absence.LastDate().Format("2006-01-02")))
+	}
+
+	accrueUntil := dateOf(uc.now())
+	if start := dateOf(absence.Date); start.After(accrueUntil) {
+		accrueUntil = start
+	}
+	ledger, err := uc.buildLedger("ValidateVacationRequest", absence.EmployeeID, accrueUntil, countDays)
+	if err != nil {
+		return err
+	}
+
+	// Business rule: Pending requests reserve days, so the request must fit in the available balance
+	if requested > ledger.Available {
+		uc.logger.LogBusinessRule("ValidateVacationRequest", "vacation_balance", "rejected", map[string]interface{}{
+			"employee_id": absence.EmployeeID,
+			"requested":   requested,
+			"available":   ledger.Available,
+		})
+		return uc.errorHandler.HandleBusinessRuleViolation("ValidateVacationRequest", "vacation_balance",
+			fmt.Sprintf("employee %d requests %.2f vacation days but only %.2f are available by %s (balance %.2f, pending %.2f)",
+				absence.EmployeeID, requested, ledger.Available, accrueUntil.Format("2006-01-02"), ledger.Balance, ledger.Pending))
+	}
+
+	uc.logger.LogValidation("ValidateVacationRequest", "vacation_balance", "passed", map[string]interface{}{
+		"employee_id": absence.EmployeeID,
+		"requested":   requested,
+		"available":   ledger.Available,
+	})
+
+	return nil
+}
+
+// ValidateAdjustmentRequest validates a manual adjustment
+func (uc *vacationUseCase) ValidateAdjustmentRequest(req dto.VacationAdjustmentRequest) error {
+	if req.Days == 0 {
+		return fmt.Errorf("days cannot be zero")
+	}
+	if req.Days > maxVacationAdjustmentDays || req.Days < -maxVacationAdjustmentDays {
+		return fmt.Errorf("days must be between -%d and %d, got: %.2f", maxVacationAdjustmentDays, maxVacationAdjustmentDays, req.Days)
+	}
+	if err := uc.validator.ValidateString(req.Reason, "reason", "required", "min:3", "max:255"); err != nil {
+		return err
+	}
+	if req.Date != "" {
+		if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+			return fmt.Errorf("invalid date format: %s. Use YYYY-MM-DD", req.Date)
+		}
+	}
+	return nil
+}
+
+// buildLedger assembles the employee's movements: monthly accruals completed by accrueUntil,
+// approved vacations (future ones included) and manual adjustments
+func (uc *vacationUseCase) buildLedger(operation string, employeeID int, accrueUntil time.Time,
+	countDays func(*domain.Absence) (float64, error)) (*domain.VacationLedger, error) {
+	employee, err := uc.userRepo.FindByID(employeeID)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	if employee == nil {
+		return nil, uc.errorHandler.HandleNotFound(operation, fmt.Sprintf("employee %d not found", employeeID))
+	}
+	if employee.HireDate == nil {
+		return nil, uc.errorHandler.HandleBusinessRuleViolation(operation, "missing_hire_date",
+			fmt.Sprintf("employee %d has no hire date to accrue vacations from", employeeID))
+	}
+	hireDate := dateOf(*employee.HireDate)
+
+	ledger := &domain.VacationLedger{
+		VacationBalance: domain.VacationBalance{
+			EmployeeID: employeeID,
+			HireDate:   hireDate,
+			AsOf:       accrueUntil,
+		},
+	}
+	movements := vacationAccruals(hireDate, accrueUntil)
+
+	vacationType, err := uc.typeRepo.GetByCode(domain.AbsenceTypeVacation)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"code": domain.AbsenceTypeVacation})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+
+	absences, err := uc.absenceRepo.GetByEmployeeAndDateRange(employeeID, hireDate, dateOf(uc.now()).AddDate(0, 0, maxAbsenceDaysAhead))
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	for i := range absences {
+		absence := &absences[i]
+		if absence.AbsenceTypeID != int(vacationType.ID) || absence.Status == domain.AbsenceStatusRejected {
+			continue
+		}
+
+		days, err := countDays(absence)
+		if err != nil {
+			return nil, err
+		}
+		if absence.Status == domain.AbsenceStatusPending {
+			ledger.Pending += days
+			continue
+		}
+		movements = append(movements, domain.VacationMovement{
+			Date:      dateOf(absence.Date),
+			Type:      domain.VacationMovementTaken,
+			Days:      -days,
+			AbsenceID: int(absence.ID),
+			Detail:    fmt.Sprintf("Vacaciones del %s al %s", absence.Date.Format("2006-01-02"), absence.LastDate().Format("2006-01-02")),
+		})
+	}
+
+	adjustments, err := uc.adjustmentRepo.GetByEmployee(employeeID)
+	if err != nil {
+		uc.logger.LogError(operation, err, map[string]interface{}{"employee_id": employeeID})
+		return nil, uc.errorHandler.HandleRepositoryError(operation, err)
+	}
+	for _, adjustment := range adjustments {
+		movements = append(movements, domain.VacationMovement{
+			Date:   dateOf(adjustment.Date),
+			Type:   domain.VacationMovementAdjustment,
+			Days:   adjustment.Days,
+			Detail: adjustment.Reason,
+		})
+	}
+
+	sort.SliceStable(movements, func(i, j int) bool { return movements[i].Date.Before(movements[j].Date) })
+
+	balance := 0.0
+	for i := range movements {
+		switch movements[i].Type {
+		case domain.VacationMovementAccrual:
+			ledger.Accrued += movements[i].Days
+		case domain.VacationMovementTaken:
+			ledger.Taken -= movements[i].Days
+		case domain.VacationMovementAdjustment:
+			ledger.Adjusted += movements[i].Days
+		}
+		balance = utils.RoundTo2(balance + movements[i].Days)
+		movements[i].Balance = balance
+	}
+
+	ledger.Accrued = utils.RoundTo2(ledger.Accrued)
+	ledger.Taken = utils.RoundTo2(ledger.Taken)
+	ledger.Adjusted = utils.RoundTo2(ledger.Adjusted)
+	ledger.Pending = utils.RoundTo2(ledger.Pending)
+	ledger.Balance = balance
+	ledger.Available = utils.RoundTo2(balance - ledger.Pending)
+	ledger.Movements = movements
+
+	return ledger, nil
+}
+
+// workingDayCounter returns a counter of the working days of an employee's absence: the ordinary
+// days of utils.BuildCalendarDays, so Sundays and holidays of the employee's calendar are not counted
+func (uc *vacationUseCase) workingDayCounter(operation string, employeeID int) (func(*domain.Absence) (float64, error), error) {
+	calendarScope, err := uc.holidays.ScopeForEmployee(operation, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	months := make(map[string][]utils.CalendarDay)
+	return func(absence *domain.Absence) (float64, error) {
+		count := 0
+		for _, day := range absence.Days(absence.Date, absence.LastDate()) {
+			key := day.Format("2006-01")
+			days, ok := months[key]
+			if !ok {
+				holidays, err := uc.holidays.HolidaysByMonth(operation, calendarScope, day.Year(), int(day.Month()))
+				if err != nil {
+					return 0, err
+				}
+				days = utils.BuildCalendarDays(day.Year(), int(day.Month()), holidays)
+				months[key] = days
+			}
+			if days[day.Day()-1].DayType == utils.Ordinary {
+				count++
+			}
+		}
+		return float64(count), nil
+	}, nil
+}
+
+// vacationAccruals returns one accrual per calendar month completed by until, of
+// domain.VacationDaysPerYear / 12 days; the hire month accrues in proportion to the days served
+func vacationAccruals(hireDate, until time.Time) []domain.VacationMovement {
+	perMonth := domain.VacationDaysPerYear / 12
+
+	var movements []domain.VacationMovement
+	for month := time.Date(hireDate.Year(), hireDate.Month(), 1, 0, 0, 0, 0, time.UTC); ; month = month.AddDate(0, 1, 0) {
+		monthEnd := month.AddDate(0, 1, -1)
+		if monthEnd.After(until) {
+			break
+		}
+
+		start := month
+		if hireDate.After(start) {
+			start = hireDate
+		}
+		served := monthEnd.Day() - start.Day() + 1
+
+		movements = append(movements, domain.VacationMovement{
+			Date:   monthEnd,
+			Type:   domain.VacationMovementAccrual,
+			Days:   utils.RoundTo2(perMonth * float64(served) / float64(monthEnd.Day())),
+			Detail: "Causación " + month.Format("2006-01"),
+		})
+	}
+	return movements
+}
+
+// dateOf drops the time of day, keeping the calendar date in UTC
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
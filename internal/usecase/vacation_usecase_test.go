@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"loopi-api/internal/domain"
+	"loopi-api/internal/repository"
+	repotesting "loopi-api/internal/repository/testing"
+	"loopi-api/internal/usecase/dto"
+)
+
+// MockVacationAdjustmentRepository keeps adjustments in insertion order
+type MockVacationAdjustmentRepository struct {
+	adjustments []domain.VacationAdjustment
+}
+
+func (m *MockVacationAdjustmentRepository) Create(adjustment *domain.VacationAdjustment) error {
+	adjustment.ID = uint(len(m.adjustments) + 1)
+	m.adjustments = append(m.adjustments, *adjustment)
+	return nil
+}
+func (m *MockVacationAdjustmentRepository) GetByEmployee(employeeID int) ([]domain.VacationAdjustment, error) {
+	var adjustments []domain.VacationAdjustment
+	for _, adjustment := range m.adjustments {
+		if adjustment.EmployeeID == employeeID {
+			adjustments = append(adjustments, adjustment)
+		}
+	}
+	return adjustments, nil
+}
+
+// newTestVacationUseCase uses the Colombian calendar without custom holidays
+func newTestVacationUseCase(users repository.UserRepository, absences repository.AbsenceRepository,
+	types repository.AbsenceTypeRepository, adjustments repository.VacationAdjustmentRepository, tenant *TenantGuard) *vacationUseCase {
+	holidays := NewHolidayCalendarResolver(repotesting.NewMockStoreRepository(), &MockFranchiseRepository{}, &MockHolidayRepository{})
+	return NewVacationUseCase(users, absences, types, adjustments, holidays, tenant).(*vacationUseCase)
+}
+
+func ymd(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestVacationAccruals_ProratesHireMonth(t *testing.T) {
+	accruals := vacationAccruals(ymd(2025, 1, 16), ymd(2025, 4, 15))
+
+	// 16 of 31 days in January, then full months; April is not complete yet
+	expected := []float64{0.65, 1.25, 1.25}
+	if len(accruals) != len(expected) {
+		t.Fatalf("Expected %d accruals, got %+v", len(expected), accruals)
+	}
+	for i, days := range expected {
+		if accruals[i].Days != days {
+			t.Errorf("Accrual %d: expected %.2f days, got %.2f", i, days, accruals[i].Days)
+		}
+	}
+	if !accruals[1].Date.Equal(ymd(2025, 2, 28)) {
+		t.Errorf("Expected accruals at month end, got %s", accruals[1].Date)
+	}
+}
+
+func TestVacationUseCase_Ledger(t *testing.T) {
+	hired := ymd(2024, 7, 1)
+	users := &MockUserRepository{users: []domain.User{{BaseEntity: domain.BaseEntity{ID: 7}, HireDate: &hired}}}
+	absences := repotesting.NewMockAbsenceRepository()
+	absences.SeedAbsenceData([]domain.Absence{
+		// Mar 24 is San José and Mar 23 a Sunday: 10 working days
+		{EmployeeID: 7, AbsenceTypeID: testVacation, Date: ymd(2025, 3, 17), EndDate: ymd(2025, 3, 28), Hours: 8, Status: domain.AbsenceStatusApproved},
+		{EmployeeID: 7, AbsenceTypeID: testVacation, Date: ymd(2025, 7, 7), EndDate: ymd(2025, 7, 8), Hours: 8, Status: domain.AbsenceStatusPending},
+		{EmployeeID: 7, AbsenceTypeID: testVacation, Date: ymd(2025, 5, 5), EndDate: ymd(2025, 5, 9), Hours: 8, Status: domain.AbsenceStatusRejected},
+		{EmployeeID: 7, AbsenceTypeID: testPersonal, Date: ymd(2025, 5, 12), Hours: 8, Status: domain.AbsenceStatusApproved},
+	})
+	tenant := NewTenantGuard(&MockTenantRepository{employeeFranchises: map[int]int{7: 1}})
+	uc := newTestVacationUseCase(users, absences, newTestAbsenceTypes(), &MockVacationAdjustmentRepository{}, tenant)
+	uc.now = func() time.Time { return time.Date(2025, 6, 30, 15, 0, 0, 0, time.UTC) }
+
+	if _, err := uc.Adjust(franchiseScope(1), 50, 7, dto.VacationAdjustmentRequest{Date: "2024-07-01", Days: 2, Reason: "Saldo inicial"}); err != nil {
+		t.Fatalf("Adjust: unexpected error %v", err)
+	}
+
+	ledger, err := uc.GetLedger(franchiseScope(1), 7)
+	if err != nil {
+		t.Fatalf("GetLedger: unexpected error %v", err)
+	}
+	if ledger.Accrued != 15 || ledger.Taken != 10 || ledger.Adjusted != 2 || ledger.Balance != 7 || ledger.Pending != 2 || ledger.Available != 5 {
+		t.Errorf("Unexpected balance %+v", ledger.VacationBalance)
+	}
+	if first := ledger.Movements[0]; first.Type != domain.VacationMovementAdjustment || first.Balance != 2 {
+		t.Errorf("Expected the opening adjustment first, got %+v", first)
+	}
+	if last := ledger.Movements[len(ledger.Movements)-1]; last.Type != domain.VacationMovementAccrual || last.Balance != 7 {
+		t.Errorf("Expected the June accrual last, got %+v", last)
+	}
+
+	if _, err := uc.GetLedger(franchiseScope(2), 7); domainStatus(t, err) != 404 {
+		t.Errorf("Expected 404 from another franchise, got %v", err)
+	}
+
+	// Monday to Friday fits the 5 available days; the Saturday makes it 6
+	request := &domain.Absence{EmployeeID: 7, AbsenceTypeID: testVacation, Date: ymd(2025, 7, 14), EndDate: ymd(2025, 7, 18)}
+	if err := uc.ValidateVacationRequest(request); err != nil {
+		t.Errorf("Expected 5 working days to fit, got %v", err)
+	}
+	request.EndDate = ymd(2025, 7, 19)
+	if err := uc.ValidateVacationRequest(request); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 above the available balance, got %v", err)
+	}
+
+	// Jul 20 is a Sunday and Independence Day
+	sunday := &domain.Absence{EmployeeID: 7, AbsenceTypeID: testVacation, Date: ymd(2025, 7, 20)}
+	if err := uc.ValidateVacationRequest(sunday); domainStatus(t, err) != 422 {
+		t.Errorf("Expected 422 for a range without working days, got %v", err)
+	}
+}
+
+func TestVacationUseCase_AdjustValidation(t *testing.T) {
+	tenant := NewTenantGuard(&MockTenantRepository{employeeFranchises: map[int]int{7: 1}})
+	uc := newTestVacationUseCase(&MockUserRepository{}, repotesting.NewMockAbsenceRepository(), newTestAbsenceTypes(), &MockVacationAdjustmentRepository{}, tenant)
+
+	for _, req := range []struct {
+		date   string
+		days   float64
+		reason string
+	}{
+		{"", 0, "Corrección"},
+		{"", 400, "Corrección"},
+		{"", 1, ""},
+		{"01/07/2025", 1, "Corrección"},
+	} {
+		if _, err := uc.Adjust(franchiseScope(1), 50, 7, dto.VacationAdjustmentRequest{Date: req.date, Days: req.days, Reason: req.reason}); domainStatus(t, err) != 400 {
+			t.Errorf("Expected 400 for %+v, got %v", req, err)
+		}
+	}
+}
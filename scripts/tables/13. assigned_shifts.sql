@@ -0,0 +1,20 @@
+-- Programación de turnos por empleado y fecha
+CREATE TABLE assigned_shifts
+(
+  id            INT AUTO_INCREMENT PRIMARY KEY,
+  employee_id   INT  NOT NULL,
+  shift_id      INT  NOT NULL,
+  store_id      INT  NOT NULL,
+  date          DATE NOT NULL,
+  start_time    TIME NOT NULL,
+  end_time      TIME NOT NULL,
+  lunch_minutes INT      DEFAULT 0,
+  created_at    DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at    DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uk_assigned_employee_date (employee_id, date),
+  INDEX idx_assigned_store_date (store_id, date),
+  CONSTRAINT fk_assigned_employee FOREIGN KEY (employee_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT fk_assigned_shift FOREIGN KEY (shift_id) REFERENCES shifts (id),
+  CONSTRAINT fk_assigned_store FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE
+);
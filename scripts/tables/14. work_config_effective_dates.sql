@@ -0,0 +1,16 @@
+-- Configuración laboral con vigencia: cada reducción de la jornada es una fila nueva.
+-- Las filas existentes quedan como la jornada de 48 h vigente antes de la Ley 2101 de 2021.
+ALTER TABLE work_config
+  ADD COLUMN effective_from        DATE          NOT NULL DEFAULT '2000-01-01' AFTER id,
+  ADD COLUMN daily_ordinary_hours  DECIMAL(4, 2) NOT NULL DEFAULT 8.00 AFTER diurnal_end,
+  ADD COLUMN weekly_ordinary_hours DECIMAL(4, 2) NOT NULL DEFAULT 48.00 AFTER daily_ordinary_hours,
+  ADD COLUMN created_at            DATETIME DEFAULT CURRENT_TIMESTAMP,
+  ADD COLUMN updated_at            DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  ADD INDEX idx_work_config_effective_from (effective_from);
+
+-- Reducción gradual de la jornada máxima (Ley 2101 de 2021), 6 días laborables por semana
+INSERT INTO work_config (effective_from, diurnal_start, diurnal_end, daily_ordinary_hours, weekly_ordinary_hours, is_active)
+VALUES ('2023-07-15', '06:00', '21:00', 7.83, 47.00, TRUE),
+       ('2024-07-15', '06:00', '21:00', 7.67, 46.00, TRUE),
+       ('2025-07-15', '06:00', '21:00', 7.33, 44.00, TRUE),
+       ('2026-07-15', '06:00', '21:00', 7.00, 42.00, TRUE);
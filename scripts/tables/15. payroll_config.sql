@@ -0,0 +1,22 @@
+-- Porcentajes de recargo para la liquidación, con fecha de vigencia
+CREATE TABLE payroll_configs
+(
+  id                         INT AUTO_INCREMENT PRIMARY KEY,
+  effective_from             DATE          NOT NULL,
+  night_surcharge            DECIMAL(5, 4) NOT NULL,
+  sunday_holiday_surcharge   DECIMAL(5, 4) NOT NULL,
+  extra_diurnal_multiplier   DECIMAL(5, 4) NOT NULL,
+  extra_nocturnal_multiplier DECIMAL(5, 4) NOT NULL,
+  is_active                  BOOLEAN DEFAULT TRUE,
+  created_at                 DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at                 DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uk_payroll_configs_effective_from (effective_from)
+);
+
+-- Recargo dominical/festivo gradual (Ley 2466 de 2025)
+INSERT INTO payroll_configs (effective_from, night_surcharge, sunday_holiday_surcharge, extra_diurnal_multiplier, extra_nocturnal_multiplier)
+VALUES ('2000-01-01', 0.35, 0.75, 1.25, 1.75),
+       ('2025-07-01', 0.35, 0.80, 1.25, 1.75),
+       ('2026-07-01', 0.35, 0.90, 1.25, 1.75),
+       ('2027-07-01', 0.35, 1.00, 1.25, 1.75);
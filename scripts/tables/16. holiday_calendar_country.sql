@@ -0,0 +1,7 @@
+-- País del calendario de festivos (ISO 3166-1 alfa-2): CO, EC, PE
+ALTER TABLE franchises
+  ADD COLUMN country CHAR(2) NOT NULL DEFAULT 'CO' AFTER name;
+
+-- NULL = usa el país de la franquicia
+ALTER TABLE stores
+  ADD COLUMN country CHAR(2) NULL AFTER address;
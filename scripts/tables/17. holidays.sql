@@ -0,0 +1,17 @@
+-- Festivos personalizados que se suman al calendario calculado del país
+CREATE TABLE holidays
+(
+  id           INT AUTO_INCREMENT PRIMARY KEY,
+  date         DATE         NOT NULL,
+  name         VARCHAR(100) NOT NULL,
+  scope        VARCHAR(10)  NOT NULL, -- global | franchise | store
+  country      CHAR(2)      NULL,     -- solo alcance global; NULL = todos los países
+  franchise_id INT          NULL,
+  store_id     INT          NULL,
+  created_at   DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at   DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  INDEX idx_holidays_date (date),
+  CONSTRAINT fk_holidays_franchise FOREIGN KEY (franchise_id) REFERENCES franchises (id) ON DELETE CASCADE,
+  CONSTRAINT fk_holidays_store FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE
+);
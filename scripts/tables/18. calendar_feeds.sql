@@ -0,0 +1,18 @@
+-- Suscripciones iCalendar con URL tokenizada (se guarda solo el hash SHA-256 del token)
+CREATE TABLE calendar_feeds
+(
+  id          INT AUTO_INCREMENT PRIMARY KEY,
+  token_hash  CHAR(64)    NOT NULL,
+  kind        VARCHAR(10) NOT NULL, -- holidays | employee
+  employee_id INT         NULL,
+  store_id    INT         NULL,
+  country     CHAR(2)     NULL,
+  revoked_at  DATETIME    NULL,
+  created_at  DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at  DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uk_calendar_feeds_token_hash (token_hash),
+  INDEX idx_calendar_feeds_employee (employee_id),
+  CONSTRAINT fk_calendar_feeds_employee FOREIGN KEY (employee_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT fk_calendar_feeds_store FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE
+);
@@ -0,0 +1,20 @@
+-- Sesiones de login con refresh token rotatorio (se guardan solo hashes SHA-256)
+CREATE TABLE auth_sessions
+(
+  id                  INT AUTO_INCREMENT PRIMARY KEY,
+  user_id             INT      NOT NULL,
+  refresh_token_hash  CHAR(64) NOT NULL,
+  previous_token_hash CHAR(64) NULL, -- refresh token rotado; reutilizarlo revoca la sesión
+  franchise_id        INT      NOT NULL DEFAULT 0,
+  store_id            INT      NOT NULL DEFAULT 0,
+  expires_at          DATETIME NOT NULL,
+  last_used_at        DATETIME NULL,
+  revoked_at          DATETIME NULL,
+  created_at          DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at          DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uk_auth_sessions_refresh_token_hash (refresh_token_hash),
+  INDEX idx_auth_sessions_previous_token_hash (previous_token_hash),
+  INDEX idx_auth_sessions_user (user_id),
+  CONSTRAINT fk_auth_sessions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
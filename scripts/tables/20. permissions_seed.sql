@@ -0,0 +1,39 @@
+-- Permisos "recurso:acción" verificados por RequireResourcePermission (GET = read, resto = write).
+-- "*" concede todos los permisos y "recurso:*" todas las acciones de un recurso.
+INSERT IGNORE INTO permissions (name, description)
+VALUES ('*', 'Todos los permisos'),
+       ('franchises:read', 'Consultar franquicias'),
+       ('franchises:write', 'Crear y modificar franquicias'),
+       ('stores:read', 'Consultar tiendas'),
+       ('stores:write', 'Crear y modificar tiendas'),
+       ('employees:read', 'Consultar empleados'),
+       ('employees:write', 'Crear y modificar empleados'),
+       ('employee_hours:read', 'Consultar resúmenes de horas'),
+       ('calendar:read', 'Consultar festivos y calendario'),
+       ('calendar:write', 'Gestionar festivos personalizados, feeds y cache'),
+       ('shifts:read', 'Consultar turnos'),
+       ('shifts:write', 'Crear y modificar turnos'),
+       ('shift_planning:read', 'Consultar proyecciones de turnos'),
+       ('shift_planning:write', 'Generar vistas previas de proyección'),
+       ('assignments:read', 'Consultar la malla de turnos'),
+       ('assignments:write', 'Asignar turnos y generar la malla'),
+       ('work_configs:read', 'Consultar configuraciones de jornada'),
+       ('work_configs:write', 'Crear configuraciones de jornada'),
+       ('payroll:read', 'Consultar liquidaciones'),
+       ('payroll:write', 'Configurar recargos'),
+       ('absences:read', 'Consultar ausencias'),
+       ('absences:write', 'Registrar ausencias'),
+       ('novelties:read', 'Consultar novedades'),
+       ('novelties:write', 'Registrar novedades'),
+       ('roles:read', 'Consultar roles y permisos'),
+       ('roles:write', 'Gestionar roles y permisos');
+
+-- El rol admin conserva acceso total
+INSERT IGNORE INTO roles (name, description)
+VALUES ('admin', 'Administrador de la franquicia');
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name = '*'
+WHERE r.name = 'admin';
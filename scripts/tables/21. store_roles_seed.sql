@@ -0,0 +1,25 @@
+-- Roles de tienda y autoservicio.
+-- Sin 'stores:all' los datos se limitan a la tienda seleccionada en el token (POST /auth/context).
+INSERT IGNORE INTO permissions (name, description)
+VALUES ('stores:all', 'Operar en todas las tiendas de la franquicia'),
+       ('self:read', 'Consultar los datos propios en /me');
+
+INSERT IGNORE INTO roles (name, description)
+VALUES ('store_manager', 'Gerente de tienda, limitado a la tienda del token'),
+       ('employee', 'Empleado con acceso de autoservicio');
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name IN ('stores:read', 'employees:read', 'employees:write', 'employee_hours:read',
+                                        'calendar:read', 'shifts:read', 'shifts:write', 'shift_planning:read',
+                                        'shift_planning:write', 'assignments:read', 'assignments:write',
+                                        'work_configs:read', 'payroll:read', 'absences:read', 'absences:write',
+                                        'novelties:read', 'novelties:write', 'self:read')
+WHERE r.name = 'store_manager';
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name IN ('self:read', 'calendar:read')
+WHERE r.name = 'employee';
@@ -0,0 +1,5 @@
+-- Administración de bloqueos por intentos de login fallidos (GET/DELETE /auth/lockouts).
+-- El rol admin ya los tiene mediante '*'.
+INSERT IGNORE INTO permissions (name, description)
+VALUES ('lockouts:read', 'Consultar bloqueos de login'),
+       ('lockouts:write', 'Desbloquear cuentas e IPs');
@@ -0,0 +1,69 @@
+-- Catálogo de tipos de ausencia. paid indica si la nómina la remunera;
+-- max_hours_per_month limita las horas del tipo por empleado y mes (0 = sin límite).
+CREATE TABLE absence_types
+(
+  id                  INT AUTO_INCREMENT PRIMARY KEY,
+  code                VARCHAR(30)   NOT NULL,
+  name                VARCHAR(100)  NOT NULL,
+  paid                BOOLEAN       NOT NULL DEFAULT TRUE,
+  max_hours_per_month DECIMAL(6, 2) NOT NULL DEFAULT 0,
+  is_active           BOOLEAN       NOT NULL DEFAULT TRUE,
+  created_at          DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at          DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  UNIQUE KEY uk_absence_types_code (code)
+);
+
+INSERT INTO absence_types (code, name, paid, max_hours_per_month)
+VALUES ('sick_leave', 'Incapacidad', TRUE, 0),
+       ('vacation', 'Vacaciones', TRUE, 0),
+       ('maternity_leave', 'Licencia de maternidad o paternidad', TRUE, 0),
+       ('bereavement', 'Licencia por luto', TRUE, 0),
+       ('unpaid_leave', 'Licencia no remunerada', FALSE, 0),
+       ('personal', 'Permiso personal', FALSE, 40),
+       ('other', 'Otra', FALSE, 40);
+
+-- Ausencias de varios días (hours son horas por día) con flujo de solicitud y aprobación.
+-- Las ausencias existentes pasan a tipo 'other', aprobadas y de un solo día.
+ALTER TABLE absences
+  ADD COLUMN absence_type_id INT          NULL AFTER employee_id,
+  ADD COLUMN end_date        DATE         NULL AFTER date,
+  ADD COLUMN status          VARCHAR(10)  NOT NULL DEFAULT 'pending' AFTER reason,
+  ADD COLUMN requested_by    INT          NULL AFTER status,
+  ADD COLUMN reviewed_by     INT          NULL AFTER requested_by,
+  ADD COLUMN reviewed_at     DATETIME     NULL AFTER reviewed_by,
+  ADD COLUMN review_note     VARCHAR(255) NULL AFTER reviewed_at;
+
+UPDATE absences
+SET absence_type_id = (SELECT id FROM absence_types WHERE code = 'other'),
+    end_date        = date,
+    status          = 'approved'
+WHERE absence_type_id IS NULL;
+
+ALTER TABLE absences
+  MODIFY absence_type_id INT  NOT NULL,
+  MODIFY end_date        DATE NOT NULL,
+  ADD INDEX idx_absences_employee_dates (employee_id, date, end_date),
+  ADD INDEX idx_absences_status (status),
+  ADD CONSTRAINT fk_absence_type FOREIGN KEY (absence_type_id) REFERENCES absence_types (id),
+  ADD CONSTRAINT fk_absence_requested_by FOREIGN KEY (requested_by) REFERENCES users (id),
+  ADD CONSTRAINT fk_absence_reviewed_by FOREIGN KEY (reviewed_by) REFERENCES users (id);
+
+-- Aprobación de ausencias, gestión del catálogo y solicitudes propias en /me.
+-- El rol admin ya los tiene mediante '*'.
+INSERT IGNORE INTO permissions (name, description)
+VALUES ('absences:approve', 'Aprobar o rechazar solicitudes de ausencia'),
+       ('absence_types:write', 'Gestionar el catálogo de tipos de ausencia'),
+       ('self:write', 'Solicitar ausencias propias en /me');
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name IN ('absences:approve', 'self:write')
+WHERE r.name = 'store_manager';
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name = 'self:write'
+WHERE r.name = 'employee';
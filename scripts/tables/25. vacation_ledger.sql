@@ -0,0 +1,37 @@
+-- Fecha de ingreso, desde la que se causan las vacaciones (15 días hábiles por año).
+-- Los empleados existentes toman la fecha de su registro.
+ALTER TABLE users
+  ADD COLUMN hire_date DATE NULL AFTER salary;
+
+UPDATE users
+SET hire_date = DATE(created_at)
+WHERE hire_date IS NULL;
+
+-- Ajustes manuales del saldo de vacaciones (saldo inicial, compensación en dinero, correcciones).
+-- Las causaciones y los días disfrutados se calculan y no se guardan.
+CREATE TABLE vacation_adjustments
+(
+  id          INT AUTO_INCREMENT PRIMARY KEY,
+  employee_id INT           NOT NULL,
+  date        DATE          NOT NULL,
+  days        DECIMAL(6, 2) NOT NULL,
+  reason      VARCHAR(255)  NOT NULL,
+  created_by  INT           NULL,
+  created_at  DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at  DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  INDEX idx_vacation_adjustments_employee (employee_id, date),
+  CONSTRAINT fk_vacation_adjustment_employee FOREIGN KEY (employee_id) REFERENCES users (id),
+  CONSTRAINT fk_vacation_adjustment_created_by FOREIGN KEY (created_by) REFERENCES users (id)
+);
+
+-- Consulta y ajuste de saldos. El rol admin ya los tiene mediante '*'.
+INSERT IGNORE INTO permissions (name, description)
+VALUES ('vacations:read', 'Consultar saldos y movimientos de vacaciones'),
+       ('vacations:write', 'Registrar ajustes manuales de vacaciones');
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name = 'vacations:read'
+WHERE r.name = 'store_manager';
@@ -0,0 +1,44 @@
+-- Reloj de asistencia: marcaciones reales de entrada y salida por empleado y tienda.
+-- clock_in y clock_out se guardan en UTC; las horas se calculan con la hora local de la tienda.
+-- Una entrada abierta por más de 16 horas pasa a 'missed_out' y espera la corrección de un gerente.
+CREATE TABLE time_clock_entries
+(
+  id                INT AUTO_INCREMENT PRIMARY KEY,
+  employee_id       INT          NOT NULL,
+  store_id          INT          NOT NULL,
+  assigned_shift_id INT          NULL,
+  lunch_minutes     INT          NOT NULL DEFAULT 0,
+  clock_in          DATETIME     NOT NULL,
+  clock_out         DATETIME     NULL,
+  status            VARCHAR(10)  NOT NULL DEFAULT 'open',
+  corrected_by      INT          NULL,
+  corrected_at      DATETIME     NULL,
+  correction_note   VARCHAR(255) NULL,
+  created_at        DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at        DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  INDEX idx_time_clock_employee (employee_id, clock_in),
+  INDEX idx_time_clock_status (status, clock_in),
+  CONSTRAINT fk_time_clock_employee FOREIGN KEY (employee_id) REFERENCES users (id),
+  CONSTRAINT fk_time_clock_store FOREIGN KEY (store_id) REFERENCES stores (id),
+  CONSTRAINT fk_time_clock_assigned_shift FOREIGN KEY (assigned_shift_id) REFERENCES assigned_shifts (id) ON DELETE SET NULL,
+  CONSTRAINT fk_time_clock_corrected_by FOREIGN KEY (corrected_by) REFERENCES users (id)
+);
+
+-- Marcaciones propias en /clock y gestión de registros. El rol admin ya los tiene mediante '*'.
+INSERT IGNORE INTO permissions (name, description)
+VALUES ('time_clock:punch', 'Marcar la entrada y la salida propias'),
+       ('time_clock:read', 'Consultar registros del reloj y marcaciones olvidadas'),
+       ('time_clock:write', 'Registrar y corregir marcaciones olvidadas');
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name IN ('time_clock:punch', 'time_clock:read', 'time_clock:write')
+WHERE r.name = 'store_manager';
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name = 'time_clock:punch'
+WHERE r.name = 'employee';
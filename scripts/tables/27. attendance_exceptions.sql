@@ -0,0 +1,12 @@
+-- Tolerancia de llegada tarde y salida anticipada frente al turno asignado, con la misma vigencia
+-- de la configuración laboral. Las configuraciones existentes toman 5 minutos.
+ALTER TABLE work_config
+  ADD COLUMN grace_minutes INT NOT NULL DEFAULT 5 AFTER weekly_ordinary_hours;
+
+-- Novedades negativas generadas de las excepciones de asistencia: una por turno y origen
+-- ('late' o 'early_leave'). Las novedades manuales no tienen turno ni origen.
+ALTER TABLE novelties
+  ADD COLUMN assigned_shift_id INT         NULL AFTER comment,
+  ADD COLUMN source            VARCHAR(20) NULL AFTER assigned_shift_id,
+  ADD UNIQUE INDEX uq_novelty_assigned_shift_source (assigned_shift_id, source),
+  ADD CONSTRAINT fk_novelty_assigned_shift FOREIGN KEY (assigned_shift_id) REFERENCES assigned_shifts (id) ON DELETE SET NULL;
@@ -0,0 +1,59 @@
+-- Intercambios de turnos entre empleados de una tienda: uno ofrece un turno asignado, un compañero
+-- lo acepta (opcionalmente dando uno propio a cambio) y un gerente lo aprueba. Al aprobarse se
+-- reasignan los turnos; la fecha, el horario y las horas se copian para conservar el historial de
+-- quién trabajó cada turno aunque los turnos cambien o se eliminen después; por eso los turnos
+-- no tienen clave foránea.
+CREATE TABLE shift_swaps
+(
+  id                 INT AUTO_INCREMENT PRIMARY KEY,
+  store_id           INT           NOT NULL,
+  offered_shift_id   INT           NOT NULL,
+  offered_by         INT           NOT NULL,
+  offered_date       DATE          NOT NULL,
+  offered_start_time VARCHAR(5)    NOT NULL,
+  offered_end_time   VARCHAR(5)    NOT NULL,
+  offered_hours      DECIMAL(5, 2) NOT NULL DEFAULT 0,
+  note               VARCHAR(255)  NULL,
+  accepted_by        INT           NULL,
+  accepted_at        DATETIME      NULL,
+  return_shift_id    INT           NULL,
+  return_date        DATE          NULL,
+  return_start_time  VARCHAR(5)    NULL,
+  return_end_time    VARCHAR(5)    NULL,
+  return_hours       DECIMAL(5, 2) NULL,
+  status             VARCHAR(10)   NOT NULL DEFAULT 'open',
+  reviewed_by        INT           NULL,
+  reviewed_at        DATETIME      NULL,
+  review_note        VARCHAR(255)  NULL,
+  created_at         DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at         DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  INDEX idx_shift_swaps_store (store_id, status, offered_date),
+  INDEX idx_shift_swaps_offered_shift (offered_shift_id, status),
+  INDEX idx_shift_swaps_return_shift (return_shift_id, status),
+  INDEX idx_shift_swaps_offered_by (offered_by, offered_date),
+  INDEX idx_shift_swaps_accepted_by (accepted_by, offered_date),
+  CONSTRAINT fk_shift_swaps_store FOREIGN KEY (store_id) REFERENCES stores (id),
+  CONSTRAINT fk_shift_swaps_offered_by FOREIGN KEY (offered_by) REFERENCES users (id),
+  CONSTRAINT fk_shift_swaps_accepted_by FOREIGN KEY (accepted_by) REFERENCES users (id),
+  CONSTRAINT fk_shift_swaps_reviewed_by FOREIGN KEY (reviewed_by) REFERENCES users (id)
+);
+
+-- Ofrecer y aceptar turnos propios, y aprobar intercambios. El rol admin ya los tiene mediante '*'.
+INSERT IGNORE INTO permissions (name, description)
+VALUES ('shift_swaps:request', 'Ofrecer, aceptar y cancelar intercambios de turnos propios'),
+       ('shift_swaps:read', 'Consultar intercambios de turnos pendientes y el historial por tienda'),
+       ('shift_swaps:write', 'Gestionar intercambios de turnos'),
+       ('shift_swaps:approve', 'Aprobar o rechazar intercambios de turnos');
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name IN ('shift_swaps:request', 'shift_swaps:read', 'shift_swaps:write', 'shift_swaps:approve')
+WHERE r.name = 'store_manager';
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name = 'shift_swaps:request'
+WHERE r.name = 'employee';
@@ -0,0 +1,52 @@
+-- Disponibilidad de los empleados: franjas semanales recurrentes en que no pueden ('unavailable') o
+-- prefieren no trabajar ('prefer_not'), y excepciones por fecha que prevalecen sobre ellas. Las horas
+-- son 'HH:MM' y end_time admite '24:00' como final del día. Una excepción sin horas aplica a todo el día.
+CREATE TABLE availability_windows
+(
+  id         INT AUTO_INCREMENT PRIMARY KEY,
+  user_id    INT          NOT NULL,
+  weekday    TINYINT      NOT NULL, -- 0 = domingo ... 6 = sábado
+  start_time VARCHAR(5)   NOT NULL,
+  end_time   VARCHAR(5)   NOT NULL,
+  type       VARCHAR(12)  NOT NULL,
+  note       VARCHAR(255) NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  INDEX idx_availability_windows_user (user_id, weekday),
+  CONSTRAINT fk_availability_windows_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE availability_exceptions
+(
+  id         INT AUTO_INCREMENT PRIMARY KEY,
+  user_id    INT          NOT NULL,
+  date       DATE         NOT NULL,
+  start_time VARCHAR(5)   NULL,
+  end_time   VARCHAR(5)   NULL,
+  type       VARCHAR(12)  NOT NULL, -- 'unavailable' | 'available'
+  reason     VARCHAR(255) NULL,
+  created_by INT          NULL,
+  created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+  updated_at DATETIME DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+  INDEX idx_availability_exceptions_user_date (user_id, date),
+  CONSTRAINT fk_availability_exceptions_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+  CONSTRAINT fk_availability_exceptions_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL
+);
+
+-- Motivo por el que se asignó un turno pese a que el empleado no estaba disponible
+ALTER TABLE assigned_shifts
+  ADD COLUMN availability_override VARCHAR(255) NULL AFTER lunch_minutes;
+
+-- Gestionar la disponibilidad de los empleados. El rol admin ya los tiene mediante '*';
+-- el rol employee declara la propia con 'self:write'.
+INSERT IGNORE INTO permissions (name, description)
+VALUES ('availability:read', 'Consultar la disponibilidad de los empleados'),
+       ('availability:write', 'Registrar franjas y excepciones de disponibilidad de los empleados');
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name IN ('availability:read', 'availability:write')
+WHERE r.name = 'store_manager';
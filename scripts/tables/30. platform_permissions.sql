@@ -0,0 +1,13 @@
+-- Datos globales de la plataforma: festivos globales, configuraciones de jornada y de nómina, roles y permisos.
+-- 'platform:admin' solo se concede por su nombre: el '*' del admin de una franquicia no lo incluye.
+INSERT IGNORE INTO permissions (name, description)
+VALUES ('platform:admin', 'Gestionar los datos globales de la plataforma');
+
+INSERT IGNORE INTO roles (name, description)
+VALUES ('platform_admin', 'Administrador de la plataforma');
+
+INSERT IGNORE INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+       JOIN permissions p ON p.name IN ('*', 'platform:admin')
+WHERE r.name = 'platform_admin';
@@ -0,0 +1,23 @@
+-- Franquicia que creó cada suscripción iCalendar: solo ella puede revocarla
+ALTER TABLE calendar_feeds
+  ADD COLUMN franchise_id INT NULL AFTER kind,
+  ADD INDEX idx_calendar_feeds_franchise (franchise_id),
+  ADD CONSTRAINT fk_calendar_feeds_franchise FOREIGN KEY (franchise_id) REFERENCES franchises (id) ON DELETE CASCADE;
+
+-- Las suscripciones existentes de una tienda toman la franquicia de la tienda
+UPDATE calendar_feeds cf
+  JOIN stores s ON s.id = cf.store_id
+SET cf.franchise_id = s.franchise_id
+WHERE cf.franchise_id IS NULL;
+
+-- Las de un empleado, la de su única franquicia; si tiene varias, la franquicia es ambigua
+UPDATE calendar_feeds cf
+  JOIN (SELECT ur.user_id, MIN(ur.franchise_id) AS franchise_id
+        FROM user_roles ur
+        GROUP BY ur.user_id
+        HAVING COUNT(DISTINCT ur.franchise_id) = 1) f ON f.user_id = cf.employee_id
+SET cf.franchise_id = f.franchise_id
+WHERE cf.franchise_id IS NULL;
+
+-- Las que quedan sin franquicia (por país, o de empleados de varias franquicias) solo se
+-- revocan con el permiso 'platform:admin'